@@ -0,0 +1,134 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/backup"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// Minimum amount of time between two progress reports in the logs
+	backupLogFrequency = 10 * time.Second
+)
+
+var (
+	errBackupRunning     = errors.New("database backup already running")
+	errInvalidBackupName = errors.New("backup name must be a directory name")
+)
+
+// BackupStatus is the status of the most recent database backup
+type BackupStatus struct {
+	Name      string
+	Running   bool
+	StartTime time.Time
+	EndTime   time.Time
+	Progress  backup.Progress
+	Err       error
+}
+
+// DatabaseBackup backs up the node's database in the background. Backups are
+// written to directories in a common backup directory.
+type DatabaseBackup struct {
+	log       logging.Logger
+	db        database.Database
+	networkID uint32
+	dbVersion string
+	dir       string
+
+	lock   sync.Mutex
+	status BackupStatus
+}
+
+// NewDatabaseBackup returns a new database backup helper for [db] that writes
+// backups to directories in [dir]
+func NewDatabaseBackup(log logging.Logger, db database.Database, networkID uint32, dbVersion string, dir string) *DatabaseBackup {
+	return &DatabaseBackup{
+		log:       log,
+		db:        db,
+		networkID: networkID,
+		dbVersion: dbVersion,
+		dir:       dir,
+	}
+}
+
+// Start backing up the database to the directory named [name] in the backup
+// directory. Only one backup may run at a time.
+func (b *DatabaseBackup) Start(name string) error {
+	// Backups may only be written to the backup directory
+	if name == "" || name == "." || name == ".." || name != filepath.Base(name) {
+		return fmt.Errorf("%w: %q", errInvalidBackupName, name)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.status.Running {
+		return errBackupRunning
+	}
+	if err := os.MkdirAll(b.dir, 0700); err != nil {
+		return fmt.Errorf("couldn't create backup directory: %w", err)
+	}
+	dir := filepath.Join(b.dir, name)
+	b.status = BackupStatus{
+		Name:      name,
+		Running:   true,
+		StartTime: time.Now(),
+	}
+	go b.log.RecoverAndPanic(func() { b.run(dir) })
+	return nil
+}
+
+// Status returns the status of the most recent backup
+func (b *DatabaseBackup) Status() BackupStatus {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.status
+}
+
+func (b *DatabaseBackup) run(dir string) {
+	b.log.Info("starting database backup to %s", dir)
+
+	lastLog := time.Now()
+	metadata, err := backup.Backup(b.db, dir, b.networkID, b.dbVersion, b.log, func(progress backup.Progress) {
+		b.lock.Lock()
+		b.status.Progress = progress
+		b.lock.Unlock()
+
+		if now := time.Now(); now.Sub(lastLog) >= backupLogFrequency {
+			lastLog = now
+			b.log.Info("database backup to %s has copied %d keys (%d bytes)", dir, progress.Keys, progress.Bytes)
+		}
+	})
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.status.Running = false
+	b.status.EndTime = time.Now()
+	b.status.Err = err
+	if err != nil {
+		b.log.Error("database backup to %s failed with: %s", dir, err)
+		return
+	}
+	b.status.Progress = backup.Progress{
+		Keys:  metadata.Keys,
+		Bytes: metadata.Bytes,
+	}
+	b.log.Info("finished database backup to %s with %d keys (%d bytes) in %s",
+		dir,
+		metadata.Keys,
+		metadata.Bytes,
+		b.status.EndTime.Sub(b.status.StartTime),
+	)
+}
@@ -0,0 +1,71 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestDatabaseBackup(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db := memdb.New()
+	if err := db.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+
+	backupDir := filepath.Join(tmpDir, "backups")
+	b := NewDatabaseBackup(logging.NoLog{}, db, 12345, "v1.0.0", backupDir)
+
+	// Backups may only be written to the backup directory
+	for _, name := range []string{"", ".", "..", "../backup", filepath.Join(tmpDir, "backup")} {
+		if err := b.Start(name); !errors.Is(err, errInvalidBackupName) {
+			t.Fatalf("Start(%q) should have failed with %s but got %v", name, errInvalidBackupName, err)
+		}
+	}
+
+	if err := b.Start("backup"); err != nil {
+		t.Fatal(err)
+	}
+	for b.Status().Running {
+		time.Sleep(time.Millisecond)
+	}
+
+	status := b.Status()
+	if status.Err != nil {
+		t.Fatal(status.Err)
+	}
+	if status.Name != "backup" {
+		t.Fatalf("backed up to %s but expected %s", status.Name, "backup")
+	}
+	if _, err := os.Stat(filepath.Join(backupDir, "backup")); err != nil {
+		t.Fatalf("backup wasn't written to the backup directory: %s", err)
+	}
+	if status.Progress.Keys != 1 {
+		t.Fatalf("backed up %d keys but expected %d", status.Progress.Keys, 1)
+	}
+
+	// Backing up to the same directory again should fail, as it isn't empty
+	if err := b.Start("backup"); err != nil {
+		t.Fatal(err)
+	}
+	for b.Status().Running {
+		time.Sleep(time.Millisecond)
+	}
+	if status := b.Status(); status.Err == nil {
+		t.Fatal("backing up to a non-empty directory should have failed")
+	}
+}
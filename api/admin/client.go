@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
 
@@ -85,3 +86,147 @@ func (c *Client) Stacktrace() (bool, error) {
 	err := c.requester.SendRequest("stacktrace", struct{}{}, res)
 	return res.Success, err
 }
+
+// BackupDatabase ...
+func (c *Client) BackupDatabase(name string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("backupDatabase", &BackupDatabaseArgs{
+		Name: name,
+	}, res)
+	return res.Success, err
+}
+
+// GetDatabaseBackupStatus ...
+func (c *Client) GetDatabaseBackupStatus() (*GetDatabaseBackupStatusReply, error) {
+	res := &GetDatabaseBackupStatusReply{}
+	err := c.requester.SendRequest("getDatabaseBackupStatus", struct{}{}, res)
+	return res, err
+}
+
+// AllowPeer ...
+func (c *Client) AllowPeer(nodeID, ip string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("allowPeer", &AllowPeerArgs{
+		NodeID: nodeID,
+		IP:     ip,
+	}, res)
+	return res.Success, err
+}
+
+// DisallowPeer ...
+func (c *Client) DisallowPeer(nodeID string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("disallowPeer", &DisallowPeerArgs{
+		NodeID: nodeID,
+	}, res)
+	return res.Success, err
+}
+
+// GetAllowedPeers ...
+func (c *Client) GetAllowedPeers() (*GetAllowedPeersReply, error) {
+	res := &GetAllowedPeersReply{}
+	err := c.requester.SendRequest("getAllowedPeers", struct{}{}, res)
+	return res, err
+}
+
+// GetPeers ...
+func (c *Client) GetPeers() ([]network.PeerID, error) {
+	res := &GetPeersReply{}
+	err := c.requester.SendRequest("getPeers", struct{}{}, res)
+	return res.Peers, err
+}
+
+// AddPeer ...
+func (c *Client) AddPeer(ip string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("addPeer", &AddPeerArgs{
+		IP: ip,
+	}, res)
+	return res.Success, err
+}
+
+// DisconnectPeer ...
+func (c *Client) DisconnectPeer(nodeID string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("disconnectPeer", &DisconnectPeerArgs{
+		NodeID: nodeID,
+	}, res)
+	return res.Success, err
+}
+
+// BanNodeID ...
+func (c *Client) BanNodeID(nodeID string, duration time.Duration) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("ban", &BanArgs{
+		NodeID:   nodeID,
+		Duration: duration.String(),
+	}, res)
+	return res.Success, err
+}
+
+// BanIP ...
+func (c *Client) BanIP(ip string, duration time.Duration) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("ban", &BanArgs{
+		IP:       ip,
+		Duration: duration.String(),
+	}, res)
+	return res.Success, err
+}
+
+// UnbanNodeID ...
+func (c *Client) UnbanNodeID(nodeID string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("unban", &UnbanArgs{
+		NodeID: nodeID,
+	}, res)
+	return res.Success, err
+}
+
+// UnbanIP ...
+func (c *Client) UnbanIP(ip string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("unban", &UnbanArgs{
+		IP: ip,
+	}, res)
+	return res.Success, err
+}
+
+// GetBans ...
+func (c *Client) GetBans() ([]BannedPeer, error) {
+	res := &GetBansReply{}
+	err := c.requester.SendRequest("getBans", struct{}{}, res)
+	return res.Bans, err
+}
+
+// StartCapture ...
+func (c *Client) StartCapture(chain string, name string, duration time.Duration) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("startCapture", &StartCaptureArgs{
+		Chain:    chain,
+		Name:     name,
+		Duration: duration.String(),
+	}, res)
+	return res.Success, err
+}
+
+// Bench ...
+func (c *Client) Bench(nodeID, chain string, duration time.Duration) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("bench", &BenchArgs{
+		NodeID:   nodeID,
+		Chain:    chain,
+		Duration: duration.String(),
+	}, res)
+	return res.Success, err
+}
+
+// Unbench ...
+func (c *Client) Unbench(nodeID, chain string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("unbench", &UnbenchArgs{
+		NodeID: nodeID,
+		Chain:  chain,
+	}, res)
+	return res.Success, err
+}
@@ -6,10 +6,12 @@ package admin
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
 
@@ -61,6 +63,18 @@ func (mc *mockClient) SendRequest(method string, params interface{}, reply inter
 	case *GetChainAliasesReply:
 		response := mc.response.(*GetChainAliasesReply)
 		*p = *response
+	case *GetDatabaseBackupStatusReply:
+		response := mc.response.(*GetDatabaseBackupStatusReply)
+		*p = *response
+	case *GetAllowedPeersReply:
+		response := mc.response.(*GetAllowedPeersReply)
+		*p = *response
+	case *GetPeersReply:
+		response := mc.response.(*GetPeersReply)
+		*p = *response
+	case *GetBansReply:
+		response := mc.response.(*GetBansReply)
+		*p = *response
 	default:
 		panic("illegal type")
 	}
@@ -221,3 +235,340 @@ func TestStacktrace(t *testing.T) {
 		}
 	}
 }
+
+func TestBackupDatabase(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.BackupDatabase("backup")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestGetDatabaseBackupStatus(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &GetDatabaseBackupStatusReply{
+			Name:    "backup",
+			Running: true,
+			Keys:    5,
+			Bytes:   100,
+		}
+		mockClient := Client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.GetDatabaseBackupStatus()
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := Client{requester: NewMockClient(&GetDatabaseBackupStatusReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetDatabaseBackupStatus()
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestAllowPeer(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.AllowPeer("NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET", "127.0.0.1:9651")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestDisallowPeer(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.DisallowPeer("NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestGetAllowedPeers(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &GetAllowedPeersReply{
+			Enabled: true,
+			Peers: []AllowedPeer{{
+				NodeID: "NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET",
+				IP:     "127.0.0.1:9651",
+			}},
+		}
+		mockClient := Client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.GetAllowedPeers()
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := Client{requester: NewMockClient(&GetAllowedPeersReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetAllowedPeers()
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestAddPeer(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.AddPeer("127.0.0.1:9651")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestDisconnectPeer(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.DisconnectPeer("NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestBanNodeID(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.BanNodeID("NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET", time.Hour)
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestBanIP(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.BanIP("127.0.0.1", time.Hour)
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestUnbanNodeID(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.UnbanNodeID("NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestUnbanIP(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.UnbanIP("127.0.0.1")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestGetPeers(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedPeers := []network.PeerID{{
+			IP:       "127.0.0.1:9651",
+			ID:       "NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET",
+			Outbound: true,
+		}}
+		mockClient := Client{requester: NewMockClient(&GetPeersReply{
+			NumPeers: 1,
+			Peers:    expectedPeers,
+		}, nil)}
+
+		peers, err := mockClient.GetPeers()
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPeers, peers)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := Client{requester: NewMockClient(&GetPeersReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetPeers()
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetBans(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedBans := []BannedPeer{
+			{
+				NodeID: "NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET",
+				Expiry: time.Unix(1000, 0),
+			},
+			{
+				IP:     "127.0.0.1",
+				Expiry: time.Unix(2000, 0),
+			},
+		}
+		mockClient := Client{requester: NewMockClient(&GetBansReply{
+			Bans: expectedBans,
+		}, nil)}
+
+		bans, err := mockClient.GetBans()
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBans, bans)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := Client{requester: NewMockClient(&GetBansReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetBans()
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestStartCapture(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.StartCapture("X", "capture.bin", time.Minute)
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestBench(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.Bench("NodeID-111111111111111111116DBWJs", "X", time.Hour)
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
+
+func TestUnbench(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := Client{requester: NewMockClient(api.SuccessResponse{Success: test.Success}, test.Err)}
+		success, err := mockClient.Unbench("NodeID-111111111111111111116DBWJs", "X")
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexepcted error: %s", err)
+		}
+		if success != test.Success {
+			t.Fatalf("Expected success response to be: %v, but found: %v", test.Success, success)
+		}
+	}
+}
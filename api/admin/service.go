@@ -5,15 +5,23 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
@@ -28,18 +36,37 @@ const (
 
 var (
 	errAliasTooLong = errors.New("alias length is too long")
+	errBanTarget    = errors.New("exactly one of nodeID and ip must be provided")
+	errInvalidIP    = errors.New("invalid IP")
+
+	errNonPositiveDuration = errors.New("duration must be positive")
 )
 
 // Admin is the API service for node admin management
 type Admin struct {
 	log          logging.Logger
 	performance  *Performance
+	backup       *DatabaseBackup
 	chainManager chains.Manager
 	httpServer   *api.Server
+	network      network.Network
+	benchlist    benchlist.Manager
 }
 
-// NewService returns a new admin API service
-func NewService(log logging.Logger, chainManager chains.Manager, httpServer *api.Server) (*common.HTTPHandler, error) {
+// NewService returns a new admin API service. [db] is the node's database, which
+// is stored with version [dbVersion] and belongs to network [networkID].
+// Backups of [db] are written to [backupDir].
+func NewService(
+	log logging.Logger,
+	chainManager chains.Manager,
+	httpServer *api.Server,
+	net network.Network,
+	benchlist benchlist.Manager,
+	db database.Database,
+	networkID uint32,
+	dbVersion string,
+	backupDir string,
+) (*common.HTTPHandler, error) {
 	newServer := rpc.NewServer()
 	codec := cjson.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
@@ -48,7 +75,10 @@ func NewService(log logging.Logger, chainManager chains.Manager, httpServer *api
 		log:          log,
 		chainManager: chainManager,
 		httpServer:   httpServer,
+		network:      net,
+		benchlist:    benchlist,
 		performance:  NewDefaultPerformanceService(),
+		backup:       NewDatabaseBackup(log, db, networkID, dbVersion, backupDir),
 	}, "admin"); err != nil {
 		return nil, err
 	}
@@ -153,6 +183,40 @@ func (service *Admin) GetChainAliases(r *http.Request, args *GetChainAliasesArgs
 	return nil
 }
 
+// StartCaptureArgs are the arguments for calling StartCapture. [Name] is the
+// name of the file, in the node's capture directory, that the capture is
+// written to.
+type StartCaptureArgs struct {
+	Chain    string `json:"chain"`
+	Name     string `json:"name"`
+	Duration string `json:"duration"`
+}
+
+// StartCapture records every message delivered to a chain's engine, and the
+// chain's decisions, to a new file for a duration. The chain's database is
+// backed up when the capture starts, so that the capture can be replayed into
+// a fresh engine on it to check that it reaches the same decisions.
+func (service *Admin) StartCapture(_ *http.Request, args *StartCaptureArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: StartCapture called with Chain: %s, Name: %s, Duration: %s", args.Chain, args.Name, args.Duration)
+
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+	if duration <= 0 {
+		return errNonPositiveDuration
+	}
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	if err := service.chainManager.StartCapture(chainID, args.Name, duration); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
 // Stacktrace returns the current global stacktrace
 func (service *Admin) Stacktrace(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
 	service.log.Info("Admin: Stacktrace called")
@@ -161,3 +225,348 @@ func (service *Admin) Stacktrace(_ *http.Request, _ *struct{}, reply *api.Succes
 	stacktrace := []byte(logging.Stacktrace{Global: true}.String())
 	return ioutil.WriteFile(stacktraceFile, stacktrace, 0600)
 }
+
+// BackupDatabaseArgs are the arguments for calling BackupDatabase. [Name] is
+// the name of the directory, in the node's backup directory, that the backup is
+// written to.
+type BackupDatabaseArgs struct {
+	Name string `json:"name"`
+}
+
+// BackupDatabase starts writing a consistent copy of the node's database to
+// the named directory, which must be empty or not exist. The copy is taken
+// from a snapshot, so the node keeps running while it is written. Progress is
+// reported by GetDatabaseBackupStatus.
+func (service *Admin) BackupDatabase(_ *http.Request, args *BackupDatabaseArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: BackupDatabase called with Name: %s", args.Name)
+
+	if err := service.backup.Start(args.Name); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// GetDatabaseBackupStatusReply is the status of the most recent database backup
+type GetDatabaseBackupStatusReply struct {
+	Name      string       `json:"name"`
+	Running   bool         `json:"running"`
+	StartTime time.Time    `json:"startTime"`
+	EndTime   time.Time    `json:"endTime"`
+	Keys      cjson.Uint64 `json:"keys"`
+	Bytes     cjson.Uint64 `json:"bytes"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// GetDatabaseBackupStatus returns the status of the most recent database backup
+func (service *Admin) GetDatabaseBackupStatus(_ *http.Request, _ *struct{}, reply *GetDatabaseBackupStatusReply) error {
+	service.log.Info("Admin: GetDatabaseBackupStatus called")
+
+	status := service.backup.Status()
+	reply.Name = status.Name
+	reply.Running = status.Running
+	reply.StartTime = status.StartTime
+	reply.EndTime = status.EndTime
+	reply.Keys = cjson.Uint64(status.Progress.Keys)
+	reply.Bytes = cjson.Uint64(status.Progress.Bytes)
+	if status.Err != nil {
+		reply.Error = status.Err.Error()
+	}
+	return nil
+}
+
+// AllowPeerArgs are the arguments for calling AllowPeer
+type AllowPeerArgs struct {
+	NodeID string `json:"nodeID"`
+	IP     string `json:"ip"`
+}
+
+// AllowPeer adds a peer to the allowlist of a node in allowlist-only mode, and
+// connects to it at the provided static IP
+func (service *Admin) AllowPeer(_ *http.Request, args *AllowPeerArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: AllowPeer called with NodeID: %s, IP: %s", args.NodeID, args.IP)
+
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return err
+	}
+	ip, err := utils.ToIPDesc(args.IP)
+	if err != nil {
+		return err
+	}
+	if err := service.network.AllowPeer(nodeID, ip); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// DisallowPeerArgs are the arguments for calling DisallowPeer
+type DisallowPeerArgs struct {
+	NodeID string `json:"nodeID"`
+}
+
+// DisallowPeer removes a peer from the allowlist of a node in allowlist-only
+// mode, and disconnects from it
+func (service *Admin) DisallowPeer(_ *http.Request, args *DisallowPeerArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: DisallowPeer called with NodeID: %s", args.NodeID)
+
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return err
+	}
+	if err := service.network.DisallowPeer(nodeID); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// AllowedPeer is a peer on the allowlist
+type AllowedPeer struct {
+	NodeID string `json:"nodeID"`
+	IP     string `json:"ip"`
+}
+
+// GetAllowedPeersReply are the peers on the allowlist
+type GetAllowedPeersReply struct {
+	Enabled bool          `json:"enabled"`
+	Peers   []AllowedPeer `json:"peers"`
+}
+
+// GetAllowedPeers returns the peers on the allowlist, and whether the node is
+// in allowlist-only mode
+func (service *Admin) GetAllowedPeers(_ *http.Request, _ *struct{}, reply *GetAllowedPeersReply) error {
+	service.log.Info("Admin: GetAllowedPeers called")
+
+	peers := service.network.AllowedPeers()
+	reply.Enabled = peers != nil
+	reply.Peers = make([]AllowedPeer, len(peers))
+	for i, peer := range peers {
+		reply.Peers[i] = AllowedPeer{
+			NodeID: peer.ID.PrefixedString(constants.NodeIDPrefix),
+			IP:     peer.IP.String(),
+		}
+	}
+	return nil
+}
+
+// GetPeersReply are the peers this node is connected to
+type GetPeersReply struct {
+	NumPeers cjson.Uint64     `json:"numPeers"`
+	Peers    []network.PeerID `json:"peers"`
+}
+
+// GetPeers returns the peers this node is connected to, with details of each
+// connection
+func (service *Admin) GetPeers(_ *http.Request, _ *struct{}, reply *GetPeersReply) error {
+	service.log.Info("Admin: GetPeers called")
+
+	reply.Peers = service.network.Peers(nil)
+	reply.NumPeers = cjson.Uint64(len(reply.Peers))
+	return nil
+}
+
+// AddPeerArgs are the arguments for calling AddPeer
+type AddPeerArgs struct {
+	IP string `json:"ip"`
+}
+
+// AddPeer makes the node attempt to connect to the provided IP until it
+// succeeds
+func (service *Admin) AddPeer(_ *http.Request, args *AddPeerArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: AddPeer called with IP: %s", args.IP)
+
+	ip, err := utils.ToIPDesc(args.IP)
+	if err != nil {
+		return err
+	}
+	service.network.Track(ip)
+	reply.Success = true
+	return nil
+}
+
+// DisconnectPeerArgs are the arguments for calling DisconnectPeer
+type DisconnectPeerArgs struct {
+	NodeID string `json:"nodeID"`
+}
+
+// DisconnectPeer closes the connection with a peer. The peer may be reconnected
+// to later, unless it's banned.
+func (service *Admin) DisconnectPeer(_ *http.Request, args *DisconnectPeerArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: DisconnectPeer called with NodeID: %s", args.NodeID)
+
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return err
+	}
+	if err := service.network.Disconnect(nodeID); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// BanArgs are the arguments for calling Ban. Exactly one of NodeID and IP must
+// be provided. Duration is formatted like "90m" or "24h".
+type BanArgs struct {
+	NodeID   string `json:"nodeID"`
+	IP       string `json:"ip"`
+	Duration string `json:"duration"`
+}
+
+// Ban bans a NodeID, or an IP on any port, for a duration, closing any
+// connections with it. Bans are stored in the node's database, so they outlive
+// the node.
+func (service *Admin) Ban(_ *http.Request, args *BanArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: Ban called with NodeID: %s, IP: %s, Duration: %s", args.NodeID, args.IP, args.Duration)
+
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+	switch {
+	case args.NodeID != "" && args.IP == "":
+		nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return err
+		}
+		if err := service.network.BanNodeID(nodeID, duration); err != nil {
+			return err
+		}
+	case args.NodeID == "" && args.IP != "":
+		ip := net.ParseIP(args.IP)
+		if ip == nil {
+			return fmt.Errorf("%w: %q", errInvalidIP, args.IP)
+		}
+		if err := service.network.BanIP(ip, duration); err != nil {
+			return err
+		}
+	default:
+		return errBanTarget
+	}
+	reply.Success = true
+	return nil
+}
+
+// UnbanArgs are the arguments for calling Unban. Exactly one of NodeID and IP
+// must be provided.
+type UnbanArgs struct {
+	NodeID string `json:"nodeID"`
+	IP     string `json:"ip"`
+}
+
+// Unban lifts the ban of a NodeID or an IP
+func (service *Admin) Unban(_ *http.Request, args *UnbanArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: Unban called with NodeID: %s, IP: %s", args.NodeID, args.IP)
+
+	switch {
+	case args.NodeID != "" && args.IP == "":
+		nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+		if err != nil {
+			return err
+		}
+		if err := service.network.UnbanNodeID(nodeID); err != nil {
+			return err
+		}
+	case args.NodeID == "" && args.IP != "":
+		ip := net.ParseIP(args.IP)
+		if ip == nil {
+			return fmt.Errorf("%w: %q", errInvalidIP, args.IP)
+		}
+		if err := service.network.UnbanIP(ip); err != nil {
+			return err
+		}
+	default:
+		return errBanTarget
+	}
+	reply.Success = true
+	return nil
+}
+
+// BannedPeer is a banned NodeID or IP
+type BannedPeer struct {
+	NodeID string    `json:"nodeID,omitempty"`
+	IP     string    `json:"ip,omitempty"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// GetBansReply are the banned NodeIDs and IPs
+type GetBansReply struct {
+	Bans []BannedPeer `json:"bans"`
+}
+
+// GetBans returns the banned NodeIDs and IPs, and when their bans expire
+func (service *Admin) GetBans(_ *http.Request, _ *struct{}, reply *GetBansReply) error {
+	service.log.Info("Admin: GetBans called")
+
+	bans := service.network.Bans()
+	reply.Bans = make([]BannedPeer, len(bans))
+	for i, ban := range bans {
+		reply.Bans[i].Expiry = ban.Expiry
+		if ban.IP == nil {
+			reply.Bans[i].NodeID = ban.NodeID.PrefixedString(constants.NodeIDPrefix)
+		} else {
+			reply.Bans[i].IP = ban.IP.String()
+		}
+	}
+	return nil
+}
+
+// BenchArgs are the arguments for calling Bench. Duration is formatted like
+// "90m" or "24h".
+type BenchArgs struct {
+	NodeID   string `json:"nodeID"`
+	Chain    string `json:"chain"`
+	Duration string `json:"duration"`
+}
+
+// Bench benches a NodeID on a chain for a duration, so that requests to it
+// regarding the chain fail immediately. Benched NodeIDs are stored in the
+// node's database, so they stay benched across restarts.
+func (service *Admin) Bench(_ *http.Request, args *BenchArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: Bench called with NodeID: %s, Chain: %s, Duration: %s", args.NodeID, args.Chain, args.Duration)
+
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return err
+	}
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+	if err := service.benchlist.Bench(chainID, nodeID, duration); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
+
+// UnbenchArgs are the arguments for calling Unbench
+type UnbenchArgs struct {
+	NodeID string `json:"nodeID"`
+	Chain  string `json:"chain"`
+}
+
+// Unbench takes a NodeID off the bench of a chain
+func (service *Admin) Unbench(_ *http.Request, args *UnbenchArgs, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: Unbench called with NodeID: %s, Chain: %s", args.NodeID, args.Chain)
+
+	nodeID, err := ids.ShortFromPrefixedString(args.NodeID, constants.NodeIDPrefix)
+	if err != nil {
+		return err
+	}
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	if err := service.benchlist.Unbench(chainID, nodeID); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
@@ -141,3 +141,6 @@ func (n *noOp) RegisterCheck(_ string, _ healthlib.Check) error {
 func (n *noOp) RegisterMonotonicCheck(_ string, _ healthlib.Check) error {
 	return nil
 }
+
+// Stop implements the Service interface
+func (n *noOp) Stop() {}
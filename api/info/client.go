@@ -81,3 +81,12 @@ func (c *Client) GetNodeIP() (string, error) {
 	err := c.requester.SendRequest("getNodeIP", struct{}{}, res)
 	return res.IP, err
 }
+
+// GetBenched ...
+func (c *Client) GetBenched(chain string) ([]BenchedNode, error) {
+	res := &GetBenchedReply{}
+	err := c.requester.SendRequest("getBenched", &GetBenchedArgs{
+		Chain: chain,
+	}, res)
+	return res.Benched, err
+}
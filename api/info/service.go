@@ -6,6 +6,7 @@ package info
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -27,6 +29,7 @@ type Info struct {
 	log           logging.Logger
 	networking    network.Network
 	chainManager  chains.Manager
+	benchlist     benchlist.Manager
 	creationTxFee uint64
 	txFee         uint64
 }
@@ -39,6 +42,7 @@ func NewService(
 	networkID uint32,
 	chainManager chains.Manager,
 	peers network.Network,
+	benchlist benchlist.Manager,
 	creationTxFee uint64,
 	txFee uint64,
 ) (*common.HTTPHandler, error) {
@@ -53,6 +57,7 @@ func NewService(
 		log:           log,
 		chainManager:  chainManager,
 		networking:    peers,
+		benchlist:     benchlist,
 		creationTxFee: creationTxFee,
 		txFee:         txFee,
 	}, "info"); err != nil {
@@ -216,3 +221,59 @@ func (service *Info) GetNodeIP(_ *http.Request, _ *struct{}, reply *GetNodeIPRep
 	reply.IP = service.networking.IP().String()
 	return nil
 }
+
+// GetBenchedArgs are the arguments for calling GetBenched
+type GetBenchedArgs struct {
+	// Alias of the chain
+	// Can also be the string representation of the chain's ID
+	Chain string `json:"chain"`
+}
+
+// BenchedNode is a node that is benched on a chain
+type BenchedNode struct {
+	NodeID string    `json:"nodeID"`
+	Since  time.Time `json:"since"`
+	Until  time.Time `json:"until"`
+	// Number of consecutive requests to the node that failed before it was
+	// benched, and when the first of them failed. Failures is 0 and
+	// FirstFailure is omitted if the node was benched manually.
+	Failures     json.Uint32 `json:"failures"`
+	FirstFailure *time.Time  `json:"firstFailure,omitempty"`
+	Manual       bool        `json:"manual"`
+}
+
+// GetBenchedReply are the results from calling GetBenched
+type GetBenchedReply struct {
+	Benched []BenchedNode `json:"benched"`
+}
+
+// GetBenched returns the nodes that are benched on a chain, ordered by when
+// they leave the bench. Requests to benched nodes regarding the chain fail
+// immediately rather than waiting to time out.
+func (service *Info) GetBenched(_ *http.Request, args *GetBenchedArgs, reply *GetBenchedReply) error {
+	service.log.Info("Info: GetBenched called with chain: %s", args.Chain)
+
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return fmt.Errorf("there is no chain with alias/ID '%s'", args.Chain)
+	}
+	benched, err := service.benchlist.Benched(chainID)
+	if err != nil {
+		return err
+	}
+	reply.Benched = make([]BenchedNode, len(benched))
+	for i, vdr := range benched {
+		reply.Benched[i] = BenchedNode{
+			NodeID:   vdr.ValidatorID.PrefixedString(constants.NodeIDPrefix),
+			Since:    vdr.Since,
+			Until:    vdr.Until,
+			Failures: json.Uint32(vdr.Failures),
+			Manual:   vdr.Manual,
+		}
+		if !vdr.Manual {
+			firstFailure := vdr.FirstFailure
+			reply.Benched[i].FirstFailure = &firstFailure
+		}
+	}
+	return nil
+}
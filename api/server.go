@@ -86,9 +86,7 @@ func (s *Server) Dispatch() error {
 		return err
 	}
 	s.log.Info("HTTP API server listening on %q", s.listenAddress)
-	handler := cors.Default().Handler(s.router)
-	handler = s.auth.WrapHandler(handler)
-	s.srv = &http.Server{Handler: handler}
+	s.srv = &http.Server{Handler: s.Handler()}
 	return s.srv.Serve(listener)
 }
 
@@ -99,9 +97,15 @@ func (s *Server) DispatchTLS(certFile, keyFile string) error {
 		return err
 	}
 	s.log.Info("HTTPS API server listening on %q", s.listenAddress)
+	return http.ServeTLS(listener, s.Handler(), certFile, keyFile)
+}
+
+// Handler returns the handler that serves this server's API requests, so that
+// they can also be served by a server that isn't listening on the configured
+// address
+func (s *Server) Handler() http.Handler {
 	handler := cors.Default().Handler(s.router)
-	handler = s.auth.WrapHandler(handler)
-	return http.ServeTLS(listener, handler, certFile, keyFile)
+	return s.auth.WrapHandler(handler)
 }
 
 // RegisterChain registers the API endpoints associated with this chain That is,
@@ -24,6 +24,18 @@ type Cacher interface {
 	Flush()
 }
 
+// SizedCacher is a Cacher that tracks the total size of the values it holds
+type SizedCacher interface {
+	Cacher
+
+	// Bytes returns the total size of the values in the cache
+	Bytes() int
+
+	// PutWithSize inserts an element of size [size] into the cache, for
+	// callers that already know the size of the value
+	PutWithSize(key ids.ID, value interface{}, size int)
+}
+
 // Evictable allows the object to be notified when it is evicted
 type Evictable interface {
 	ID() ids.ID
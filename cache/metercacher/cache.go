@@ -20,10 +20,19 @@ type Cache struct {
 func New(
 	namespace string,
 	registerer prometheus.Registerer,
-	cache cache.Cacher,
+	cacher cache.Cacher,
 ) (cache.Cacher, error) {
-	meterCache := &Cache{cache: cache}
-	return meterCache, meterCache.metrics.Initialize(namespace, registerer)
+	meterCache := &Cache{cache: cacher}
+	if err := meterCache.metrics.Initialize(namespace, registerer); err != nil {
+		return nil, err
+	}
+	if sizedCache, ok := cacher.(cache.SizedCacher); ok {
+		return &SizedCache{
+			Cache: meterCache,
+			sized: sizedCache,
+		}, meterCache.metrics.InitializeSize(namespace, registerer, sizedCache)
+	}
+	return meterCache, nil
 }
 
 func (c *Cache) Put(key ids.ID, value interface{}) {
@@ -60,3 +69,18 @@ func (c *Cache) Flush() {
 	end := c.clock.Time()
 	c.flush.Observe(float64(end.Sub(start)))
 }
+
+// SizedCache is a Cache that wraps a cache.SizedCacher
+type SizedCache struct {
+	*Cache
+	sized cache.SizedCacher
+}
+
+func (c *SizedCache) Bytes() int { return c.sized.Bytes() }
+
+func (c *SizedCache) PutWithSize(key ids.ID, value interface{}, size int) {
+	start := c.clock.Time()
+	c.sized.PutWithSize(key, value, size)
+	end := c.clock.Time()
+	c.put.Observe(float64(end.Sub(start)))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
 )
 
 func TestInterface(t *testing.T) {
@@ -19,3 +20,46 @@ func TestInterface(t *testing.T) {
 		test.Func(t, c)
 	}
 }
+
+func TestSizedInterface(t *testing.T) {
+	for _, test := range cache.CacherTests {
+		cache := &cache.SizedLRU{
+			MaxSize:  test.Size,
+			SizeFunc: func(interface{}) int { return 1 },
+		}
+		c, err := New("", prometheus.NewRegistry(), cache)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test.Func(t, c)
+	}
+}
+
+func TestBytesMetric(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c, err := New("", registry, &cache.SizedLRU{
+		MaxSize:  10,
+		SizeFunc: func(value interface{}) int { return len(value.([]byte)) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Put(ids.ID{1}, make([]byte, 4))
+	c.(cache.SizedCacher).PutWithSize(ids.ID{2}, nil, 3)
+
+	metrics, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, metric := range metrics {
+		if metric.GetName() != "bytes" {
+			continue
+		}
+		if value := metric.GetMetric()[0].GetGauge().GetValue(); value != 7 {
+			t.Fatalf("bytes metric is %f but expected %d", value, 7)
+		}
+		return
+	}
+	t.Fatal("missing bytes metric")
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
@@ -61,3 +62,19 @@ func (m *metrics) Initialize(
 	)
 	return errs.Err
 }
+
+// InitializeSize reports the number of bytes held by [sizedCache]
+func (m *metrics) InitializeSize(
+	namespace string,
+	registerer prometheus.Registerer,
+	sizedCache cache.SizedCacher,
+) error {
+	return registerer.Register(prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "bytes",
+			Help:      "Total size of the values held by the cache",
+		},
+		func() float64 { return float64(sizedCache.Bytes()) },
+	))
+}
@@ -0,0 +1,157 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+type sizedEntry struct {
+	Key   ids.ID
+	Value interface{}
+	Size  int
+}
+
+// SizedLRU is a key value store bounded by the total size of its values, as
+// reported by SizeFunc. If the size is attempted to be exceeded, then the least
+// recently used values are removed from the cache until the insertion fits. A
+// value larger than MaxSize is never cached.
+type SizedLRU struct {
+	lock        sync.Mutex
+	entryMap    map[ids.ID]*list.Element
+	entryList   *list.List
+	currentSize int
+
+	// MaxSize is the maximum total size of the values in the cache
+	MaxSize int
+	// SizeFunc returns the size of a value, usually in bytes
+	SizeFunc func(value interface{}) int
+}
+
+// Put implements the cache interface
+func (c *SizedLRU) Put(key ids.ID, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value, c.SizeFunc(value))
+}
+
+// PutWithSize implements the SizedCacher interface
+func (c *SizedLRU) PutWithSize(key ids.ID, value interface{}, size int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value, size)
+}
+
+// Get implements the cache interface
+func (c *SizedLRU) Get(key ids.ID) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+// Evict implements the cache interface
+func (c *SizedLRU) Evict(key ids.ID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+// Flush implements the cache interface
+func (c *SizedLRU) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+// Bytes implements the SizedCacher interface
+func (c *SizedLRU) Bytes() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.currentSize
+}
+
+func (c *SizedLRU) init() {
+	if c.entryMap == nil {
+		c.entryMap = make(map[ids.ID]*list.Element, minCacheSize)
+	}
+	if c.entryList == nil {
+		c.entryList = list.New()
+	}
+	if c.MaxSize < 0 {
+		c.MaxSize = 0
+	}
+}
+
+func (c *SizedLRU) resize() {
+	for c.currentSize > c.MaxSize {
+		c.remove(c.entryList.Front())
+	}
+}
+
+func (c *SizedLRU) remove(e *list.Element) {
+	c.entryList.Remove(e)
+
+	val := e.Value.(*sizedEntry)
+	delete(c.entryMap, val.Key)
+	c.currentSize -= val.Size
+}
+
+func (c *SizedLRU) put(key ids.ID, value interface{}, size int) {
+	c.init()
+
+	if e, ok := c.entryMap[key]; ok {
+		c.remove(e)
+	}
+	if size > c.MaxSize {
+		c.resize()
+		return
+	}
+
+	c.entryMap[key] = c.entryList.PushBack(&sizedEntry{
+		Key:   key,
+		Value: value,
+		Size:  size,
+	})
+	c.currentSize += size
+	c.resize()
+}
+
+func (c *SizedLRU) get(key ids.ID) (interface{}, bool) {
+	c.init()
+	c.resize()
+
+	if e, ok := c.entryMap[key]; ok {
+		c.entryList.MoveToBack(e)
+
+		val := e.Value.(*sizedEntry)
+		return val.Value, true
+	}
+	return struct{}{}, false
+}
+
+func (c *SizedLRU) evict(key ids.ID) {
+	c.init()
+	c.resize()
+
+	if e, ok := c.entryMap[key]; ok {
+		c.remove(e)
+	}
+}
+
+func (c *SizedLRU) flush() {
+	c.init()
+
+	c.entryMap = make(map[ids.ID]*list.Element, minCacheSize)
+	c.entryList = list.New()
+	c.currentSize = 0
+}
@@ -0,0 +1,133 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func unitSize(interface{}) int { return 1 }
+
+func bytesSize(value interface{}) int { return len(value.([]byte)) }
+
+func TestSizedLRU(t *testing.T) {
+	cache := &SizedLRU{MaxSize: 1, SizeFunc: unitSize}
+
+	TestBasic(t, cache)
+}
+
+func TestSizedLRUEviction(t *testing.T) {
+	cache := &SizedLRU{MaxSize: 2, SizeFunc: unitSize}
+
+	TestEviction(t, cache)
+}
+
+func TestSizedLRUEvictsBySize(t *testing.T) {
+	cache := &SizedLRU{MaxSize: 10, SizeFunc: bytesSize}
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+	id3 := ids.ID{3}
+
+	cache.Put(id1, make([]byte, 4))
+	cache.Put(id2, make([]byte, 4))
+	if size := cache.Bytes(); size != 8 {
+		t.Fatalf("cache holds %d bytes but expected %d", size, 8)
+	}
+
+	// Inserting 6 bytes requires evicting the least recently used value, id1
+	cache.Get(id2)
+	cache.Put(id3, make([]byte, 6))
+	if _, found := cache.Get(id1); found {
+		t.Fatalf("Retrieved value when none exists")
+	}
+	if _, found := cache.Get(id2); !found {
+		t.Fatalf("Failed to retrieve value when one exists")
+	}
+	if _, found := cache.Get(id3); !found {
+		t.Fatalf("Failed to retrieve value when one exists")
+	}
+	if size := cache.Bytes(); size != 10 {
+		t.Fatalf("cache holds %d bytes but expected %d", size, 10)
+	}
+
+	// Replacing a value updates the size
+	cache.Put(id2, make([]byte, 1))
+	if size := cache.Bytes(); size != 7 {
+		t.Fatalf("cache holds %d bytes but expected %d", size, 7)
+	}
+
+	cache.Evict(id3)
+	if size := cache.Bytes(); size != 1 {
+		t.Fatalf("cache holds %d bytes but expected %d", size, 1)
+	}
+
+	cache.Flush()
+	if size := cache.Bytes(); size != 0 {
+		t.Fatalf("cache holds %d bytes but expected %d", size, 0)
+	}
+}
+
+func TestSizedLRUTooLarge(t *testing.T) {
+	cache := &SizedLRU{MaxSize: 10, SizeFunc: bytesSize}
+
+	id1 := ids.ID{1}
+	cache.Put(id1, make([]byte, 4))
+
+	// A value larger than the cache is never cached, and replaces the previous
+	// value for the key
+	cache.Put(id1, make([]byte, 11))
+	if _, found := cache.Get(id1); found {
+		t.Fatalf("Retrieved value larger than the cache")
+	}
+	if size := cache.Bytes(); size != 0 {
+		t.Fatalf("cache holds %d bytes but expected %d", size, 0)
+	}
+}
+
+func TestSizedLRUPutWithSize(t *testing.T) {
+	cache := &SizedLRU{
+		MaxSize: 10,
+		SizeFunc: func(interface{}) int {
+			t.Fatal("shouldn't size values put with their size")
+			return 0
+		},
+	}
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+
+	cache.PutWithSize(id1, "value1", 6)
+	cache.PutWithSize(id2, "value2", 6)
+	if _, found := cache.Get(id1); found {
+		t.Fatalf("Retrieved value when none exists")
+	}
+	if size := cache.Bytes(); size != 6 {
+		t.Fatalf("cache holds %d bytes but expected %d", size, 6)
+	}
+}
+
+func TestSizedLRUResize(t *testing.T) {
+	cache := &SizedLRU{MaxSize: 10, SizeFunc: bytesSize}
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+
+	cache.Put(id1, make([]byte, 5))
+	cache.Put(id2, make([]byte, 5))
+
+	cache.MaxSize = 5
+	if _, found := cache.Get(id1); found {
+		t.Fatalf("Retrieved value when none exists")
+	} else if _, found := cache.Get(id2); !found {
+		t.Fatalf("Failed to retrieve value when one exists")
+	}
+
+	cache.MaxSize = 0
+	if _, found := cache.Get(id2); found {
+		t.Fatalf("Retrieved value when none exists")
+	}
+}
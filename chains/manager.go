@@ -4,8 +4,11 @@
 package chains
 
 import (
+	"crypto"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/backup"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
@@ -24,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/networking/capture"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
@@ -32,6 +37,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/proposervm"
 
 	avcon "github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 	aveng "github.com/ava-labs/avalanchego/snow/engine/avalanche"
@@ -44,6 +50,22 @@ import (
 
 const (
 	defaultChannelSize = 1024
+
+	// Identifier that captures are registered for decision events under
+	captureHandlerID = "capture"
+
+	// Suffix of the name of the directory that the databases of a captured
+	// chain are backed up to when the capture starts
+	captureBackupSuffix = ".backup"
+
+	// Prefix of the shared memory in the node's database
+	sharedMemoryPrefix = "shared memory"
+)
+
+var (
+	errInvalidCaptureName   = errors.New("capture name must be a file name")
+	errNoValidatorState     = errors.New("proposer windows require the P-chain's validator sets")
+	errPlatformChainWindows = errors.New("proposer windows can't be enabled on the P-chain, as they're sampled from its validator sets")
 )
 
 // Manager manages the chains running on this node.
@@ -85,6 +107,13 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Record the messages delivered to the chain with the given ID, and the
+	// chain's decisions, to a new file named [name] in the capture directory
+	// for [duration]. The chain's database and the shared memory are backed
+	// up, as they were when the capture started, to the directory [name] with
+	// the suffix ".backup".
+	StartCapture(chainID ids.ID, name string, duration time.Duration) error
+
 	Shutdown()
 }
 
@@ -121,6 +150,7 @@ type ManagerConfig struct {
 	DecisionEvents            *triggers.EventDispatcher
 	ConsensusEvents           *triggers.EventDispatcher
 	DB                        database.Database
+	DBVersion                 string // Version of [DB]
 	Router                    router.Router    // Routes incoming messages to the appropriate chain
 	Net                       network.Network  // Sends consensus messages to other validators
 	ConsensusParams           avcon.Parameters // The consensus parameters (alpha, beta, etc.) for new chains
@@ -138,8 +168,17 @@ type ManagerConfig struct {
 	WhitelistedSubnets        ids.Set          // Subnets to validate
 	TimeoutManager            *timeout.Manager // Manages request timeouts when sending messages to other validators
 	HealthService             health.Service
-	RetryBootstrap            bool // Should Bootstrap be retried
-	RetryBootstrapMaxAttempts int  // Max number of times to retry bootstrap
+	RetryBootstrap            bool   // Should Bootstrap be retried
+	RetryBootstrapMaxAttempts int    // Max number of times to retry bootstrap
+	VertexCacheBytes          int    // If positive, byte budget of each chain's vertex cache
+	CaptureDir                string // Directory that captures are written to
+	CaptureMaxBytes           uint64 // If positive, the maximum size of a capture
+
+	// IDs or aliases of VMs --> Proposer windows of their chains. Only snowman
+	// VMs may have proposer windows. Blocks are signed with the staking key.
+	ProposerWindows map[string]proposervm.Config
+	StakingKey      crypto.Signer
+	StakingCert     []byte // DER encoded
 }
 
 type manager struct {
@@ -161,6 +200,10 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]*router.Handler
+
+	// Validator sets of the P-chain, which proposer windows are sampled from.
+	// Set once the P-chain is created.
+	validatorState validators.State
 }
 
 // New returns a new Manager
@@ -360,6 +403,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 	case block.ChainVM:
 		chain, err = m.createSnowmanChain(
 			ctx,
+			vmID,
 			chainParams.GenesisData,
 			vdrs,
 			beacons,
@@ -441,20 +485,34 @@ func (m *manager) createAvalancheChain(
 	// VM uses this channel to notify engine that a block is ready to be made
 	msgChan := make(chan common.Message, defaultChannelSize)
 
+	// Passes messages from the consensus engine to the network
+	sender := sender.Sender{}
+	err = sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager, m.ConsensusParams.Namespace, m.ConsensusParams.Metrics)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
+	}
+
+	// Records the requests and samples of the engine while the chain is being
+	// captured
+	recorder := &capture.Recorder{}
+	sender.SetRecorder(recorder)
+
+	// If the VM exchanges application-level messages, it sends them with the
+	// same sender as the consensus engine
+	appHandler, isAppHandler := vm.(common.AppHandler)
+	if isAppHandler {
+		appHandler.SetAppSender(&sender)
+	}
+
 	if err := vm.Initialize(ctx, vmDB, genesisData, msgChan, fxs); err != nil {
 		return nil, fmt.Errorf("error during vm's Initialize: %w", err)
 	}
 
 	// Handles serialization/deserialization of vertices and also the
 	// persistence of vertices
-	vtxManager := &state.Serializer{}
-	vtxManager.Initialize(ctx, vm, vertexDB)
-
-	// Passes messages from the consensus engine to the network
-	sender := sender.Sender{}
-	err = sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager, m.ConsensusParams.Namespace, m.ConsensusParams.Metrics)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
+	vtxManager := &state.Serializer{CacheBytes: m.VertexCacheBytes}
+	if err := vtxManager.Initialize(ctx, vm, vertexDB); err != nil {
+		return nil, fmt.Errorf("couldn't initialize vertex manager: %w", err)
 	}
 
 	sampleK := consensusParams.K
@@ -470,7 +528,7 @@ func (m *manager) createAvalancheChain(
 		Config: avbootstrap.Config{
 			Config: common.Config{
 				Ctx:                       ctx,
-				Validators:                validators,
+				Validators:                capture.NewValidators(validators, recorder),
 				Beacons:                   beacons,
 				SampleK:                   sampleK,
 				StartupAlpha:              (3*bootstrapWeight + 3) / 4,
@@ -521,6 +579,10 @@ func (m *manager) createAvalancheChain(
 		consensusParams.Metrics,
 		delay,
 	)
+	handler.SetRecorder(recorder)
+	if isAppHandler {
+		handler.SetAppHandler(appHandler)
+	}
 
 	return &chain{
 		Name:    chainAlias,
@@ -534,6 +596,7 @@ func (m *manager) createAvalancheChain(
 // Create a linear chain using the Snowman consensus engine
 func (m *manager) createSnowmanChain(
 	ctx *snow.Context,
+	vmID ids.ID,
 	genesisData []byte,
 	validators,
 	beacons validators.Set,
@@ -559,11 +622,6 @@ func (m *manager) createSnowmanChain(
 	// VM uses this channel to notify engine that a block is ready to be made
 	msgChan := make(chan common.Message, defaultChannelSize)
 
-	// Initialize the VM
-	if err := vm.Initialize(ctx, vmDB, genesisData, msgChan, fxs); err != nil {
-		return nil, err
-	}
-
 	// Passes messages from the consensus engine to the network
 	sender := sender.Sender{}
 	err = sender.Initialize(
@@ -578,6 +636,52 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
 	}
 
+	// Records the requests and samples of the engine while the chain is being
+	// captured
+	recorder := &capture.Recorder{}
+	sender.SetRecorder(recorder)
+
+	// If the VM exchanges application-level messages, it sends them with the
+	// same sender as the consensus engine
+	appHandler, isAppHandler := vm.(common.AppHandler)
+	if isAppHandler {
+		appHandler.SetAppSender(&sender)
+	}
+
+	// Proposer windows of other chains are sampled from the P-chain's
+	// validator sets
+	if ctx.ChainID == constants.PlatformChainID {
+		m.setValidatorState(ctx, vm)
+	}
+
+	// Wrap the VM if its blocks have proposer windows
+	if config, ok := m.proposerWindows(vmID); ok {
+		switch {
+		case ctx.ChainID == constants.PlatformChainID:
+			return nil, errPlatformChainWindows
+		case m.validatorState == nil:
+			return nil, errNoValidatorState
+		}
+		ctx.Log.Info("enabling proposer windows with %d proposers and %s windows",
+			config.NumProposers, config.WindowDuration)
+		vm, err = proposervm.New(
+			vm,
+			config,
+			prefixdb.New([]byte("proposer"), db),
+			m.validatorState,
+			m.StakingKey,
+			m.StakingCert,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't enable proposer windows: %w", err)
+		}
+	}
+
+	// Initialize the VM
+	if err := vm.Initialize(ctx, vmDB, genesisData, msgChan, fxs); err != nil {
+		return nil, err
+	}
+
 	sampleK := consensusParams.K
 	if uint64(sampleK) > bootstrapWeight {
 		sampleK = int(bootstrapWeight)
@@ -591,7 +695,7 @@ func (m *manager) createSnowmanChain(
 		Config: smbootstrap.Config{
 			Config: common.Config{
 				Ctx:                       ctx,
-				Validators:                validators,
+				Validators:                capture.NewValidators(validators, recorder),
 				Beacons:                   beacons,
 				SampleK:                   sampleK,
 				StartupAlpha:              (3*bootstrapWeight + 3) / 4,
@@ -626,6 +730,10 @@ func (m *manager) createSnowmanChain(
 		consensusParams.Metrics,
 		delay,
 	)
+	handler.SetRecorder(recorder)
+	if isAppHandler {
+		handler.SetAppHandler(appHandler)
+	}
 
 	// Register health checks
 	chainAlias, err := m.PrimaryAlias(ctx.ChainID)
@@ -651,6 +759,35 @@ func (m *manager) createSnowmanChain(
 	}, nil
 }
 
+// proposerWindows returns the proposer windows of chains of the VM [vmID], if
+// it has any. VMs are registered after the manager is created, so their
+// aliases are only resolved once a chain is created.
+func (m *manager) proposerWindows(vmID ids.ID) (proposervm.Config, bool) {
+	for vmAlias, config := range m.ProposerWindows {
+		id, err := m.VMManager.Lookup(vmAlias)
+		if err != nil {
+			m.Log.Warn("proposer windows are configured for unknown VM %s", vmAlias)
+			continue
+		}
+		if id == vmID {
+			return config, true
+		}
+	}
+	return proposervm.Config{}, false
+}
+
+// setValidatorState stores the validator sets of the P-chain, whose context
+// is [ctx] and whose VM is [vm], so that the proposers of other chains can be
+// sampled from them
+func (m *manager) setValidatorState(ctx *snow.Context, vm block.ChainVM) {
+	state, ok := vm.(validators.State)
+	if !ok {
+		m.Log.Warn("the P-chain's VM doesn't provide its validator sets")
+		return
+	}
+	m.validatorState = validators.NewLockedState(&ctx.Lock, state)
+}
+
 func (m *manager) SubnetID(chainID ids.ID) (ids.ID, error) {
 	m.chainsLock.Lock()
 	defer m.chainsLock.Unlock()
@@ -673,6 +810,126 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Engine().IsBootstrapped()
 }
 
+func (m *manager) StartCapture(chainID ids.ID, name string, duration time.Duration) error {
+	// Captures may only be written to the capture directory
+	if name == "" || name == "." || name == ".." || name != filepath.Base(name) {
+		return fmt.Errorf("%w: %q", errInvalidCaptureName, name)
+	}
+
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return fmt.Errorf("unknown chain %s", chainID)
+	}
+
+	if err := os.MkdirAll(m.CaptureDir, 0700); err != nil {
+		return fmt.Errorf("couldn't create capture directory: %w", err)
+	}
+	path := filepath.Join(m.CaptureDir, name)
+	backupDir := path + captureBackupSuffix
+	if err := os.Mkdir(backupDir, 0700); err != nil {
+		return fmt.Errorf("couldn't create backup directory: %w", err)
+	}
+
+	// No message is delivered to the chain while its lock is held, so the
+	// capture starts from the state that is backed up
+	ctx := chain.Context()
+	ctx.Lock.Lock()
+	c, snapshots, err := m.startCapture(chain, path)
+	ctx.Lock.Unlock()
+	if err != nil {
+		_ = os.Remove(backupDir)
+		return err
+	}
+	m.Log.Info("capturing the messages of chain %s to %s for %s", chainID, path, duration)
+	go m.backupCapturedChain(chainID, backupDir, snapshots, time.Now())
+
+	time.AfterFunc(duration, func() {
+		chain.StopCapture(c)
+		if m.DecisionEvents != nil {
+			_ = m.DecisionEvents.DeregisterChain(chainID, captureHandlerID)
+		}
+		numRecords, err := c.Stop()
+		if err != nil {
+			m.Log.Error("capture of chain %s to %s failed after %d records: %s", chainID, path, numRecords, err)
+			return
+		}
+		if c.Full() {
+			m.Log.Warn("capture of chain %s to %s reached its maximum size of %d bytes, so later records were dropped",
+				chainID, path, m.CaptureMaxBytes)
+		}
+		m.Log.Info("captured %d records of chain %s to %s", numRecords, chainID, path)
+	})
+	return nil
+}
+
+// startCapture starts capturing the events of [chain] to a new file at
+// [path], and returns snapshots of the chain's database and of the shared
+// memory. Assumes the chain's lock is held.
+func (m *manager) startCapture(chain *router.Handler, path string) (*capture.Capture, []backup.Prefixed, error) {
+	chainID := chain.Context().ChainID
+	hdr := capture.Header{ChainID: chainID}
+	if engine, ok := chain.Engine().(*smeng.Transitive); ok {
+		lastAccepted, err := engine.VM.LastAccepted()
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't get last accepted block: %w", err)
+		}
+		hdr.LastAccepted = lastAccepted
+	}
+
+	snapshots := []backup.Prefixed(nil)
+	for _, prefix := range [][]byte{chainID[:], []byte(sharedMemoryPrefix)} {
+		snapshot, err := prefixdb.New(prefix, m.DB).NewSnapshot()
+		if err != nil {
+			releaseSnapshots(snapshots)
+			return nil, nil, fmt.Errorf("couldn't snapshot the database: %w", err)
+		}
+		snapshots = append(snapshots, backup.Prefixed{
+			Prefix:   prefix,
+			Snapshot: snapshot,
+		})
+	}
+
+	c, err := capture.Start(path, hdr, m.CaptureMaxBytes)
+	if err != nil {
+		releaseSnapshots(snapshots)
+		return nil, nil, fmt.Errorf("couldn't create capture file: %w", err)
+	}
+	if err := chain.StartCapture(c); err != nil {
+		_, _ = c.Stop()
+		_ = os.Remove(path)
+		releaseSnapshots(snapshots)
+		return nil, nil, err
+	}
+	if m.DecisionEvents != nil {
+		if err := m.DecisionEvents.RegisterChain(chainID, captureHandlerID, c); err != nil {
+			m.Log.Warn("decisions of chain %s won't be captured: %s", chainID, err)
+		}
+	}
+	return c, snapshots, nil
+}
+
+// backupCapturedChain writes [snapshots], which were created at [timestamp]
+// when the capture of chain [chainID] started, to [dir]. The capture can be
+// replayed on the backup.
+func (m *manager) backupCapturedChain(chainID ids.ID, dir string, snapshots []backup.Prefixed, timestamp time.Time) {
+	defer releaseSnapshots(snapshots)
+
+	metadata, err := backup.BackupPrefixed(snapshots, timestamp, dir, m.NetworkID, m.DBVersion, m.Log, nil)
+	if err != nil {
+		m.Log.Error("backup of captured chain %s to %s failed: %s", chainID, dir, err)
+		return
+	}
+	m.Log.Info("backed up %d keys of captured chain %s to %s", metadata.Keys, chainID, dir)
+}
+
+func releaseSnapshots(snapshots []backup.Prefixed) {
+	for _, prefixed := range snapshots {
+		prefixed.Snapshot.Release()
+	}
+}
+
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
 	m.Log.Info("shutting down chain manager")
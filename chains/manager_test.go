@@ -0,0 +1,134 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/backup"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestStartCaptureOnlyWritesToCaptureDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "captures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := &manager{ManagerConfig: ManagerConfig{CaptureDir: filepath.Join(dir, "captures")}}
+	for _, name := range []string{"", ".", "..", "../capture.bin", "sub/capture.bin", filepath.Join(dir, "capture.bin")} {
+		if err := m.StartCapture(ids.GenerateTestID(), name, time.Minute); !errors.Is(err, errInvalidCaptureName) {
+			t.Fatalf("capture name %q should have failed with %s but got %v", name, errInvalidCaptureName, err)
+		}
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("expected no files to be written but found %d", len(files))
+	}
+}
+
+func TestStartCaptureBacksUpChain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "captures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+	engine := &common.EngineTest{T: t}
+	engine.Default(true)
+	engine.ContextF = func() *snow.Context { return ctx }
+	handler := &router.Handler{}
+	handler.Initialize(
+		engine,
+		validators.NewSet(),
+		nil,
+		16,
+		router.DefaultMaxNonStakerPendingMsgs,
+		router.DefaultStakerPortion,
+		router.DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+		&router.Delay{},
+	)
+
+	db := memdb.New()
+	chainDB := prefixdb.New(ctx.ChainID[:], db)
+	sharedMemoryDB := prefixdb.New([]byte(sharedMemoryPrefix), db)
+	otherDB := prefixdb.New([]byte("other"), db)
+	for _, db := range []database.Database{chainDB, sharedMemoryDB, otherDB} {
+		if err := db.Put([]byte("key"), []byte("value")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	const networkID = 12345
+	m := &manager{
+		ManagerConfig: ManagerConfig{
+			Log:        logging.NoLog{},
+			DB:         db,
+			DBVersion:  "v1.0.0",
+			NetworkID:  networkID,
+			CaptureDir: dir,
+		},
+		chains: map[ids.ID]*router.Handler{ctx.ChainID: handler},
+	}
+	if err := m.StartCapture(ctx.ChainID, "capture.bin", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	// Writes after the capture started aren't backed up
+	if err := chainDB.Put([]byte("later"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	// An existing capture or backup isn't written over
+	if err := m.StartCapture(ctx.ChainID, "capture.bin", time.Minute); err == nil {
+		t.Fatal("starting a capture with the name of an existing capture should have failed")
+	}
+
+	backupDir := filepath.Join(dir, "capture.bin"+captureBackupSuffix)
+	var metadata *backup.Metadata
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		if metadata, err = backup.ReadMetadata(backupDir); err == nil {
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("backup was never written: %s", err)
+		}
+	}
+	if metadata.Keys != 2 {
+		t.Fatalf("backup has %d keys but expected the chain's and the shared memory's", metadata.Keys)
+	}
+	backupDB, _, err := backup.OpenReadOnly(backupDir, networkID, "v1.0.0", logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backupDB.Close()
+	for _, prefix := range [][]byte{ctx.ChainID[:], []byte(sharedMemoryPrefix)} {
+		if has, err := prefixdb.New(prefix, backupDB).Has([]byte("key")); err != nil {
+			t.Fatal(err)
+		} else if !has {
+			t.Fatalf("backup is missing the database with prefix %q", prefix)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package hierarchycodec
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/codec"
@@ -22,6 +23,7 @@ const (
 type Codec interface {
 	codec.Registry
 	codec.Codec
+	codec.Describer
 	SkipRegistrations(int)
 	NextGroup()
 }
@@ -132,3 +134,28 @@ func (c *hierarchyCodec) UnpackPrefix(p *wrappers.Packer, valueType reflect.Type
 	}
 	return reflect.New(implementingType).Elem(), nil // instance of the proper type
 }
+
+// PrefixSize implements the reflectcodec.TypeCodec interface
+func (c *hierarchyCodec) PrefixSize() int { return 2 * wrappers.ShortLen }
+
+// Describe implements the codec.Describer interface. Type IDs are written as a
+// uint16 group ID followed by a uint16 type ID.
+func (c *hierarchyCodec) Describe(roots ...interface{}) (*codec.CodecSchema, error) {
+	c.lock.RLock()
+	types := make([]reflectcodec.RegisteredType, 0, len(c.typeIDToType))
+	for typeID, t := range c.typeIDToType {
+		types = append(types, reflectcodec.RegisteredType{
+			TypeID: []uint32{uint32(typeID.groupID), uint32(typeID.typeID)},
+			Type:   t,
+		})
+	}
+	c.lock.RUnlock()
+
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].TypeID[0] != types[j].TypeID[0] {
+			return types[i].TypeID[0] < types[j].TypeID[0]
+		}
+		return types[i].TypeID[1] < types[j].TypeID[1]
+	})
+	return reflectcodec.Describe(c.Codec, []string{"uint16", "uint16"}, types, roots)
+}
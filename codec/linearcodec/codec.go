@@ -6,6 +6,7 @@ package linearcodec
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/codec"
@@ -22,6 +23,7 @@ const (
 type Codec interface {
 	codec.Registry
 	codec.Codec
+	codec.Describer
 	SkipRegistrations(int)
 }
 
@@ -104,3 +106,23 @@ func (c *linearCodec) UnpackPrefix(p *wrappers.Packer, valueType reflect.Type) (
 	}
 	return reflect.New(implementingType).Elem(), nil // instance of the proper type
 }
+
+// PrefixSize implements the reflectcodec.TypeCodec interface
+func (c *linearCodec) PrefixSize() int { return wrappers.IntLen }
+
+// Describe implements the codec.Describer interface. Type IDs are written as a
+// uint32.
+func (c *linearCodec) Describe(roots ...interface{}) (*codec.CodecSchema, error) {
+	c.lock.RLock()
+	types := make([]reflectcodec.RegisteredType, 0, len(c.typeIDToType))
+	for typeID, t := range c.typeIDToType {
+		types = append(types, reflectcodec.RegisteredType{
+			TypeID: []uint32{typeID},
+			Type:   t,
+		})
+	}
+	c.lock.RUnlock()
+
+	sort.Slice(types, func(i, j int) bool { return types[i].TypeID[0] < types[j].TypeID[0] })
+	return reflectcodec.Describe(c.Codec, []string{"uint32"}, types, roots)
+}
@@ -4,6 +4,7 @@
 package linearcodec
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/codec"
@@ -15,3 +16,26 @@ func TestVectors(t *testing.T) {
 		test(c, t)
 	}
 }
+
+func TestDescribeTypeIDs(t *testing.T) {
+	type registered struct{}
+
+	c := NewDefault()
+	c.SkipRegistrations(5)
+	if err := c.RegisterType(&registered{}); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := c.Describe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"uint32"}; !reflect.DeepEqual(schema.TypeIDPrefix, expected) {
+		t.Fatalf("expected type ID prefix %v but got %v", expected, schema.TypeIDPrefix)
+	}
+	if len(schema.Types) != 1 {
+		t.Fatalf("expected 1 registered type but got %d", len(schema.Types))
+	}
+	if expected := []uint32{5}; !reflect.DeepEqual(schema.Types[0].TypeID, expected) {
+		t.Fatalf("expected type ID %v but got %v", expected, schema.Types[0].TypeID)
+	}
+}
@@ -47,6 +47,11 @@ type Manager interface {
 	// be a pointer or an interface. Returns the version of the codec that
 	// produces the given bytes.
 	Unmarshal(source []byte, destination interface{}) (version uint16, err error)
+
+	// Schema returns a description of the wire format of every registered
+	// codec version. [roots] are values that are marshaled directly, rather
+	// than as the value of an interface, whose types should be described too.
+	Schema(roots ...interface{}) (*Schema, error)
 }
 
 // NewManager returns a new codec manager.
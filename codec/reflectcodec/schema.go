@@ -0,0 +1,190 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reflectcodec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// Wire types of the length prefixes of slices and strings
+	sliceLengthPrefix  = "uint32"
+	stringLengthPrefix = "uint16"
+)
+
+var errNotReflectCodec = errors.New("codec wasn't created by reflectcodec")
+
+// RegisteredType is a type registered with a codec, with the parts of its type
+// ID in the order they are written
+type RegisteredType struct {
+	TypeID []uint32
+	Type   reflect.Type
+}
+
+// Describe returns the schema of [c], which must have been returned by New.
+// [typeIDPrefix] are the wire types of the parts of a type ID and [types] are
+// the types registered with [c], in type ID order.
+func Describe(
+	c codec.Codec,
+	typeIDPrefix []string,
+	types []RegisteredType,
+	roots []interface{},
+) (*codec.CodecSchema, error) {
+	gc, ok := c.(*genericCodec)
+	if !ok {
+		return nil, errNotReflectCodec
+	}
+	d := describer{
+		codec: gc,
+		types: types,
+		schema: &codec.CodecSchema{
+			TypeIDPrefix: typeIDPrefix,
+			Types:        make([]*codec.RegisteredType, len(types)),
+			Structs:      make(map[string]*codec.StructSchema),
+			Interfaces:   make(map[string][]string),
+		},
+	}
+	for i, t := range types {
+		typeSchema, err := d.describe(t.Type, gc.maxSliceLen)
+		if err != nil {
+			return nil, err
+		}
+		d.schema.Types[i] = &codec.RegisteredType{
+			TypeID: t.TypeID,
+			Name:   typeName(t.Type),
+			Type:   typeSchema,
+		}
+	}
+	for _, root := range roots {
+		typeSchema, err := d.describe(reflect.TypeOf(root), gc.maxSliceLen)
+		if err != nil {
+			return nil, err
+		}
+		d.schema.Roots = append(d.schema.Roots, typeSchema)
+	}
+	return d.schema, nil
+}
+
+type describer struct {
+	codec  *genericCodec
+	types  []RegisteredType
+	schema *codec.CodecSchema
+}
+
+// describe [t] as it is marshaled, adding the structs and interfaces it
+// references to the schema
+func (d *describer) describe(t reflect.Type, maxSliceLen int) (*codec.TypeSchema, error) {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &codec.TypeSchema{
+			Kind: t.Kind().String(),
+			Name: namedType(t),
+		}, nil
+	case reflect.String:
+		if maxSliceLen > wrappers.MaxStringLen {
+			maxSliceLen = wrappers.MaxStringLen
+		}
+		return &codec.TypeSchema{
+			Kind:         codec.KindString,
+			Name:         namedType(t),
+			LengthPrefix: stringLengthPrefix,
+			MaxLength:    maxSliceLen,
+		}, nil
+	case reflect.Ptr:
+		// Pointers are transparent
+		return d.describe(t.Elem(), d.codec.maxSliceLen)
+	case reflect.Slice:
+		elem, err := d.describe(t.Elem(), d.codec.maxSliceLen)
+		if err != nil {
+			return nil, err
+		}
+		return &codec.TypeSchema{
+			Kind:         codec.KindSlice,
+			Name:         namedType(t),
+			LengthPrefix: sliceLengthPrefix,
+			MaxLength:    maxSliceLen,
+			Elem:         elem,
+		}, nil
+	case reflect.Array:
+		elem, err := d.describe(t.Elem(), d.codec.maxSliceLen)
+		if err != nil {
+			return nil, err
+		}
+		return &codec.TypeSchema{
+			Kind:   codec.KindArray,
+			Name:   namedType(t),
+			Length: t.Len(),
+			Elem:   elem,
+		}, nil
+	case reflect.Interface:
+		name := typeName(t)
+		if _, exists := d.schema.Interfaces[name]; !exists {
+			implementations := []string{}
+			for _, registered := range d.types {
+				if registered.Type.Implements(t) {
+					implementations = append(implementations, typeName(registered.Type))
+				}
+			}
+			d.schema.Interfaces[name] = implementations
+		}
+		return &codec.TypeSchema{
+			Kind: codec.KindInterface,
+			Name: name,
+		}, nil
+	case reflect.Struct:
+		name := typeName(t)
+		if _, exists := d.schema.Structs[name]; !exists {
+			// Added before its fields are described, so a struct that
+			// references itself is only described once
+			structSchema := &codec.StructSchema{}
+			d.schema.Structs[name] = structSchema
+
+			fields, err := d.codec.fielder.GetSerializedFields(t)
+			if err != nil {
+				return nil, err
+			}
+			structSchema.Fields = make([]*codec.FieldSchema, len(fields))
+			for i, fieldDesc := range fields {
+				field := t.Field(fieldDesc.Index)
+				fieldType, err := d.describe(field.Type, fieldDesc.MaxSliceLen)
+				if err != nil {
+					return nil, fmt.Errorf("couldn't describe field %s of %s: %w", field.Name, name, err)
+				}
+				structSchema.Fields[i] = &codec.FieldSchema{
+					Name: field.Name,
+					Type: fieldType,
+				}
+			}
+		}
+		return &codec.TypeSchema{
+			Kind: codec.KindStruct,
+			Name: name,
+		}, nil
+	default:
+		return nil, fmt.Errorf("can't marshal unknown kind %s", t.Kind())
+	}
+}
+
+// typeName returns the name of [t], or of the type [t] points to
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.String()
+}
+
+// namedType returns the name of [t] if it is a named type, or the empty string
+// otherwise
+func namedType(t reflect.Type) string {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return ""
+	}
+	return t.String()
+}
@@ -9,10 +9,13 @@ import (
 	"strconv"
 	"sync"
 	"unicode"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 const (
-	// SliceLenTagName that specifies the length of a slice.
+	// SliceLenTagName that specifies the maximum number of elements of a slice,
+	// or bytes of a string.
 	SliceLenTagName = "len"
 
 	// TagValue is the value the tag must have to be serialized.
@@ -22,24 +25,30 @@ const (
 type FieldDesc struct {
 	Index       int
 	MaxSliceLen int
+	// The minimum number of bytes that the field is serialized into
+	MinSize int
 }
 
 // StructFielder handles discovery of serializable fields in a struct.
 type StructFielder interface {
 	// Returns the fields that have been marked as serializable in [t], which is
-	// a struct type. Additionally, returns the custom maximum length slice that
-	// may be serialized into the field, if any.
+	// a struct type. Additionally, returns the custom maximum length slice, or
+	// string, that may be serialized into the field, if any.
 	// Returns an error if a field has tag "[tagName]: [TagValue]" but the field
-	// is un-exported.
+	// is un-exported, or if the maximum length of a field is invalid.
+	// The minimum size of each field is computed along with the fields.
 	// GetSerializedField(Foo) --> [1,5,8] means Foo.Field(1), Foo.Field(5),
 	// Foo.Field(8) are to be serialized/deserialized.
 	GetSerializedFields(t reflect.Type) ([]FieldDesc, error)
 }
 
-func NewStructFielder(tagName string, maxSliceLen int) StructFielder {
+// NewStructFielder returns a new StructFielder. [prefixSize] is the number of
+// bytes that the type prefix of an interface is serialized into.
+func NewStructFielder(tagName string, maxSliceLen int, prefixSize int) StructFielder {
 	return &structFielder{
 		tagName:                tagName,
 		maxSliceLen:            maxSliceLen,
+		prefixSize:             prefixSize,
 		serializedFieldIndices: make(map[reflect.Type][]FieldDesc),
 	}
 }
@@ -48,6 +57,7 @@ type structFielder struct {
 	lock        sync.Mutex
 	tagName     string
 	maxSliceLen int
+	prefixSize  int
 
 	// Key: a struct type
 	// Value: Slice where each element is index in the struct type of a field
@@ -64,9 +74,20 @@ func (s *structFielder) GetSerializedFields(t reflect.Type) ([]FieldDesc, error)
 	if s.serializedFieldIndices == nil {
 		s.serializedFieldIndices = make(map[reflect.Type][]FieldDesc)
 	}
+	return s.getSerializedFields(t, make(map[reflect.Type]bool))
+}
+
+// getSerializedFields returns the fields of [t] that are serialized.
+// [visiting] holds the structs whose fields are being computed, which a
+// recursive type may reference.
+// s.lock should be held for the duration of this function
+func (s *structFielder) getSerializedFields(t reflect.Type, visiting map[reflect.Type]bool) ([]FieldDesc, error) {
 	if serializedFields, ok := s.serializedFieldIndices[t]; ok { // use pre-computed result
 		return serializedFields, nil
 	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
 	numFields := t.NumField()
 	serializedFields := make([]FieldDesc, 0, numFields)
 	for i := 0; i < numFields; i++ { // Go through all fields of this struct
@@ -77,16 +98,76 @@ func (s *structFielder) GetSerializedFields(t reflect.Type) ([]FieldDesc, error)
 		if unicode.IsLower(rune(field.Name[0])) { // Can only marshal exported fields
 			return nil, fmt.Errorf("can't marshal un-exported field %s", field.Name)
 		}
-		sliceLenField := field.Tag.Get(SliceLenTagName)
 		maxSliceLen := s.maxSliceLen
-		if newLen, err := strconv.Atoi(sliceLenField); err == nil {
+		if sliceLenField, ok := field.Tag.Lookup(SliceLenTagName); ok {
+			switch field.Type.Kind() {
+			case reflect.Slice, reflect.String:
+			default:
+				return nil, fmt.Errorf("can't limit the length of field %s of kind %s", field.Name, field.Type.Kind())
+			}
+			newLen, err := strconv.Atoi(sliceLenField)
+			if err != nil || newLen < 0 {
+				return nil, fmt.Errorf("invalid maximum length %q of field %s", sliceLenField, field.Name)
+			}
 			maxSliceLen = newLen
 		}
+		minSize, err := typeMinSize(field.Type, s.prefixSize, func(structType reflect.Type) (int, error) {
+			if visiting[structType] {
+				// The struct references itself. Counting it as empty still
+				// gives a lower bound.
+				return 0, nil
+			}
+			fields, err := s.getSerializedFields(structType, visiting)
+			return structMinSize(fields), err
+		})
+		if err != nil {
+			return nil, err
+		}
 		serializedFields = append(serializedFields, FieldDesc{
 			Index:       i,
 			MaxSliceLen: maxSliceLen,
+			MinSize:     minSize,
 		})
 	}
 	s.serializedFieldIndices[t] = serializedFields // cache result
 	return serializedFields, nil
 }
+
+// typeMinSize returns the minimum number of bytes that a value of type [t] is
+// serialized into. [prefixSize] is the size of the type prefix of an interface
+// and [structSize] returns the minimum size of a struct type.
+func typeMinSize(t reflect.Type, prefixSize int, structSize func(reflect.Type) (int, error)) (int, error) {
+	switch t.Kind() {
+	case reflect.Uint8, reflect.Int8:
+		return wrappers.ByteLen, nil
+	case reflect.Bool:
+		return wrappers.BoolLen, nil
+	case reflect.Uint16, reflect.Int16, reflect.String:
+		return wrappers.ShortLen, nil
+	case reflect.Uint32, reflect.Int32, reflect.Slice:
+		return wrappers.IntLen, nil
+	case reflect.Uint64, reflect.Int64:
+		return wrappers.LongLen, nil
+	case reflect.Interface:
+		return prefixSize, nil
+	case reflect.Ptr:
+		return typeMinSize(t.Elem(), prefixSize, structSize)
+	case reflect.Array:
+		elemSize, err := typeMinSize(t.Elem(), prefixSize, structSize)
+		return t.Len() * elemSize, err
+	case reflect.Struct:
+		return structSize(t)
+	default:
+		return 0, fmt.Errorf("can't unmarshal unknown type %s", t.Kind())
+	}
+}
+
+// structMinSize returns the minimum number of bytes that a struct with the
+// serialized [fields] is serialized into
+func structMinSize(fields []FieldDesc) int {
+	size := 0
+	for _, fieldDesc := range fields {
+		size += fieldDesc.MinSize
+	}
+	return size
+}
@@ -37,6 +37,10 @@ type TypeCodec interface {
 	// When deserializing the bytes, the prefix specifies which concrete type
 	// to deserialize into.
 	PackPrefix(*wrappers.Packer, reflect.Type) error
+
+	// PrefixSize returns the number of bytes that the prefix of an interface
+	// is packed into.
+	PrefixSize() int
 }
 
 // genericCodec handles marshaling and unmarshaling of structs with a generic
@@ -53,6 +57,8 @@ type TypeCodec interface {
 // 5) To unmarshal an interface,  you must call codec.RegisterType([instance of the type that fulfills the interface]).
 // 6) Serialized fields must be exported
 // 7) nil slices are marshaled as empty slices
+// 8) To limit the number of elements of a slice, or bytes of a string, that a field may hold, add the tag `len:"{max}"` to it.
+//    Slices are rejected before they are allocated if they are longer than this limit, or than what the remaining bytes could hold.
 type genericCodec struct {
 	typer       TypeCodec
 	maxSliceLen int
@@ -64,7 +70,7 @@ func New(typer TypeCodec, tagName string, maxSliceLen int) codec.Codec {
 	return &genericCodec{
 		typer:       typer,
 		maxSliceLen: maxSliceLen,
-		fielder:     NewStructFielder(tagName, maxSliceLen),
+		fielder:     NewStructFielder(tagName, maxSliceLen, typer.PrefixSize()),
 	}
 }
 
@@ -115,6 +121,11 @@ func (c *genericCodec) marshal(value reflect.Value, p *wrappers.Packer, maxSlice
 		p.PackLong(uint64(value.Int()))
 		return p.Err
 	case reflect.String:
+		if numBytes := value.Len(); numBytes > maxSliceLen {
+			return fmt.Errorf("string length, %d, exceeds maximum length, %d",
+				numBytes,
+				maxSliceLen)
+		}
 		p.PackStr(value.String())
 		return p.Err
 	case reflect.Bool:
@@ -278,6 +289,20 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 				numElts,
 				maxSliceLen)
 		}
+		// Make sure the remaining bytes could hold [numElts] elements before
+		// allocating them
+		if numElts > 0 {
+			eltSize, err := c.minSize(value.Type().Elem())
+			if err != nil {
+				return fmt.Errorf("couldn't unmarshal slice: %w", err)
+			}
+			if remaining := len(p.Bytes) - p.Offset; uint64(numElts)*uint64(eltSize) > uint64(remaining) {
+				return fmt.Errorf("slice length, %d, needs at least %d bytes but only %d remain",
+					numElts,
+					uint64(numElts)*uint64(eltSize),
+					remaining)
+			}
+		}
 		// If this is a slice of bytes, manually unpack the bytes rather
 		// than calling unmarshal on each byte. This improves performance.
 		if elemKind := value.Type().Elem().Kind(); elemKind == reflect.Uint8 {
@@ -312,7 +337,16 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 		}
 		return nil
 	case reflect.String:
-		value.SetString(p.UnpackStr())
+		numBytes := int(p.UnpackShort())
+		if p.Err != nil {
+			return fmt.Errorf("couldn't unmarshal string: %w", p.Err)
+		}
+		if numBytes > maxSliceLen {
+			return fmt.Errorf("string length, %d, exceeds maximum length, %d",
+				numBytes,
+				maxSliceLen)
+		}
+		value.SetString(string(p.UnpackFixedBytes(numBytes)))
 		if p.Err != nil {
 			return fmt.Errorf("couldn't unmarshal string: %w", p.Err)
 		}
@@ -360,3 +394,12 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 		return fmt.Errorf("can't unmarshal unknown type %s", value.Kind().String())
 	}
 }
+
+// minSize returns the minimum number of bytes that a value of type [t] is
+// serialized into. The sizes of structs are cached along with their fields.
+func (c *genericCodec) minSize(t reflect.Type) (int, error) {
+	return typeMinSize(t, c.typer.PrefixSize(), func(structType reflect.Type) (int, error) {
+		fields, err := c.fielder.GetSerializedFields(structType)
+		return structMinSize(fields), err
+	})
+}
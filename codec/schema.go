@@ -0,0 +1,146 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	// SchemaVersion is the version of the schema format. It is incremented
+	// whenever the format changes in a way that decoders must handle.
+	SchemaVersion = 1
+
+	// CodecVersionPrefix is the wire type of the codec version that every
+	// value marshaled by a Manager starts with
+	CodecVersionPrefix = "uint16"
+)
+
+// Kinds of wire types in a schema
+const (
+	KindBool      = "bool"
+	KindUint8     = "uint8"
+	KindUint16    = "uint16"
+	KindUint32    = "uint32"
+	KindUint64    = "uint64"
+	KindInt8      = "int8"
+	KindInt16     = "int16"
+	KindInt32     = "int32"
+	KindInt64     = "int64"
+	KindString    = "string"
+	KindSlice     = "slice"
+	KindArray     = "array"
+	KindStruct    = "struct"
+	KindInterface = "interface"
+)
+
+var errNotDescribable = errors.New("codec can't describe its types")
+
+// Describer is implemented by codecs that can describe the wire format of the
+// types they serialize
+type Describer interface {
+	// Describe returns the schema of the types registered with this codec, of
+	// the types they reference and of the types of [roots], which are values
+	// that are marshaled directly rather than as an interface.
+	Describe(roots ...interface{}) (*CodecSchema, error)
+}
+
+// Schema describes the wire format of every codec version of a Manager.
+// Integers are big-endian. Every marshaled value starts with the codec version,
+// whose wire type is VersionPrefix.
+type Schema struct {
+	SchemaVersion uint32         `json:"schemaVersion"`
+	VersionPrefix string         `json:"versionPrefix"`
+	Codecs        []*CodecSchema `json:"codecs"`
+}
+
+// CodecSchema describes the wire format of a single codec version
+type CodecSchema struct {
+	Version uint16 `json:"version"`
+	// Wire types of the parts of the type ID that precedes the value of an
+	// interface
+	TypeIDPrefix []string `json:"typeIDPrefix"`
+	// Types that may be the value of an interface, ordered by type ID
+	Types []*RegisteredType `json:"types"`
+	// Types of values that are marshaled directly, without a type ID
+	Roots []*TypeSchema `json:"roots,omitempty"`
+	// Every struct reachable from the registered types and the roots, by name
+	Structs map[string]*StructSchema `json:"structs"`
+	// Every interface reachable from the registered types and the roots, by
+	// name, with the names of the registered types that implement it
+	Interfaces map[string][]string `json:"interfaces"`
+}
+
+// RegisteredType is a type with a type ID
+type RegisteredType struct {
+	// Parts of the type ID, with the wire types in TypeIDPrefix
+	TypeID []uint32    `json:"typeID"`
+	Name   string      `json:"name"`
+	Type   *TypeSchema `json:"type"`
+}
+
+// StructSchema describes a struct. Its serialized fields are written one after
+// another, in order.
+type StructSchema struct {
+	Fields []*FieldSchema `json:"fields"`
+}
+
+// FieldSchema describes a serialized field of a struct
+type FieldSchema struct {
+	Name string      `json:"name"`
+	Type *TypeSchema `json:"type"`
+}
+
+// TypeSchema describes a wire type. Structs and interfaces are referenced by
+// name and described in the Structs and Interfaces of their CodecSchema.
+type TypeSchema struct {
+	Kind string `json:"kind"`
+	// Name of a named type
+	Name string `json:"name,omitempty"`
+	// Wire type of the number of elements of a slice, or bytes of a string,
+	// which precedes them
+	LengthPrefix string `json:"lengthPrefix,omitempty"`
+	// Maximum number of elements of a slice, or bytes of a string
+	MaxLength int `json:"maxLength,omitempty"`
+	// Number of elements of an array
+	Length int `json:"length,omitempty"`
+	// Elements of a slice or array
+	Elem *TypeSchema `json:"elem,omitempty"`
+}
+
+// Schema returns the schema of every registered codec version. Every codec
+// must implement Describer. See Describer for the meaning of [roots].
+func (m *manager) Schema(roots ...interface{}) (*Schema, error) {
+	m.lock.RLock()
+	versions := make([]uint16, 0, len(m.codecs))
+	codecs := make(map[uint16]Codec, len(m.codecs))
+	for version, c := range m.codecs {
+		versions = append(versions, version)
+		codecs[version] = c
+	}
+	m.lock.RUnlock()
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	schema := &Schema{
+		SchemaVersion: SchemaVersion,
+		VersionPrefix: CodecVersionPrefix,
+		Codecs:        make([]*CodecSchema, 0, len(versions)),
+	}
+	for _, version := range versions {
+		describer, ok := codecs[version].(Describer)
+		if !ok {
+			return nil, fmt.Errorf("%w: version %d", errNotDescribable, version)
+		}
+		codecSchema, err := describer.Describe(roots...)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't describe codec version %d: %w", version, err)
+		}
+		codecSchema.Version = version
+		schema.Codecs = append(schema.Codecs, codecSchema)
+	}
+	return schema, nil
+}
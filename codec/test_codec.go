@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"math"
 	"reflect"
+	"runtime"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -37,6 +38,10 @@ var (
 		TestSliceWithEmptySerialization,
 		TestRestrictedSlice,
 		TestExtraSpace,
+		TestSchema,
+		TestRestrictedString,
+		TestSliceLongerThanBytes,
+		TestInvalidSliceLenTag,
 	}
 )
 
@@ -969,3 +974,174 @@ func TestExtraSpace(codec GeneralCodec, t testing.TB) {
 		t.Fatalf("Should have errored due to too many bytes being passed in")
 	}
 }
+
+// Test describing the wire format of registered types
+func TestSchema(codec GeneralCodec, t testing.TB) {
+	type restricted struct {
+		Bytes []byte `serialize:"true" len:"2"`
+	}
+
+	manager := NewDefaultManager()
+	errs := wrappers.Errs{}
+	errs.Add(
+		codec.RegisterType(&MyInnerStruct{}),
+		codec.RegisterType(&MyInnerStruct2{}),
+		manager.RegisterCodec(0, codec),
+	)
+	if errs.Errored() {
+		t.Fatal(errs.Err)
+	}
+
+	schema, err := manager.Schema(myStruct{}, restricted{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.SchemaVersion != SchemaVersion || schema.VersionPrefix != "uint16" || len(schema.Codecs) != 1 {
+		t.Fatalf("unexpected schema %+v", schema)
+	}
+	codecSchema := schema.Codecs[0]
+	if len(codecSchema.Types) != 2 ||
+		codecSchema.Types[0].Name != "codec.MyInnerStruct" ||
+		codecSchema.Types[1].Name != "codec.MyInnerStruct2" {
+		t.Fatalf("unexpected registered types %+v", codecSchema.Types)
+	}
+	if len(codecSchema.Roots) != 2 ||
+		codecSchema.Roots[0].Kind != KindStruct ||
+		codecSchema.Roots[0].Name != "codec.myStruct" {
+		t.Fatalf("unexpected roots %+v", codecSchema.Roots)
+	}
+	if implementations := codecSchema.Interfaces["codec.Foo"]; !reflect.DeepEqual(implementations, []string{"codec.MyInnerStruct", "codec.MyInnerStruct2"}) {
+		t.Fatalf("unexpected implementations of Foo %v", implementations)
+	}
+
+	myStructSchema, ok := codecSchema.Structs["codec.myStruct"]
+	if !ok {
+		t.Fatal("myStruct should have been described")
+	}
+	if len(myStructSchema.Fields) != 16 {
+		t.Fatalf("expected 16 fields but got %d", len(myStructSchema.Fields))
+	}
+	fields := map[string]*TypeSchema{}
+	for _, field := range myStructSchema.Fields {
+		fields[field.Name] = field.Type
+	}
+	if field := fields["InnerStruct2"]; field.Kind != KindStruct || field.Name != "codec.MyInnerStruct" {
+		t.Fatalf("pointers should be transparent but got %+v", field)
+	}
+	if field := fields["MySlice"]; field.Kind != KindSlice || field.LengthPrefix != "uint32" || field.Elem.Kind != KindUint8 {
+		t.Fatalf("unexpected slice %+v", field)
+	}
+	if field := fields["MyArray2"]; field.Kind != KindArray || field.Length != 5 || field.Elem.Kind != KindString || field.Elem.LengthPrefix != "uint16" {
+		t.Fatalf("unexpected array %+v", field)
+	}
+	if field := fields["MyPointer"]; field.Kind != KindInterface || field.Name != "codec.Foo" {
+		t.Fatalf("unexpected interface %+v", field)
+	}
+	if _, ok := codecSchema.Structs["codec.MyInnerStruct3"]; !ok {
+		t.Fatal("nested structs should have been described")
+	}
+
+	restrictedSchema := codecSchema.Structs["codec.restricted"]
+	if restrictedSchema == nil || restrictedSchema.Fields[0].Type.MaxLength != 2 {
+		t.Fatalf("the length of restricted slices should have been described but got %+v", restrictedSchema)
+	}
+}
+
+// Ensure strings that have been length restricted are rejected
+func TestRestrictedString(codec GeneralCodec, t testing.TB) {
+	var _ GeneralCodec = codec
+
+	type inner struct {
+		Str string `serialize:"true" len:"2"`
+	}
+	bytes := []byte{0, 0, 0, 3, 'a', 'b', 'c'}
+
+	manager := NewDefaultManager()
+	if err := manager.RegisterCodec(0, codec); err != nil {
+		t.Fatal(err)
+	}
+
+	s := inner{}
+	if _, err := manager.Unmarshal(bytes, &s); err == nil {
+		t.Fatalf("Should have errored due to too long of a string")
+	}
+
+	s.Str = "abc"
+	if _, err := manager.Marshal(0, s); err == nil {
+		t.Fatalf("Should have errored due to too long of a string")
+	}
+
+	s.Str = "ab"
+	bytes, err := manager.Marshal(0, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2 := inner{}
+	if _, err := manager.Unmarshal(bytes, &s2); err != nil {
+		t.Fatal(err)
+	}
+	if s2.Str != "ab" {
+		t.Fatalf("expected %q but got %q", "ab", s2.Str)
+	}
+}
+
+// Ensure a slice that claims more elements than the remaining bytes could hold
+// is rejected before it is allocated
+func TestSliceLongerThanBytes(codec GeneralCodec, t testing.TB) {
+	var _ GeneralCodec = codec
+
+	manager := NewDefaultManager()
+	if err := manager.RegisterCodec(0, codec); err != nil {
+		t.Fatal(err)
+	}
+
+	// codec version, then 1<<18 elements, each of which needs at least 24
+	// bytes, but only 3 elements follow. Allocating the slice would take 6 MiB.
+	bytes := []byte{0, 0, 0, 4, 0, 0}
+	bytes = append(bytes, make([]byte, 3*24)...)
+	s := []struct {
+		A uint64    `serialize:"true"`
+		B [2]uint64 `serialize:"true"`
+	}{}
+
+	before := runtime.MemStats{}
+	runtime.ReadMemStats(&before)
+	if _, err := manager.Unmarshal(bytes, &s); err == nil {
+		t.Fatalf("Should have errored due to too few bytes for the slice")
+	}
+	after := runtime.MemStats{}
+	runtime.ReadMemStats(&after)
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1<<20 {
+		t.Fatalf("Shouldn't have allocated the slice but allocated %d bytes", allocated)
+	}
+
+	// Interfaces are at least as long as their type ID
+	foos := []Foo{}
+	if _, err := manager.Unmarshal([]byte{0, 0, 0, 1, 0, 0, 0}, &foos); err == nil {
+		t.Fatalf("Should have errored due to too few bytes for the slice")
+	}
+}
+
+// Ensure invalid length restrictions are reported
+func TestInvalidSliceLenTag(codec GeneralCodec, t testing.TB) {
+	var _ GeneralCodec = codec
+
+	type invalidLen struct {
+		Bytes []byte `serialize:"true" len:"two"`
+	}
+	type notASlice struct {
+		Int uint32 `serialize:"true" len:"2"`
+	}
+
+	manager := NewDefaultManager()
+	if err := manager.RegisterCodec(0, codec); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := manager.Marshal(0, invalidLen{}); err == nil {
+		t.Fatalf("Should have errored due to an invalid length")
+	}
+	if _, err := manager.Marshal(0, notASlice{}); err == nil {
+		t.Fatalf("Should have errored due to a length on a field that isn't a slice")
+	}
+}
@@ -0,0 +1,354 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// metadataFile is the name of the file that describes a backup. It is
+	// written last, so a backup without it is incomplete.
+	metadataFile = "backup.json"
+
+	// dbDir is the name of the directory the database is copied to
+	dbDir = "db"
+
+	// restoredFile is the name of the file, in a restored database's
+	// directory, that holds the metadata of the backup it was restored from
+	restoredFile = "restored.json"
+
+	// restoringSuffix is appended to the directory a backup is restored to.
+	// The directory is renamed once the restore finishes, so a database is
+	// never opened in a partially restored state.
+	restoringSuffix = ".restoring"
+)
+
+var (
+	errNotEmpty        = errors.New("directory is not empty")
+	errWrongNetworkID  = errors.New("backup was taken on a different network")
+	errWrongDBVersion  = errors.New("backup has a different database version")
+	errMissingMetadata = errors.New("backup is incomplete or isn't a backup")
+	errPartialBackup   = errors.New("backup only holds part of a database, so it can't be restored")
+)
+
+// Metadata describes a backup
+type Metadata struct {
+	// ID of the network the node that was backed up is running
+	NetworkID uint32 `json:"networkID"`
+	// Version of the database that was backed up
+	DBVersion string `json:"dbVersion"`
+	// Time the snapshot the backup was taken from was created
+	Timestamp time.Time `json:"timestamp"`
+	// Number of keys and bytes, keys and values included, in the backup
+	Keys  uint64 `json:"keys"`
+	Bytes uint64 `json:"bytes"`
+	// True if the backup only holds some prefixed databases of the database
+	// that was backed up
+	Partial bool `json:"partial,omitempty"`
+}
+
+// Progress of a copy between two databases
+type Progress = manager.Progress
+
+// Prefixed is a snapshot of a prefixed database, and the prefix of the
+// database
+type Prefixed struct {
+	Prefix   []byte
+	Snapshot database.Snapshot
+}
+
+// Backup writes a consistent copy of [db] to [dir], taken from a snapshot of
+// [db]. [dir] must be empty or not exist. [progress], if non-nil, is called
+// periodically with the amount of data copied so far.
+//
+// Writes to [db] aren't blocked while the backup is in progress, and aren't
+// included in the backup.
+func Backup(
+	db database.Snapshotter,
+	dir string,
+	networkID uint32,
+	dbVersion string,
+	log logging.Logger,
+	progress func(Progress),
+) (*Metadata, error) {
+	if err := ensureEmpty(dir); err != nil {
+		return nil, fmt.Errorf("couldn't back up to %s: %w", dir, err)
+	}
+
+	snapshot, err := db.NewSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't snapshot the database: %w", err)
+	}
+	defer snapshot.Release()
+
+	metadata := &Metadata{
+		NetworkID: networkID,
+		DBVersion: dbVersion,
+		Timestamp: time.Now(),
+	}
+	return metadata, write(dir, metadata, log, func(dst database.Database) (Progress, error) {
+		return copyDB(dst, snapshot, progress)
+	})
+}
+
+// BackupPrefixed writes [snapshots], each of a prefixed database of the same
+// database, to [dir]. Each snapshot is written to a prefixed database with the
+// same prefix, so the backup can be read like the database that was backed up.
+// As the backup only holds these prefixed databases, it can't be restored.
+// [timestamp] is the time the snapshots were created. [dir] must be empty or
+// not exist. [progress], if non-nil, is called periodically with the amount of
+// data copied so far.
+func BackupPrefixed(
+	snapshots []Prefixed,
+	timestamp time.Time,
+	dir string,
+	networkID uint32,
+	dbVersion string,
+	log logging.Logger,
+	progress func(Progress),
+) (*Metadata, error) {
+	if err := ensureEmpty(dir); err != nil {
+		return nil, fmt.Errorf("couldn't back up to %s: %w", dir, err)
+	}
+	if progress == nil {
+		progress = func(Progress) {}
+	}
+
+	metadata := &Metadata{
+		NetworkID: networkID,
+		DBVersion: dbVersion,
+		Timestamp: timestamp,
+		Partial:   true,
+	}
+	return metadata, write(dir, metadata, log, func(dst database.Database) (Progress, error) {
+		total := Progress{}
+		for _, prefixed := range snapshots {
+			copied, err := copyDB(prefixdb.New(prefixed.Prefix, dst), prefixed.Snapshot, func(p Progress) {
+				progress(Progress{
+					Keys:  total.Keys + p.Keys,
+					Bytes: total.Bytes + p.Bytes,
+				})
+			})
+			total.Keys += copied.Keys
+			total.Bytes += copied.Bytes
+			if err != nil {
+				return total, err
+			}
+		}
+		return total, nil
+	})
+}
+
+// write a new database to [dir] with [copyTo], and then [metadata] with the
+// amount of data that was copied
+func write(dir string, metadata *Metadata, log logging.Logger, copyTo func(database.Database) (Progress, error)) error {
+	dst, err := leveldb.New(filepath.Join(dir, dbDir), log, 0, 0, 0)
+	if err != nil {
+		return fmt.Errorf("couldn't create the backup database: %w", err)
+	}
+	copied, err := copyTo(dst)
+	errs := wrappers.Errs{}
+	errs.Add(err, dst.Close())
+	if errs.Errored() {
+		return errs.Err
+	}
+	metadata.Keys = copied.Keys
+	metadata.Bytes = copied.Bytes
+
+	metadataBytes, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, metadataFile), metadataBytes, 0600)
+}
+
+// ReadMetadata returns the metadata of the backup in [dir]
+func ReadMetadata(dir string) (*Metadata, error) {
+	metadataBytes, err := ioutil.ReadFile(filepath.Join(dir, metadataFile))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("couldn't read %s: %w", dir, errMissingMetadata)
+	}
+	if err != nil {
+		return nil, err
+	}
+	metadata := &Metadata{}
+	return metadata, json.Unmarshal(metadataBytes, metadata)
+}
+
+// OpenReadOnly opens the database in the backup in [dir] without allowing any
+// writes. The backup must have been taken on network [networkID] from a
+// database with version [dbVersion].
+func OpenReadOnly(
+	dir string,
+	networkID uint32,
+	dbVersion string,
+	log logging.Logger,
+) (*leveldb.Database, *Metadata, error) {
+	metadata, err := ReadMetadata(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := metadata.verify(networkID, dbVersion); err != nil {
+		return nil, nil, err
+	}
+	db, err := leveldb.NewReadOnly(filepath.Join(dir, dbDir), log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't open the backup database: %w", err)
+	}
+	return db, metadata, nil
+}
+
+// verify that the backup was taken on network [networkID] from a database
+// with version [dbVersion]
+func (m *Metadata) verify(networkID uint32, dbVersion string) error {
+	if m.NetworkID != networkID {
+		return fmt.Errorf("%w: expected network ID %d but got %d", errWrongNetworkID, networkID, m.NetworkID)
+	}
+	if m.DBVersion != dbVersion {
+		return fmt.Errorf("%w: expected %s but got %s", errWrongDBVersion, dbVersion, m.DBVersion)
+	}
+	return nil
+}
+
+// Restored returns true if the database at [dbPath] was restored from the
+// backup in [dir]
+func Restored(dir string, dbPath string) (bool, error) {
+	metadata, err := ReadMetadata(dir)
+	if err != nil {
+		return false, err
+	}
+	restoredBytes, err := ioutil.ReadFile(filepath.Join(dbPath, restoredFile))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	restored := &Metadata{}
+	if err := json.Unmarshal(restoredBytes, restored); err != nil {
+		return false, err
+	}
+	return restored.NetworkID == metadata.NetworkID &&
+		restored.DBVersion == metadata.DBVersion &&
+		restored.Timestamp.Equal(metadata.Timestamp) &&
+		restored.Keys == metadata.Keys &&
+		restored.Bytes == metadata.Bytes, nil
+}
+
+// Restore copies the backup in [dir] to a new database at [dbPath]. The backup
+// must have been taken on network [networkID] from a database with version
+// [dbVersion]. [dbPath] must be empty or not exist, unless it was already
+// restored from this backup, in which case nothing is done. [progress], if
+// non-nil, is called periodically with the amount of data copied so far.
+func Restore(
+	dir string,
+	dbPath string,
+	networkID uint32,
+	dbVersion string,
+	log logging.Logger,
+	progress func(Progress),
+) (*Metadata, error) {
+	metadata, err := ReadMetadata(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := metadata.verify(networkID, dbVersion); err != nil {
+		return nil, err
+	}
+	if metadata.Partial {
+		return nil, fmt.Errorf("couldn't restore %s: %w", dir, errPartialBackup)
+	}
+	if restored, err := Restored(dir, dbPath); err != nil {
+		return nil, err
+	} else if restored {
+		return metadata, nil
+	}
+	if err := ensureEmpty(dbPath); err != nil {
+		return nil, fmt.Errorf("couldn't restore to %s: %w", dbPath, err)
+	}
+
+	src, err := leveldb.NewReadOnly(filepath.Join(dir, dbDir), log)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open the backup database: %w", err)
+	}
+	defer src.Close()
+
+	// Discard the result of a restore that was interrupted
+	restoringPath := dbPath + restoringSuffix
+	if err := os.RemoveAll(restoringPath); err != nil {
+		return nil, err
+	}
+	dst, err := leveldb.New(restoringPath, log, 0, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create the database: %w", err)
+	}
+	copied, err := copyDB(dst, src, progress)
+	errs := wrappers.Errs{}
+	errs.Add(err, dst.Close())
+	if errs.Errored() {
+		return nil, errs.Err
+	}
+	if copied.Keys != metadata.Keys || copied.Bytes != metadata.Bytes {
+		return nil, fmt.Errorf("restored %d keys and %d bytes but the backup has %d keys and %d bytes",
+			copied.Keys, copied.Bytes, metadata.Keys, metadata.Bytes)
+	}
+
+	metadataBytes, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(restoringPath, restoredFile), metadataBytes, 0600); err != nil {
+		return nil, err
+	}
+	// [dbPath] may exist if it's empty
+	if err := os.RemoveAll(dbPath); err != nil {
+		return nil, err
+	}
+	return metadata, os.Rename(restoringPath, dbPath)
+}
+
+// copyDB writes every key of [src] into [dst]
+func copyDB(dst database.Batcher, src database.Iteratee, progress func(Progress)) (Progress, error) {
+	if progress == nil {
+		progress = func(Progress) {}
+	}
+	return manager.WriteTransformed(dst, src, func(key, value []byte) ([]byte, []byte, bool) {
+		return key, value, true
+	}, progress)
+}
+
+// ensureEmpty returns an error if [dir] exists and isn't an empty directory
+func ensureEmpty(dir string) error {
+	f, err := os.Open(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.Readdirnames(1); err != io.EOF {
+		if err == nil {
+			return errNotEmpty
+		}
+		return err
+	}
+	return nil
+}
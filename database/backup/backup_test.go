@@ -0,0 +1,261 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	testNetworkID = 12345
+	testDBVersion = "v1.0.0"
+)
+
+func TestBackupRestore(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db := memdb.New()
+	for i := 0; i < 1000; i++ {
+		if err := db.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backupDir := filepath.Join(tmpDir, "backup")
+	calls := 0
+	metadata, err := Backup(db, backupDir, testNetworkID, testDBVersion, logging.NoLog{}, func(Progress) { calls++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.Keys != 1000 {
+		t.Fatalf("backed up %d keys but expected %d", metadata.Keys, 1000)
+	}
+	if calls == 0 {
+		t.Fatal("progress should have been reported")
+	}
+
+	if readMetadata, err := ReadMetadata(backupDir); err != nil {
+		t.Fatal(err)
+	} else if readMetadata.Keys != metadata.Keys || readMetadata.Bytes != metadata.Bytes {
+		t.Fatalf("read metadata %+v but expected %+v", readMetadata, metadata)
+	}
+
+	if _, err := Backup(db, backupDir, testNetworkID, testDBVersion, logging.NoLog{}, nil); !errors.Is(err, errNotEmpty) {
+		t.Fatalf("backing up to a non-empty directory should have failed with %s but got %s", errNotEmpty, err)
+	}
+
+	dbPath := filepath.Join(tmpDir, "restored")
+	if _, err := Restore(backupDir, dbPath, testNetworkID+1, testDBVersion, logging.NoLog{}, nil); !errors.Is(err, errWrongNetworkID) {
+		t.Fatalf("restoring on the wrong network should have failed with %s but got %s", errWrongNetworkID, err)
+	}
+	if _, err := Restore(backupDir, dbPath, testNetworkID, "v0.0.0", logging.NoLog{}, nil); !errors.Is(err, errWrongDBVersion) {
+		t.Fatalf("restoring the wrong version should have failed with %s but got %s", errWrongDBVersion, err)
+	}
+	if restored, err := Restored(backupDir, dbPath); err != nil {
+		t.Fatal(err)
+	} else if restored {
+		t.Fatal("database shouldn't have been restored yet")
+	}
+	if _, err := Restore(backupDir, dbPath, testNetworkID, testDBVersion, logging.NoLog{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if restored, err := Restored(backupDir, dbPath); err != nil {
+		t.Fatal(err)
+	} else if !restored {
+		t.Fatal("database should have been restored")
+	}
+	// Restoring the same backup again does nothing
+	if _, err := Restore(backupDir, dbPath, testNetworkID, testDBVersion, logging.NoLog{}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	otherDBPath := filepath.Join(tmpDir, "other")
+	if err := os.MkdirAll(otherDBPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(otherDBPath, "file"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Restore(backupDir, otherDBPath, testNetworkID, testDBVersion, logging.NoLog{}, nil); !errors.Is(err, errNotEmpty) {
+		t.Fatalf("restoring to a non-empty directory should have failed with %s but got %s", errNotEmpty, err)
+	}
+
+	restored, err := leveldb.NewReadOnly(dbPath, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+
+	iter := db.NewIterator()
+	defer iter.Release()
+	for iter.Next() {
+		value, err := restored.Get(iter.Key())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(value, iter.Value()) {
+			t.Fatalf("restored 0x%x for key 0x%x but expected 0x%x", value, iter.Key(), iter.Value())
+		}
+	}
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db := memdb.New()
+	if err := db.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Backup(db, tmpDir, testNetworkID, testDBVersion, logging.NoLog{}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := OpenReadOnly(tmpDir, testNetworkID+1, testDBVersion, logging.NoLog{}); !errors.Is(err, errWrongNetworkID) {
+		t.Fatalf("opening a backup of the wrong network should have failed with %s but got %s", errWrongNetworkID, err)
+	}
+	backupDB, metadata, err := OpenReadOnly(tmpDir, testNetworkID, testDBVersion, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backupDB.Close()
+	if metadata.Keys != 1 {
+		t.Fatalf("backup has %d keys but expected %d", metadata.Keys, 1)
+	}
+	if value, err := backupDB.Get([]byte("key")); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(value, []byte("value")) {
+		t.Fatalf("read 0x%x but expected 0x%x", value, []byte("value"))
+	}
+	if err := backupDB.Put([]byte("key"), nil); err == nil {
+		t.Fatal("the backup should be read-only")
+	}
+}
+
+func TestBackupIgnoresLaterWrites(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db := memdb.New()
+	if err := db.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write to the database while the backup is copying
+	written := false
+	progress := func(Progress) {
+		if !written {
+			written = true
+			if err := db.Put([]byte("hello2"), []byte("world2")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	backupDir := filepath.Join(tmpDir, "backup")
+	metadata, err := Backup(db, backupDir, testNetworkID, testDBVersion, logging.NoLog{}, progress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.Keys != 1 {
+		t.Fatalf("backed up %d keys but expected %d", metadata.Keys, 1)
+	}
+}
+
+func TestRestoreIncompleteBackup(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if _, err := Restore(tmpDir, filepath.Join(tmpDir, "restored"), testNetworkID, testDBVersion, logging.NoLog{}, nil); !errors.Is(err, errMissingMetadata) {
+		t.Fatalf("restoring an incomplete backup should have failed with %s but got %s", errMissingMetadata, err)
+	}
+}
+
+func TestBackupPrefixed(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	backupDir := filepath.Join(tmpDir, "backup")
+
+	db := memdb.New()
+	prefixes := [][]byte{[]byte("a"), []byte("b")}
+	snapshots := []Prefixed(nil)
+	for _, prefix := range prefixes {
+		if err := prefixdb.New(prefix, db).Put([]byte("key"), prefix); err != nil {
+			t.Fatal(err)
+		}
+		snapshot, err := prefixdb.New(prefix, db).NewSnapshot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer snapshot.Release()
+		snapshots = append(snapshots, Prefixed{
+			Prefix:   prefix,
+			Snapshot: snapshot,
+		})
+	}
+	// Only the snapshotted prefixed databases are backed up
+	if err := prefixdb.New([]byte("c"), db).Put([]byte("key"), []byte("c")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := BackupPrefixed(snapshots, time.Now(), backupDir, testNetworkID, testDBVersion, logging.NoLog{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	backupDB, metadata, err := OpenReadOnly(backupDir, testNetworkID, testDBVersion, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backupDB.Close()
+	if !metadata.Partial {
+		t.Fatal("the backup should be partial")
+	}
+	if metadata.Keys != 2 {
+		t.Fatalf("backup has %d keys but expected %d", metadata.Keys, 2)
+	}
+	for _, prefix := range prefixes {
+		if value, err := prefixdb.New(prefix, backupDB).Get([]byte("key")); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(value, prefix) {
+			t.Fatalf("read 0x%x but expected 0x%x", value, prefix)
+		}
+	}
+	if has, err := prefixdb.New([]byte("c"), backupDB).Has([]byte("key")); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatal("the backup should only hold the snapshotted prefixed databases")
+	}
+
+	// A partial backup can't be restored
+	_, err = Restore(backupDir, filepath.Join(tmpDir, "restored"), testNetworkID, testDBVersion, logging.NoLog{}, nil)
+	if !errors.Is(err, errPartialBackup) {
+		t.Fatalf("restoring a partial backup should have failed with %s but got %v", errPartialBackup, err)
+	}
+}
@@ -0,0 +1,312 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package corruptabledb
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// Database halts on the first unexpected error returned by the database it
+// wraps. An error returned in the middle of a write may leave the database
+// partially updated, so rather than letting callers continue on state that may
+// be inconsistent, every operation after the first error fails with that
+// error.
+type Database struct {
+	db database.Database
+
+	lock sync.RWMutex
+	// First error, other than "not found" or "closed", returned by [db]. If
+	// non-nil, every operation fails with this error.
+	err error
+}
+
+// New returns a new database that halts on the first unexpected error
+// returned by [db]
+func New(db database.Database) *Database { return &Database{db: db} }
+
+// Has implements the Database interface
+func (db *Database) Has(key []byte) (bool, error) {
+	if err := db.corrupted(); err != nil {
+		return false, err
+	}
+	has, err := db.db.Has(key)
+	return has, db.handleError(err)
+}
+
+// Get implements the Database interface
+func (db *Database) Get(key []byte) ([]byte, error) {
+	if err := db.corrupted(); err != nil {
+		return nil, err
+	}
+	value, err := db.db.Get(key)
+	return value, db.handleError(err)
+}
+
+// Put implements the Database interface
+func (db *Database) Put(key, value []byte) error {
+	if err := db.corrupted(); err != nil {
+		return err
+	}
+	return db.handleError(db.db.Put(key, value))
+}
+
+// Delete implements the Database interface
+func (db *Database) Delete(key []byte) error {
+	if err := db.corrupted(); err != nil {
+		return err
+	}
+	return db.handleError(db.db.Delete(key))
+}
+
+// NewBatch implements the Database interface
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+// NewIterator implements the Database interface
+func (db *Database) NewIterator() database.Iterator {
+	return db.newIterator(db.db.NewIterator())
+}
+
+// NewIteratorWithStart implements the Database interface
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.newIterator(db.db.NewIteratorWithStart(start))
+}
+
+// NewIteratorWithPrefix implements the Database interface
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIterator(db.db.NewIteratorWithPrefix(prefix))
+}
+
+// NewIteratorWithStartAndPrefix implements the Database interface
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.newIterator(db.db.NewIteratorWithStartAndPrefix(start, prefix))
+}
+
+// NewIteratorWithStartAndEnd implements the Database interface
+func (db *Database) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return db.newIterator(db.db.NewIteratorWithStartAndEnd(start, end))
+}
+
+// NewReverseIterator implements the Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.newIterator(db.db.NewReverseIterator())
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIterator(db.db.NewReverseIteratorWithPrefix(prefix))
+}
+
+// NewReverseIteratorWithStartAndEnd implements the Database interface
+func (db *Database) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return db.newIterator(db.db.NewReverseIteratorWithStartAndEnd(start, end))
+}
+
+// NewSnapshot implements the Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if err := db.corrupted(); err != nil {
+		return nil, err
+	}
+	s, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, db.handleError(err)
+	}
+	return &snapshot{
+		snapshot: s,
+		db:       db,
+	}, nil
+}
+
+// Stat implements the Database interface
+func (db *Database) Stat(stat string) (string, error) {
+	if err := db.corrupted(); err != nil {
+		return "", err
+	}
+	return db.db.Stat(stat)
+}
+
+// Compact implements the Database interface
+func (db *Database) Compact(start, limit []byte) error {
+	if err := db.corrupted(); err != nil {
+		return err
+	}
+	return db.handleError(db.db.Compact(start, limit))
+}
+
+// Close implements the Database interface. Closing is allowed after an error,
+// so the node can shut down cleanly.
+func (db *Database) Close() error { return db.db.Close() }
+
+// HealthCheck fails once the database has returned an unexpected error
+func (db *Database) HealthCheck() (interface{}, error) {
+	if err := db.corrupted(); err != nil {
+		return nil, fmt.Errorf("database halted to avoid possible corruption: %w", err)
+	}
+	return nil, nil
+}
+
+// corrupted returns the first unexpected error, or nil if there hasn't been one
+func (db *Database) corrupted() error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.err
+}
+
+// handleError records [err] if it is the first unexpected error and returns
+// it unchanged
+func (db *Database) handleError(err error) error {
+	switch err {
+	case nil, database.ErrNotFound, database.ErrClosed:
+		// "not found" is part of normal operation and "closed" is expected
+		// during shutdown. Neither indicates the database is damaged.
+	default:
+		db.lock.Lock()
+		if db.err == nil {
+			db.err = err
+		}
+		db.lock.Unlock()
+	}
+	return err
+}
+
+func (db *Database) newIterator(it database.Iterator) database.Iterator {
+	return &iterator{
+		iterator: it,
+		db:       db,
+	}
+}
+
+type batch struct {
+	batch database.Batch
+	db    *Database
+}
+
+func (b *batch) Put(key, value []byte) error { return b.db.handleError(b.batch.Put(key, value)) }
+
+func (b *batch) Delete(key []byte) error { return b.db.handleError(b.batch.Delete(key)) }
+
+func (b *batch) ValueSize() int { return b.batch.ValueSize() }
+
+func (b *batch) Write() error {
+	if err := b.db.corrupted(); err != nil {
+		return err
+	}
+	return b.db.handleError(b.batch.Write())
+}
+
+func (b *batch) Reset() { b.batch.Reset() }
+
+// Replay doesn't record errors, as they are returned by [w] rather than the
+// database
+func (b *batch) Replay(w database.KeyValueWriter) error { return b.batch.Replay(w) }
+
+func (b *batch) Inner() database.Batch { return b.batch.Inner() }
+
+type snapshot struct {
+	snapshot database.Snapshot
+	db       *Database
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if err := s.db.corrupted(); err != nil {
+		return false, err
+	}
+	has, err := s.snapshot.Has(key)
+	return has, s.db.handleError(err)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if err := s.db.corrupted(); err != nil {
+		return nil, err
+	}
+	value, err := s.snapshot.Get(key)
+	return value, s.db.handleError(err)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.db.newIterator(s.snapshot.NewIterator())
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.db.newIterator(s.snapshot.NewIteratorWithStart(start))
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.db.newIterator(s.snapshot.NewIteratorWithPrefix(prefix))
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.db.newIterator(s.snapshot.NewIteratorWithStartAndPrefix(start, prefix))
+}
+
+func (s *snapshot) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return s.db.newIterator(s.snapshot.NewIteratorWithStartAndEnd(start, end))
+}
+
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.db.newIterator(s.snapshot.NewReverseIterator())
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.db.newIterator(s.snapshot.NewReverseIteratorWithPrefix(prefix))
+}
+
+func (s *snapshot) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return s.db.newIterator(s.snapshot.NewReverseIteratorWithStartAndEnd(start, end))
+}
+
+func (s *snapshot) Release() { s.snapshot.Release() }
+
+// iterator stops iterating once the database has returned an unexpected error
+type iterator struct {
+	iterator database.Iterator
+	db       *Database
+	err      error
+}
+
+func (it *iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.db.corrupted(); err != nil {
+		it.err = err
+		return false
+	}
+	next := it.iterator.Next()
+	if !next {
+		it.err = it.db.handleError(it.iterator.Error())
+	}
+	return next
+}
+
+func (it *iterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.db.handleError(it.iterator.Error())
+}
+
+func (it *iterator) Key() []byte {
+	if it.err != nil {
+		return nil
+	}
+	return it.iterator.Key()
+}
+
+func (it *iterator) Value() []byte {
+	if it.err != nil {
+		return nil
+	}
+	return it.iterator.Value()
+}
+
+func (it *iterator) Release() { it.iterator.Release() }
@@ -0,0 +1,142 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package corruptabledb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/nodb"
+)
+
+var errTest = errors.New("non-nil error")
+
+// failingDB fails writes and iteration with [err] if it is set
+type failingDB struct {
+	database.Database
+	err error
+}
+
+func (db *failingDB) Put(key, value []byte) error {
+	if db.err != nil {
+		return db.err
+	}
+	return db.Database.Put(key, value)
+}
+
+func (db *failingDB) NewIterator() database.Iterator {
+	if db.err != nil {
+		return &nodb.Iterator{Err: db.err}
+	}
+	return db.Database.NewIterator()
+}
+
+func TestInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		test(t, New(memdb.New()))
+	}
+}
+
+func TestHaltOnError(t *testing.T) {
+	baseDB := &failingDB{Database: memdb.New()}
+	db := New(baseDB)
+
+	if err := db.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Get([]byte("missing")); err != database.ErrNotFound {
+		t.Fatalf("expected %s but got %s", database.ErrNotFound, err)
+	}
+	if _, err := db.HealthCheck(); err != nil {
+		t.Fatalf("\"not found\" shouldn't fail the health check but got %s", err)
+	}
+
+	baseDB.err = errTest
+	if err := db.Put([]byte("hello"), []byte("world")); err != errTest {
+		t.Fatalf("expected %s but got %s", errTest, err)
+	}
+	baseDB.err = nil
+
+	// Every later operation fails with the first error, even though the
+	// underlying database works again
+	if _, err := db.Get([]byte("hello")); err != errTest {
+		t.Fatalf("expected %s but got %s", errTest, err)
+	}
+	if _, err := db.Has([]byte("hello")); err != errTest {
+		t.Fatalf("expected %s but got %s", errTest, err)
+	}
+	if err := db.Delete([]byte("hello")); err != errTest {
+		t.Fatalf("expected %s but got %s", errTest, err)
+	}
+	batch := db.NewBatch()
+	if err := batch.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Write(); err != errTest {
+		t.Fatalf("expected %s but got %s", errTest, err)
+	}
+	if _, err := db.NewSnapshot(); err != errTest {
+		t.Fatalf("expected %s but got %s", errTest, err)
+	}
+	iter := db.NewIterator()
+	if iter.Next() {
+		t.Fatal("iterator should have stopped")
+	}
+	if err := iter.Error(); err != errTest {
+		t.Fatalf("expected %s but got %s", errTest, err)
+	}
+	iter.Release()
+	if _, err := db.HealthCheck(); !errors.Is(err, errTest) {
+		t.Fatalf("health check should have failed with %s but got %s", errTest, err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHaltOnIteratorError(t *testing.T) {
+	baseDB := &failingDB{
+		Database: memdb.New(),
+		err:      errTest,
+	}
+	db := New(baseDB)
+
+	iter := db.NewIterator()
+	if iter.Next() {
+		t.Fatal("iterator should have failed")
+	}
+	if err := iter.Error(); err != errTest {
+		t.Fatalf("expected %s but got %s", errTest, err)
+	}
+	iter.Release()
+
+	baseDB.err = nil
+	if _, err := db.Get([]byte("hello")); err != errTest {
+		t.Fatalf("expected %s but got %s", errTest, err)
+	}
+	if _, err := db.HealthCheck(); !errors.Is(err, errTest) {
+		t.Fatalf("health check should have failed with %s but got %s", errTest, err)
+	}
+}
+
+func TestSnapshotHaltsOnError(t *testing.T) {
+	baseDB := &failingDB{Database: memdb.New()}
+	db := New(baseDB)
+
+	snapshot, err := db.NewSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer snapshot.Release()
+
+	baseDB.err = errTest
+	if err := db.Put([]byte("hello"), []byte("world")); err != errTest {
+		t.Fatalf("expected %s but got %s", errTest, err)
+	}
+	if _, err := snapshot.Get([]byte("hello")); err != errTest {
+		t.Fatalf("expected %s but got %s", errTest, err)
+	}
+}
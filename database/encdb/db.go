@@ -136,6 +136,71 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewIteratorWithStartAndEnd implements the Database interface
+func (db *Database) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewIteratorWithStartAndEnd(start, end),
+		db:       db,
+	}
+}
+
+// NewReverseIterator implements the Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithPrefix(nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewReverseIteratorWithPrefix(prefix),
+		db:       db,
+	}
+}
+
+// NewReverseIteratorWithStartAndEnd implements the Database interface
+func (db *Database) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewReverseIteratorWithStartAndEnd(start, end),
+		db:       db,
+	}
+}
+
+// NewSnapshot implements the Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &encSnapshot{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	db.lock.RLock()
@@ -232,6 +297,64 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 	return nil
 }
 
+// encSnapshot decrypts all values read from the underlying snapshot
+type encSnapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+func (s *encSnapshot) Get(key []byte) ([]byte, error) {
+	encVal, err := s.Snapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.decrypt(encVal)
+}
+
+func (s *encSnapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *encSnapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *encSnapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *encSnapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+}
+
+func (s *encSnapshot) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndEnd(start, end),
+		db:       s.db,
+	}
+}
+
+func (s *encSnapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithPrefix(nil)
+}
+
+func (s *encSnapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewReverseIteratorWithPrefix(prefix),
+		db:       s.db,
+	}
+}
+
+func (s *encSnapshot) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewReverseIteratorWithStartAndEnd(start, end),
+		db:       s.db,
+	}
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
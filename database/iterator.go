@@ -7,7 +7,8 @@
 
 package database
 
-// Iterator iterates over a database's key/value pairs in ascending key order.
+// Iterator iterates over a database's key/value pairs in ascending key order,
+// or in descending key order if it was created as a reverse iterator.
 //
 // When it encounters an error any seek will return false and will yield no key/
 // value pairs. The error can be queried by calling the Error method. Calling
@@ -55,4 +56,25 @@ type Iteratee interface {
 	// a subset of database content with a particular key prefix starting at a
 	// specified key.
 	NewIteratorWithStartAndPrefix(start, prefix []byte) Iterator
+
+	// NewIteratorWithStartAndEnd creates a binary-alphabetical iterator over
+	// the subset of database content with keys in the range [start, end). A nil
+	// start is treated as a key before all keys in the database and a nil end
+	// is treated as a key after all keys in the database.
+	NewIteratorWithStartAndEnd(start, end []byte) Iterator
+
+	// NewReverseIterator creates a reverse binary-alphabetical iterator over
+	// the entire keyspace contained within the key-value database.
+	NewReverseIterator() Iterator
+
+	// NewReverseIteratorWithPrefix creates a reverse binary-alphabetical
+	// iterator over a subset of database content with a particular key prefix.
+	NewReverseIteratorWithPrefix(prefix []byte) Iterator
+
+	// NewReverseIteratorWithStartAndEnd creates a reverse binary-alphabetical
+	// iterator over the subset of database content with keys in the range
+	// [start, end). Iteration begins at the largest key less than end. A nil
+	// start is treated as a key before all keys in the database and a nil end
+	// is treated as a key after all keys in the database.
+	NewReverseIteratorWithStartAndEnd(start, end []byte) Iterator
 }
@@ -79,6 +79,26 @@ func New(file string, log logging.Logger, blockCacheSize, writeBufferSize, handl
 	}, nil
 }
 
+// NewReadOnly returns a wrapped LevelDB object that refuses all writes. The
+// database must already exist at [file]. Unlike New, corruptions are not
+// recovered, as recovery would require rewriting the database.
+func NewReadOnly(file string, log logging.Logger) (*Database, error) {
+	db, err := leveldb.OpenFile(file, &opt.Options{
+		OpenFilesCacheCapacity: minHandleCap,
+		BlockCacheCapacity:     minBlockCacheSize,
+		Filter:                 filter.NewBloomFilter(10),
+		ErrorIfMissing:         true,
+		ReadOnly:               true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Database{
+		DB:  db,
+		log: log,
+	}, nil
+}
+
 // Has returns if the key is set in the database
 func (db *Database) Has(key []byte) (bool, error) {
 	if db.errored {
@@ -119,30 +139,72 @@ func (db *Database) NewBatch() database.Batch { return &batch{db: db} }
 
 // NewIterator creates a lexicographically ordered iterator over the database
 func (db *Database) NewIterator() database.Iterator {
-	return &iter{db.DB.NewIterator(new(util.Range), nil)}
+	return &iter{Iterator: db.DB.NewIterator(new(util.Range), nil)}
 }
 
 // NewIteratorWithStart creates a lexicographically ordered iterator over the
 // database starting at the provided key
 func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
-	return &iter{db.DB.NewIterator(&util.Range{Start: start}, nil)}
+	return &iter{Iterator: db.DB.NewIterator(&util.Range{Start: start}, nil)}
 }
 
 // NewIteratorWithPrefix creates a lexicographically ordered iterator over the
 // database ignoring keys that do not start with the provided prefix
 func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
-	return &iter{db.DB.NewIterator(util.BytesPrefix(prefix), nil)}
+	return &iter{Iterator: db.DB.NewIterator(util.BytesPrefix(prefix), nil)}
 }
 
 // NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
 // over the database starting at start and ignoring keys that do not start with
 // the provided prefix
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
-	iterRange := util.BytesPrefix(prefix)
-	if bytes.Compare(start, prefix) == 1 {
-		iterRange.Start = start
+	return &iter{Iterator: db.DB.NewIterator(startAndPrefixRange(start, prefix), nil)}
+}
+
+// NewIteratorWithStartAndEnd creates a lexicographically ordered iterator
+// over the database starting at start and ending before end
+func (db *Database) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return &iter{Iterator: db.DB.NewIterator(&util.Range{Start: start, Limit: end}, nil)}
+}
+
+// NewReverseIterator creates a reverse lexicographically ordered iterator over
+// the database
+func (db *Database) NewReverseIterator() database.Iterator {
+	return &iter{
+		Iterator: db.DB.NewIterator(new(util.Range), nil),
+		reverse:  true,
 	}
-	return &iter{db.DB.NewIterator(iterRange, nil)}
+}
+
+// NewReverseIteratorWithPrefix creates a reverse lexicographically ordered
+// iterator over the database ignoring keys that do not start with the provided
+// prefix
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iter{
+		Iterator: db.DB.NewIterator(util.BytesPrefix(prefix), nil),
+		reverse:  true,
+	}
+}
+
+// NewReverseIteratorWithStartAndEnd creates a reverse lexicographically
+// ordered iterator over the database starting before end and ending at start
+func (db *Database) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return &iter{
+		Iterator: db.DB.NewIterator(&util.Range{Start: start, Limit: end}, nil),
+		reverse:  true,
+	}
+}
+
+// NewSnapshot returns a read-only view of the current state of the database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	snap, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, db.handleError(err)
+	}
+	return &snapshot{
+		Snapshot: snap,
+		db:       db,
+	}, nil
 }
 
 // Stat returns a particular internal stat of the database.
@@ -250,7 +312,108 @@ func (r *replayer) Delete(key []byte) {
 	r.err = r.writer.Delete(key)
 }
 
-type iter struct{ iterator.Iterator }
+// snapshot is a wrapper around a levelDB snapshot that converts errors into
+// their database equivalents.
+type snapshot struct {
+	*leveldb.Snapshot
+	db *Database
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.errored {
+		return false, database.ErrAvoidCorruption
+	}
+	has, err := s.Snapshot.Has(key, nil)
+	return has, s.db.handleError(err)
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.errored {
+		return nil, database.ErrAvoidCorruption
+	}
+	value, err := s.Snapshot.Get(key, nil)
+	return value, s.db.handleError(err)
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snapshot) NewIterator() database.Iterator {
+	return &iter{Iterator: s.Snapshot.NewIterator(new(util.Range), nil)}
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return &iter{Iterator: s.Snapshot.NewIterator(&util.Range{Start: start}, nil)}
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iter{Iterator: s.Snapshot.NewIterator(util.BytesPrefix(prefix), nil)}
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iter{Iterator: s.Snapshot.NewIterator(startAndPrefixRange(start, prefix), nil)}
+}
+
+// NewIteratorWithStartAndEnd creates a lexicographically ordered iterator
+// over the snapshot starting at start and ending before end
+func (s *snapshot) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return &iter{Iterator: s.Snapshot.NewIterator(&util.Range{Start: start, Limit: end}, nil)}
+}
+
+// NewReverseIterator creates a reverse lexicographically ordered iterator over
+// the snapshot
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return &iter{
+		Iterator: s.Snapshot.NewIterator(new(util.Range), nil),
+		reverse:  true,
+	}
+}
+
+// NewReverseIteratorWithPrefix creates a reverse lexicographically ordered
+// iterator over the snapshot ignoring keys that do not start with the provided
+// prefix
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iter{
+		Iterator: s.Snapshot.NewIterator(util.BytesPrefix(prefix), nil),
+		reverse:  true,
+	}
+}
+
+// NewReverseIteratorWithStartAndEnd creates a reverse lexicographically
+// ordered iterator over the snapshot starting before end and ending at start
+func (s *snapshot) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return &iter{
+		Iterator: s.Snapshot.NewIterator(&util.Range{Start: start, Limit: end}, nil),
+		reverse:  true,
+	}
+}
+
+// iter is a wrapper around a levelDB iterator that copies the returned keys and
+// values. If [reverse] is true, the iterator is walked from its last key to its
+// first key.
+type iter struct {
+	iterator.Iterator
+	reverse, initialized bool
+}
+
+// Next implements the Iterator interface
+func (it *iter) Next() bool {
+	if !it.reverse {
+		return it.Iterator.Next()
+	}
+	if !it.initialized {
+		it.initialized = true
+		return it.Iterator.Last()
+	}
+	return it.Iterator.Prev()
+}
 
 // Error implements the Iterator interface
 func (it *iter) Error() error { return updateError(it.Iterator.Error()) }
@@ -261,9 +424,19 @@ func (it *iter) Key() []byte { return utils.CopyBytes(it.Iterator.Key()) }
 // Value implements the Iterator interface
 func (it *iter) Value() []byte { return utils.CopyBytes(it.Iterator.Value()) }
 
+// startAndPrefixRange returns the range of keys that start with [prefix] and
+// are not less than [start]
+func startAndPrefixRange(start, prefix []byte) *util.Range {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return iterRange
+}
+
 func updateError(err error) error {
 	switch err {
-	case leveldb.ErrClosed:
+	case leveldb.ErrClosed, leveldb.ErrSnapshotReleased:
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
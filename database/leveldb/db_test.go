@@ -4,6 +4,7 @@
 package leveldb
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"testing"
@@ -26,3 +27,37 @@ func TestInterface(t *testing.T) {
 		test(t, db)
 	}
 }
+
+func TestReadOnly(t *testing.T) {
+	folder := "dbreadonly"
+	defer os.RemoveAll(folder)
+
+	if _, err := NewReadOnly(folder, logging.NoLog{}); err == nil {
+		t.Fatalf("NewReadOnly should have failed on a missing database")
+	}
+
+	db, err := New(folder, logging.NoLog{}, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("leveldb.New(%s, 0, 0) errored with %s", folder, err)
+	}
+	key := []byte("hello")
+	value := []byte("world")
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Unexpected error on db.Close: %s", err)
+	}
+
+	db, err = NewReadOnly(folder, logging.NoLog{})
+	if err != nil {
+		t.Fatalf("leveldb.NewReadOnly(%s) errored with %s", folder, err)
+	}
+	defer db.Close()
+
+	if v, err := db.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+}
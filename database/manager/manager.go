@@ -0,0 +1,427 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	// migratingSuffix is appended to the directory a migration writes to. The
+	// directory is renamed to its version once the migration finishes, so a
+	// database is never opened in a partially migrated state.
+	migratingSuffix = ".migrating"
+
+	// Minimum amount of time between two progress reports in the logs
+	progressLogFrequency = 10 * time.Second
+)
+
+var (
+	errNoMigrationPath  = errors.New("no migration path")
+	errMigrationFailed  = errors.New("database migration failed")
+	errInvalidMigration = errors.New("invalid migration")
+
+	// Database versions are formatted as vX.Y.Z
+	versionParser = version.NewParser("v", ".")
+)
+
+// MigrationStatus is the status of a single migration
+type MigrationStatus struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Progress  Progress  `json:"progress"`
+	Done      bool      `json:"done"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Status of the database manager
+type Status struct {
+	// Version of the database the node runs on
+	Version string `json:"version"`
+	// Version the database was migrated from, if it was migrated
+	MigratedFrom string `json:"migratedFrom,omitempty"`
+	// True if the migrations were only a dry run
+	DryRun     bool               `json:"dryRun,omitempty"`
+	Migrations []*MigrationStatus `json:"migrations,omitempty"`
+}
+
+// Manager manages the versioned databases of a node. Each version is stored in
+// its own directory, named after the version, under a common directory. When
+// the node starts on a version without a database, the most recent previous
+// version is migrated forward by running the registered migrations in order. If
+// there is no migration path, the database isn't opened, so the node never
+// silently starts over with an empty database. The current and previous
+// versions are kept, older versions are deleted.
+type Manager struct {
+	log        logging.Logger
+	dir        string
+	version    version.Version
+	migrations map[string]Migration
+
+	lock   sync.Mutex
+	status Status
+}
+
+// New returns a manager of the databases in [dir] that opens the database of
+// version [currentVersion]. At most one migration may start from each version.
+func New(dir string, currentVersion string, migrations []Migration, log logging.Logger) (*Manager, error) {
+	current, err := versionParser.Parse(currentVersion)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manager{
+		log:        log,
+		dir:        dir,
+		version:    current,
+		migrations: make(map[string]Migration, len(migrations)),
+		status:     Status{Version: current.String()},
+	}
+	for _, migration := range migrations {
+		from, err := versionParser.Parse(migration.From)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidMigration, err)
+		}
+		to, err := versionParser.Parse(migration.To)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidMigration, err)
+		}
+		switch {
+		case !from.Before(to):
+			return nil, fmt.Errorf("%w: %s isn't before %s", errInvalidMigration, from, to)
+		case migration.Migrate == nil:
+			return nil, fmt.Errorf("%w: %s to %s has no migration function", errInvalidMigration, from, to)
+		}
+		if _, exists := m.migrations[from.String()]; exists {
+			return nil, fmt.Errorf("%w: multiple migrations from %s", errInvalidMigration, from)
+		}
+		migration.From = from.String()
+		migration.To = to.String()
+		m.migrations[from.String()] = migration
+	}
+	return m, nil
+}
+
+// Path returns the directory of the current database
+func (m *Manager) Path() string { return m.path(m.version.String()) }
+
+// Open migrates the database to the current version if needed and opens it
+func (m *Manager) Open() (database.Database, error) {
+	if err := m.Migrate(false); err != nil {
+		return nil, err
+	}
+	return leveldb.New(m.Path(), m.log, 0, 0, 0)
+}
+
+// NeedsMigration returns true if a previous database will be migrated to the
+// current version when the database is opened
+func (m *Manager) NeedsMigration() (bool, error) {
+	versions, err := m.versions()
+	if err != nil {
+		return false, err
+	}
+	var prev version.Version
+	for _, v := range versions {
+		switch {
+		case v.String() == m.version.String():
+			return false, nil
+		case v.Before(m.version):
+			prev = v
+		}
+	}
+	if prev == nil {
+		return false, nil
+	}
+	_, err = m.plan(prev)
+	return err == nil, nil
+}
+
+// Migrate the most recent previous database to the current version, if the
+// current database doesn't exist yet. If [dryRun] is true, the migrations are
+// run but their result is discarded, and nothing else on disk is changed.
+func (m *Manager) Migrate(dryRun bool) error {
+	if err := m.removeIncomplete(); err != nil {
+		return err
+	}
+	versions, err := m.versions()
+	if err != nil {
+		return err
+	}
+
+	var prev version.Version
+	for _, v := range versions {
+		switch {
+		case v.String() == m.version.String():
+			m.log.Info("database %s is up to date", m.version)
+			if dryRun {
+				return nil
+			}
+			return m.prune(versions)
+		case v.Before(m.version):
+			prev = v
+		}
+	}
+	if prev == nil {
+		m.log.Info("no previous database to migrate to %s", m.version)
+		return nil
+	}
+
+	plan, err := m.plan(prev)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	m.status.MigratedFrom = prev.String()
+	m.status.DryRun = dryRun
+	m.status.Migrations = make([]*MigrationStatus, len(plan))
+	for i, migration := range plan {
+		m.status.Migrations[i] = &MigrationStatus{
+			From: migration.From,
+			To:   migration.To,
+		}
+	}
+	m.lock.Unlock()
+
+	if dryRun {
+		m.log.Info("starting dry run of the database migration from %s to %s", prev, m.version)
+	} else {
+		m.log.Info("migrating database from %s to %s", prev, m.version)
+	}
+
+	// Every migration reads the database written by the previous one. Only
+	// the last one is renamed to its version, unless this is a dry run.
+	src := m.path(prev.String())
+	defer func() {
+		for _, migration := range plan {
+			if err := os.RemoveAll(m.path(migration.To) + migratingSuffix); err != nil {
+				m.log.Warn("couldn't remove %s: %s", m.path(migration.To)+migratingSuffix, err)
+			}
+		}
+	}()
+	for i, migration := range plan {
+		dst := m.path(migration.To) + migratingSuffix
+		if err := m.run(i, migration, src, dst); err != nil {
+			return err
+		}
+		src = dst
+	}
+
+	if dryRun {
+		m.log.Info("dry run of the database migration from %s to %s succeeded", prev, m.version)
+		return nil
+	}
+	if err := os.Rename(src, m.Path()); err != nil {
+		return err
+	}
+	m.log.Info("migrated database from %s to %s", prev, m.version)
+
+	versions, err = m.versions()
+	if err != nil {
+		return err
+	}
+	return m.prune(versions)
+}
+
+// Status returns the status of the migrations run by this manager
+func (m *Manager) Status() Status {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	status := m.status
+	status.Migrations = make([]*MigrationStatus, len(m.status.Migrations))
+	for i, migration := range m.status.Migrations {
+		migrationCopy := *migration
+		status.Migrations[i] = &migrationCopy
+	}
+	return status
+}
+
+// HealthCheck returns the status of the database migrations. It fails if a
+// migration failed.
+func (m *Manager) HealthCheck() (interface{}, error) {
+	status := m.Status()
+	for _, migration := range status.Migrations {
+		if migration.Error != "" {
+			return status, errMigrationFailed
+		}
+	}
+	return status, nil
+}
+
+// run [migration] from the database at [src] into a new database at [dst]
+func (m *Manager) run(index int, migration Migration, src, dst string) error {
+	m.updateStatus(index, func(status *MigrationStatus) {
+		status.StartTime = time.Now()
+	})
+	m.log.Info("running database migration from %s to %s", migration.From, migration.To)
+
+	err := m.migrate(index, migration, src, dst)
+
+	var status MigrationStatus
+	m.updateStatus(index, func(s *MigrationStatus) {
+		s.EndTime = time.Now()
+		if err != nil {
+			s.Error = err.Error()
+		} else {
+			s.Done = true
+		}
+		status = *s
+	})
+	if err != nil {
+		m.log.Error("database migration from %s to %s failed with: %s", migration.From, migration.To, err)
+		return fmt.Errorf("%w from %s to %s: %s", errMigrationFailed, migration.From, migration.To, err)
+	}
+	m.log.Info("finished database migration from %s to %s with %d keys (%d bytes) in %s",
+		migration.From,
+		migration.To,
+		status.Progress.Keys,
+		status.Progress.Bytes,
+		status.EndTime.Sub(status.StartTime),
+	)
+	return nil
+}
+
+func (m *Manager) migrate(index int, migration Migration, src, dst string) error {
+	prev, err := leveldb.NewReadOnly(src, m.log)
+	if err != nil {
+		return fmt.Errorf("couldn't open %s: %w", src, err)
+	}
+	current, err := leveldb.New(dst, m.log, 0, 0, 0)
+	if err != nil {
+		_ = prev.Close()
+		return fmt.Errorf("couldn't create %s: %w", dst, err)
+	}
+
+	lastLog := time.Now()
+	err = migration.Migrate(prev, current, func(progress Progress) {
+		m.updateStatus(index, func(status *MigrationStatus) {
+			status.Progress = progress
+		})
+		if now := time.Now(); now.Sub(lastLog) >= progressLogFrequency {
+			lastLog = now
+			m.log.Info("database migration from %s to %s has written %d keys (%d bytes)",
+				migration.From, migration.To, progress.Keys, progress.Bytes)
+		}
+	})
+
+	errs := wrappers.Errs{}
+	errs.Add(err, current.Close(), prev.Close())
+	return errs.Err
+}
+
+func (m *Manager) updateStatus(index int, f func(*MigrationStatus)) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	f(m.status.Migrations[index])
+}
+
+// plan returns the migrations that move a database from [from] to the current
+// version, in the order they must be run
+func (m *Manager) plan(from version.Version) ([]Migration, error) {
+	plan := []Migration(nil)
+	current := from.String()
+	for current != m.version.String() {
+		migration, exists := m.migrations[current]
+		if !exists {
+			return nil, fmt.Errorf("%w from %s to %s", errNoMigrationPath, from, m.version)
+		}
+		to, err := versionParser.Parse(migration.To)
+		if err != nil {
+			return nil, err
+		}
+		if m.version.Before(to) {
+			return nil, fmt.Errorf("%w from %s to %s: migration to %s skips the current version",
+				errNoMigrationPath, from, m.version, to)
+		}
+		plan = append(plan, migration)
+		current = to.String()
+	}
+	return plan, nil
+}
+
+// prune deletes every database older than the most recent database before the
+// current version
+func (m *Manager) prune(versions []version.Version) error {
+	var prev version.Version
+	for _, v := range versions {
+		if v.Before(m.version) {
+			prev = v
+		}
+	}
+	for _, v := range versions {
+		if prev == nil || !v.Before(prev) {
+			continue
+		}
+		m.log.Info("deleting database %s, which is older than the previous database %s", v, prev)
+		if err := os.RemoveAll(m.path(v.String())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeIncomplete deletes the results of migrations that were interrupted
+func (m *Manager) removeIncomplete() error {
+	files, err := ioutil.ReadDir(m.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if !file.IsDir() || !strings.HasSuffix(file.Name(), migratingSuffix) {
+			continue
+		}
+		m.log.Warn("deleting incomplete database migration %s", file.Name())
+		if err := os.RemoveAll(filepath.Join(m.dir, file.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// versions returns the versions of the databases in the manager's directory,
+// oldest first
+func (m *Manager) versions() ([]version.Version, error) {
+	files, err := ioutil.ReadDir(m.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	versions := []version.Version(nil)
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+		v, err := versionParser.Parse(file.Name())
+		if err != nil || v.String() != file.Name() {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Before(versions[j]) })
+	return versions, nil
+}
+
+func (m *Manager) path(v string) string { return filepath.Join(m.dir, v) }
@@ -0,0 +1,323 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manager
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// appendMigration appends [suffix] to every value
+func appendMigration(from, to string, suffix byte) Migration {
+	return Migration{
+		From: from,
+		To:   to,
+		Migrate: Transform(func(key, value []byte) ([]byte, []byte, bool) {
+			return key, append(append([]byte(nil), value...), suffix), true
+		}),
+	}
+}
+
+func createDB(t *testing.T, path string, kvs map[string]string) {
+	db, err := leveldb.New(path, logging.NoLog{}, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range kvs {
+		if err := db.Put([]byte(k), []byte(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertValue(t *testing.T, db database.Database, key, expected string) {
+	value, err := db.Get([]byte(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, []byte(expected)) {
+		t.Fatalf("got %q for key %q but expected %q", value, key, expected)
+	}
+}
+
+func assertExists(t *testing.T, path string, expected bool) {
+	_, err := os.Stat(path)
+	switch {
+	case err == nil && !expected:
+		t.Fatalf("%s shouldn't exist", path)
+	case os.IsNotExist(err) && expected:
+		t.Fatalf("%s should exist", path)
+	case err != nil && !os.IsNotExist(err):
+		t.Fatal(err)
+	}
+}
+
+func TestOpenNew(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m, err := New(dir, "v1.0.0", nil, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := m.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	assertExists(t, filepath.Join(dir, "v1.0.0"), true)
+
+	status := m.Status()
+	if status.MigratedFrom != "" || len(status.Migrations) != 0 {
+		t.Fatalf("a new database shouldn't be migrated but got %+v", status)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	createDB(t, filepath.Join(dir, "v0.1.0"), map[string]string{"key": "ancient"})
+	createDB(t, filepath.Join(dir, "v1.0.0"), map[string]string{"key": "value"})
+	// Left behind by an interrupted migration
+	createDB(t, filepath.Join(dir, "v1.1.0"+migratingSuffix), map[string]string{"key": "partial"})
+
+	migrations := []Migration{
+		appendMigration("v1.1.0", "v1.2.0", '2'),
+		appendMigration("v1.0.0", "v1.1.0", '1'),
+	}
+	m, err := New(dir, "v1.2.0", migrations, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if needsMigration, err := m.NeedsMigration(); err != nil {
+		t.Fatal(err)
+	} else if !needsMigration {
+		t.Fatal("should need a migration")
+	}
+	db, err := m.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValue(t, db, "key", "value12")
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The previous database is kept, older databases, intermediate results
+	// and incomplete migrations are removed.
+	assertExists(t, filepath.Join(dir, "v1.0.0"), true)
+	assertExists(t, filepath.Join(dir, "v0.1.0"), false)
+	assertExists(t, filepath.Join(dir, "v1.1.0"), false)
+	assertExists(t, filepath.Join(dir, "v1.1.0"+migratingSuffix), false)
+	assertExists(t, filepath.Join(dir, "v1.2.0"+migratingSuffix), false)
+
+	status, err := m.HealthCheck()
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrationStatus := status.(Status)
+	if migrationStatus.MigratedFrom != "v1.0.0" || len(migrationStatus.Migrations) != 2 {
+		t.Fatalf("unexpected status %+v", migrationStatus)
+	}
+	for _, s := range migrationStatus.Migrations {
+		if !s.Done || s.Progress.Keys != 1 {
+			t.Fatalf("unexpected migration status %+v", s)
+		}
+	}
+
+	// Opening again shouldn't migrate again
+	m, err = New(dir, "v1.2.0", migrations, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if needsMigration, err := m.NeedsMigration(); err != nil {
+		t.Fatal(err)
+	} else if needsMigration {
+		t.Fatal("shouldn't need a migration")
+	}
+	db, err = m.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	assertValue(t, db, "key", "value12")
+	if status := m.Status(); len(status.Migrations) != 0 {
+		t.Fatalf("shouldn't have migrated but got %+v", status)
+	}
+}
+
+func TestHealthCheckDuringMigration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	createDB(t, filepath.Join(dir, "v1.0.0"), map[string]string{"key": "value"})
+
+	// The migration reports progress, then waits until the test has checked
+	// the health of the manager
+	migrating := make(chan struct{})
+	checked := make(chan struct{})
+	migrations := []Migration{{
+		From: "v1.0.0",
+		To:   "v1.1.0",
+		Migrate: func(_ database.Database, _ database.Database, progress func(Progress)) error {
+			progress(Progress{Keys: 1, Bytes: 8})
+			close(migrating)
+			<-checked
+			return nil
+		},
+	}}
+	m, err := New(dir, "v1.1.0", migrations, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := make(chan error, 1)
+	go func() { errs <- m.Migrate(false) }()
+
+	<-migrating
+	status, err := m.HealthCheck()
+	close(checked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrationStatus := status.(Status).Migrations[0]
+	if migrationStatus.Done || migrationStatus.Progress.Keys != 1 || migrationStatus.StartTime.IsZero() {
+		t.Fatalf("unexpected status of a running migration %+v", migrationStatus)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if migrationStatus := m.Status().Migrations[0]; !migrationStatus.Done {
+		t.Fatalf("unexpected status of a finished migration %+v", migrationStatus)
+	}
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	createDB(t, filepath.Join(dir, "v0.1.0"), map[string]string{"key": "ancient"})
+	createDB(t, filepath.Join(dir, "v1.0.0"), map[string]string{"key": "value"})
+
+	m, err := New(dir, "v1.1.0", []Migration{appendMigration("v1.0.0", "v1.1.0", '1')}, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Migrate(true); err != nil {
+		t.Fatal(err)
+	}
+	if status := m.Status(); !status.DryRun || len(status.Migrations) != 1 || !status.Migrations[0].Done {
+		t.Fatalf("unexpected status %+v", status)
+	}
+
+	assertExists(t, filepath.Join(dir, "v0.1.0"), true)
+	assertExists(t, filepath.Join(dir, "v1.0.0"), true)
+	assertExists(t, filepath.Join(dir, "v1.1.0"), false)
+	assertExists(t, filepath.Join(dir, "v1.1.0"+migratingSuffix), false)
+}
+
+func TestMigrateFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	createDB(t, filepath.Join(dir, "v1.0.0"), map[string]string{"key": "value"})
+
+	errTest := errors.New("non-nil error")
+	migrations := []Migration{{
+		From: "v1.0.0",
+		To:   "v1.1.0",
+		Migrate: func(database.Database, database.Database, func(Progress)) error {
+			return errTest
+		},
+	}}
+	m, err := New(dir, "v1.1.0", migrations, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Open(); !errors.Is(err, errMigrationFailed) {
+		t.Fatalf("expected %s but got %s", errMigrationFailed, err)
+	}
+	if _, err := m.HealthCheck(); !errors.Is(err, errMigrationFailed) {
+		t.Fatalf("health check should have failed with %s but got %s", errMigrationFailed, err)
+	}
+	assertExists(t, filepath.Join(dir, "v1.0.0"), true)
+	assertExists(t, filepath.Join(dir, "v1.1.0"), false)
+	assertExists(t, filepath.Join(dir, "v1.1.0"+migratingSuffix), false)
+}
+
+func TestNoMigrationPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	createDB(t, filepath.Join(dir, "v1.0.0"), map[string]string{"key": "value"})
+
+	m, err := New(dir, "v1.2.0", []Migration{appendMigration("v1.0.0", "v1.1.0", '1')}, logging.NoLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Migrate(true); !errors.Is(err, errNoMigrationPath) {
+		t.Fatalf("expected %s but got %s", errNoMigrationPath, err)
+	}
+	assertExists(t, filepath.Join(dir, "v1.2.0"), false)
+
+	// Without a migration path, the node doesn't start with a new database
+	if db, err := m.Open(); !errors.Is(err, errNoMigrationPath) {
+		if err == nil {
+			db.Close()
+		}
+		t.Fatalf("expected %s but got %v", errNoMigrationPath, err)
+	}
+	assertExists(t, filepath.Join(dir, "v1.2.0"), false)
+	assertExists(t, filepath.Join(dir, "v1.0.0"), true)
+}
+
+func TestInvalidMigrations(t *testing.T) {
+	tests := map[string][]Migration{
+		"backwards":  {appendMigration("v1.1.0", "v1.0.0", '1')},
+		"unparsable": {appendMigration("1.0.0", "v1.1.0", '1')},
+		"duplicate": {
+			appendMigration("v1.0.0", "v1.1.0", '1'),
+			appendMigration("v1.0.0", "v1.2.0", '1'),
+		},
+		"no function": {{From: "v1.0.0", To: "v1.1.0"}},
+	}
+	for name, migrations := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := New("", "v1.2.0", migrations, logging.NoLog{}); !errors.Is(err, errInvalidMigration) {
+				t.Fatalf("expected %s but got %s", errInvalidMigration, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manager
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+)
+
+const (
+	// batchSize is the number of bytes written to the new database at a time
+	// by Transform. Progress is reported after every batch.
+	batchSize = 4 * 1024 * 1024
+)
+
+// Migrations that move a node's database forward. A migration must be
+// registered here for every database version that a node may need to upgrade
+// from.
+var Migrations = []Migration{}
+
+// Progress of a migration
+type Progress struct {
+	Keys  uint64 `json:"keys"`
+	Bytes uint64 `json:"bytes"`
+}
+
+// MigrateFunc writes the contents of [prev] into [current] in the format of
+// the newer version. [prev] is read-only and [current] is empty when the
+// function is called. [progress] should be called periodically with the amount
+// of data written so far.
+type MigrateFunc func(prev database.Database, current database.Database, progress func(Progress)) error
+
+// Migration moves a database from version [From] to version [To]. Versions are
+// formatted as vX.Y.Z, the same as the database directories.
+type Migration struct {
+	From    string
+	To      string
+	Migrate MigrateFunc
+}
+
+// Transform returns a MigrateFunc that writes every key of the previous
+// database to the new database after passing it through [f]. If [f] returns
+// false, the key isn't written.
+func Transform(f func(key, value []byte) ([]byte, []byte, bool)) MigrateFunc {
+	return func(prev database.Database, current database.Database, progress func(Progress)) error {
+		_, err := WriteTransformed(current, prev, f, progress)
+		return err
+	}
+}
+
+// WriteTransformed writes every key of [src] to [dst] after passing it through
+// [f], in batches. If [f] returns false, the key isn't written. [progress] is
+// called after every batch with the amount of data written so far, and the
+// total amount written is returned.
+func WriteTransformed(
+	dst database.Batcher,
+	src database.Iteratee,
+	f func(key, value []byte) ([]byte, []byte, bool),
+	progress func(Progress),
+) (Progress, error) {
+	iter := src.NewIterator()
+	defer iter.Release()
+
+	written := Progress{}
+	batch := dst.NewBatch()
+	for iter.Next() {
+		key, value, keep := f(iter.Key(), iter.Value())
+		if !keep {
+			continue
+		}
+		if err := batch.Put(key, value); err != nil {
+			return written, err
+		}
+		written.Keys++
+		written.Bytes += uint64(len(key) + len(value))
+
+		if batch.ValueSize() < batchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return written, err
+		}
+		batch.Reset()
+		progress(written)
+	}
+	if err := iter.Error(); err != nil {
+		return written, err
+	}
+	if err := batch.Write(); err != nil {
+		return written, err
+	}
+	progress(written)
+	return written, nil
+}
+
+// Copy is a MigrateFunc that writes the previous database to the new database
+// unchanged. It can be used when a version changes without changing the
+// database format.
+var Copy = Transform(func(key, value []byte) ([]byte, []byte, bool) {
+	return key, value, true
+})
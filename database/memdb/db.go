@@ -114,6 +114,33 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 
 // NewIteratorWithStartAndPrefix implements the Database interface
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.newIterator(start, nil, prefix, false)
+}
+
+// NewIteratorWithStartAndEnd implements the Database interface
+func (db *Database) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return db.newIterator(start, end, nil, false)
+}
+
+// NewReverseIterator implements the Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.newIterator(nil, nil, nil, true)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIterator(nil, nil, prefix, true)
+}
+
+// NewReverseIteratorWithStartAndEnd implements the Database interface
+func (db *Database) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return db.newIterator(start, end, nil, true)
+}
+
+// newIterator returns an iterator over the keys that start with [prefix], are
+// not less than [start] and, if [end] is non-nil, are less than [end]. If
+// [reverse] is true, the keys are iterated over in descending order.
+func (db *Database) newIterator(start, end, prefix []byte, reverse bool) database.Iterator {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
@@ -122,14 +149,19 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 
 	startString := string(start)
+	endString := string(end)
 	prefixString := string(prefix)
 	keys := make([]string, 0, len(db.db))
 	for key := range db.db {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
+		if strings.HasPrefix(key, prefixString) && key >= startString && (end == nil || key < endString) {
 			keys = append(keys, key)
 		}
 	}
-	sort.Strings(keys) // Keys need to be in sorted order
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys) // Keys need to be in sorted order
+	}
 	values := make([][]byte, 0, len(keys))
 	for _, key := range keys {
 		values = append(values, db.db[key])
@@ -140,6 +172,24 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewSnapshot implements the Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+
+	// Values are never modified after being inserted, so they can be shared
+	// with the snapshot.
+	snapshotDB := NewWithSize(len(db.db))
+	for key, value := range db.db {
+		snapshotDB.db[key] = value
+	}
+	return &snapshot{Database: snapshotDB}, nil
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(property string) (string, error) { return "", database.ErrNotFound }
 
@@ -228,6 +278,13 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 // Inner returns itself
 func (b *batch) Inner() database.Batch { return b }
 
+// snapshot is a read-only copy of the database taken at the time the snapshot
+// was created.
+type snapshot struct{ *Database }
+
+// Release implements the Snapshot interface
+func (s *snapshot) Release() { _ = s.Database.Close() }
+
 type iterator struct {
 	initialized bool
 	keys        []string
@@ -105,6 +105,62 @@ func (db *Database) NewIteratorWithStartAndPrefix(
 	return it
 }
 
+// NewIteratorWithStartAndEnd implements the Database interface
+func (db *Database) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	startTime := db.clock.Time()
+	it := &iterator{
+		iterator: db.db.NewIteratorWithStartAndEnd(start, end),
+		db:       db,
+	}
+	endTime := db.clock.Time()
+	db.newIterator.Observe(float64(endTime.Sub(startTime)))
+	return it
+}
+
+// NewReverseIterator implements the Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithPrefix(nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	start := db.clock.Time()
+	it := &iterator{
+		iterator: db.db.NewReverseIteratorWithPrefix(prefix),
+		db:       db,
+	}
+	end := db.clock.Time()
+	db.newIterator.Observe(float64(end.Sub(start)))
+	return it
+}
+
+// NewReverseIteratorWithStartAndEnd implements the Database interface
+func (db *Database) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	startTime := db.clock.Time()
+	it := &iterator{
+		iterator: db.db.NewReverseIteratorWithStartAndEnd(start, end),
+		db:       db,
+	}
+	endTime := db.clock.Time()
+	db.newIterator.Observe(float64(endTime.Sub(startTime)))
+	return it
+}
+
+// NewSnapshot implements the Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	start := db.clock.Time()
+	snapshot, err := db.db.NewSnapshot()
+	end := db.clock.Time()
+	db.newSnapshot.Observe(float64(end.Sub(start)))
+	if err != nil {
+		return nil, err
+	}
+	return &meteredSnapshot{
+		snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	start := db.clock.Time()
@@ -192,6 +248,97 @@ func (b *batch) Inner() database.Batch {
 	return inner
 }
 
+type meteredSnapshot struct {
+	snapshot database.Snapshot
+	db       *Database
+}
+
+func (s *meteredSnapshot) Has(key []byte) (bool, error) {
+	start := s.db.clock.Time()
+	has, err := s.snapshot.Has(key)
+	end := s.db.clock.Time()
+	s.db.sHas.Observe(float64(end.Sub(start)))
+	return has, err
+}
+
+func (s *meteredSnapshot) Get(key []byte) ([]byte, error) {
+	start := s.db.clock.Time()
+	value, err := s.snapshot.Get(key)
+	end := s.db.clock.Time()
+	s.db.sGet.Observe(float64(end.Sub(start)))
+	return value, err
+}
+
+func (s *meteredSnapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *meteredSnapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *meteredSnapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *meteredSnapshot) NewIteratorWithStartAndPrefix(
+	start,
+	prefix []byte,
+) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *meteredSnapshot) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewIteratorWithStartAndEnd(start, end),
+		db:       s.db,
+	}
+	endTime := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(endTime.Sub(startTime)))
+	return it
+}
+
+func (s *meteredSnapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithPrefix(nil)
+}
+
+func (s *meteredSnapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	start := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewReverseIteratorWithPrefix(prefix),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(start)))
+	return it
+}
+
+func (s *meteredSnapshot) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewReverseIteratorWithStartAndEnd(start, end),
+		db:       s.db,
+	}
+	endTime := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(endTime.Sub(startTime)))
+	return it
+}
+
+func (s *meteredSnapshot) Release() {
+	start := s.db.clock.Time()
+	s.snapshot.Release()
+	end := s.db.clock.Time()
+	s.db.sRelease.Observe(float64(end.Sub(start)))
+}
+
 type iterator struct {
 	iterator database.Iterator
 	db       *Database
@@ -28,6 +28,7 @@ type metrics struct {
 	delete,
 	newBatch,
 	newIterator,
+	newSnapshot,
 	stat,
 	compact,
 	close,
@@ -38,6 +39,10 @@ type metrics struct {
 	bReset,
 	bReplay,
 	bInner,
+	sHas,
+	sGet,
+	sNewIterator,
+	sRelease,
 	iNext,
 	iError,
 	iKey,
@@ -55,6 +60,7 @@ func (m *metrics) Initialize(
 	m.delete = newMetric(namespace, "delete")
 	m.newBatch = newMetric(namespace, "new_batch")
 	m.newIterator = newMetric(namespace, "new_iterator")
+	m.newSnapshot = newMetric(namespace, "new_snapshot")
 	m.stat = newMetric(namespace, "stat")
 	m.compact = newMetric(namespace, "compact")
 	m.close = newMetric(namespace, "close")
@@ -65,6 +71,10 @@ func (m *metrics) Initialize(
 	m.bReset = newMetric(namespace, "batch_reset")
 	m.bReplay = newMetric(namespace, "batch_replay")
 	m.bInner = newMetric(namespace, "batch_inner")
+	m.sHas = newMetric(namespace, "snapshot_has")
+	m.sGet = newMetric(namespace, "snapshot_get")
+	m.sNewIterator = newMetric(namespace, "snapshot_new_iterator")
+	m.sRelease = newMetric(namespace, "snapshot_release")
 	m.iNext = newMetric(namespace, "iterator_next")
 	m.iError = newMetric(namespace, "iterator_error")
 	m.iKey = newMetric(namespace, "iterator_key")
@@ -79,6 +89,7 @@ func (m *metrics) Initialize(
 		registerer.Register(m.delete),
 		registerer.Register(m.newBatch),
 		registerer.Register(m.newIterator),
+		registerer.Register(m.newSnapshot),
 		registerer.Register(m.stat),
 		registerer.Register(m.compact),
 		registerer.Register(m.close),
@@ -89,6 +100,10 @@ func (m *metrics) Initialize(
 		registerer.Register(m.bReset),
 		registerer.Register(m.bReplay),
 		registerer.Register(m.bInner),
+		registerer.Register(m.sHas),
+		registerer.Register(m.sGet),
+		registerer.Register(m.sNewIterator),
+		registerer.Register(m.sRelease),
 		registerer.Register(m.iNext),
 		registerer.Register(m.iError),
 		registerer.Register(m.iKey),
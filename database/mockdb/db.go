@@ -21,18 +21,23 @@ var errNoFunction = errors.New("user didn't specify what value(s) return")
 // If you
 type Database struct {
 	// Executed when Has is called
-	OnHas                           func([]byte) (bool, error)
-	OnGet                           func([]byte) ([]byte, error)
-	OnPut                           func([]byte, []byte) error
-	OnDelete                        func([]byte) error
-	OnNewBatch                      func() database.Batch
-	OnNewIterator                   func() database.Iterator
-	OnNewIteratorWithStart          func([]byte) database.Iterator
-	OnNewIteratorWithPrefix         func([]byte) database.Iterator
-	OnNewIteratorWithStartAndPrefix func([]byte, []byte) database.Iterator
-	OnStat                          func(string) (string, error)
-	OnCompact                       func([]byte, []byte) error
-	OnClose                         func() error
+	OnHas                               func([]byte) (bool, error)
+	OnGet                               func([]byte) ([]byte, error)
+	OnPut                               func([]byte, []byte) error
+	OnDelete                            func([]byte) error
+	OnNewBatch                          func() database.Batch
+	OnNewIterator                       func() database.Iterator
+	OnNewIteratorWithStart              func([]byte) database.Iterator
+	OnNewIteratorWithPrefix             func([]byte) database.Iterator
+	OnNewIteratorWithStartAndPrefix     func([]byte, []byte) database.Iterator
+	OnNewIteratorWithStartAndEnd        func([]byte, []byte) database.Iterator
+	OnNewReverseIterator                func() database.Iterator
+	OnNewReverseIteratorWithPrefix      func([]byte) database.Iterator
+	OnNewReverseIteratorWithStartAndEnd func([]byte, []byte) database.Iterator
+	OnNewSnapshot                       func() (database.Snapshot, error)
+	OnStat                              func(string) (string, error)
+	OnCompact                           func([]byte, []byte) error
+	OnClose                             func() error
 }
 
 // Has implements the database.Database interface
@@ -107,6 +112,46 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return db.OnNewIteratorWithStartAndPrefix(start, prefix)
 }
 
+// NewIteratorWithStartAndEnd implements the database.Database interface
+func (db *Database) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	if db.OnNewIteratorWithStartAndEnd == nil {
+		return nil
+	}
+	return db.OnNewIteratorWithStartAndEnd(start, end)
+}
+
+// NewReverseIterator implements the database.Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	if db.OnNewReverseIterator == nil {
+		return nil
+	}
+	return db.OnNewReverseIterator()
+}
+
+// NewReverseIteratorWithPrefix implements the database.Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	if db.OnNewReverseIteratorWithPrefix == nil {
+		return nil
+	}
+	return db.OnNewReverseIteratorWithPrefix(prefix)
+}
+
+// NewReverseIteratorWithStartAndEnd implements the database.Database interface
+func (db *Database) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	if db.OnNewReverseIteratorWithStartAndEnd == nil {
+		return nil
+	}
+	return db.OnNewReverseIteratorWithStartAndEnd(start, end)
+}
+
+// NewSnapshot implements the database.Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if db.OnNewSnapshot == nil {
+		return nil, errNoFunction
+	}
+	return db.OnNewSnapshot()
+}
+
 // Stat implements the database.Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	if db.OnStat == nil {
@@ -43,6 +43,21 @@ func TestDefaultError(t *testing.T) {
 	if iterator := db.NewIteratorWithStartAndPrefix([]byte{}, []byte{}); iterator != nil {
 		t.Fatal("should have errored")
 	}
+	if iterator := db.NewIteratorWithStartAndEnd([]byte{}, []byte{}); iterator != nil {
+		t.Fatal("should have errored")
+	}
+	if iterator := db.NewReverseIterator(); iterator != nil {
+		t.Fatal("should have errored")
+	}
+	if iterator := db.NewReverseIteratorWithPrefix([]byte{}); iterator != nil {
+		t.Fatal("should have errored")
+	}
+	if iterator := db.NewReverseIteratorWithStartAndEnd([]byte{}, []byte{}); iterator != nil {
+		t.Fatal("should have errored")
+	}
+	if _, err := db.NewSnapshot(); err == nil {
+		t.Fatal("should have errored")
+	}
 	if err := db.Compact([]byte{}, []byte{}); err == nil {
 		t.Fatal("should have errored")
 	}
@@ -39,6 +39,23 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return &Iterator{}
 }
 
+// NewSnapshot returns an error
+func (*Database) NewSnapshot() (database.Snapshot, error) { return nil, database.ErrClosed }
+
+// NewIteratorWithStartAndEnd returns a new empty iterator
+func (*Database) NewIteratorWithStartAndEnd(_, _ []byte) database.Iterator { return &Iterator{} }
+
+// NewReverseIterator returns a new empty iterator
+func (*Database) NewReverseIterator() database.Iterator { return &Iterator{} }
+
+// NewReverseIteratorWithPrefix returns a new empty iterator
+func (*Database) NewReverseIteratorWithPrefix([]byte) database.Iterator { return &Iterator{} }
+
+// NewReverseIteratorWithStartAndEnd returns a new empty iterator
+func (*Database) NewReverseIteratorWithStartAndEnd(_, _ []byte) database.Iterator {
+	return &Iterator{}
+}
+
 // Stat returns an error
 func (*Database) Stat(string) (string, error) { return "", database.ErrClosed }
 
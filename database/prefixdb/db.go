@@ -21,6 +21,9 @@ type Database struct {
 	lock sync.RWMutex
 	// All keys in this db begin with this byte slice
 	dbPrefix []byte
+	// The smallest key that is larger than all keys in this db, or nil if
+	// there is no such key
+	dbLimit []byte
 	// The underlying storage
 	db database.Database
 	// Holds unused []byte
@@ -41,8 +44,10 @@ func New(prefix []byte, db database.Database) *Database {
 // NewNested returns a new prefixed database without attempting to compress
 // prefixes.
 func NewNested(prefix []byte, db database.Database) *Database {
+	dbPrefix := hashing.ComputeHash256(prefix)
 	return &Database{
-		dbPrefix: hashing.ComputeHash256(prefix),
+		dbPrefix: dbPrefix,
+		dbLimit:  PrefixLimit(dbPrefix),
 		db:       db,
 		bufferPool: sync.Pool{
 			New: func() interface{} {
@@ -165,6 +170,102 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return it
 }
 
+// NewIteratorWithStartAndEnd implements the Database interface.
+// It is safe to modify [start] and [end] after this method returns.
+func (db *Database) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newIteratorWithStartAndEnd(db.db, start, end, false)
+}
+
+// NewReverseIterator implements the Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithPrefix(nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface.
+// It is safe to modify [prefix] after this method returns.
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newReverseIteratorWithPrefix(db.db, prefix)
+}
+
+// NewReverseIteratorWithStartAndEnd implements the Database interface.
+// It is safe to modify [start] and [end] after this method returns.
+func (db *Database) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newIteratorWithStartAndEnd(db.db, start, end, true)
+}
+
+// newIteratorWithStartAndEnd returns an iterator over the keys of [iteratee]
+// that are in this db and in the range [start, end).
+// Assumes it is safe to modify the arguments to the iterator constructors of
+// [iteratee] after they return.
+func (db *Database) newIteratorWithStartAndEnd(iteratee database.Iteratee, start, end []byte, reverse bool) database.Iterator {
+	prefixedStart := db.prefix(start)
+	defer db.bufferPool.Put(prefixedStart)
+
+	prefixedEnd := db.dbLimit
+	if end != nil {
+		prefixedEnd = db.prefix(end)
+		defer db.bufferPool.Put(prefixedEnd)
+	}
+
+	it := &iterator{db: db}
+	if reverse {
+		it.Iterator = iteratee.NewReverseIteratorWithStartAndEnd(prefixedStart, prefixedEnd)
+	} else {
+		it.Iterator = iteratee.NewIteratorWithStartAndEnd(prefixedStart, prefixedEnd)
+	}
+	return it
+}
+
+// newReverseIteratorWithPrefix returns a reverse iterator over the keys of
+// [iteratee] that are in this db and start with [prefix].
+// Assumes it is safe to modify the argument to
+// iteratee.NewReverseIteratorWithPrefix after it returns.
+func (db *Database) newReverseIteratorWithPrefix(iteratee database.Iteratee, prefix []byte) database.Iterator {
+	prefixedPrefix := db.prefix(prefix)
+	it := &iterator{
+		Iterator: iteratee.NewReverseIteratorWithPrefix(prefixedPrefix),
+		db:       db,
+	}
+	db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
+// NewSnapshot implements the Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &prefixedSnapshot{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	db.lock.RLock()
@@ -309,6 +410,83 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 	return nil
 }
 
+// prefixedSnapshot is a snapshot of the underlying database that only exposes
+// the keys of this prefixed database.
+type prefixedSnapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// Has implements the Snapshot interface
+// [key] may be modified after this method returns.
+func (s *prefixedSnapshot) Has(key []byte) (bool, error) {
+	prefixedKey := s.db.prefix(key)
+	has, err := s.Snapshot.Has(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return has, err
+}
+
+// Get implements the Snapshot interface
+// [key] may be modified after this method returns.
+func (s *prefixedSnapshot) Get(key []byte) ([]byte, error) {
+	prefixedKey := s.db.prefix(key)
+	val, err := s.Snapshot.Get(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return val, err
+}
+
+// NewIterator implements the Snapshot interface
+func (s *prefixedSnapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *prefixedSnapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *prefixedSnapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface.
+// It is safe to modify [start] and [prefix] after this method returns.
+func (s *prefixedSnapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	prefixedStart := s.db.prefix(start)
+	prefixedPrefix := s.db.prefix(prefix)
+	it := &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(prefixedStart, prefixedPrefix),
+		db:       s.db,
+	}
+	s.db.bufferPool.Put(prefixedStart)
+	s.db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
+// NewIteratorWithStartAndEnd implements the Snapshot interface.
+// It is safe to modify [start] and [end] after this method returns.
+func (s *prefixedSnapshot) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return s.db.newIteratorWithStartAndEnd(s.Snapshot, start, end, false)
+}
+
+// NewReverseIterator implements the Snapshot interface
+func (s *prefixedSnapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithPrefix(nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Snapshot interface.
+// It is safe to modify [prefix] after this method returns.
+func (s *prefixedSnapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.db.newReverseIteratorWithPrefix(s.Snapshot, prefix)
+}
+
+// NewReverseIteratorWithStartAndEnd implements the Snapshot interface.
+// It is safe to modify [start] and [end] after this method returns.
+func (s *prefixedSnapshot) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return s.db.newIteratorWithStartAndEnd(s.Snapshot, start, end, true)
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
@@ -322,3 +500,17 @@ func (it *iterator) Key() []byte {
 	}
 	return key
 }
+
+// PrefixLimit returns the smallest key that is larger than all keys that start
+// with [prefix]. If no such key exists, nil is returned.
+func PrefixLimit(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] < 0xff {
+			limit := make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i]++
+			return limit
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcdb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+const (
+	benchmarkNumKeys   = 4096
+	benchmarkKeySize   = 32
+	benchmarkValueSize = 128
+)
+
+func newBenchmarkData(b *testing.B) ([][]byte, [][]byte) {
+	keys := make([][]byte, benchmarkNumKeys)
+	values := make([][]byte, benchmarkNumKeys)
+	for i := range keys {
+		keys[i] = make([]byte, benchmarkKeySize)
+		values[i] = make([]byte, benchmarkValueSize)
+		if _, err := rand.Read(keys[i]); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := rand.Read(values[i]); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return keys, values
+}
+
+// BenchmarkIterator compares fetching one key/value pair per request, which is
+// how iterators used to behave, with fetching pages of key/value pairs.
+func BenchmarkIterator(b *testing.B) {
+	keys, values := newBenchmarkData(b)
+	baseDB := memdb.New()
+	for i, key := range keys {
+		if err := baseDB.Put(key, values[i]); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	for _, pageSize := range []int{1, 64, DefaultPageSize} {
+		b.Run(fmt.Sprintf("page_size_%d", pageSize), func(b *testing.B) {
+			db, closeFn := setupDB(b, baseDB, pageSize)
+			defer closeFn()
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				iterator := db.NewIterator()
+				numKeys := 0
+				for iterator.Next() {
+					numKeys++
+				}
+				if err := iterator.Error(); err != nil {
+					b.Fatal(err)
+				}
+				iterator.Release()
+				if numKeys != benchmarkNumKeys {
+					b.Fatalf("iterated over %d keys ; expected %d", numKeys, benchmarkNumKeys)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkPut writes every key/value pair with its own request.
+func BenchmarkPut(b *testing.B) {
+	keys, values := newBenchmarkData(b)
+	db, closeFn := setupDB(b, memdb.New(), DefaultPageSize)
+	defer closeFn()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i, key := range keys {
+			if err := db.Put(key, values[i]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkBatchWrite writes every key/value pair in a single request.
+func BenchmarkBatchWrite(b *testing.B) {
+	keys, values := newBenchmarkData(b)
+	db, closeFn := setupDB(b, memdb.New(), DefaultPageSize)
+	defer closeFn()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		batch := db.NewBatch()
+		for i, key := range keys {
+			if err := batch.Put(key, values[i]); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if err := batch.Write(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
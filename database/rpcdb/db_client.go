@@ -13,12 +13,33 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+const (
+	// DefaultPageSize is the default maximum number of key/value pairs that an
+	// iterator fetches from the remote database per request.
+	DefaultPageSize = 1024
+)
+
 // DatabaseClient is an implementation of database that talks over RPC.
-type DatabaseClient struct{ client rpcdbproto.DatabaseClient }
+type DatabaseClient struct {
+	client rpcdbproto.DatabaseClient
+
+	// maximum number of key/value pairs to request per IteratorNext call
+	pageSize int
+}
 
 // NewClient returns a database instance connected to a remote database instance
 func NewClient(client rpcdbproto.DatabaseClient) *DatabaseClient {
-	return &DatabaseClient{client: client}
+	return NewClientWithPageSize(client, DefaultPageSize)
+}
+
+// NewClientWithPageSize returns a database instance connected to a remote
+// database instance whose iterators fetch at most [pageSize] key/value pairs
+// per request. [pageSize] must be positive.
+func NewClientWithPageSize(client rpcdbproto.DatabaseClient, pageSize int) *DatabaseClient {
+	return &DatabaseClient{
+		client:   client,
+		pageSize: pageSize,
+	}
 }
 
 // Has attempts to return if the database has a key with the provided value.
@@ -99,6 +120,59 @@ func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) da
 	}
 }
 
+// NewIteratorWithStartAndEnd implements the Database interface
+func (db *DatabaseClient) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return db.newIteratorWithRange(start, end, nil, false)
+}
+
+// NewReverseIterator implements the Database interface
+func (db *DatabaseClient) NewReverseIterator() database.Iterator {
+	return db.newIteratorWithRange(nil, nil, nil, true)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *DatabaseClient) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIteratorWithRange(nil, nil, prefix, true)
+}
+
+// NewReverseIteratorWithStartAndEnd implements the Database interface
+func (db *DatabaseClient) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return db.newIteratorWithRange(start, end, nil, true)
+}
+
+// newIteratorWithRange returns a new iterator over the requested range
+func (db *DatabaseClient) newIteratorWithRange(start, end, prefix []byte, reverse bool) database.Iterator {
+	resp, err := db.client.NewIteratorWithRange(context.Background(), &rpcdbproto.NewIteratorWithRangeRequest{
+		Start:   start,
+		End:     end,
+		Prefix:  prefix,
+		Reverse: reverse,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	return &iterator{
+		db: db,
+		id: resp.Id,
+	}
+}
+
+// NewSnapshot returns a read-only view of the current state of the remote
+// database
+func (db *DatabaseClient) NewSnapshot() (database.Snapshot, error) {
+	resp, err := db.client.NewSnapshot(context.Background(), &rpcdbproto.NewSnapshotRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if err := errCodeToError[resp.Err]; err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		db: db,
+		id: resp.Id,
+	}, nil
+}
+
 // Stat attempts to return the statistic of this database
 func (db *DatabaseClient) Stat(property string) (string, error) {
 	resp, err := db.client.Stat(context.Background(), &rpcdbproto.StatRequest{
@@ -212,28 +286,156 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 
 func (b *batch) Inner() database.Batch { return b }
 
+type snapshot struct {
+	db *DatabaseClient
+	id uint64
+}
+
+// Has attempts to return if the snapshot has a key with the provided value.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	resp, err := s.db.client.SnapshotHas(context.Background(), &rpcdbproto.SnapshotHasRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Has, errCodeToError[resp.Err]
+}
+
+// Get attempts to return the value that was mapped to the key that was
+// provided when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	resp, err := s.db.client.SnapshotGet(context.Background(), &rpcdbproto.SnapshotGetRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Value, errCodeToError[resp.Err]
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix returns a new iterator over the snapshot
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	resp, err := s.db.client.SnapshotNewIteratorWithStartAndPrefix(context.Background(), &rpcdbproto.SnapshotNewIteratorWithStartAndPrefixRequest{
+		Id:     s.id,
+		Start:  start,
+		Prefix: prefix,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	if err := errCodeToError[resp.Err]; err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	return &iterator{
+		db: s.db,
+		id: resp.Id,
+	}
+}
+
+// NewIteratorWithStartAndEnd implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return s.newIteratorWithRange(start, end, nil, false)
+}
+
+// NewReverseIterator implements the Snapshot interface
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.newIteratorWithRange(nil, nil, nil, true)
+}
+
+// NewReverseIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.newIteratorWithRange(nil, nil, prefix, true)
+}
+
+// NewReverseIteratorWithStartAndEnd implements the Snapshot interface
+func (s *snapshot) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	return s.newIteratorWithRange(start, end, nil, true)
+}
+
+// newIteratorWithRange returns a new iterator over the requested range of the
+// snapshot
+func (s *snapshot) newIteratorWithRange(start, end, prefix []byte, reverse bool) database.Iterator {
+	resp, err := s.db.client.SnapshotNewIteratorWithRange(context.Background(), &rpcdbproto.SnapshotNewIteratorWithRangeRequest{
+		Id:      s.id,
+		Start:   start,
+		End:     end,
+		Prefix:  prefix,
+		Reverse: reverse,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	if err := errCodeToError[resp.Err]; err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	return &iterator{
+		db: s.db,
+		id: resp.Id,
+	}
+}
+
+// Release frees any resources held by the snapshot
+func (s *snapshot) Release() {
+	_, _ = s.db.client.SnapshotRelease(context.Background(), &rpcdbproto.SnapshotReleaseRequest{
+		Id: s.id,
+	})
+}
+
+// iterator buffers pages of key/value pairs fetched from the remote iterator.
+// The current key/value pair is the first element of [data].
 type iterator struct {
-	db    *DatabaseClient
-	id    uint64
-	key   []byte
-	value []byte
-	errs  wrappers.Errs
+	db *DatabaseClient
+	id uint64
+
+	data      []*rpcdbproto.PutRequest
+	exhausted bool
+	errs      wrappers.Errs
 }
 
 // Next attempts to move the iterator to the next element and returns if this
 // succeeded
 func (it *iterator) Next() bool {
+	if len(it.data) > 1 {
+		it.data[0] = nil
+		it.data = it.data[1:]
+		return true
+	}
+	it.data = nil
+	if it.exhausted {
+		return false
+	}
+
 	resp, err := it.db.client.IteratorNext(context.Background(), &rpcdbproto.IteratorNextRequest{
-		Id: it.id,
+		Id:       it.id,
+		PageSize: uint32(it.db.pageSize),
 	})
 	if err != nil {
 		it.errs.Add(err)
+		it.exhausted = true
 		return false
 	}
 
-	it.key = resp.Key
-	it.value = resp.Value
-	return resp.FoundNext
+	it.data = resp.Data
+	it.exhausted = len(it.data) == 0
+	return !it.exhausted
 }
 
 // Error returns any that occurred while iterating
@@ -254,13 +456,26 @@ func (it *iterator) Error() error {
 }
 
 // Key returns the key of the current element
-func (it *iterator) Key() []byte { return it.key }
+func (it *iterator) Key() []byte {
+	if len(it.data) == 0 {
+		return nil
+	}
+	return it.data[0].Key
+}
 
 // Value returns the value of the current element
-func (it *iterator) Value() []byte { return it.value }
+func (it *iterator) Value() []byte {
+	if len(it.data) == 0 {
+		return nil
+	}
+	return it.data[0].Value
+}
 
 // Release frees any resources held by the iterator
 func (it *iterator) Release() {
+	it.data = nil
+	it.exhausted = true
+
 	_, err := it.db.client.IteratorRelease(context.Background(), &rpcdbproto.IteratorReleaseRequest{
 		Id: it.id,
 	})
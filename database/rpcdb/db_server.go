@@ -13,6 +13,13 @@ import (
 	"github.com/ava-labs/avalanchego/database/rpcdb/rpcdbproto"
 )
 
+const (
+	// maxPageBytes is the maximum number of bytes of keys and values that will
+	// be returned in a single IteratorNext response. At least one key/value
+	// pair is always returned if the iterator isn't exhausted.
+	maxPageBytes = 512 * 1024
+)
+
 var (
 	errUnknownIterator = errors.New("unknown iterator")
 )
@@ -25,6 +32,9 @@ type DatabaseServer struct {
 
 	nextIteratorID uint64
 	iterators      map[uint64]database.Iterator
+
+	nextSnapshotID uint64
+	snapshots      map[uint64]database.Snapshot
 }
 
 // NewServer returns a database instance that is managed remotely
@@ -33,6 +43,7 @@ func NewServer(db database.Database) *DatabaseServer {
 		db:        db,
 		batch:     db.NewBatch(),
 		iterators: make(map[uint64]database.Iterator),
+		snapshots: make(map[uint64]database.Snapshot),
 	}
 }
 
@@ -127,7 +138,22 @@ func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *
 	return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
 }
 
-// IteratorNext attempts to call next on the requested iterator
+// NewIteratorWithRange allocates an iterator over the requested range and
+// returns the iterator ID
+func (db *DatabaseServer) NewIteratorWithRange(_ context.Context, req *rpcdbproto.NewIteratorWithRangeRequest) (*rpcdbproto.NewIteratorWithStartAndPrefixResponse, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	id := db.nextIteratorID
+	it := newRangeIterator(db.db, req.Start, req.End, req.Prefix, req.Reverse)
+	db.iterators[id] = it
+
+	db.nextIteratorID++
+	return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
+}
+
+// IteratorNext returns the next page of key/value pairs of the requested
+// iterator. An empty page is returned once the iterator is exhausted.
 func (db *DatabaseServer) IteratorNext(_ context.Context, req *rpcdbproto.IteratorNextRequest) (*rpcdbproto.IteratorNextResponse, error) {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -136,11 +162,27 @@ func (db *DatabaseServer) IteratorNext(_ context.Context, req *rpcdbproto.Iterat
 	if !exists {
 		return nil, errUnknownIterator
 	}
-	return &rpcdbproto.IteratorNextResponse{
-		FoundNext: it.Next(),
-		Key:       it.Key(),
-		Value:     it.Value(),
-	}, nil
+
+	// Always return at least one key/value pair so that the client can make
+	// progress
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = 1
+	}
+
+	size := 0
+	data := []*rpcdbproto.PutRequest(nil)
+	for len(data) < pageSize && size < maxPageBytes && it.Next() {
+		key := it.Key()
+		value := it.Value()
+		size += len(key) + len(value)
+
+		data = append(data, &rpcdbproto.PutRequest{
+			Key:   key,
+			Value: value,
+		})
+	}
+	return &rpcdbproto.IteratorNextResponse{Data: data}, nil
 }
 
 // IteratorError attempts to report any errors that occurred during iteration
@@ -168,3 +210,132 @@ func (db *DatabaseServer) IteratorRelease(_ context.Context, req *rpcdbproto.Ite
 	}
 	return &rpcdbproto.IteratorReleaseResponse{}, nil
 }
+
+// NewSnapshot takes a snapshot of the managed database and returns the
+// snapshot ID
+func (db *DatabaseServer) NewSnapshot(context.Context, *rpcdbproto.NewSnapshotRequest) (*rpcdbproto.NewSnapshotResponse, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snapshot, err := db.db.NewSnapshot()
+	if err != nil {
+		return &rpcdbproto.NewSnapshotResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+
+	id := db.nextSnapshotID
+	db.snapshots[id] = snapshot
+
+	db.nextSnapshotID++
+	return &rpcdbproto.NewSnapshotResponse{Id: id}, nil
+}
+
+// SnapshotHas delegates the Has call to the requested snapshot and returns the
+// result. If the snapshot was released, ErrClosed is reported.
+func (db *DatabaseServer) SnapshotHas(_ context.Context, req *rpcdbproto.SnapshotHasRequest) (*rpcdbproto.HasResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return &rpcdbproto.HasResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+	has, err := snapshot.Has(req.Key)
+	return &rpcdbproto.HasResponse{
+		Has: has,
+		Err: errorToErrCode[err],
+	}, errorToRPCError(err)
+}
+
+// SnapshotGet delegates the Get call to the requested snapshot and returns the
+// result. If the snapshot was released, ErrClosed is reported.
+func (db *DatabaseServer) SnapshotGet(_ context.Context, req *rpcdbproto.SnapshotGetRequest) (*rpcdbproto.GetResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return &rpcdbproto.GetResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+	value, err := snapshot.Get(req.Key)
+	return &rpcdbproto.GetResponse{
+		Value: value,
+		Err:   errorToErrCode[err],
+	}, errorToRPCError(err)
+}
+
+// SnapshotNewIteratorWithStartAndPrefix allocates an iterator over the
+// requested snapshot and returns the iterator ID
+func (db *DatabaseServer) SnapshotNewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbproto.SnapshotNewIteratorWithStartAndPrefixRequest) (*rpcdbproto.NewIteratorWithStartAndPrefixResponse, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snapshot, exists := db.snapshots[req.Id]
+	if !exists {
+		err := database.ErrClosed
+		return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+
+	id := db.nextIteratorID
+	it := snapshot.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	db.iterators[id] = it
+
+	db.nextIteratorID++
+	return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
+}
+
+// SnapshotNewIteratorWithRange allocates an iterator over the requested range
+// of the requested snapshot and returns the iterator ID
+func (db *DatabaseServer) SnapshotNewIteratorWithRange(_ context.Context, req *rpcdbproto.SnapshotNewIteratorWithRangeRequest) (*rpcdbproto.NewIteratorWithStartAndPrefixResponse, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snapshot, exists := db.snapshots[req.Id]
+	if !exists {
+		err := database.ErrClosed
+		return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+
+	id := db.nextIteratorID
+	it := newRangeIterator(snapshot, req.Start, req.End, req.Prefix, req.Reverse)
+	db.iterators[id] = it
+
+	db.nextIteratorID++
+	return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
+}
+
+// SnapshotRelease attempts to release the resources allocated to a snapshot
+func (db *DatabaseServer) SnapshotRelease(_ context.Context, req *rpcdbproto.SnapshotReleaseRequest) (*rpcdbproto.SnapshotReleaseResponse, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snapshot, exists := db.snapshots[req.Id]
+	if exists {
+		delete(db.snapshots, req.Id)
+		snapshot.Release()
+	}
+	return &rpcdbproto.SnapshotReleaseResponse{}, nil
+}
+
+// getSnapshot returns the snapshot with the provided ID, or ErrClosed if the
+// snapshot has already been released
+func (db *DatabaseServer) getSnapshot(id uint64) (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snapshot, exists := db.snapshots[id]
+	if !exists {
+		return nil, database.ErrClosed
+	}
+	return snapshot, nil
+}
+
+// newRangeIterator creates the iterator over [iteratee] that the client
+// requested. A reverse iterator without bounds iterates over [prefix]. A
+// forward iterator without an end iterates over [prefix] starting at [start].
+// Otherwise the iterator is over the range [start, end).
+func newRangeIterator(iteratee database.Iteratee, start, end, prefix []byte, reverse bool) database.Iterator {
+	switch {
+	case reverse && start == nil && end == nil:
+		return iteratee.NewReverseIteratorWithPrefix(prefix)
+	case reverse:
+		return iteratee.NewReverseIteratorWithStartAndEnd(start, end)
+	case end == nil:
+		return iteratee.NewIteratorWithStartAndPrefix(start, prefix)
+	default:
+		return iteratee.NewIteratorWithStartAndEnd(start, end)
+	}
+}
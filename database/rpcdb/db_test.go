@@ -4,6 +4,8 @@
 package rpcdb
 
 import (
+	"bytes"
+	"fmt"
 	"log"
 	"net"
 	"testing"
@@ -22,30 +24,80 @@ const (
 	bufSize = 1 << 20
 )
 
-func TestInterface(t *testing.T) {
-	for _, test := range database.Tests {
-		listener := bufconn.Listen(bufSize)
-		server := grpc.NewServer()
-		rpcdbproto.RegisterDatabaseServer(server, NewServer(memdb.New()))
-		go func() {
-			if err := server.Serve(listener); err != nil {
-				log.Fatalf("Server exited with error: %v", err)
-			}
-		}()
-
-		dialer := grpc.WithContextDialer(
-			func(context.Context, string) (net.Conn, error) {
-				return listener.Dial()
-			})
-
-		ctx := context.Background()
-		conn, err := grpc.DialContext(ctx, "", dialer, grpc.WithInsecure())
-		if err != nil {
-			t.Fatalf("Failed to dial: %s", err)
+// setupDB serves [db] over an in-memory gRPC connection and returns a client
+// for it, along with a function that closes the connection
+func setupDB(t testing.TB, db database.Database, pageSize int) (*DatabaseClient, func()) {
+	listener := bufconn.Listen(bufSize)
+	server := grpc.NewServer()
+	rpcdbproto.RegisterDatabaseServer(server, NewServer(db))
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatalf("Server exited with error: %v", err)
 		}
+	}()
+
+	dialer := grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		})
 
-		db := NewClient(rpcdbproto.NewDatabaseClient(conn))
-		test(t, db)
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", dialer, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+
+	client := NewClientWithPageSize(rpcdbproto.NewDatabaseClient(conn), pageSize)
+	return client, func() {
 		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestInterface(t *testing.T) {
+	for _, pageSize := range []int{1, 2, DefaultPageSize} {
+		for _, test := range database.Tests {
+			db, closeFn := setupDB(t, memdb.New(), pageSize)
+			test(t, db)
+			closeFn()
+		}
+	}
+}
+
+func TestIteratorPaging(t *testing.T) {
+	baseDB := memdb.New()
+	numKeys := 10
+	for i := 0; i < numKeys; i++ {
+		key := []byte(fmt.Sprintf("key%02d", i))
+		if err := baseDB.Put(key, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	db, closeFn := setupDB(t, baseDB, 3)
+	defer closeFn()
+
+	iterator := db.NewIterator()
+	defer iterator.Release()
+
+	for i := 0; i < numKeys; i++ {
+		expectedKey := []byte(fmt.Sprintf("key%02d", i))
+		if !iterator.Next() {
+			t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+		} else if key := iterator.Key(); !bytes.Equal(key, expectedKey) {
+			t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, expectedKey)
+		} else if value := iterator.Value(); !bytes.Equal(value, expectedKey) {
+			t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, expectedKey)
+		}
+	}
+
+	if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if key := iterator.Key(); key != nil {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: nil", key)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
 	}
 }
@@ -768,6 +768,7 @@ func (m *NewIteratorWithStartAndPrefixRequest) GetPrefix() []byte {
 
 type NewIteratorWithStartAndPrefixResponse struct {
 	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Err                  uint32   `protobuf:"varint,2,opt,name=err,proto3" json:"err,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -805,8 +806,79 @@ func (m *NewIteratorWithStartAndPrefixResponse) GetId() uint64 {
 	return 0
 }
 
+func (m *NewIteratorWithStartAndPrefixResponse) GetErr() uint32 {
+	if m != nil {
+		return m.Err
+	}
+	return 0
+}
+
+type NewIteratorWithRangeRequest struct {
+	Start                []byte   `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  []byte   `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Prefix               []byte   `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Reverse              bool     `protobuf:"varint,4,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewIteratorWithRangeRequest) Reset()         { *m = NewIteratorWithRangeRequest{} }
+func (m *NewIteratorWithRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*NewIteratorWithRangeRequest) ProtoMessage()    {}
+func (*NewIteratorWithRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{19}
+}
+
+func (m *NewIteratorWithRangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewIteratorWithRangeRequest.Unmarshal(m, b)
+}
+func (m *NewIteratorWithRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewIteratorWithRangeRequest.Marshal(b, m, deterministic)
+}
+func (m *NewIteratorWithRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewIteratorWithRangeRequest.Merge(m, src)
+}
+func (m *NewIteratorWithRangeRequest) XXX_Size() int {
+	return xxx_messageInfo_NewIteratorWithRangeRequest.Size(m)
+}
+func (m *NewIteratorWithRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewIteratorWithRangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewIteratorWithRangeRequest proto.InternalMessageInfo
+
+func (m *NewIteratorWithRangeRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *NewIteratorWithRangeRequest) GetEnd() []byte {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *NewIteratorWithRangeRequest) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+func (m *NewIteratorWithRangeRequest) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
 type IteratorNextRequest struct {
 	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PageSize             uint32   `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -816,7 +888,7 @@ func (m *IteratorNextRequest) Reset()         { *m = IteratorNextRequest{} }
 func (m *IteratorNextRequest) String() string { return proto.CompactTextString(m) }
 func (*IteratorNextRequest) ProtoMessage()    {}
 func (*IteratorNextRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{19}
+	return fileDescriptor_af52f4b90339c3f4, []int{20}
 }
 
 func (m *IteratorNextRequest) XXX_Unmarshal(b []byte) error {
@@ -844,20 +916,25 @@ func (m *IteratorNextRequest) GetId() uint64 {
 	return 0
 }
 
+func (m *IteratorNextRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
 type IteratorNextResponse struct {
-	FoundNext            bool     `protobuf:"varint,1,opt,name=foundNext,proto3" json:"foundNext,omitempty"`
-	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Value                []byte   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Data                 []*PutRequest `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *IteratorNextResponse) Reset()         { *m = IteratorNextResponse{} }
 func (m *IteratorNextResponse) String() string { return proto.CompactTextString(m) }
 func (*IteratorNextResponse) ProtoMessage()    {}
 func (*IteratorNextResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{20}
+	return fileDescriptor_af52f4b90339c3f4, []int{21}
 }
 
 func (m *IteratorNextResponse) XXX_Unmarshal(b []byte) error {
@@ -878,23 +955,9 @@ func (m *IteratorNextResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_IteratorNextResponse proto.InternalMessageInfo
 
-func (m *IteratorNextResponse) GetFoundNext() bool {
-	if m != nil {
-		return m.FoundNext
-	}
-	return false
-}
-
-func (m *IteratorNextResponse) GetKey() []byte {
+func (m *IteratorNextResponse) GetData() []*PutRequest {
 	if m != nil {
-		return m.Key
-	}
-	return nil
-}
-
-func (m *IteratorNextResponse) GetValue() []byte {
-	if m != nil {
-		return m.Value
+		return m.Data
 	}
 	return nil
 }
@@ -910,7 +973,7 @@ func (m *IteratorErrorRequest) Reset()         { *m = IteratorErrorRequest{} }
 func (m *IteratorErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*IteratorErrorRequest) ProtoMessage()    {}
 func (*IteratorErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{21}
+	return fileDescriptor_af52f4b90339c3f4, []int{22}
 }
 
 func (m *IteratorErrorRequest) XXX_Unmarshal(b []byte) error {
@@ -949,7 +1012,7 @@ func (m *IteratorErrorResponse) Reset()         { *m = IteratorErrorResponse{} }
 func (m *IteratorErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*IteratorErrorResponse) ProtoMessage()    {}
 func (*IteratorErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{22}
+	return fileDescriptor_af52f4b90339c3f4, []int{23}
 }
 
 func (m *IteratorErrorResponse) XXX_Unmarshal(b []byte) error {
@@ -988,7 +1051,7 @@ func (m *IteratorReleaseRequest) Reset()         { *m = IteratorReleaseRequest{}
 func (m *IteratorReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*IteratorReleaseRequest) ProtoMessage()    {}
 func (*IteratorReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{23}
+	return fileDescriptor_af52f4b90339c3f4, []int{24}
 }
 
 func (m *IteratorReleaseRequest) XXX_Unmarshal(b []byte) error {
@@ -1026,7 +1089,7 @@ func (m *IteratorReleaseResponse) Reset()         { *m = IteratorReleaseResponse
 func (m *IteratorReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*IteratorReleaseResponse) ProtoMessage()    {}
 func (*IteratorReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{24}
+	return fileDescriptor_af52f4b90339c3f4, []int{25}
 }
 
 func (m *IteratorReleaseResponse) XXX_Unmarshal(b []byte) error {
@@ -1047,6 +1110,378 @@ func (m *IteratorReleaseResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_IteratorReleaseResponse proto.InternalMessageInfo
 
+type NewSnapshotRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewSnapshotRequest) Reset()         { *m = NewSnapshotRequest{} }
+func (m *NewSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*NewSnapshotRequest) ProtoMessage()    {}
+func (*NewSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{26}
+}
+
+func (m *NewSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewSnapshotRequest.Unmarshal(m, b)
+}
+func (m *NewSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *NewSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewSnapshotRequest.Merge(m, src)
+}
+func (m *NewSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_NewSnapshotRequest.Size(m)
+}
+func (m *NewSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewSnapshotRequest proto.InternalMessageInfo
+
+type NewSnapshotResponse struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Err                  uint32   `protobuf:"varint,2,opt,name=err,proto3" json:"err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewSnapshotResponse) Reset()         { *m = NewSnapshotResponse{} }
+func (m *NewSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*NewSnapshotResponse) ProtoMessage()    {}
+func (*NewSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{27}
+}
+
+func (m *NewSnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewSnapshotResponse.Unmarshal(m, b)
+}
+func (m *NewSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewSnapshotResponse.Marshal(b, m, deterministic)
+}
+func (m *NewSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewSnapshotResponse.Merge(m, src)
+}
+func (m *NewSnapshotResponse) XXX_Size() int {
+	return xxx_messageInfo_NewSnapshotResponse.Size(m)
+}
+func (m *NewSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewSnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewSnapshotResponse proto.InternalMessageInfo
+
+func (m *NewSnapshotResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *NewSnapshotResponse) GetErr() uint32 {
+	if m != nil {
+		return m.Err
+	}
+	return 0
+}
+
+type SnapshotHasRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotHasRequest) Reset()         { *m = SnapshotHasRequest{} }
+func (m *SnapshotHasRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotHasRequest) ProtoMessage()    {}
+func (*SnapshotHasRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{28}
+}
+
+func (m *SnapshotHasRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotHasRequest.Unmarshal(m, b)
+}
+func (m *SnapshotHasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotHasRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotHasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotHasRequest.Merge(m, src)
+}
+func (m *SnapshotHasRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotHasRequest.Size(m)
+}
+func (m *SnapshotHasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotHasRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotHasRequest proto.InternalMessageInfo
+
+func (m *SnapshotHasRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SnapshotHasRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type SnapshotGetRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotGetRequest) Reset()         { *m = SnapshotGetRequest{} }
+func (m *SnapshotGetRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotGetRequest) ProtoMessage()    {}
+func (*SnapshotGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{29}
+}
+
+func (m *SnapshotGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotGetRequest.Unmarshal(m, b)
+}
+func (m *SnapshotGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotGetRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotGetRequest.Merge(m, src)
+}
+func (m *SnapshotGetRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotGetRequest.Size(m)
+}
+func (m *SnapshotGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotGetRequest proto.InternalMessageInfo
+
+func (m *SnapshotGetRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SnapshotGetRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type SnapshotNewIteratorWithStartAndPrefixRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Start                []byte   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Prefix               []byte   `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) Reset() {
+	*m = SnapshotNewIteratorWithStartAndPrefixRequest{}
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*SnapshotNewIteratorWithStartAndPrefixRequest) ProtoMessage() {}
+func (*SnapshotNewIteratorWithStartAndPrefixRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{30}
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Unmarshal(m, b)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Merge(m, src)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Size(m)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest proto.InternalMessageInfo
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+type SnapshotNewIteratorWithRangeRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Start                []byte   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End                  []byte   `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	Prefix               []byte   `protobuf:"bytes,4,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Reverse              bool     `protobuf:"varint,5,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotNewIteratorWithRangeRequest) Reset()         { *m = SnapshotNewIteratorWithRangeRequest{} }
+func (m *SnapshotNewIteratorWithRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotNewIteratorWithRangeRequest) ProtoMessage()    {}
+func (*SnapshotNewIteratorWithRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{31}
+}
+
+func (m *SnapshotNewIteratorWithRangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotNewIteratorWithRangeRequest.Unmarshal(m, b)
+}
+func (m *SnapshotNewIteratorWithRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotNewIteratorWithRangeRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotNewIteratorWithRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotNewIteratorWithRangeRequest.Merge(m, src)
+}
+func (m *SnapshotNewIteratorWithRangeRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotNewIteratorWithRangeRequest.Size(m)
+}
+func (m *SnapshotNewIteratorWithRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotNewIteratorWithRangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotNewIteratorWithRangeRequest proto.InternalMessageInfo
+
+func (m *SnapshotNewIteratorWithRangeRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SnapshotNewIteratorWithRangeRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *SnapshotNewIteratorWithRangeRequest) GetEnd() []byte {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *SnapshotNewIteratorWithRangeRequest) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+func (m *SnapshotNewIteratorWithRangeRequest) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+type SnapshotReleaseRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotReleaseRequest) Reset()         { *m = SnapshotReleaseRequest{} }
+func (m *SnapshotReleaseRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReleaseRequest) ProtoMessage()    {}
+func (*SnapshotReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{32}
+}
+
+func (m *SnapshotReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotReleaseRequest.Unmarshal(m, b)
+}
+func (m *SnapshotReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotReleaseRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReleaseRequest.Merge(m, src)
+}
+func (m *SnapshotReleaseRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotReleaseRequest.Size(m)
+}
+func (m *SnapshotReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotReleaseRequest proto.InternalMessageInfo
+
+func (m *SnapshotReleaseRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type SnapshotReleaseResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotReleaseResponse) Reset()         { *m = SnapshotReleaseResponse{} }
+func (m *SnapshotReleaseResponse) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReleaseResponse) ProtoMessage()    {}
+func (*SnapshotReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{33}
+}
+
+func (m *SnapshotReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotReleaseResponse.Unmarshal(m, b)
+}
+func (m *SnapshotReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotReleaseResponse.Marshal(b, m, deterministic)
+}
+func (m *SnapshotReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReleaseResponse.Merge(m, src)
+}
+func (m *SnapshotReleaseResponse) XXX_Size() int {
+	return xxx_messageInfo_SnapshotReleaseResponse.Size(m)
+}
+func (m *SnapshotReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReleaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotReleaseResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*HasRequest)(nil), "rpcdbproto.HasRequest")
 	proto.RegisterType((*HasResponse)(nil), "rpcdbproto.HasResponse")
@@ -1067,61 +1502,84 @@ func init() {
 	proto.RegisterType((*NewIteratorRequest)(nil), "rpcdbproto.NewIteratorRequest")
 	proto.RegisterType((*NewIteratorWithStartAndPrefixRequest)(nil), "rpcdbproto.NewIteratorWithStartAndPrefixRequest")
 	proto.RegisterType((*NewIteratorWithStartAndPrefixResponse)(nil), "rpcdbproto.NewIteratorWithStartAndPrefixResponse")
+	proto.RegisterType((*NewIteratorWithRangeRequest)(nil), "rpcdbproto.NewIteratorWithRangeRequest")
 	proto.RegisterType((*IteratorNextRequest)(nil), "rpcdbproto.IteratorNextRequest")
 	proto.RegisterType((*IteratorNextResponse)(nil), "rpcdbproto.IteratorNextResponse")
 	proto.RegisterType((*IteratorErrorRequest)(nil), "rpcdbproto.IteratorErrorRequest")
 	proto.RegisterType((*IteratorErrorResponse)(nil), "rpcdbproto.IteratorErrorResponse")
 	proto.RegisterType((*IteratorReleaseRequest)(nil), "rpcdbproto.IteratorReleaseRequest")
 	proto.RegisterType((*IteratorReleaseResponse)(nil), "rpcdbproto.IteratorReleaseResponse")
+	proto.RegisterType((*NewSnapshotRequest)(nil), "rpcdbproto.NewSnapshotRequest")
+	proto.RegisterType((*NewSnapshotResponse)(nil), "rpcdbproto.NewSnapshotResponse")
+	proto.RegisterType((*SnapshotHasRequest)(nil), "rpcdbproto.SnapshotHasRequest")
+	proto.RegisterType((*SnapshotGetRequest)(nil), "rpcdbproto.SnapshotGetRequest")
+	proto.RegisterType((*SnapshotNewIteratorWithStartAndPrefixRequest)(nil), "rpcdbproto.SnapshotNewIteratorWithStartAndPrefixRequest")
+	proto.RegisterType((*SnapshotNewIteratorWithRangeRequest)(nil), "rpcdbproto.SnapshotNewIteratorWithRangeRequest")
+	proto.RegisterType((*SnapshotReleaseRequest)(nil), "rpcdbproto.SnapshotReleaseRequest")
+	proto.RegisterType((*SnapshotReleaseResponse)(nil), "rpcdbproto.SnapshotReleaseResponse")
 }
 
 func init() { proto.RegisterFile("rpcdb.proto", fileDescriptor_af52f4b90339c3f4) }
 
 var fileDescriptor_af52f4b90339c3f4 = []byte{
-	// 677 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xdd, 0x4f, 0x13, 0x4f,
-	0x14, 0x4d, 0x3f, 0xf8, 0x3a, 0x2d, 0xe5, 0xf7, 0x1b, 0x2b, 0x94, 0x55, 0xbe, 0x16, 0x21, 0xc5,
-	0x07, 0x22, 0x1f, 0x62, 0x4c, 0x48, 0x8c, 0x80, 0x01, 0x63, 0x42, 0x70, 0x21, 0x21, 0x31, 0xbe,
-	0x0c, 0x74, 0x08, 0x1b, 0x0b, 0xbb, 0xce, 0x4e, 0x15, 0xdf, 0x7d, 0xf1, 0xbf, 0x36, 0x33, 0x9d,
-	0xdd, 0x99, 0x69, 0x77, 0xab, 0xbe, 0xcd, 0x9d, 0x7b, 0xce, 0x99, 0xbb, 0x77, 0xef, 0xb9, 0xa8,
-	0xf1, 0xf8, 0xba, 0x73, 0xb5, 0x19, 0xf3, 0x48, 0x44, 0x04, 0x2a, 0x50, 0x67, 0x7f, 0x11, 0x38,
-	0xa1, 0x49, 0xc0, 0xbe, 0xf6, 0x58, 0x22, 0xc8, 0x7f, 0xa8, 0x7c, 0x61, 0x3f, 0x5a, 0xa5, 0xe5,
-	0x52, 0xbb, 0x1e, 0xc8, 0xa3, 0xbf, 0x85, 0x9a, 0xca, 0x27, 0x71, 0x74, 0x9f, 0x30, 0x09, 0xb8,
-	0xa5, 0x89, 0x02, 0x4c, 0x06, 0xf2, 0x28, 0x6f, 0x18, 0xe7, 0xad, 0xf2, 0x72, 0xa9, 0x3d, 0x1d,
-	0xc8, 0xa3, 0x94, 0x3c, 0x66, 0xa2, 0x58, 0xf2, 0x25, 0x6a, 0x2a, 0xaf, 0x25, 0x9b, 0x18, 0xfb,
-	0x46, 0xbb, 0x3d, 0xa6, 0x21, 0xfd, 0x20, 0x47, 0x76, 0x17, 0x38, 0xeb, 0x15, 0xcb, 0x1a, 0x9d,
-	0xb2, 0xa5, 0xe3, 0x2f, 0xa1, 0xa6, 0x58, 0xa6, 0x7e, 0x29, 0x5b, 0x32, 0xb2, 0x2b, 0x98, 0x3e,
-	0x62, 0x5d, 0x26, 0x58, 0x71, 0xc1, 0x3e, 0x1a, 0x29, 0xa4, 0x50, 0x66, 0x03, 0xb5, 0x73, 0x41,
-	0xb3, 0xf2, 0x3c, 0x4c, 0xc6, 0x3c, 0x8a, 0x19, 0x17, 0x7d, 0xa5, 0xa9, 0x20, 0x8b, 0xfd, 0x5d,
-	0xd4, 0xfb, 0x50, 0x2d, 0x46, 0x50, 0x4d, 0x04, 0x15, 0x1a, 0xa7, 0xce, 0x39, 0x9f, 0xbf, 0x8f,
-	0xc6, 0x61, 0x74, 0x17, 0xd3, 0xeb, 0xec, 0x8d, 0x26, 0xc6, 0x12, 0x41, 0xb9, 0x48, 0x1b, 0xa7,
-	0x02, 0x79, 0xdb, 0x0d, 0xef, 0x42, 0x91, 0xb6, 0x41, 0x05, 0xfe, 0x2a, 0x66, 0x32, 0x76, 0xe1,
-	0x37, 0x34, 0x50, 0x3f, 0xec, 0x46, 0x49, 0xda, 0x09, 0xd9, 0x1a, 0x1d, 0x17, 0x52, 0x04, 0xfe,
-	0xbf, 0xe4, 0xa1, 0x60, 0x07, 0x54, 0x5c, 0xdf, 0xa6, 0x85, 0x3d, 0x47, 0x35, 0xee, 0x09, 0x39,
-	0x25, 0x95, 0x76, 0x6d, 0x7b, 0x76, 0xd3, 0x8c, 0xdb, 0xa6, 0xf9, 0x83, 0x81, 0xc2, 0x90, 0x1d,
-	0x4c, 0x74, 0x54, 0x6f, 0x93, 0x56, 0x59, 0xc1, 0xe7, 0x6d, 0xb8, 0xf3, 0x67, 0x82, 0x14, 0xe9,
-	0xaf, 0x83, 0xd8, 0xaf, 0x16, 0x56, 0xd7, 0x04, 0x39, 0x65, 0xdf, 0xdf, 0x0b, 0xc6, 0xa9, 0x88,
-	0x78, 0xfa, 0x59, 0x17, 0x78, 0x66, 0xdd, 0x5e, 0x86, 0xe2, 0xf6, 0x5c, 0x76, 0xee, 0xed, 0x7d,
-	0xe7, 0x8c, 0xb3, 0x9b, 0xf0, 0x61, 0x74, 0x7f, 0x67, 0x31, 0x1e, 0x2b, 0x98, 0x6e, 0xb0, 0x8e,
-	0xfc, 0x57, 0x58, 0xfb, 0x83, 0xaa, 0x2e, 0xb3, 0x81, 0x72, 0xd8, 0x51, 0x9a, 0xd5, 0xa0, 0x1c,
-	0x76, 0xfc, 0x35, 0x3c, 0x4a, 0x59, 0xa7, 0xec, 0x21, 0xfb, 0xbb, 0x83, 0xb0, 0xcf, 0x68, 0xba,
-	0x30, 0x2d, 0xf7, 0x14, 0x53, 0x37, 0x51, 0xef, 0xbe, 0x23, 0x2f, 0xb5, 0x2f, 0xcd, 0x45, 0x3a,
-	0xcc, 0xe5, 0x1c, 0x9b, 0x54, 0x6c, 0x9b, 0xac, 0x1b, 0xf5, 0x77, 0x9c, 0x67, 0xbd, 0x1a, 0xaa,
-	0x62, 0x03, 0x8f, 0x07, 0x70, 0x85, 0xcd, 0x6f, 0x63, 0xd6, 0x74, 0xbe, 0xcb, 0x68, 0x36, 0x57,
-	0x43, 0xa2, 0xf3, 0x98, 0x1b, 0x42, 0xf6, 0x65, 0xb7, 0x7f, 0x4d, 0x60, 0xf2, 0x88, 0x0a, 0x7a,
-	0x45, 0x13, 0x46, 0xf6, 0x50, 0x39, 0xa1, 0x09, 0x71, 0x06, 0xca, 0x2c, 0x2f, 0x6f, 0x6e, 0xe8,
-	0x5e, 0xd7, 0xb6, 0x87, 0xca, 0x31, 0x13, 0x2e, 0xcf, 0x6c, 0x28, 0x97, 0x67, 0x6f, 0xa6, 0x3d,
-	0x54, 0xce, 0x7a, 0x03, 0x3c, 0x33, 0xc0, 0x2e, 0xcf, 0x5e, 0x32, 0x6f, 0x30, 0xde, 0x1f, 0x5c,
-	0x52, 0x3c, 0xcc, 0x9e, 0x97, 0x97, 0xd2, 0x02, 0xaf, 0x51, 0x95, 0x1b, 0x82, 0x38, 0x2f, 0x58,
-	0xeb, 0xc5, 0x6b, 0x0d, 0x27, 0x34, 0xf5, 0x00, 0x13, 0xda, 0xe8, 0xc4, 0x79, 0xc1, 0xdd, 0x1d,
-	0xde, 0x93, 0xdc, 0x9c, 0xd6, 0xd8, 0xc7, 0x98, 0xf2, 0x3d, 0x71, 0x9e, 0xb1, 0x57, 0x83, 0x37,
-	0x9f, 0x93, 0xd1, 0xec, 0x0f, 0x80, 0x31, 0x27, 0x59, 0xb0, 0x81, 0x43, 0xab, 0xc2, 0x5b, 0x2c,
-	0x4a, 0x6b, 0xb1, 0x9f, 0x25, 0x2c, 0x8c, 0xb4, 0x15, 0x79, 0x61, 0x2b, 0xfc, 0x8d, 0xaf, 0xbd,
-	0xad, 0x7f, 0x60, 0xe8, 0x32, 0x3e, 0xa2, 0x6e, 0x9b, 0x8f, 0x2c, 0xd9, 0x12, 0x39, 0xee, 0xf5,
-	0x96, 0x8b, 0x01, 0x5a, 0xf2, 0x02, 0xd3, 0x8e, 0x93, 0x48, 0x2e, 0xc5, 0x36, 0xa3, 0xb7, 0x32,
-	0x02, 0xa1, 0x55, 0x3f, 0x61, 0x66, 0xc0, 0x4a, 0xc4, 0xcf, 0x63, 0xb9, 0x8e, 0xf4, 0x56, 0x47,
-	0x62, 0xfa, 0xda, 0x57, 0xe3, 0x2a, 0xbd, 0xf3, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x78, 0xfe, 0x0b,
-	0x6b, 0x4c, 0x08, 0x00, 0x00,
+	// 910 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xdf, 0x8f, 0x1b, 0x35,
+	0x10, 0xd6, 0xe6, 0xc7, 0xdd, 0x31, 0x9b, 0xcb, 0x81, 0x1b, 0x92, 0x9c, 0x4b, 0xef, 0xae, 0x1b,
+	0x5a, 0xd2, 0x0a, 0x1d, 0xf4, 0x07, 0x07, 0x48, 0x95, 0x50, 0xaf, 0x85, 0xb6, 0x42, 0x3a, 0x85,
+	0x4d, 0xa5, 0x4a, 0x7d, 0xf3, 0x5d, 0x4c, 0xb3, 0x22, 0xcd, 0x2e, 0x5e, 0xa7, 0x77, 0xf0, 0x88,
+	0x78, 0xe7, 0x85, 0x7f, 0x8d, 0xff, 0x07, 0xd9, 0xf1, 0x66, 0xed, 0x5d, 0x7b, 0x13, 0x40, 0xbc,
+	0xd9, 0x9e, 0x6f, 0xbe, 0x19, 0x8f, 0x3f, 0xcf, 0x80, 0xcf, 0x92, 0x8b, 0xc9, 0xf9, 0x71, 0xc2,
+	0x62, 0x1e, 0x23, 0x90, 0x1b, 0xb9, 0x0e, 0x0e, 0x00, 0x9e, 0x93, 0x34, 0xa4, 0x3f, 0x2f, 0x68,
+	0xca, 0xd1, 0xfb, 0x50, 0xff, 0x89, 0xfe, 0xd2, 0xf7, 0x8e, 0xbc, 0x61, 0x2b, 0x14, 0xcb, 0xe0,
+	0x1e, 0xf8, 0xd2, 0x9e, 0x26, 0xf1, 0x3c, 0xa5, 0x02, 0x30, 0x25, 0xa9, 0x04, 0xec, 0x84, 0x62,
+	0x29, 0x4e, 0x28, 0x63, 0xfd, 0xda, 0x91, 0x37, 0xdc, 0x0d, 0xc5, 0x52, 0x50, 0x3e, 0xa3, 0xdc,
+	0x4d, 0xf9, 0x05, 0xf8, 0xd2, 0xae, 0x28, 0x3b, 0xd0, 0x7c, 0x47, 0x66, 0x0b, 0xaa, 0x20, 0xcb,
+	0x8d, 0x85, 0xf6, 0x21, 0xc0, 0x68, 0xe1, 0xa6, 0xcd, 0x79, 0x6a, 0x1a, 0x4f, 0x70, 0x08, 0xfe,
+	0x68, 0x91, 0x07, 0x53, 0xb4, 0x5e, 0x4e, 0x7b, 0x13, 0x76, 0x9f, 0xd2, 0x19, 0xe5, 0xd4, 0x9d,
+	0x70, 0x00, 0xed, 0x0c, 0xe2, 0xa4, 0xb9, 0x03, 0xfe, 0x98, 0x93, 0x55, 0x7a, 0x18, 0x76, 0x12,
+	0x16, 0x27, 0x94, 0xf1, 0x25, 0xd3, 0x7b, 0xe1, 0x6a, 0x1f, 0x3c, 0x84, 0xd6, 0x12, 0xaa, 0xc8,
+	0x10, 0x34, 0x52, 0x4e, 0xb8, 0xc2, 0xc9, 0xb5, 0xe5, 0xfa, 0x8f, 0xa0, 0xfd, 0x24, 0x7e, 0x9b,
+	0x90, 0x8b, 0x55, 0x8c, 0x0e, 0x34, 0x53, 0x4e, 0x18, 0xcf, 0x0a, 0x27, 0x37, 0xe2, 0x74, 0x16,
+	0xbd, 0x8d, 0x78, 0x56, 0x06, 0xb9, 0x09, 0x06, 0xb0, 0xb7, 0xf2, 0x76, 0xde, 0xa1, 0x0d, 0xad,
+	0x27, 0xb3, 0x38, 0xcd, 0x2a, 0x21, 0x4a, 0xa3, 0xf6, 0x4e, 0x17, 0x0e, 0x1f, 0xbc, 0x62, 0x11,
+	0xa7, 0xa7, 0x84, 0x5f, 0x4c, 0xb3, 0xc4, 0xee, 0x42, 0x23, 0x59, 0x70, 0xa1, 0x92, 0xfa, 0xd0,
+	0xbf, 0xdf, 0x3d, 0xce, 0xe5, 0x76, 0x9c, 0xbf, 0x60, 0x28, 0x31, 0xe8, 0x01, 0x6c, 0x4f, 0x64,
+	0x6d, 0xd3, 0x7e, 0x4d, 0xc2, 0xf7, 0x75, 0xb8, 0xf1, 0x32, 0x61, 0x86, 0x0c, 0x6e, 0x03, 0xd2,
+	0xa3, 0x3a, 0xb3, 0xeb, 0x00, 0x3a, 0xa3, 0x97, 0x2f, 0x38, 0x65, 0x84, 0xc7, 0x2c, 0xbb, 0xd6,
+	0x4b, 0xf8, 0x58, 0x3b, 0x7d, 0x15, 0xf1, 0xe9, 0x58, 0x54, 0xee, 0xf1, 0x7c, 0x32, 0x62, 0xf4,
+	0xc7, 0xe8, 0xaa, 0xba, 0xbe, 0x5d, 0xd8, 0x4a, 0x24, 0x4c, 0x15, 0x58, 0xed, 0x82, 0x17, 0x70,
+	0x6b, 0x0d, 0xab, 0x4a, 0xb3, 0x0d, 0xb5, 0x68, 0x22, 0x39, 0x1b, 0x61, 0x2d, 0x9a, 0x58, 0x9e,
+	0xfa, 0x12, 0xae, 0x17, 0xa8, 0x42, 0x32, 0x7f, 0x43, 0xab, 0xf3, 0x12, 0x34, 0xf3, 0x89, 0x4a,
+	0x4a, 0x2c, 0xb5, 0x4c, 0xeb, 0x7a, 0xa6, 0xa8, 0x0f, 0xdb, 0x8c, 0xbe, 0xa3, 0x2c, 0xa5, 0xfd,
+	0x86, 0xfc, 0xc7, 0xd9, 0x36, 0x78, 0x0c, 0xd7, 0xb2, 0xa8, 0x67, 0xf4, 0x6a, 0x25, 0xb4, 0x62,
+	0xc6, 0x42, 0xdc, 0xe4, 0x0d, 0x1d, 0x47, 0xbf, 0x52, 0x95, 0xf6, 0x6a, 0x1f, 0x9c, 0x42, 0xc7,
+	0xa4, 0x50, 0xb7, 0xbe, 0x0b, 0x8d, 0x09, 0xe1, 0x64, 0x9d, 0x26, 0x04, 0x26, 0xb8, 0x9d, 0x73,
+	0x7c, 0xcb, 0x58, 0xcc, 0x1c, 0x79, 0x04, 0x77, 0xe0, 0xc3, 0x02, 0xce, 0xa9, 0x84, 0x21, 0x74,
+	0x73, 0x19, 0xcc, 0x28, 0x49, 0xa9, 0x8b, 0x74, 0x1f, 0x7a, 0x25, 0xe4, 0x92, 0x56, 0xc9, 0x69,
+	0x3c, 0x27, 0x49, 0x3a, 0x8d, 0xb3, 0x9c, 0x83, 0x2f, 0xe1, 0x9a, 0x71, 0xba, 0xf1, 0x33, 0x9f,
+	0x00, 0xca, 0xbc, 0xb4, 0x16, 0x6c, 0xf1, 0x13, 0xed, 0xa8, 0x96, 0xb7, 0x23, 0xcd, 0x4f, 0xeb,
+	0xb3, 0xeb, 0xfd, 0x66, 0xf0, 0x69, 0xe6, 0xb7, 0x91, 0xfe, 0x8b, 0x8c, 0x2b, 0xdd, 0xd5, 0xec,
+	0xff, 0xc1, 0x50, 0x59, 0xf0, 0x87, 0x07, 0x03, 0x47, 0x38, 0x43, 0xcd, 0x9b, 0x45, 0x51, 0xea,
+	0xae, 0xdb, 0xd4, 0xdd, 0x70, 0xa9, 0xbb, 0x69, 0xaa, 0x7b, 0x08, 0xdd, 0xfc, 0x95, 0xd6, 0x69,
+	0xa0, 0x84, 0x5c, 0x3e, 0xeb, 0xfd, 0xbf, 0x5a, 0xb0, 0xf3, 0x94, 0x70, 0x72, 0x4e, 0x52, 0x8a,
+	0x4e, 0xa0, 0xfe, 0x9c, 0xa4, 0xc8, 0x50, 0x73, 0xfe, 0x94, 0xb8, 0x57, 0x3a, 0x57, 0xda, 0x38,
+	0x81, 0xfa, 0x33, 0xca, 0x4d, 0xbf, 0xfc, 0x29, 0x71, 0xaf, 0x74, 0x9e, 0xfb, 0x8d, 0x16, 0x1c,
+	0x39, 0x7e, 0x0f, 0xee, 0x95, 0xce, 0x95, 0xdf, 0x37, 0xb0, 0xb5, 0xec, 0xa4, 0xc8, 0xdd, 0x5d,
+	0x31, 0xb6, 0x99, 0x14, 0xc1, 0xd7, 0xd0, 0x10, 0x23, 0x0b, 0x19, 0x11, 0xb4, 0x79, 0x87, 0xfb,
+	0x65, 0x83, 0x72, 0x3d, 0x85, 0x6d, 0x35, 0x79, 0x90, 0x11, 0xc1, 0x1c, 0x66, 0xf8, 0xba, 0xd5,
+	0xa6, 0x38, 0x1e, 0x41, 0x53, 0x0e, 0x22, 0x64, 0x84, 0xd1, 0x67, 0x15, 0xde, 0xb7, 0x58, 0x94,
+	0xf7, 0xf7, 0x00, 0xf9, 0xb4, 0x40, 0x37, 0x74, 0x60, 0x69, 0x76, 0xe1, 0x03, 0x97, 0x59, 0x91,
+	0xfd, 0xee, 0xc1, 0x8d, 0xca, 0xdf, 0x83, 0x3e, 0xd7, 0x19, 0x36, 0xf9, 0x68, 0xf8, 0xde, 0x3f,
+	0xf0, 0x50, 0x69, 0x30, 0xe8, 0xd8, 0x3e, 0x15, 0xfa, 0xa4, 0x82, 0x4a, 0xff, 0x76, 0xff, 0x26,
+	0xe6, 0x0f, 0xd0, 0xd2, 0x5b, 0x3b, 0x3a, 0xd4, 0x29, 0x2c, 0x73, 0x03, 0x1f, 0xb9, 0x01, 0x8a,
+	0xf2, 0x25, 0xec, 0x1a, 0x1d, 0x1c, 0x59, 0x5d, 0xf4, 0x21, 0x80, 0x6f, 0x56, 0x20, 0x14, 0xeb,
+	0x6b, 0xd8, 0x2b, 0xb4, 0x70, 0x14, 0xd8, 0xbc, 0xcc, 0x2e, 0x80, 0x07, 0x95, 0x18, 0xc5, 0x7d,
+	0x06, 0xbe, 0xd6, 0xed, 0xd1, 0x41, 0xa1, 0x8c, 0x85, 0xe1, 0x80, 0x0f, 0x9d, 0x76, 0xc5, 0xf7,
+	0x1d, 0xf8, 0xda, 0x10, 0x30, 0xf9, 0xca, 0xd3, 0xc1, 0xdd, 0x52, 0x34, 0x1e, 0xd1, 0x5a, 0xac,
+	0x3c, 0x9b, 0xb4, 0x98, 0x3f, 0x3d, 0xb8, 0xb5, 0xd1, 0x94, 0x40, 0x5f, 0xd9, 0x42, 0xfc, 0x5f,
+	0x7a, 0xff, 0xcd, 0x83, 0x8f, 0xaa, 0xa6, 0x09, 0xfa, 0x6c, 0x83, 0x6c, 0xfe, 0xeb, 0x07, 0x78,
+	0x0d, 0x7b, 0x85, 0xb1, 0x60, 0xea, 0xca, 0x3e, 0x5d, 0xf0, 0xa0, 0x12, 0xb3, 0xe4, 0x3e, 0xdf,
+	0x92, 0xe6, 0x07, 0x7f, 0x0f, 0x00, 0x6a, 0x3d, 0x65, 0xd4, 0xa9, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1145,9 +1603,16 @@ type DatabaseClient interface {
 	Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error)
 	WriteBatch(ctx context.Context, in *WriteBatchRequest, opts ...grpc.CallOption) (*WriteBatchResponse, error)
 	NewIteratorWithStartAndPrefix(ctx context.Context, in *NewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
+	NewIteratorWithRange(ctx context.Context, in *NewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
 	IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error)
 	IteratorError(ctx context.Context, in *IteratorErrorRequest, opts ...grpc.CallOption) (*IteratorErrorResponse, error)
 	IteratorRelease(ctx context.Context, in *IteratorReleaseRequest, opts ...grpc.CallOption) (*IteratorReleaseResponse, error)
+	NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error)
+	SnapshotHas(ctx context.Context, in *SnapshotHasRequest, opts ...grpc.CallOption) (*HasResponse, error)
+	SnapshotGet(ctx context.Context, in *SnapshotGetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	SnapshotNewIteratorWithStartAndPrefix(ctx context.Context, in *SnapshotNewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
+	SnapshotNewIteratorWithRange(ctx context.Context, in *SnapshotNewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
+	SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error)
 }
 
 type databaseClient struct {
@@ -1239,6 +1704,15 @@ func (c *databaseClient) NewIteratorWithStartAndPrefix(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *databaseClient) NewIteratorWithRange(ctx context.Context, in *NewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error) {
+	out := new(NewIteratorWithStartAndPrefixResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/NewIteratorWithRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error) {
 	out := new(IteratorNextResponse)
 	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/IteratorNext", in, out, opts...)
@@ -1266,6 +1740,60 @@ func (c *databaseClient) IteratorRelease(ctx context.Context, in *IteratorReleas
 	return out, nil
 }
 
+func (c *databaseClient) NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error) {
+	out := new(NewSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/NewSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotHas(ctx context.Context, in *SnapshotHasRequest, opts ...grpc.CallOption) (*HasResponse, error) {
+	out := new(HasResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotHas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotGet(ctx context.Context, in *SnapshotGetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotNewIteratorWithStartAndPrefix(ctx context.Context, in *SnapshotNewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error) {
+	out := new(NewIteratorWithStartAndPrefixResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotNewIteratorWithStartAndPrefix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotNewIteratorWithRange(ctx context.Context, in *SnapshotNewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error) {
+	out := new(NewIteratorWithStartAndPrefixResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotNewIteratorWithRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error) {
+	out := new(SnapshotReleaseResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DatabaseServer is the server API for Database service.
 type DatabaseServer interface {
 	Has(context.Context, *HasRequest) (*HasResponse, error)
@@ -1277,9 +1805,16 @@ type DatabaseServer interface {
 	Close(context.Context, *CloseRequest) (*CloseResponse, error)
 	WriteBatch(context.Context, *WriteBatchRequest) (*WriteBatchResponse, error)
 	NewIteratorWithStartAndPrefix(context.Context, *NewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error)
+	NewIteratorWithRange(context.Context, *NewIteratorWithRangeRequest) (*NewIteratorWithStartAndPrefixResponse, error)
 	IteratorNext(context.Context, *IteratorNextRequest) (*IteratorNextResponse, error)
 	IteratorError(context.Context, *IteratorErrorRequest) (*IteratorErrorResponse, error)
 	IteratorRelease(context.Context, *IteratorReleaseRequest) (*IteratorReleaseResponse, error)
+	NewSnapshot(context.Context, *NewSnapshotRequest) (*NewSnapshotResponse, error)
+	SnapshotHas(context.Context, *SnapshotHasRequest) (*HasResponse, error)
+	SnapshotGet(context.Context, *SnapshotGetRequest) (*GetResponse, error)
+	SnapshotNewIteratorWithStartAndPrefix(context.Context, *SnapshotNewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error)
+	SnapshotNewIteratorWithRange(context.Context, *SnapshotNewIteratorWithRangeRequest) (*NewIteratorWithStartAndPrefixResponse, error)
+	SnapshotRelease(context.Context, *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error)
 }
 
 // UnimplementedDatabaseServer can be embedded to have forward compatible implementations.
@@ -1313,6 +1848,9 @@ func (*UnimplementedDatabaseServer) WriteBatch(ctx context.Context, req *WriteBa
 func (*UnimplementedDatabaseServer) NewIteratorWithStartAndPrefix(ctx context.Context, req *NewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewIteratorWithStartAndPrefix not implemented")
 }
+func (*UnimplementedDatabaseServer) NewIteratorWithRange(ctx context.Context, req *NewIteratorWithRangeRequest) (*NewIteratorWithStartAndPrefixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewIteratorWithRange not implemented")
+}
 func (*UnimplementedDatabaseServer) IteratorNext(ctx context.Context, req *IteratorNextRequest) (*IteratorNextResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorNext not implemented")
 }
@@ -1322,6 +1860,24 @@ func (*UnimplementedDatabaseServer) IteratorError(ctx context.Context, req *Iter
 func (*UnimplementedDatabaseServer) IteratorRelease(ctx context.Context, req *IteratorReleaseRequest) (*IteratorReleaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorRelease not implemented")
 }
+func (*UnimplementedDatabaseServer) NewSnapshot(ctx context.Context, req *NewSnapshotRequest) (*NewSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewSnapshot not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotHas(ctx context.Context, req *SnapshotHasRequest) (*HasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotHas not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotGet(ctx context.Context, req *SnapshotGetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotGet not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotNewIteratorWithStartAndPrefix(ctx context.Context, req *SnapshotNewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotNewIteratorWithStartAndPrefix not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotNewIteratorWithRange(ctx context.Context, req *SnapshotNewIteratorWithRangeRequest) (*NewIteratorWithStartAndPrefixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotNewIteratorWithRange not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotRelease(ctx context.Context, req *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotRelease not implemented")
+}
 
 func RegisterDatabaseServer(s *grpc.Server, srv DatabaseServer) {
 	s.RegisterService(&_Database_serviceDesc, srv)
@@ -1489,6 +2045,24 @@ func _Database_NewIteratorWithStartAndPrefix_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_NewIteratorWithRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewIteratorWithRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).NewIteratorWithRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/NewIteratorWithRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).NewIteratorWithRange(ctx, req.(*NewIteratorWithRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_IteratorNext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IteratorNextRequest)
 	if err := dec(in); err != nil {
@@ -1543,6 +2117,114 @@ func _Database_IteratorRelease_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_NewSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).NewSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/NewSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).NewSnapshot(ctx, req.(*NewSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotHas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotHasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotHas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotHas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotHas(ctx, req.(*SnapshotHasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotGet(ctx, req.(*SnapshotGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotNewIteratorWithStartAndPrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotNewIteratorWithStartAndPrefixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithStartAndPrefix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotNewIteratorWithStartAndPrefix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithStartAndPrefix(ctx, req.(*SnapshotNewIteratorWithStartAndPrefixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotNewIteratorWithRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotNewIteratorWithRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotNewIteratorWithRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithRange(ctx, req.(*SnapshotNewIteratorWithRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, req.(*SnapshotReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Database_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcdbproto.Database",
 	HandlerType: (*DatabaseServer)(nil),
@@ -1583,6 +2265,10 @@ var _Database_serviceDesc = grpc.ServiceDesc{
 			MethodName: "NewIteratorWithStartAndPrefix",
 			Handler:    _Database_NewIteratorWithStartAndPrefix_Handler,
 		},
+		{
+			MethodName: "NewIteratorWithRange",
+			Handler:    _Database_NewIteratorWithRange_Handler,
+		},
 		{
 			MethodName: "IteratorNext",
 			Handler:    _Database_IteratorNext_Handler,
@@ -1595,6 +2281,30 @@ var _Database_serviceDesc = grpc.ServiceDesc{
 			MethodName: "IteratorRelease",
 			Handler:    _Database_IteratorRelease_Handler,
 		},
+		{
+			MethodName: "NewSnapshot",
+			Handler:    _Database_NewSnapshot_Handler,
+		},
+		{
+			MethodName: "SnapshotHas",
+			Handler:    _Database_SnapshotHas_Handler,
+		},
+		{
+			MethodName: "SnapshotGet",
+			Handler:    _Database_SnapshotGet_Handler,
+		},
+		{
+			MethodName: "SnapshotNewIteratorWithStartAndPrefix",
+			Handler:    _Database_SnapshotNewIteratorWithStartAndPrefix_Handler,
+		},
+		{
+			MethodName: "SnapshotNewIteratorWithRange",
+			Handler:    _Database_SnapshotNewIteratorWithRange_Handler,
+		},
+		{
+			MethodName: "SnapshotRelease",
+			Handler:    _Database_SnapshotRelease_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpcdb.proto",
@@ -0,0 +1,30 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// For ease of implementation, our database's interface matches Ethereum's
+// database implementation. This was to allow use to use Geth code as is for the
+// EVM chain.
+
+package database
+
+// Snapshot is a read-only, point-in-time view of a database. Writes performed
+// on the host database after the snapshot was taken are not visible through
+// the snapshot.
+//
+// A snapshot must be released after use. After a snapshot has been released,
+// all reads will return ErrClosed. A snapshot is safe for concurrent use.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases associated resources. Release should always succeed and
+	// can be called multiple times without causing error.
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot creates a read-only view of the current state of the
+	// database.
+	NewSnapshot() (Snapshot, error)
+}
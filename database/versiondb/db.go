@@ -123,26 +123,95 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	if db.mem == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return newIterator(
+		db.mem,
+		db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		start,
+		nil,
+		prefix,
+		false,
+	)
+}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
-	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
-		}
+// NewIteratorWithStartAndEnd implements the database.Database interface
+func (db *Database) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
 	}
-	sort.Strings(keys) // Keys need to be in sorted order
-	values := make([]valueDelete, len(keys))
-	for i, key := range keys {
-		values[i] = db.mem[key]
+	return newIterator(
+		db.mem,
+		db.db.NewIteratorWithStartAndEnd(start, end),
+		start,
+		end,
+		nil,
+		false,
+	)
+}
+
+// NewReverseIterator implements the database.Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithPrefix(nil)
+}
+
+// NewReverseIteratorWithPrefix implements the database.Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return newIterator(
+		db.mem,
+		db.db.NewReverseIteratorWithPrefix(prefix),
+		nil,
+		nil,
+		prefix,
+		true,
+	)
+}
 
-	return &iterator{
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
-		keys:     keys,
-		values:   values,
+// NewReverseIteratorWithStartAndEnd implements the database.Database interface
+func (db *Database) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return newIterator(
+		db.mem,
+		db.db.NewReverseIteratorWithStartAndEnd(start, end),
+		start,
+		end,
+		nil,
+		true,
+	)
+}
+
+// NewSnapshot implements the database.Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	mem := make(map[string]valueDelete, len(db.mem))
+	for key, value := range db.mem {
+		mem[key] = value
+	}
+	return &versionSnapshot{
+		mem:      mem,
+		snapshot: snapshot,
+	}, nil
 }
 
 // Stat implements the database.Database interface
@@ -346,6 +415,149 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 // Inner returns itself
 func (b *batch) Inner() database.Batch { return b }
 
+// versionSnapshot is a snapshot of the uncommitted operations layered on top of
+// a snapshot of the underlying database.
+type versionSnapshot struct {
+	lock     sync.RWMutex
+	mem      map[string]valueDelete
+	snapshot database.Snapshot
+}
+
+// Has implements the database.Snapshot interface
+func (s *versionSnapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	return s.snapshot.Has(key)
+}
+
+// Get implements the database.Snapshot interface
+func (s *versionSnapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return utils.CopyBytes(val.value), nil
+	}
+	return s.snapshot.Get(key)
+}
+
+// NewIterator implements the database.Snapshot interface
+func (s *versionSnapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the database.Snapshot interface
+func (s *versionSnapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the database.Snapshot interface
+func (s *versionSnapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the database.Snapshot interface
+func (s *versionSnapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		s.mem,
+		s.snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		start,
+		nil,
+		prefix,
+		false,
+	)
+}
+
+// NewIteratorWithStartAndEnd implements the database.Snapshot interface
+func (s *versionSnapshot) NewIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		s.mem,
+		s.snapshot.NewIteratorWithStartAndEnd(start, end),
+		start,
+		end,
+		nil,
+		false,
+	)
+}
+
+// NewReverseIterator implements the database.Snapshot interface
+func (s *versionSnapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithPrefix(nil)
+}
+
+// NewReverseIteratorWithPrefix implements the database.Snapshot interface
+func (s *versionSnapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		s.mem,
+		s.snapshot.NewReverseIteratorWithPrefix(prefix),
+		nil,
+		nil,
+		prefix,
+		true,
+	)
+}
+
+// NewReverseIteratorWithStartAndEnd implements the database.Snapshot interface
+func (s *versionSnapshot) NewReverseIteratorWithStartAndEnd(start, end []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		s.mem,
+		s.snapshot.NewReverseIteratorWithStartAndEnd(start, end),
+		start,
+		end,
+		nil,
+		true,
+	)
+}
+
+// Release implements the database.Snapshot interface
+func (s *versionSnapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.mem == nil {
+		return
+	}
+	s.mem = nil
+	s.snapshot.Release()
+}
+
 // iterator walks over both the in memory database and the underlying database
 // at the same time.
 type iterator struct {
@@ -356,7 +568,56 @@ type iterator struct {
 	keys   []string
 	values []valueDelete
 
-	initialized, exhausted bool
+	reverse, initialized, exhausted bool
+}
+
+// newIterator returns an iterator that merges the keys in [mem] with the keys
+// returned by [it]. Only the keys in [mem] that start with [prefix], are not
+// less than [start] and, if [end] is non-nil, are less than [end] are included.
+// [it] is expected to iterate over the same range, in descending order if
+// [reverse] is true. Assumes the caller is holding the lock that protects
+// [mem].
+func newIterator(
+	mem map[string]valueDelete,
+	it database.Iterator,
+	start,
+	end,
+	prefix []byte,
+	reverse bool,
+) *iterator {
+	startString := string(start)
+	endString := string(end)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
+		if strings.HasPrefix(key, prefixString) && key >= startString && (end == nil || key < endString) {
+			keys = append(keys, key)
+		}
+	}
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys) // Keys need to be in sorted order
+	}
+	values := make([]valueDelete, len(keys))
+	for i, key := range keys {
+		values[i] = mem[key]
+	}
+
+	return &iterator{
+		Iterator: it,
+		keys:     keys,
+		values:   values,
+		reverse:  reverse,
+	}
+}
+
+// before returns true if [a] should be yielded before [b]
+func (it *iterator) before(a, b string) bool {
+	if it.reverse {
+		return a > b
+	}
+	return a < b
 }
 
 // Next moves the iterator to the next key/value pair. It returns whether the
@@ -399,7 +660,7 @@ func (it *iterator) Next() bool {
 
 			dbStringKey := string(dbKey)
 			switch {
-			case memKey < dbStringKey:
+			case it.before(memKey, dbStringKey):
 				it.keys = it.keys[1:]
 				it.values = it.values[1:]
 
@@ -408,7 +669,7 @@ func (it *iterator) Next() bool {
 					it.value = memValue.value
 					return true
 				}
-			case dbStringKey < memKey:
+			case it.before(dbStringKey, memKey):
 				it.key = dbKey
 				it.value = it.Iterator.Value()
 				it.exhausted = !it.Iterator.Next()
@@ -179,6 +179,84 @@ func TestIterate(t *testing.T) {
 	}
 }
 
+func TestIterateReverse(t *testing.T) {
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Commit(); err != nil {
+		t.Fatalf("Unexpected error on db.Commit: %s", err)
+	}
+
+	if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key1, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	iterator := db.NewReverseIterator()
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIterator returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key3) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key3)
+	} else if value := iterator.Value(); !bytes.Equal(value, value3) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value3)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key2) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key2)
+	} else if value := iterator.Value(); !bytes.Equal(value, value2) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value2)
+	} else if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key1) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key1)
+	} else if value := iterator.Value(); !bytes.Equal(value, value2) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value2)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+
+	if err := db.Delete(key3); err != nil {
+		t.Fatalf("Unexpected error on db.Delete: %s", err)
+	}
+
+	iterator = db.NewReverseIteratorWithStartAndEnd(key2, nil)
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithStartAndEnd returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key2) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key2)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
 func TestCommit(t *testing.T) {
 	baseDB := memdb.New()
 	db := New(baseDB)
@@ -0,0 +1,79 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+var (
+	errStateCorrupted = errors.New("state failed to decode")
+
+	checkCommand = &command{
+		name:  "check",
+		usage: "check                      check that the platformvm and avm state decodes with their codecs",
+		run:   runCheck,
+	}
+)
+
+func runCheck(config *config, _ *pflag.FlagSet) error {
+	chains, err := config.chains()
+	if err != nil {
+		return err
+	}
+
+	db, err := config.openReadOnly()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	failed := false
+	for _, c := range chains {
+		// This must match the database the chain manager passes to the VM
+		vmDB := prefixdb.New([]byte("vm"), prefixdb.New(c.id[:], db))
+
+		var counts map[string]int
+		switch c.vmID {
+		case platformvm.ID:
+			counts, err = platformvm.VerifyState(vmDB)
+		case avm.ID:
+			fxs, fxErr := c.fxs()
+			if fxErr != nil {
+				return fxErr
+			}
+			counts, err = avm.VerifyState(vmDB, fxs)
+		default:
+			fmt.Printf("%s: skipped, no state check for VM %s\n", c.alias, c.vmID)
+			continue
+		}
+
+		kinds := make([]string, 0, len(counts))
+		for kind := range counts {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			fmt.Printf("%s: decoded %d %s\n", c.alias, counts[kind], kind)
+		}
+		if err != nil {
+			fmt.Printf("%s: %s\n", c.alias, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("%s: ok\n", c.alias)
+	}
+	if failed {
+		return errStateCorrupted
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	rangeStartKey = "start"
+	rangeLimitKey = "limit"
+)
+
+var compactCommand = &command{
+	name:  "compact",
+	usage: "compact [prefix]           compact the keys under a prefix, or the range given by --start and --limit",
+	flags: func(fs *pflag.FlagSet) {
+		fs.String(rangeStartKey, "", "Hex encoded first key of the range to compact. Ignored if a prefix is provided")
+		fs.String(rangeLimitKey, "", "Hex encoded key the range to compact ends before. Ignored if a prefix is provided")
+	},
+	run: runCompact,
+}
+
+func runCompact(config *config, fs *pflag.FlagSet) error {
+	var start, limit []byte
+	if fs.NArg() > 0 {
+		chains, err := config.chains()
+		if err != nil {
+			return err
+		}
+		p, err := layout(chains).find(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		start = p.key
+		limit = prefixdb.PrefixLimit(p.key)
+	} else {
+		var err error
+		if start, err = parseHexFlag(fs, rangeStartKey); err != nil {
+			return err
+		}
+		if limit, err = parseHexFlag(fs, rangeLimitKey); err != nil {
+			return err
+		}
+	}
+
+	// Compaction rewrites the database, so it can't be opened read-only.
+	db, err := leveldb.New(config.dbPath, logging.NoLog{}, 0, 0, 0)
+	if err != nil {
+		return fmt.Errorf("couldn't open database at %s: %w", config.dbPath, err)
+	}
+	defer db.Close()
+
+	r := []util.Range{{Start: start, Limit: limit}}
+	before, err := db.SizeOf(r)
+	if err != nil {
+		return err
+	}
+	startTime := time.Now()
+	if err := db.Compact(start, limit); err != nil {
+		return err
+	}
+	after, err := db.SizeOf(r)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("compacted [0x%x, 0x%x) in %s: %s -> %s\n",
+		start,
+		limit,
+		time.Since(startTime),
+		formatBytes(uint64(before.Sum())),
+		formatBytes(uint64(after.Sum())),
+	)
+	return nil
+}
+
+// parseHexFlag returns the bytes of the hex encoded flag [name], or nil if the
+// flag is empty
+func parseHexFlag(fs *pflag.FlagSet, name string) ([]byte, error) {
+	str, err := fs.GetString(name)
+	if err != nil || str == "" {
+		return nil, err
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse --%s: %w", name, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,101 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+const (
+	encodingKey = "encoding"
+	limitKey    = "limit"
+	keysOnlyKey = "keys-only"
+)
+
+var dumpCommand = &command{
+	name:  "dump",
+	usage: "dump [prefix]              print the keys and values under a prefix, such as P/vm or 0x1234",
+	flags: func(fs *pflag.FlagSet) {
+		fs.String(encodingKey, "hex", "Encoding of the printed keys and values. Should be one of {hex, cb58}")
+		fs.Int(limitKey, 0, "Maximum number of entries to print. 0 prints every entry")
+		fs.Bool(keysOnlyKey, false, "Only print the keys")
+	},
+	run: runDump,
+}
+
+func runDump(config *config, fs *pflag.FlagSet) error {
+	encodingName, err := fs.GetString(encodingKey)
+	if err != nil {
+		return err
+	}
+	var encode func([]byte) (string, error)
+	switch strings.ToLower(encodingName) {
+	case "hex":
+		encode = func(b []byte) (string, error) { return fmt.Sprintf("0x%s", hex.EncodeToString(b)), nil }
+	case "cb58":
+		encode = func(b []byte) (string, error) { return formatting.Encode(formatting.CB58, b) }
+	default:
+		return fmt.Errorf("unknown encoding %q", encodingName)
+	}
+	limit, err := fs.GetInt(limitKey)
+	if err != nil {
+		return err
+	}
+	keysOnly, err := fs.GetBool(keysOnlyKey)
+	if err != nil {
+		return err
+	}
+
+	var p []byte
+	if fs.NArg() > 0 {
+		chains, err := config.chains()
+		if err != nil {
+			return err
+		}
+		found, err := layout(chains).find(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		p = found.key
+	}
+
+	db, err := config.openReadOnly()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	iter := db.NewIteratorWithPrefix(p)
+	defer iter.Release()
+
+	w := bufio.NewWriter(os.Stdout)
+	for n := 0; (limit == 0 || n < limit) && iter.Next(); n++ {
+		// Keys are printed relative to the dumped prefix
+		key, err := encode(iter.Key()[len(p):])
+		if err != nil {
+			return err
+		}
+		if keysOnly {
+			fmt.Fprintln(w, key)
+			continue
+		}
+		value, err := encode(iter.Value())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s %s\n", key, value)
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
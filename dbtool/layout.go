@@ -0,0 +1,227 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// prefix is a node of the layout the node partitions its database into with
+// prefixdb. A prefix without a key only groups its children, no keys are stored
+// directly under it.
+type prefix struct {
+	name string
+	// hash is the prefix of the prefixdb this prefix represents
+	hash []byte
+	// key is the prefix keys are stored under in the node's database
+	key      []byte
+	children []*prefix
+}
+
+// add a child to this prefix and return the child
+func (p *prefix) add(child *prefix) *prefix {
+	p.children = append(p.children, child)
+	return child
+}
+
+// group returns a prefix that represents prefixdb.New([raw], db) where db isn't
+// prefixed, but that doesn't store any keys itself.
+func group(name string, raw []byte) *prefix {
+	return &prefix{
+		name: name,
+		hash: hashing.ComputeHash256(raw),
+	}
+}
+
+// newPrefix returns the prefix that represents prefixdb.New([raw], db) where db
+// isn't prefixed.
+func newPrefix(name string, raw []byte) *prefix {
+	p := group(name, raw)
+	p.key = p.hash
+	return p
+}
+
+// compressed returns the prefix that represents prefixdb.New([raw], db) where db
+// is partitioned by [p]. prefixdb compresses nested prefixes by hashing the
+// parent's prefix along with [raw].
+func (p *prefix) compressed(name string, raw []byte) *prefix {
+	full := make([]byte, 0, len(p.hash)+len(raw))
+	full = append(full, p.hash...)
+	full = append(full, raw...)
+	return newPrefix(name, full)
+}
+
+// nested returns the prefix that represents prefixdb.NewNested([raw], db) where
+// db is partitioned by [p].
+func (p *prefix) nested(name string, raw []byte) *prefix {
+	hash := hashing.ComputeHash256(raw)
+	key := make([]byte, 0, len(p.key)+len(hash))
+	key = append(key, p.key...)
+	key = append(key, hash...)
+	return &prefix{
+		name: name,
+		hash: hash,
+		key:  key,
+	}
+}
+
+// walk calls [f] on this prefix and every descendant, depth first
+func (p *prefix) walk(depth int, f func(p *prefix, depth int)) {
+	f(p, depth)
+	for _, child := range p.children {
+		child.walk(depth+1, f)
+	}
+}
+
+// find the descendant of this prefix at [path]. [path] is either a list of
+// prefix names separated by '/' or a raw hex encoded prefix starting with 0x.
+func (p *prefix) find(path string) (*prefix, error) {
+	if strings.HasPrefix(path, "0x") {
+		key, err := hex.DecodeString(path[2:])
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse prefix %q: %w", path, err)
+		}
+		return &prefix{name: path, key: key}, nil
+	}
+
+	current := p
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		var next *prefix
+		for _, child := range current.children {
+			if child.name == name {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("unknown prefix %q in path %q", name, path)
+		}
+		current = next
+	}
+	if current.key == nil {
+		return nil, fmt.Errorf("%q doesn't partition the database itself, use one of its children", path)
+	}
+	return current, nil
+}
+
+// chain is a blockchain that is known to exist in the database
+type chain struct {
+	alias string
+	id    ids.ID
+	vmID  ids.ID
+	fxIDs []ids.ID
+}
+
+// fxs returns the fxs this chain's VM is initialized with
+func (c *chain) fxs() ([]*common.Fx, error) {
+	fxs := make([]*common.Fx, len(c.fxIDs))
+	for i, fxID := range c.fxIDs {
+		fxs[i] = &common.Fx{ID: fxID}
+		switch fxID {
+		case secp256k1fx.ID:
+			fxs[i].Fx = &secp256k1fx.Fx{}
+		case nftfx.ID:
+			fxs[i].Fx = &nftfx.Fx{}
+		case propertyfx.ID:
+			fxs[i].Fx = &propertyfx.Fx{}
+		default:
+			return nil, fmt.Errorf("unknown fx %s", fxID)
+		}
+	}
+	return fxs, nil
+}
+
+// chains returns the chains created in the genesis of the network
+func (c *config) chains() ([]*chain, error) {
+	genesisBytes, _, err := genesis.Genesis(c.networkID, c.genesisConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	_, chainAliases, _, err := genesis.Aliases(genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := &platformvm.Genesis{}
+	if _, err := platformvm.GenesisCodec.Unmarshal(genesisBytes, gen); err != nil {
+		return nil, err
+	}
+	if err := gen.Initialize(); err != nil {
+		return nil, err
+	}
+
+	chains := []*chain{{
+		alias: chainAliases[constants.PlatformChainID][0],
+		id:    constants.PlatformChainID,
+		vmID:  platformvm.ID,
+	}}
+	for _, tx := range gen.Chains {
+		unsignedTx, ok := tx.UnsignedTx.(*platformvm.UnsignedCreateChainTx)
+		if !ok {
+			return nil, fmt.Errorf("unexpected genesis chain type %T", tx.UnsignedTx)
+		}
+		alias := tx.ID().String()
+		if aliases := chainAliases[tx.ID()]; len(aliases) > 0 {
+			alias = aliases[0]
+		}
+		chains = append(chains, &chain{
+			alias: alias,
+			id:    tx.ID(),
+			vmID:  unsignedTx.VMID,
+			fxIDs: unsignedTx.FxIDs,
+		})
+	}
+	return chains, nil
+}
+
+// layout returns the known prefixes of a node's database that runs [chains].
+// This must be kept in sync with the prefixes used by the node and the chain
+// manager.
+func layout(chains []*chain) *prefix {
+	root := &prefix{}
+	root.add(newPrefix("shared memory", []byte("shared memory")))
+
+	keystore := root.add(group("keystore", []byte("keystore")))
+	keystore.add(keystore.compressed("users", []byte("users")))
+	keystore.add(keystore.compressed("bcs", []byte("bcs")))
+
+	for _, c := range chains {
+		chainPrefix := root.add(group(c.alias, c.id[:]))
+
+		var subPrefixes []string
+		switch c.vmID {
+		case avm.ID:
+			subPrefixes = []string{"vm", "vertex", "vertex_bs", "tx_bs"}
+		default:
+			subPrefixes = []string{"vm", "bs"}
+		}
+		for _, subPrefix := range subPrefixes {
+			p := chainPrefix.add(chainPrefix.compressed(subPrefix, []byte(subPrefix)))
+
+			if c.vmID == platformvm.ID && subPrefix == "vm" {
+				for _, name := range []string{
+					fmt.Sprintf("%sstart", constants.PrimaryNetworkID),
+					fmt.Sprintf("%sstop", constants.PrimaryNetworkID),
+					"uptime",
+				} {
+					p.add(p.nested(name, []byte(name)))
+				}
+			}
+		}
+	}
+	return root
+}
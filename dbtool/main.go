@@ -0,0 +1,147 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	dbDirKey             = "db-dir"
+	dbPathKey            = "db-path"
+	networkNameKey       = "network-id"
+	genesisConfigFileKey = "genesis"
+)
+
+var (
+	defaultDbDir = filepath.Join(os.ExpandEnv("$HOME"), fmt.Sprintf(".%s", constants.AppName), "db")
+
+	commands = []*command{
+		prefixesCommand,
+		dumpCommand,
+		sizesCommand,
+		compactCommand,
+		checkCommand,
+	}
+)
+
+// command is a subcommand of the database tool
+type command struct {
+	name  string
+	usage string
+	// flags registers the flags specific to this command
+	flags func(fs *pflag.FlagSet)
+	// run executes the command against the provided config
+	run func(config *config, fs *pflag.FlagSet) error
+}
+
+// config is the configuration shared by all commands
+type config struct {
+	networkID         uint32
+	dbPath            string
+	genesisConfigFile string
+}
+
+// main is the entry point of the offline database tool. It inspects the
+// database of a stopped node.
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	var cmd *command
+	for _, c := range commands {
+		if c.name == os.Args[1] {
+			cmd = c
+		}
+	}
+	if cmd == nil {
+		printUsage()
+		os.Exit(2)
+	}
+
+	fs := pflag.NewFlagSet(cmd.name, pflag.ExitOnError)
+	fs.String(dbDirKey, defaultDbDir, "Path to the node's database directory")
+	fs.String(dbPathKey, "", "Path to the leveldb directory. Overrides the path derived from --db-dir and --network-id")
+	fs.String(networkNameKey, constants.MainnetName, "Network ID of the node")
+	fs.String(genesisConfigFileKey, "", "Genesis config file of the node (ignored for standard networks)")
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s %s\n", filepath.Base(os.Args[0]), cmd.usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(2)
+	}
+
+	config, err := parseConfig(fs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "parsing parameters returned with error %s\n", err)
+		os.Exit(2)
+	}
+	if err := cmd.run(config, fs); err != nil {
+		fmt.Fprintf(os.Stderr, "%s failed with: %s\n", cmd.name, err)
+		os.Exit(1)
+	}
+}
+
+func parseConfig(fs *pflag.FlagSet) (*config, error) {
+	networkName, err := fs.GetString(networkNameKey)
+	if err != nil {
+		return nil, err
+	}
+	networkID, err := constants.NetworkID(networkName)
+	if err != nil {
+		return nil, err
+	}
+	dbDir, err := fs.GetString(dbDirKey)
+	if err != nil {
+		return nil, err
+	}
+	dbPath, err := fs.GetString(dbPathKey)
+	if err != nil {
+		return nil, err
+	}
+	if dbPath == "" {
+		dbPath = filepath.Join(os.ExpandEnv(dbDir), constants.NetworkName(networkID), constants.DatabaseVersion)
+	}
+	genesisConfigFile, err := fs.GetString(genesisConfigFileKey)
+	if err != nil {
+		return nil, err
+	}
+	return &config{
+		networkID:         networkID,
+		dbPath:            dbPath,
+		genesisConfigFile: genesisConfigFile,
+	}, nil
+}
+
+// openReadOnly opens the node's database without allowing any writes
+func (c *config) openReadOnly() (*leveldb.Database, error) {
+	db, err := leveldb.NewReadOnly(c.dbPath, logging.NoLog{})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open database at %s: %w", c.dbPath, err)
+	}
+	return db, nil
+}
+
+func printUsage() {
+	name := filepath.Base(os.Args[0])
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [flags]\n\ncommands:\n", name)
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %s\n", c.usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nrun '%s <command> --help' for the flags of a command\n", name)
+}
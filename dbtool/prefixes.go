@@ -0,0 +1,76 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/pflag"
+)
+
+var prefixesCommand = &command{
+	name:  "prefixes",
+	usage: "prefixes                   list the prefix trees of the database and the keys under them",
+	run:   runPrefixes,
+}
+
+func runPrefixes(config *config, _ *pflag.FlagSet) error {
+	chains, err := config.chains()
+	if err != nil {
+		return err
+	}
+	root := layout(chains)
+
+	db, err := config.openReadOnly()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	all, err := scan(db, root)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PREFIX\tKEY\tKEYS\tSIZE")
+	root.walk(-1, func(p *prefix, depth int) {
+		if depth < 0 {
+			return
+		}
+		name := strings.Repeat("  ", depth) + p.name
+		if p.key == nil {
+			fmt.Fprintf(w, "%s\t\t\t\n", name)
+			return
+		}
+		s, ok := all[string(p.key)]
+		if !ok {
+			s = &stats{}
+		}
+		delete(all, string(p.key))
+		fmt.Fprintf(w, "%s\t0x%x\t%d\t%s\n", name, p.key, s.keys, formatBytes(s.keyBytes+s.valueBytes))
+	})
+
+	// Report whatever isn't part of the known layout, such as chains that
+	// were created after genesis.
+	unknown := make([]string, 0, len(all))
+	for key := range all {
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		s := all[key]
+		switch {
+		case key == "":
+			fmt.Fprintf(w, "(unprefixed)\t\t%d\t%s\n", s.keys, formatBytes(s.keyBytes+s.valueBytes))
+		default:
+			fmt.Fprintf(w, "(unknown)\t0x%x\t%d\t%s\n", key, s.keys, formatBytes(s.keyBytes+s.valueBytes))
+		}
+	}
+	return w.Flush()
+}
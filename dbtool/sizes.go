@@ -0,0 +1,123 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/pflag"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+)
+
+const (
+	histogramKey = "histogram"
+)
+
+var sizesCommand = &command{
+	name:  "sizes",
+	usage: "sizes                      report the size of every prefix and a histogram of its value sizes",
+	flags: func(fs *pflag.FlagSet) {
+		fs.Bool(histogramKey, true, "Report the histogram of the value sizes of every prefix")
+	},
+	run: runSizes,
+}
+
+func runSizes(config *config, fs *pflag.FlagSet) error {
+	histogram, err := fs.GetBool(histogramKey)
+	if err != nil {
+		return err
+	}
+
+	chains, err := config.chains()
+	if err != nil {
+		return err
+	}
+	root := layout(chains)
+
+	names := make(map[string]string)
+	var name func(p *prefix, path string)
+	name = func(p *prefix, path string) {
+		if p.key != nil {
+			names[string(p.key)] = path
+		}
+		for _, child := range p.children {
+			if path == "" {
+				name(child, child.name)
+			} else {
+				name(child, path+"/"+child.name)
+			}
+		}
+	}
+	name(root, "")
+
+	db, err := config.openReadOnly()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	all, err := scan(db, root)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(all))
+	for key := range all {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		si, sj := all[keys[i]], all[keys[j]]
+		return si.keyBytes+si.valueBytes > sj.keyBytes+sj.valueBytes
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PREFIX\tKEYS\tKEY SIZE\tVALUE SIZE\tDISK SIZE")
+	for _, key := range keys {
+		s := all[key]
+
+		label, ok := names[key]
+		switch {
+		case key == "":
+			label = "(unprefixed)"
+		case !ok:
+			label = fmt.Sprintf("0x%x", key)
+		}
+
+		// The size on disk is approximated by leveldb and accounts for
+		// compression.
+		disk := "-"
+		if key != "" {
+			sizes, err := db.SizeOf([]util.Range{{
+				Start: []byte(key),
+				Limit: prefixdb.PrefixLimit([]byte(key)),
+			}})
+			if err != nil {
+				return err
+			}
+			disk = formatBytes(uint64(sizes.Sum()))
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", label, s.keys, formatBytes(s.keyBytes), formatBytes(s.valueBytes), disk)
+
+		if !histogram {
+			continue
+		}
+		for i, count := range s.valueSizes {
+			if count == 0 {
+				continue
+			}
+			switch i {
+			case 0:
+				fmt.Fprintf(w, "  empty values\t%d\t\t\t\n", count)
+			default:
+				fmt.Fprintf(w, "  values in [%s, %s)\t%d\t\t\t\n", formatBytes(1<<(i-1)), formatBytes(1<<i), count)
+			}
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,86 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// stats of the keys sharing a prefix
+type stats struct {
+	keys       int
+	keyBytes   uint64
+	valueBytes uint64
+	// valueSizes[i] is the number of values whose length is in [2^(i-1), 2^i)
+	valueSizes [bits.UintSize + 1]int
+}
+
+func (s *stats) add(key, value []byte) {
+	s.keys++
+	s.keyBytes += uint64(len(key))
+	s.valueBytes += uint64(len(value))
+	s.valueSizes[bits.Len(uint(len(value)))]++
+}
+
+// scan iterates over the whole database and returns the stats of every prefix
+// of length [hashing.HashLen], keyed by the prefix, and of every known nested
+// prefix. Keys that are too short to be prefixed are accounted under the empty
+// prefix.
+func scan(db database.Iteratee, root *prefix) (map[string]*stats, error) {
+	known := make(map[string]bool)
+	root.walk(0, func(p *prefix, _ int) {
+		if len(p.key) > hashing.HashLen {
+			known[string(p.key)] = true
+		}
+	})
+
+	all := make(map[string]*stats)
+	get := func(key []byte) *stats {
+		s, ok := all[string(key)]
+		if !ok {
+			s = &stats{}
+			all[string(key)] = s
+		}
+		return s
+	}
+
+	iter := db.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		key := iter.Key()
+		value := iter.Value()
+
+		if len(key) <= hashing.HashLen {
+			get(nil).add(key, value)
+			continue
+		}
+		get(key[:hashing.HashLen]).add(key, value)
+
+		// Nested prefixes are only accounted for when they are known, as
+		// prefixed values may themselves be keyed by hashes.
+		if len(key) > 2*hashing.HashLen && known[string(key[:2*hashing.HashLen])] {
+			get(key[:2*hashing.HashLen]).add(key, value)
+		}
+	}
+	return all, iter.Error()
+}
+
+// formatBytes returns a human readable representation of [n] bytes
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
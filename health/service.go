@@ -17,6 +17,8 @@ type Service interface {
 	RegisterCheck(name string, checkFn Check) error
 	RegisterMonotonicCheck(name string, checkFn Check) error
 	Results() (map[string]health.Result, bool)
+	// Stop running the health checks
+	Stop()
 }
 
 // NewService returns a new [Service] where the health checks
@@ -42,6 +44,9 @@ type service struct {
 	checkFreq time.Duration
 }
 
+// Stop implements the Service interface
+func (s *service) Stop() { s.Health.DeregisterAll() }
+
 // RegisterCheckFn adds a check that calls [checkFn] to evaluate health
 func (s *service) RegisterCheck(name string, checkFn Check) error {
 	check := &check{
@@ -23,6 +23,11 @@ const (
 	signatureVerificationEnabledKey         = "signature-verification-enabled"
 	dbEnabledKey                            = "db-enabled"
 	dbPathKey                               = "db-dir"
+	dbRestoreDirKey                         = "db-restore-dir"
+	dbMigrationDryRunKey                    = "db-migration-dry-run"
+	dbBackupDirKey                          = "db-backup-dir"
+	captureDirKey                           = "capture-dir"
+	captureMaxBytesKey                      = "capture-max-bytes"
 	publicIPKey                             = "public-ip"
 	dynamicUpdateDurationKey                = "dynamic-update-duration"
 	dynamicPublicIPResolverKey              = "dynamic-public-ip"
@@ -52,6 +57,12 @@ const (
 	networkMaximumTimeoutKey                = "network-maximum-timeout"
 	networkTimeoutHalflifeKey               = "network-timeout-halflife"
 	networkTimeoutCoefficientKey            = "network-timeout-coefficient"
+	appInitialTimeoutKey                    = "app-request-initial-timeout"
+	appMinimumTimeoutKey                    = "app-request-minimum-timeout"
+	appMaximumTimeoutKey                    = "app-request-maximum-timeout"
+	appTimeoutHalflifeKey                   = "app-request-timeout-halflife"
+	appTimeoutCoefficientKey                = "app-request-timeout-coefficient"
+	networkRequestTimeoutsKey               = "network-request-timeouts"
 	networkHealthMinPeersKey                = "network-health-min-conn-peers"
 	networkHealthMaxTimeSinceMsgReceivedKey = "network-health-max-time-since-msg-received"
 	networkHealthMaxTimeSinceMsgSentKey     = "network-health-max-time-since-msg-sent"
@@ -80,6 +91,7 @@ const (
 	snowMaxTimeProcessingKey                = "snow-max-time-processing"
 	snowEpochFirstTransition                = "snow-epoch-first-transition"
 	snowEpochDuration                       = "snow-epoch-duration"
+	proposerWindowsKey                      = "snow-proposer-windows"
 	whitelistedSubnetsKey                   = "whitelisted-subnets"
 	adminAPIEnabledKey                      = "api-admin-enabled"
 	infoAPIEnabledKey                       = "api-info-enabled"
@@ -100,9 +112,25 @@ const (
 	restartOnDisconnectedKey                = "restart-on-disconnected"
 	routerHealthMaxDropRateKey              = "router-health-max-drop-rate"
 	routerHealthMaxOutstandingRequestsKey   = "router-health-max-outstanding-requests"
+	routerMaxConcurrentChainsKey            = "router-max-concurrent-chains"
+	routerChainWeightsKey                   = "router-chain-weights"
+	routerPriorityChainsKey                 = "router-priority-chains"
 	healthCheckFreqKey                      = "health-check-frequency"
 	healthCheckAveragerHalflifeKey          = "health-check-averager-halflife"
 	retryBootstrap                          = "bootstrap-retry-enabled"
 	retryBootstrapMaxAttempts               = "bootstrap-retry-max-attempts"
+	vertexCacheBytesKey                     = "vertex-cache-bytes"
+	vmCacheBytesKey                         = "vm-cache-bytes"
 	peerAliasTimeoutKey                     = "peer-alias-timeout"
+	networkCompressionKey                   = "network-compression"
+	inboundBandwidthKey                     = "network-inbound-bandwidth"
+	inboundPeerBandwidthKey                 = "network-inbound-peer-bandwidth"
+	outboundBandwidthKey                    = "network-outbound-bandwidth"
+	outboundPeerBandwidthKey                = "network-outbound-peer-bandwidth"
+	stakerBandwidthReservedKey              = "staker-bandwidth-reserved-portion"
+	peerAllowlistEnabledKey                 = "peer-allowlist-enabled"
+	peerAllowlistIPsKey                     = "peer-allowlist-ips"
+	peerAllowlistIDsKey                     = "peer-allowlist-ids"
+	minimumCompatibleVersionKey             = "network-minimum-compatible-version"
+	versionUpgradesKey                      = "network-version-upgrades"
 )
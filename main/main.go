@@ -5,9 +5,15 @@ package main
 
 import (
 	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"time"
 
+	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/database"
-	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/backup"
+	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/node"
@@ -19,6 +25,13 @@ import (
 )
 
 const (
+	// Minimum amount of time between two progress reports of a restore
+	restoreLogFrequency = 10 * time.Second
+
+	// Endpoint of the health API, which is served on its own while the
+	// database is migrated
+	migrationHealthEndpoint = "/ext/health"
+
 	header = "" +
 		`     _____               .__                       .__` + "\n" +
 		`    /  _  \___  _______  |  | _____    ____   ____ |  |__   ____    ,_ o` + "\n" +
@@ -52,8 +65,62 @@ func main() {
 	fmt.Println(header)
 
 	var db database.Database
+	restore := Config.DBEnabled && Config.DBRestorePath != ""
+	if restore {
+		restored, err := backup.Restored(Config.DBRestorePath, Config.DBPath)
+		if err != nil {
+			log.Error("couldn't read database backup %s: %s", Config.DBRestorePath, err)
+			return
+		}
+		if restored {
+			log.Info("database backup %s was already restored to %s", Config.DBRestorePath, Config.DBPath)
+		}
+		restore = !restored
+	}
+	if restore {
+		log.Info("restoring database backup %s to %s", Config.DBRestorePath, Config.DBPath)
+		lastLog := time.Now()
+		metadata, err := backup.Restore(
+			Config.DBRestorePath,
+			Config.DBPath,
+			Config.NetworkID,
+			Config.DBVersion,
+			log,
+			func(progress backup.Progress) {
+				if now := time.Now(); now.Sub(lastLog) >= restoreLogFrequency {
+					lastLog = now
+					log.Info("restored %d keys (%d bytes)", progress.Keys, progress.Bytes)
+				}
+			},
+		)
+		if err != nil {
+			log.Error("couldn't restore database backup %s: %s", Config.DBRestorePath, err)
+			return
+		}
+		log.Info("restored database backup taken at %s", metadata.Timestamp)
+	}
 	if Config.DBEnabled {
-		db, err = leveldb.New(Config.DBPath, log, 0, 0, 0)
+		Config.DBManager, err = manager.New(path.Dir(Config.DBPath), Config.DBVersion, manager.Migrations, log)
+		if err != nil {
+			log.Error("couldn't create database manager: %s", err)
+			return
+		}
+		// The node's API server doesn't exist until the database is open, so
+		// the progress of a migration is served by a server of its own
+		stopHealth, err := serveMigrationHealth(log, Config.DBManager)
+		if err != nil {
+			log.Error("couldn't serve the health of the database migration: %s", err)
+			return
+		}
+		if Config.DBMigrationDryRun {
+			if err := Config.DBManager.Migrate(true); err != nil {
+				log.Error("database migration dry run failed with: %s", err)
+			}
+			stopHealth()
+			return
+		}
+		db, err = Config.DBManager.Open()
+		stopHealth()
 		if err != nil {
 			log.Error("couldn't open database at %s: %s", Config.DBPath, err)
 			return
@@ -196,3 +263,51 @@ func (r *restarter) Restart() {
 	// Shutdown is safe to call multiple times because it uses sync.Once
 	r.node.Shutdown()
 }
+
+// serveMigrationHealth serves the health API, with only the "database" check,
+// if opening the database will migrate it. The returned function stops the
+// server so that the node's API server can listen on the same port.
+func serveMigrationHealth(log logging.Logger, dbManager *manager.Manager) (func(), error) {
+	if !Config.HealthAPIEnabled {
+		return func() {}, nil
+	}
+	needsMigration, err := dbManager.NeedsMigration()
+	if err != nil || !needsMigration {
+		return func() {}, err
+	}
+
+	healthService := health.NewService(Config.HealthCheckFreq, log)
+	if err := healthService.RegisterCheck("database", dbManager.HealthCheck); err != nil {
+		return nil, err
+	}
+	handler, err := healthService.Handler()
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", Config.HTTPHost, Config.HTTPPort))
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(migrationHealthEndpoint, handler.Handler)
+	server := &http.Server{Handler: mux}
+	go func() {
+		var err error
+		if Config.HTTPSEnabled {
+			err = server.ServeTLS(listener, Config.HTTPSCertFile, Config.HTTPSKeyFile)
+		} else {
+			err = server.Serve(listener)
+		}
+		if err != http.ErrServerClosed {
+			log.Warn("health API server of the database migration failed with: %s", err)
+		}
+	}()
+	log.Info("serving the health of the database migration at %s", migrationHealthEndpoint)
+
+	return func() {
+		if err := server.Close(); err != nil {
+			log.Warn("couldn't close the health API server of the database migration: %s", err)
+		}
+		healthService.Stop()
+	}, nil
+}
@@ -12,6 +12,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,8 +26,10 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/ipcs"
 	"github.com/ava-labs/avalanchego/nat"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/node"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -35,10 +39,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/password"
 	"github.com/ava-labs/avalanchego/utils/ulimit"
 	"github.com/ava-labs/avalanchego/utils/units"
-)
-
-const (
-	dbVersion = "v1.0.0"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/proposervm"
 )
 
 // Results of parsing the CLI
@@ -49,6 +51,8 @@ var (
 	homeDir                = os.ExpandEnv("$HOME")
 	prefixedAppName        = fmt.Sprintf(".%s", constants.AppName)
 	defaultDbDir           = filepath.Join(homeDir, prefixedAppName, "db")
+	defaultBackupDir       = filepath.Join(homeDir, prefixedAppName, "backups")
+	defaultCaptureDir      = filepath.Join(homeDir, prefixedAppName, "captures")
 	defaultStakingKeyPath  = filepath.Join(homeDir, prefixedAppName, "staking", "staker.key")
 	defaultStakingCertPath = filepath.Join(homeDir, prefixedAppName, "staking", "staker.crt")
 	defaultPluginDirs      = []string{
@@ -69,9 +73,13 @@ func init() {
 }
 
 var (
-	errBootstrapMismatch    = errors.New("more bootstrap IDs provided than bootstrap IPs")
-	errStakingRequiresTLS   = errors.New("if staking is enabled, network TLS must also be enabled")
-	errInvalidStakerWeights = errors.New("staking weights must be positive")
+	errBootstrapMismatch         = errors.New("more bootstrap IDs provided than bootstrap IPs")
+	errAllowlistMismatch         = errors.New("number of allowlist IDs doesn't match the number of allowlist IPs")
+	errBootstrapNotAllowed       = errors.New("bootstrap peer isn't on the allowlist")
+	errStakingRequiresTLS        = errors.New("if staking is enabled, network TLS must also be enabled")
+	errProposerWindowsRequireTLS = errors.New("if proposer windows are enabled, network TLS must also be enabled")
+	errInvalidStakerWeights      = errors.New("staking weights must be positive")
+	errInvalidUpgrade            = errors.New("version upgrades must have the form <version>@<unix timestamp>")
 )
 
 // avalancheFlagSet returns the complete set of flags for avalanchego
@@ -98,6 +106,11 @@ func avalancheFlagSet() *flag.FlagSet {
 	// Database
 	fs.Bool(dbEnabledKey, true, "Turn on persistent storage")
 	fs.String(dbPathKey, defaultDbDir, "Path to database directory")
+	fs.String(dbRestoreDirKey, "", "Path to a database backup to restore before starting. The database directory of the network must be empty, unless it was already restored from this backup")
+	fs.Bool(dbMigrationDryRunKey, false, "If true, run the migrations needed to upgrade the database without keeping their result, then exit")
+	fs.String(dbBackupDirKey, defaultBackupDir, "Directory that database backups, started with the admin API, are written to")
+	fs.String(captureDirKey, defaultCaptureDir, "Directory that captures of chains, started with the admin API, are written to")
+	fs.Uint64(captureMaxBytesKey, 1<<30, "Maximum number of bytes a capture may hold. Later records are dropped. If 0, there is no limit")
 	// Coreth Config
 	fs.String(corethConfigKey, defaultString, "Specifies config to pass into coreth")
 	// Logging
@@ -131,7 +144,13 @@ func avalancheFlagSet() *flag.FlagSet {
 	fs.Duration(networkMaximumTimeoutKey, 10*time.Second, "Maximum timeout value of the adaptive timeout manager.")
 	fs.Duration(networkTimeoutHalflifeKey, 5*time.Minute, "Halflife of average network response time. Higher value --> network timeout is less volatile. Can't be 0.")
 	fs.Float64(networkTimeoutCoefficientKey, 2, "Multiplied by average network response time to get the network timeout. Must be >= 1.")
-	fs.Uint(sendQueueSizeKey, 4096, "Max number of messages waiting to be sent to peers.")
+	fs.Duration(appInitialTimeoutKey, 5*time.Second, "Initial timeout value of VM-defined application requests.")
+	fs.Duration(appMinimumTimeoutKey, 2*time.Second, "Minimum timeout value of VM-defined application requests.")
+	fs.Duration(appMaximumTimeoutKey, 10*time.Second, "Maximum timeout value of VM-defined application requests.")
+	fs.Duration(appTimeoutHalflifeKey, 5*time.Minute, "Halflife of average application response time. Can't be 0.")
+	fs.Float64(appTimeoutCoefficientKey, 2, "Multiplied by average application response time to get the application request timeout. Must be >= 1.")
+	fs.String(networkRequestTimeoutsKey, "{}", "JSON object that overrides the timeout config of types of requests, such as {\"get_ancestors\":{\"maximumTimeout\":\"30s\"}}. Each type of request on each chain keeps its own timeout.")
+	fs.Uint(sendQueueSizeKey, 4096, "Max number of messages of each priority waiting to be sent to a peer.")
 	// Restart on Disconnect
 	fs.Duration(disconnectedCheckFreqKey, 10*time.Second, "How often the node checks if it is connected to any peers. "+
 		"See [restart-on-disconnected]. If 0, node will not restart due to disconnection.")
@@ -141,6 +160,20 @@ func avalancheFlagSet() *flag.FlagSet {
 	// Peer alias configuration
 	fs.Duration(peerAliasTimeoutKey, 10*time.Minute, "How often the node will attempt to connect "+
 		"to an IP address previously associated with a peer (i.e. a peer alias).")
+	// Compression
+	fs.String(networkCompressionKey, "none", "Compression of the containers in Put, MultiPut and PushQuery messages sent to peers that support it. "+
+		"Should be one of {none, gzip, flate}.")
+	// Version compatibility
+	fs.String(minimumCompatibleVersionKey, node.MinimumCompatibleVersion.String(), "Oldest version of the peers this node connects to.")
+	fs.String(versionUpgradesKey, "", "Comma separated list of scheduled raises of [network-minimum-compatible-version], in order. "+
+		"Each is a version and the unix timestamp, in seconds, from which peers must run it. Example: avalanche/1.3.0@1620000000")
+	// Bandwidth
+	fs.Uint64(inboundBandwidthKey, 0, "Max bytes per second read from all peers together. If 0, there is no limit.")
+	fs.Uint64(inboundPeerBandwidthKey, 0, "Max bytes per second read from any single peer. If 0, there is no limit.")
+	fs.Uint64(outboundBandwidthKey, 0, "Max bytes per second written to all peers together. If 0, there is no limit.")
+	fs.Uint64(outboundPeerBandwidthKey, 0, "Max bytes per second written to any single peer. If 0, there is no limit.")
+	fs.Float64(stakerBandwidthReservedKey, 0.375, "Portion of the bandwidth of all peers together that is reserved for stakers and split between them by stake. "+
+		"Must be in [0,1).")
 	// Benchlist
 	fs.Int(benchlistFailThresholdKey, 10, "Number of consecutive failed queries before benchlisting a node.")
 	fs.Bool(benchlistPeerSummaryEnabledKey, false, "Enables peer specific query latency metrics.")
@@ -184,6 +217,9 @@ func avalancheFlagSet() *flag.FlagSet {
 	// Router Health
 	fs.Float64(routerHealthMaxDropRateKey, 1, "Node reports unhealthy if the router drops more than this portion of messages.")
 	fs.Uint(routerHealthMaxOutstandingRequestsKey, 1024, "Node reports unhealthy if there are more than this many outstanding consensus requests (Get, PullQuery, etc.) over all chains")
+	fs.Uint(routerMaxConcurrentChainsKey, uint(runtime.NumCPU()), "Maximum number of chains that may process messages at the same time. If 0, chains never wait for each other.")
+	fs.String(routerChainWeightsKey, "{}", "JSON object that maps chain IDs or aliases to their weights, such as {\"X\":2}. When chains wait for each other, each gets CPU time in proportion to its weight. Chains that aren't listed have weight 1.")
+	fs.String(routerPriorityChainsKey, "P", "Comma separated list of chain IDs or aliases of chains that may always process messages, ahead of other chains")
 	fs.Duration(networkHealthMaxTimeSinceNoReqsKey, 5*time.Minute, "Node reports unhealthy if there is at least 1 outstanding request continuously for this duration")
 
 	// Staking
@@ -215,6 +251,15 @@ func avalancheFlagSet() *flag.FlagSet {
 	fs.String(bootstrapIDsKey, defaultString, "Comma separated list of bootstrap peer ids to connect to. Example: NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET,NodeID-8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
 	fs.Bool(retryBootstrap, true, "Specifies whether bootstrap should be retried")
 	fs.Int(retryBootstrapMaxAttempts, 50, "Specifies how many times bootstrap should be retried")
+	// Allowlist
+	fs.Bool(peerAllowlistEnabledKey, false, "If true, this node only connects to the peers on the allowlist, and never gossips its peer list. "+
+		"Peers may also be added to the allowlist through the admin API. Unless [bootstrap-ips] is set, the node bootstraps from the allowed peers.")
+	fs.String(peerAllowlistIPsKey, "", "Comma separated list of the static IPs of the peers on the allowlist. Example: 127.0.0.1:9630,127.0.0.1:9631")
+	fs.String(peerAllowlistIDsKey, "", "Comma separated list of the IDs of the peers on the allowlist, in the same order as [peer-allowlist-ips]. "+
+		"Example: NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET,NodeID-8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
+	// Caches
+	fs.Int(vertexCacheBytesKey, 0, "If positive, the number of bytes each chain's vertex cache may hold. Otherwise, the cache holds a fixed number of vertices")
+	fs.Int(vmCacheBytesKey, 0, "If positive, the number of bytes the state cache of AVM chains, and the block caches of the P-chain and of plugin VMs, may hold. Otherwise, the caches hold a fixed number of entries")
 
 	// Consensus
 	fs.Int(snowSampleSizeKey, 20, "Number of nodes to query for each network poll")
@@ -229,6 +274,9 @@ func avalancheFlagSet() *flag.FlagSet {
 	fs.Duration(snowMaxTimeProcessingKey, 2*time.Minute, "Maximum amount of time an item should be processing and still be healthy")
 	fs.Int64(snowEpochFirstTransition, 1607626800, "Unix timestamp of the first epoch transaction, in seconds. Defaults to 12/10/2020 @ 7:00pm (UTC)")
 	fs.Duration(snowEpochDuration, 6*time.Hour, "Duration of each epoch")
+	fs.String(proposerWindowsKey, "{}", "JSON object that maps the IDs or aliases of snowman VMs to the proposer windows of their chains, such as {\"evm\":{\"numProposers\":6,\"windowDuration\":\"5s\",\"activationHeight\":1000}}. "+
+		"Blocks of these chains may only be proposed by each height's stake-weighted proposers, once their windows start. Requires TLS. Every node of a chain must use the same config. "+
+		"activationHeight must be set for chains that already have blocks, and can't change once it's reached")
 
 	// IPC
 	fs.String(ipcsChainIDsKey, "", "Comma separated list of chain ids to add to the IPC engine. Example: 11111111111111111111111111111111LpoYY,4R5p2RXDGLqaifZE4hHWH9owe34pfoBULn1DrQTWivjg8o4aH")
@@ -320,7 +368,13 @@ func setNodeConfig(v *viper.Viper) error {
 	if Config.DBPath == defaultString {
 		Config.DBPath = defaultDbDir
 	}
-	Config.DBPath = path.Join(Config.DBPath, constants.NetworkName(Config.NetworkID), dbVersion)
+	Config.DBPath = path.Join(Config.DBPath, constants.NetworkName(Config.NetworkID), constants.DatabaseVersion)
+	Config.DBVersion = constants.DatabaseVersion
+	Config.DBRestorePath = os.ExpandEnv(v.GetString(dbRestoreDirKey))
+	Config.DBMigrationDryRun = v.GetBool(dbMigrationDryRunKey)
+	Config.DBBackupDir = os.ExpandEnv(v.GetString(dbBackupDirKey))
+	Config.CaptureDir = os.ExpandEnv(v.GetString(captureDirKey))
+	Config.CaptureMaxBytes = v.GetUint64(captureMaxBytesKey)
 
 	// IP Configuration
 	// Resolves our public IP, or does nothing
@@ -466,6 +520,67 @@ func setNodeConfig(v *viper.Viper) error {
 		}
 	}
 
+	// Allowlist:
+	Config.AllowlistConfig.Enabled = v.GetBool(peerAllowlistEnabledKey)
+	if Config.AllowlistConfig.Enabled {
+		allowlistIDs := []string(nil)
+		if Config.EnableP2PTLS {
+			for _, id := range strings.Split(v.GetString(peerAllowlistIDsKey), ",") {
+				if id != "" {
+					allowlistIDs = append(allowlistIDs, id)
+				}
+			}
+		}
+		for _, ip := range strings.Split(v.GetString(peerAllowlistIPsKey), ",") {
+			if ip == "" {
+				continue
+			}
+			addr, err := utils.ToIPDesc(ip)
+			if err != nil {
+				return fmt.Errorf("couldn't parse allowlist ip %s: %w", ip, err)
+			}
+			peer := network.AllowedPeer{IP: addr}
+			if Config.EnableP2PTLS {
+				i := len(Config.AllowlistConfig.Peers)
+				if len(allowlistIDs) <= i {
+					return errAllowlistMismatch
+				}
+				peer.ID, err = ids.ShortFromPrefixedString(allowlistIDs[i], constants.NodeIDPrefix)
+				if err != nil {
+					return fmt.Errorf("couldn't parse allowlist peer id: %w", err)
+				}
+			} else {
+				peer.ID = ids.ShortID(hashing.ComputeHash160Array([]byte(addr.String())))
+			}
+			Config.AllowlistConfig.Peers = append(Config.AllowlistConfig.Peers, peer)
+		}
+		if Config.EnableP2PTLS && len(allowlistIDs) != len(Config.AllowlistConfig.Peers) {
+			return errAllowlistMismatch
+		}
+
+		if v.GetString(bootstrapIPsKey) == defaultString {
+			// The default beacons would be rejected, so bootstrap from the
+			// allowed peers instead
+			Config.BootstrapPeers = nil
+			for _, peer := range Config.AllowlistConfig.Peers {
+				Config.BootstrapPeers = append(Config.BootstrapPeers, &node.Peer{
+					ID: peer.ID,
+					IP: peer.IP,
+				})
+			}
+		} else {
+			allowed := ids.ShortSet{}
+			for _, peer := range Config.AllowlistConfig.Peers {
+				allowed.Add(peer.ID)
+			}
+			for _, peer := range Config.BootstrapPeers {
+				if !allowed.Contains(peer.ID) {
+					return fmt.Errorf("%w: %s", errBootstrapNotAllowed, peer.ID.PrefixedString(constants.NodeIDPrefix))
+				}
+			}
+		}
+	}
+
 	Config.WhitelistedSubnets.Add(constants.PrimaryNetworkID)
 	for _, subnet := range strings.Split(v.GetString(whitelistedSubnetsKey), ",") {
 		if subnet != "" {
@@ -545,6 +660,20 @@ func setNodeConfig(v *viper.Viper) error {
 		return fmt.Errorf("%s must be positive", networkHealthMaxTimeSinceNoReqsKey)
 	}
 
+	// Scheduling of chains
+	Config.RouterSchedulerConfig.MaxConcurrentChains = int(v.GetUint(routerMaxConcurrentChainsKey))
+	if err := json.Unmarshal([]byte(v.GetString(routerChainWeightsKey)), &Config.RouterSchedulerConfig.Weights); err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", routerChainWeightsKey, err)
+	}
+	for chain, weight := range Config.RouterSchedulerConfig.Weights {
+		if weight == 0 {
+			return fmt.Errorf("%s: weight of %s must be positive", routerChainWeightsKey, chain)
+		}
+	}
+	if priorityChains := v.GetString(routerPriorityChainsKey); priorityChains != "" {
+		Config.RouterSchedulerConfig.PriorityChains = strings.Split(priorityChains, ",")
+	}
+
 	// IPCs
 	ipcsChainIDs := v.GetString(ipcsChainIDsKey)
 	if ipcsChainIDs != "" {
@@ -609,6 +738,37 @@ func setNodeConfig(v *viper.Viper) error {
 		return errors.New("network timeout coefficient must be >= 1")
 	}
 
+	// Application Request Timeout
+	Config.AppTimeoutConfig.InitialTimeout = v.GetDuration(appInitialTimeoutKey)
+	Config.AppTimeoutConfig.MinimumTimeout = v.GetDuration(appMinimumTimeoutKey)
+	Config.AppTimeoutConfig.MaximumTimeout = v.GetDuration(appMaximumTimeoutKey)
+	Config.AppTimeoutConfig.TimeoutHalflife = v.GetDuration(appTimeoutHalflifeKey)
+	Config.AppTimeoutConfig.TimeoutCoefficient = v.GetFloat64(appTimeoutCoefficientKey)
+
+	switch {
+	case Config.AppTimeoutConfig.MinimumTimeout < 1:
+		return fmt.Errorf("%s must be positive", appMinimumTimeoutKey)
+	case Config.AppTimeoutConfig.MinimumTimeout > Config.AppTimeoutConfig.MaximumTimeout:
+		return fmt.Errorf("%s can't be less than %s", appMaximumTimeoutKey, appMinimumTimeoutKey)
+	case Config.AppTimeoutConfig.InitialTimeout < Config.AppTimeoutConfig.MinimumTimeout ||
+		Config.AppTimeoutConfig.InitialTimeout > Config.AppTimeoutConfig.MaximumTimeout:
+		return fmt.Errorf("%s should be in the range [%s, %s]", appInitialTimeoutKey, appMinimumTimeoutKey, appMaximumTimeoutKey)
+	case Config.AppTimeoutConfig.TimeoutHalflife <= 0:
+		return fmt.Errorf("%s must be positive", appTimeoutHalflifeKey)
+	case Config.AppTimeoutConfig.TimeoutCoefficient < 1:
+		return fmt.Errorf("%s must be >= 1", appTimeoutCoefficientKey)
+	}
+
+	// Per-Request Timeouts
+	Config.RequestTimeoutConfigs, err = timeout.ParseOpConfigs(
+		[]byte(v.GetString(networkRequestTimeoutsKey)),
+		Config.NetworkConfig,
+		Config.AppTimeoutConfig,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", networkRequestTimeoutsKey, err)
+	}
+
 	// Restart:
 	Config.RestartOnDisconnected = v.GetBool(restartOnDisconnectedKey)
 	Config.DisconnectedCheckFreq = v.GetDuration(disconnectedCheckFreqKey)
@@ -712,9 +872,68 @@ func setNodeConfig(v *viper.Viper) error {
 	Config.RetryBootstrap = v.GetBool(retryBootstrap)
 	Config.RetryBootstrapMaxAttempts = v.GetInt(retryBootstrapMaxAttempts)
 
+	// Caches
+	Config.VertexCacheBytes = v.GetInt(vertexCacheBytesKey)
+	Config.VMCacheBytes = v.GetInt(vmCacheBytesKey)
+
+	// Proposer windows
+	Config.ProposerWindows, err = proposervm.ParseConfigs([]byte(v.GetString(proposerWindowsKey)))
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", proposerWindowsKey, err)
+	}
+	if len(Config.ProposerWindows) > 0 && !Config.EnableP2PTLS {
+		return errProposerWindowsRequireTLS
+	}
+
 	// Peer alias
 	Config.PeerAliasTimeout = v.GetDuration(peerAliasTimeoutKey)
 
+	// Compression
+	Config.NetworkCompression, err = network.ParseCompression(v.GetString(networkCompressionKey))
+	if err != nil {
+		return err
+	}
+
+	// Version compatibility
+	versionParser := version.NewDefaultParser()
+	Config.MinimumCompatibleVersion, err = versionParser.Parse(v.GetString(minimumCompatibleVersionKey))
+	if err != nil {
+		return fmt.Errorf("couldn't parse minimum compatible version: %w", err)
+	}
+	for _, upgrade := range strings.Split(v.GetString(versionUpgradesKey), ",") {
+		if upgrade == "" {
+			continue
+		}
+		parts := strings.SplitN(upgrade, "@", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("%w: %s", errInvalidUpgrade, upgrade)
+		}
+		minimumVersion, err := versionParser.Parse(parts[0])
+		if err != nil {
+			return fmt.Errorf("couldn't parse version upgrade %s: %w", upgrade, err)
+		}
+		timestamp, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("couldn't parse version upgrade %s: %w", upgrade, err)
+		}
+		Config.VersionUpgrades = append(Config.VersionUpgrades, version.Upgrade{
+			Time:           time.Unix(timestamp, 0),
+			MinimumVersion: minimumVersion,
+		})
+	}
+
+	// Bandwidth
+	Config.BandwidthConfig = network.BandwidthConfig{
+		InboundBytesPerSec:      v.GetUint64(inboundBandwidthKey),
+		InboundPeerBytesPerSec:  v.GetUint64(inboundPeerBandwidthKey),
+		OutboundBytesPerSec:     v.GetUint64(outboundBandwidthKey),
+		OutboundPeerBytesPerSec: v.GetUint64(outboundPeerBandwidthKey),
+		StakerPortion:           v.GetFloat64(stakerBandwidthReservedKey),
+	}
+	if Config.BandwidthConfig.StakerPortion < 0 || Config.BandwidthConfig.StakerPortion >= 1 {
+		return fmt.Errorf("%s must be in [0,1)", stakerBandwidthReservedKey)
+	}
+
 	return nil
 }
 
@@ -743,7 +962,7 @@ func parseViper() error {
 		args = append(args, networkGeneration)
 
 		format += ", database=%s"
-		args = append(args, dbVersion)
+		args = append(args, constants.DatabaseVersion)
 
 		if GitCommit != "" {
 			format += ", commit=%s"
@@ -0,0 +1,143 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var (
+	errAllowlistDisabled = errors.New("allowlist-only mode is disabled")
+	errNotAllowed        = errors.New("peer isn't on the allowlist")
+)
+
+// AllowlistConfig configures allowlist-only mode, in which a node only connects
+// to a fixed set of peers. This is meant for private subnets, whose nodes
+// shouldn't be reachable by, or known to, the rest of the network.
+type AllowlistConfig struct {
+	// If true, the node rejects every peer that isn't on the allowlist, only
+	// dials the IPs of allowed peers and never gossips its peer list
+	Enabled bool
+
+	// Peers initially on the allowlist. More may be added while the node is
+	// running.
+	Peers []AllowedPeer
+}
+
+// AllowedPeer is a peer that a node in allowlist-only mode may connect to
+type AllowedPeer struct {
+	ID ids.ShortID
+	// Static IP the peer is dialed at
+	IP utils.IPDesc
+}
+
+// AllowPeer adds [nodeID] to the allowlist and attempts to connect to it at
+// [ip]. If [nodeID] was already allowed, it's now dialed at [ip] instead.
+// assumes the stateLock is not held.
+func (n *network) AllowPeer(nodeID ids.ShortID, ip utils.IPDesc) error {
+	if !n.allowlistOnly {
+		return errAllowlistDisabled
+	}
+
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	oldIP, wasAllowed := n.allowlist[nodeID]
+	n.allowlist[nodeID] = ip
+	if wasAllowed && !oldIP.Equal(ip) {
+		n.stopTracking(oldIP)
+	}
+	n.log.Info("allowing peer %s at %s", nodeID.PrefixedString(constants.NodeIDPrefix), ip)
+
+	n.track(ip)
+	return nil
+}
+
+// DisallowPeer removes [nodeID] from the allowlist, stops attempting to connect
+// to it and disconnects from it.
+// assumes the stateLock is not held.
+func (n *network) DisallowPeer(nodeID ids.ShortID) error {
+	if !n.allowlistOnly {
+		return errAllowlistDisabled
+	}
+
+	n.stateLock.Lock()
+	ip, ok := n.allowlist[nodeID]
+	if !ok {
+		n.stateLock.Unlock()
+		return fmt.Errorf("%w: %s", errNotAllowed, nodeID.PrefixedString(constants.NodeIDPrefix))
+	}
+	delete(n.allowlist, nodeID)
+	n.stopTracking(ip)
+	peer := n.peers[nodeID]
+	n.stateLock.Unlock()
+
+	n.log.Info("disallowing peer %s", nodeID.PrefixedString(constants.NodeIDPrefix))
+	if peer != nil {
+		peer.Close() // Grabs the stateLock
+	}
+	return nil
+}
+
+// AllowedPeers returns the peers on the allowlist, or nil if allowlist-only
+// mode is disabled.
+// assumes the stateLock is not held.
+func (n *network) AllowedPeers() []AllowedPeer {
+	n.stateLock.RLock()
+	defer n.stateLock.RUnlock()
+
+	if !n.allowlistOnly {
+		return nil
+	}
+	peers := make([]AllowedPeer, 0, len(n.allowlist))
+	for nodeID, ip := range n.allowlist {
+		peers = append(peers, AllowedPeer{
+			ID: nodeID,
+			IP: ip,
+		})
+	}
+	return peers
+}
+
+// allowed returns true if [nodeID] may be connected to.
+// assumes the stateLock is held.
+func (n *network) allowed(nodeID ids.ShortID) bool {
+	if !n.allowlistOnly {
+		return true
+	}
+	_, ok := n.allowlist[nodeID]
+	return ok
+}
+
+// allowedIP returns true if [ip] may be dialed. In allowlist-only mode, only the
+// static IPs of allowed peers are dialed.
+// assumes the stateLock is held.
+func (n *network) allowedIP(ip utils.IPDesc) bool {
+	if !n.allowlistOnly {
+		return true
+	}
+	for _, allowedIP := range n.allowlist {
+		if allowedIP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// stopTracking [ip] if no allowed peer is dialed at it anymore, so that any
+// pending connection attempts to it are abandoned.
+// assumes the stateLock is held.
+func (n *network) stopTracking(ip utils.IPDesc) {
+	if n.allowedIP(ip) {
+		return
+	}
+	str := ip.String()
+	delete(n.disconnectedIPs, str)
+	delete(n.retryDelay, str)
+}
@@ -0,0 +1,134 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newAllowlistTestNetwork(allowlistConfig AllowlistConfig) *network {
+	n := &network{
+		log: logging.NoLog{},
+		id:  ids.ShortEmpty,
+		ip:  utils.NewDynamicIPDesc(net.IPv6loopback, 0),
+		// Every dial fails, and isn't retried during the test
+		dialer: &testDialer{
+			addr:      &net.TCPAddr{IP: net.IPv6loopback},
+			outbounds: make(map[string]*testListener),
+		},
+		initialReconnectDelay: time.Hour,
+		maxReconnectDelay:     time.Hour,
+		disconnectedIPs:       make(map[string]struct{}),
+		connectedIPs:          make(map[string]struct{}),
+		peerAliasIPs:          make(map[string]struct{}),
+		myIPs:                 make(map[string]struct{}),
+		retryDelay:            make(map[string]time.Duration),
+		peers:                 make(map[ids.ShortID]*peer),
+		allowlistOnly:         allowlistConfig.Enabled,
+		allowlist:             make(map[ids.ShortID]utils.IPDesc),
+	}
+	for _, peer := range allowlistConfig.Peers {
+		n.allowlist[peer.ID] = peer.IP
+	}
+	n.banlist = newBanlist(memdb.New())
+	return n
+}
+
+// stopDialing closes [n] for the goroutines that dial its tracked IPs, which
+// copy the closed flag while holding the [stateLock]
+func (n *network) stopDialing() {
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	n.closed.SetValue(true)
+}
+
+func (n *network) tracking(ip utils.IPDesc) bool {
+	n.stateLock.RLock()
+	defer n.stateLock.RUnlock()
+
+	_, ok := n.disconnectedIPs[ip.String()]
+	return ok
+}
+
+func TestAllowlistTrack(t *testing.T) {
+	id0 := ids.ShortID{1}
+	ip0 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	id1 := ids.ShortID{2}
+	ip1 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 5), Port: 9651}
+	ip2 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 6), Port: 9651}
+
+	n := newAllowlistTestNetwork(AllowlistConfig{
+		Enabled: true,
+		Peers:   []AllowedPeer{{ID: id0, IP: ip0}},
+	})
+	defer n.stopDialing()
+
+	n.Track(ip1)
+	assert.False(t, n.tracking(ip1), "IPs of peers that aren't allowed shouldn't be dialed")
+	n.Track(ip0)
+	assert.True(t, n.tracking(ip0))
+
+	assert.NoError(t, n.AllowPeer(id1, ip1))
+	assert.True(t, n.tracking(ip1))
+	assert.Len(t, n.AllowedPeers(), 2)
+
+	assert.NoError(t, n.AllowPeer(id1, ip2))
+	assert.False(t, n.tracking(ip1), "the old IP of a moved peer shouldn't be dialed")
+	assert.True(t, n.tracking(ip2))
+	assert.Len(t, n.AllowedPeers(), 2)
+
+	assert.NoError(t, n.DisallowPeer(id1))
+	assert.False(t, n.tracking(ip2))
+	assert.Equal(t, []AllowedPeer{{ID: id0, IP: ip0}}, n.AllowedPeers())
+
+	err := n.DisallowPeer(id1)
+	assert.True(t, errors.Is(err, errNotAllowed))
+}
+
+func TestAllowlistRejectsPeers(t *testing.T) {
+	id0 := ids.ShortID{1}
+	ip0 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	id1 := ids.ShortID{2}
+
+	n := newAllowlistTestNetwork(AllowlistConfig{
+		Enabled: true,
+		Peers:   []AllowedPeer{{ID: id0, IP: ip0}},
+	})
+	defer n.stopDialing()
+
+	err := n.tryAddPeer(&peer{net: n, id: id1})
+	assert.True(t, errors.Is(err, errNotAllowed), "inbound peers that aren't allowed should be rejected")
+
+	n.Track(ip0)
+	err = n.tryAddPeer(&peer{net: n, id: id1, ip: ip0})
+	assert.True(t, errors.Is(err, errNotAllowed), "peers that aren't allowed should be rejected at an allowed IP")
+	assert.True(t, n.tracking(ip0), "an allowed IP should still be dialed after it was reached by another peer")
+	assert.Empty(t, n.peers)
+}
+
+func TestAllowlistDisabled(t *testing.T) {
+	n := newAllowlistTestNetwork(AllowlistConfig{})
+	defer n.stopDialing()
+
+	ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	err := n.AllowPeer(ids.ShortID{1}, ip)
+	assert.True(t, errors.Is(err, errAllowlistDisabled))
+	err = n.DisallowPeer(ids.ShortID{1})
+	assert.True(t, errors.Is(err, errAllowlistDisabled))
+	assert.Nil(t, n.AllowedPeers())
+
+	n.Track(ip)
+	assert.True(t, n.tracking(ip), "every IP may be dialed when allowlist-only mode is disabled")
+}
@@ -0,0 +1,317 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// Prefixes of the database keys of banned NodeIDs and IPs
+	nodeIDBanPrefix byte = iota
+	ipBanPrefix
+)
+
+var (
+	errBanned           = errors.New("banned")
+	errNotBanned        = errors.New("not banned")
+	errNotConnected     = errors.New("not connected")
+	errNonPositiveBan   = errors.New("ban duration must be positive")
+	errInvalidBannedIP  = errors.New("invalid IP")
+	errMalformedBanInDB = errors.New("malformed ban in database")
+)
+
+// Ban of a NodeID or an IP. While a NodeID is banned, connections with it are
+// dropped. While an IP is banned, it isn't dialed, and connections from it are
+// dropped before they are upgraded.
+type Ban struct {
+	// NodeID that is banned, or ids.ShortEmpty if an IP is banned
+	NodeID ids.ShortID
+	// IP that is banned, on any port, or nil if a NodeID is banned
+	IP net.IP
+	// Time at which the ban is lifted
+	Expiry time.Time
+}
+
+// banlist is the set of banned NodeIDs and IPs. Bans are written to [db], so
+// they outlive the node. It isn't thread safe.
+type banlist struct {
+	db database.Database
+
+	nodeIDs map[ids.ShortID]time.Time
+	// keyed by net.IP.String()
+	ips map[string]time.Time
+}
+
+// newBanlist returns an empty banlist that writes bans to [db]. The bans
+// already in [db] are enforced once they're loaded.
+func newBanlist(db database.Database) *banlist {
+	return &banlist{
+		db:      db,
+		nodeIDs: make(map[ids.ShortID]time.Time),
+		ips:     make(map[string]time.Time),
+	}
+}
+
+// load the bans in the database, deleting those that expired before [now].
+// Malformed bans can't be enforced, so they're deleted too, rather than
+// keeping the other bans from loading. Returns the number of malformed bans.
+func (b *banlist) load(now time.Time) (int, error) {
+	deleted := [][]byte(nil)
+	numMalformed := 0
+	it := b.db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		ban, err := parseBan(it.Key(), it.Value())
+		if err != nil {
+			numMalformed++
+		}
+		if err != nil || !ban.Expiry.After(now) {
+			// Copy the key, as the iterator may reuse it
+			deleted = append(deleted, append([]byte(nil), it.Key()...))
+			continue
+		}
+		b.add(ban)
+	}
+	if err := it.Error(); err != nil {
+		return numMalformed, err
+	}
+
+	for _, key := range deleted {
+		if err := b.db.Delete(key); err != nil {
+			return numMalformed, err
+		}
+	}
+	return numMalformed, nil
+}
+
+// ban [ban.NodeID] or [ban.IP] until [ban.Expiry], replacing any existing ban
+// of it
+func (b *banlist) ban(ban Ban) error {
+	key, err := banKey(ban)
+	if err != nil {
+		return err
+	}
+	p := wrappers.Packer{MaxSize: wrappers.LongLen}
+	p.PackLong(uint64(ban.Expiry.Unix()))
+	if err := b.db.Put(key, p.Bytes); err != nil {
+		return err
+	}
+	b.add(ban)
+	return nil
+}
+
+// unban [ban.NodeID] or [ban.IP]
+func (b *banlist) unban(ban Ban) error {
+	key, err := banKey(ban)
+	if err != nil {
+		return err
+	}
+	if ban.IP == nil {
+		if _, ok := b.nodeIDs[ban.NodeID]; !ok {
+			return fmt.Errorf("%s is %w", ban.NodeID.PrefixedString(constants.NodeIDPrefix), errNotBanned)
+		}
+		delete(b.nodeIDs, ban.NodeID)
+	} else {
+		if _, ok := b.ips[ban.IP.String()]; !ok {
+			return fmt.Errorf("%s is %w", ban.IP, errNotBanned)
+		}
+		delete(b.ips, ban.IP.String())
+	}
+	return b.db.Delete(key)
+}
+
+// nodeIDBanned returns true if [nodeID] is banned at [now]
+func (b *banlist) nodeIDBanned(nodeID ids.ShortID, now time.Time) bool {
+	expiry, ok := b.nodeIDs[nodeID]
+	return ok && expiry.After(now)
+}
+
+// ipBanned returns true if [ip] is banned at [now]
+func (b *banlist) ipBanned(ip net.IP, now time.Time) bool {
+	expiry, ok := b.ips[ip.String()]
+	return ok && expiry.After(now)
+}
+
+// list the bans that haven't expired at [now]
+func (b *banlist) list(now time.Time) []Ban {
+	bans := make([]Ban, 0, len(b.nodeIDs)+len(b.ips))
+	for nodeID, expiry := range b.nodeIDs {
+		if expiry.After(now) {
+			bans = append(bans, Ban{
+				NodeID: nodeID,
+				Expiry: expiry,
+			})
+		}
+	}
+	for ipStr, expiry := range b.ips {
+		if expiry.After(now) {
+			bans = append(bans, Ban{
+				IP:     net.ParseIP(ipStr),
+				Expiry: expiry,
+			})
+		}
+	}
+	return bans
+}
+
+func (b *banlist) add(ban Ban) {
+	if ban.IP == nil {
+		b.nodeIDs[ban.NodeID] = ban.Expiry
+	} else {
+		b.ips[ban.IP.String()] = ban.Expiry
+	}
+}
+
+// banKey returns the database key of [ban]
+func banKey(ban Ban) ([]byte, error) {
+	if ban.IP == nil {
+		return append([]byte{nodeIDBanPrefix}, ban.NodeID[:]...), nil
+	}
+	ip := ban.IP.To16()
+	if ip == nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidBannedIP, ban.IP)
+	}
+	return append([]byte{ipBanPrefix}, ip...), nil
+}
+
+// parseBan returns the ban stored at [key] with [value]
+func parseBan(key, value []byte) (Ban, error) {
+	p := wrappers.Packer{Bytes: value}
+	ban := Ban{Expiry: time.Unix(int64(p.UnpackLong()), 0)}
+	if p.Errored() || len(key) == 0 {
+		return Ban{}, errMalformedBanInDB
+	}
+
+	switch key[0] {
+	case nodeIDBanPrefix:
+		nodeID, err := ids.ToShortID(key[1:])
+		if err != nil {
+			return Ban{}, fmt.Errorf("%w: %s", errMalformedBanInDB, err)
+		}
+		ban.NodeID = nodeID
+	case ipBanPrefix:
+		if len(key[1:]) != net.IPv6len {
+			return Ban{}, errMalformedBanInDB
+		}
+		ban.IP = net.IP(append([]byte(nil), key[1:]...))
+	default:
+		return Ban{}, errMalformedBanInDB
+	}
+	return ban, nil
+}
+
+// Disconnect from [nodeID]. It may be reconnected to later, unless it's banned.
+// assumes the stateLock is not held.
+func (n *network) Disconnect(nodeID ids.ShortID) error {
+	n.stateLock.RLock()
+	peer, ok := n.peers[nodeID]
+	n.stateLock.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%s is %w", nodeID.PrefixedString(constants.NodeIDPrefix), errNotConnected)
+	}
+	n.log.Info("disconnecting from %s", nodeID.PrefixedString(constants.NodeIDPrefix))
+	peer.Close() // Grabs the stateLock
+	return nil
+}
+
+// BanNodeID bans [nodeID] for [duration] and disconnects from it.
+// assumes the stateLock is not held.
+func (n *network) BanNodeID(nodeID ids.ShortID, duration time.Duration) error {
+	if duration <= 0 {
+		return errNonPositiveBan
+	}
+
+	n.stateLock.Lock()
+	err := n.banlist.ban(Ban{
+		NodeID: nodeID,
+		Expiry: n.clock.Time().Add(duration),
+	})
+	peer := n.peers[nodeID]
+	n.stateLock.Unlock()
+
+	if err != nil {
+		return err
+	}
+	n.log.Info("banned %s for %s", nodeID.PrefixedString(constants.NodeIDPrefix), duration)
+	if peer != nil {
+		peer.Close() // Grabs the stateLock
+	}
+	return nil
+}
+
+// BanIP bans [ip], on any port, for [duration]. Attempts to connect to it are
+// abandoned, and peers connected at it are disconnected from.
+// assumes the stateLock is not held.
+func (n *network) BanIP(ip net.IP, duration time.Duration) error {
+	if duration <= 0 {
+		return errNonPositiveBan
+	}
+
+	n.stateLock.Lock()
+	err := n.banlist.ban(Ban{
+		IP:     ip,
+		Expiry: n.clock.Time().Add(duration),
+	})
+	if err != nil {
+		n.stateLock.Unlock()
+		return err
+	}
+	for str := range n.disconnectedIPs {
+		if trackedIP, err := utils.ToIPDesc(str); err == nil && trackedIP.IP.Equal(ip) {
+			delete(n.disconnectedIPs, str)
+			delete(n.retryDelay, str)
+		}
+	}
+	peersToClose := []*peer(nil)
+	for _, peer := range n.peers {
+		if peer.getIP().IP.Equal(ip) || peer.remoteIP().Equal(ip) {
+			peersToClose = append(peersToClose, peer)
+		}
+	}
+	n.stateLock.Unlock()
+
+	n.log.Info("banned %s for %s", ip, duration)
+	for _, peer := range peersToClose {
+		peer.Close() // Grabs the stateLock
+	}
+	return nil
+}
+
+// UnbanNodeID lifts the ban of [nodeID].
+// assumes the stateLock is not held.
+func (n *network) UnbanNodeID(nodeID ids.ShortID) error {
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	return n.banlist.unban(Ban{NodeID: nodeID})
+}
+
+// UnbanIP lifts the ban of [ip].
+// assumes the stateLock is not held.
+func (n *network) UnbanIP(ip net.IP) error {
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	return n.banlist.unban(Ban{IP: ip})
+}
+
+// Bans returns the NodeIDs and IPs that are banned.
+// assumes the stateLock is not held.
+func (n *network) Bans() []Ban {
+	n.stateLock.RLock()
+	defer n.stateLock.RUnlock()
+
+	return n.banlist.list(n.clock.Time())
+}
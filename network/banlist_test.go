@@ -0,0 +1,156 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+func TestBanlistPersistence(t *testing.T) {
+	db := memdb.New()
+	now := time.Unix(1000, 0)
+
+	b := newBanlist(db)
+	if _, err := b.load(now); err != nil {
+		t.Fatal(err)
+	}
+	nodeID := ids.ShortID{1}
+	ip := net.IPv4(1, 2, 3, 4)
+	expiredIP := net.IPv4(1, 2, 3, 5)
+	assert.NoError(t, b.ban(Ban{NodeID: nodeID, Expiry: now.Add(time.Hour)}))
+	assert.NoError(t, b.ban(Ban{IP: ip, Expiry: now.Add(time.Hour)}))
+	assert.NoError(t, b.ban(Ban{IP: expiredIP, Expiry: now.Add(time.Minute)}))
+	assert.True(t, b.nodeIDBanned(nodeID, now))
+	assert.True(t, b.ipBanned(ip, now))
+	assert.False(t, b.ipBanned(expiredIP, now.Add(time.Minute)), "bans should be lifted when they expire")
+
+	later := now.Add(2 * time.Minute)
+	b = newBanlist(db)
+	if _, err := b.load(later); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, b.nodeIDBanned(nodeID, later), "bans should be loaded from the database")
+	assert.True(t, b.ipBanned(ip, later), "bans should be loaded from the database")
+	assert.Len(t, b.list(later), 2)
+
+	key, err := banKey(Ban{IP: expiredIP})
+	if err != nil {
+		t.Fatal(err)
+	}
+	has, err := db.Has(key)
+	assert.NoError(t, err)
+	assert.False(t, has, "expired bans should be deleted when loaded")
+
+	assert.NoError(t, b.unban(Ban{IP: ip}))
+	err = b.unban(Ban{IP: ip})
+	assert.True(t, errors.Is(err, errNotBanned))
+
+	b = newBanlist(db)
+	if _, err := b.load(later); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []Ban{{NodeID: nodeID, Expiry: now.Add(time.Hour)}}, b.list(later))
+}
+
+func TestBanlistDeletesMalformedBans(t *testing.T) {
+	db := memdb.New()
+	now := time.Unix(1000, 0)
+
+	b := newBanlist(db)
+	if _, err := b.load(now); err != nil {
+		t.Fatal(err)
+	}
+	nodeID := ids.ShortID{1}
+	assert.NoError(t, b.ban(Ban{NodeID: nodeID, Expiry: now.Add(time.Hour)}))
+
+	expiry := []byte{0, 0, 0, 0, 0, 0, 0x13, 0x88}
+	malformedKeys := [][]byte{
+		{ipBanPrefix, 1, 2, 3, 4},          // IP of the wrong length
+		{nodeIDBanPrefix, 1},               // NodeID of the wrong length
+		append([]byte{0xff}, nodeID[:]...), // unknown prefix
+	}
+	for _, key := range malformedKeys {
+		assert.NoError(t, db.Put(key, expiry))
+	}
+	malformedValueKey := append([]byte{nodeIDBanPrefix}, ids.ShortID{2}.Bytes()...)
+	assert.NoError(t, db.Put(malformedValueKey, []byte{1}))
+	malformedKeys = append(malformedKeys, malformedValueKey)
+
+	b = newBanlist(db)
+	numMalformed, err := b.load(now)
+	assert.NoError(t, err)
+	assert.Equal(t, len(malformedKeys), numMalformed)
+	assert.Equal(t, []Ban{{NodeID: nodeID, Expiry: now.Add(time.Hour)}}, b.list(now), "well formed bans should still be loaded")
+
+	for _, key := range malformedKeys {
+		has, err := db.Has(key)
+		assert.NoError(t, err)
+		assert.False(t, has, "malformed bans should be deleted when loaded")
+	}
+}
+
+func TestBanNodeID(t *testing.T) {
+	n := newAllowlistTestNetwork(AllowlistConfig{})
+	defer n.stopDialing()
+	now := time.Unix(1000, 0)
+	n.clock.Set(now)
+
+	nodeID := ids.ShortID{1}
+	err := n.BanNodeID(nodeID, 0)
+	assert.True(t, errors.Is(err, errNonPositiveBan))
+
+	assert.NoError(t, n.BanNodeID(nodeID, time.Hour))
+	assert.Equal(t, []Ban{{NodeID: nodeID, Expiry: now.Add(time.Hour)}}, n.Bans())
+
+	ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	n.Track(ip)
+	err = n.tryAddPeer(&peer{net: n, id: nodeID, ip: ip})
+	assert.True(t, errors.Is(err, errBanned), "banned peers should be rejected")
+	assert.False(t, n.tracking(ip), "the IP of a banned peer shouldn't be dialed again")
+	assert.Empty(t, n.peers)
+
+	err = n.Disconnect(nodeID)
+	assert.True(t, errors.Is(err, errNotConnected))
+
+	assert.NoError(t, n.UnbanNodeID(nodeID))
+	err = n.UnbanNodeID(nodeID)
+	assert.True(t, errors.Is(err, errNotBanned))
+	assert.Empty(t, n.Bans())
+}
+
+func TestBanIP(t *testing.T) {
+	n := newAllowlistTestNetwork(AllowlistConfig{})
+	defer n.stopDialing()
+	now := time.Unix(1000, 0)
+	n.clock.Set(now)
+
+	ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	n.Track(ip)
+	assert.True(t, n.tracking(ip))
+
+	assert.NoError(t, n.BanIP(ip.IP, time.Hour))
+	assert.False(t, n.tracking(ip), "banned IPs shouldn't be dialed")
+
+	otherPort := utils.IPDesc{IP: ip.IP, Port: 9652}
+	n.Track(otherPort)
+	assert.False(t, n.tracking(otherPort), "IPs should be banned on every port")
+
+	upgrade, err := n.upgradeIncoming(otherPort.String())
+	assert.True(t, errors.Is(err, errBanned), "connections from banned IPs should be dropped")
+	assert.False(t, upgrade)
+
+	n.clock.Set(now.Add(time.Hour))
+	n.Track(ip)
+	assert.True(t, n.tracking(ip), "IPs should be dialed once their ban expires")
+	assert.Empty(t, n.Bans())
+}
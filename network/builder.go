@@ -14,15 +14,27 @@ type Builder struct{ Codec }
 // GetVersion message
 func (m Builder) GetVersion() (Msg, error) { return m.Pack(GetVersion, nil) }
 
-// Version message
-func (m Builder) Version(networkID, nodeID uint32, myTime uint64, ip utils.IPDesc, myVersion string) (Msg, error) {
-	return m.Pack(Version, map[Field]interface{}{
+// Version message. [compressions] is the set of compression algorithms the
+// sender can decompress, and [features] is the set of features it supports.
+// If both are empty, the message can be parsed by peers that don't support
+// compression. If [features] is empty, it can be parsed by peers that don't
+// support feature negotiation.
+func (m Builder) Version(networkID, nodeID uint32, myTime uint64, ip utils.IPDesc, myVersion string, compressions byte, features uint64) (Msg, error) {
+	fields := map[Field]interface{}{
 		NetworkID:  networkID,
 		NodeID:     nodeID,
 		MyTime:     myTime,
 		IP:         ip,
 		VersionStr: myVersion,
-	})
+	}
+	// Optional fields are trailing, so [compressions] is sent if [features] is
+	if compressions != 0 || features != 0 {
+		fields[Compressions] = compressions
+	}
+	if features != 0 {
+		fields[Features] = features
+	}
+	return m.Pack(Version, fields)
 }
 
 // GetPeerList message
@@ -33,11 +45,39 @@ func (m Builder) PeerList(ipDescs []utils.IPDesc) (Msg, error) {
 	return m.Pack(PeerList, map[Field]interface{}{Peers: ipDescs})
 }
 
-// Ping message
-func (m Builder) Ping() (Msg, error) { return m.Pack(Ping, nil) }
+// SignedPeerList message. It is only sent to peers that support signed IP
+// claims.
+func (m Builder) SignedPeerList(claims []IPClaim) (Msg, error) {
+	return m.Pack(SignedPeerList, map[Field]interface{}{SignedPeers: claims})
+}
+
+// SignedIP message. [sig] is the sender's signature of its claim that it could
+// be reached at [ip] at [myTime].
+func (m Builder) SignedIP(myTime uint64, ip utils.IPDesc, sig []byte) (Msg, error) {
+	return m.Pack(SignedIP, map[Field]interface{}{
+		MyTime:   myTime,
+		IP:       ip,
+		SigBytes: sig,
+	})
+}
+
+// Ping message. If [nonce] is 0, the message can be parsed by peers that don't
+// measure round trip times.
+func (m Builder) Ping(nonce uint32) (Msg, error) {
+	if nonce == 0 {
+		return m.Pack(Ping, nil)
+	}
+	return m.Pack(Ping, map[Field]interface{}{Nonce: nonce})
+}
 
-// Pong message
-func (m Builder) Pong() (Msg, error) { return m.Pack(Pong, nil) }
+// Pong message. [nonce] is the nonce of the Ping being responded to, or 0 if it
+// didn't have one.
+func (m Builder) Pong(nonce uint32) (Msg, error) {
+	if nonce == 0 {
+		return m.Pack(Pong, nil)
+	}
+	return m.Pack(Pong, map[Field]interface{}{Nonce: nonce})
+}
 
 // GetAcceptedFrontier message
 func (m Builder) GetAcceptedFrontier(chainID ids.ID, requestID uint32, deadline uint64) (Msg, error) {
@@ -164,3 +204,30 @@ func (m Builder) Chits(chainID ids.ID, requestID uint32, containerIDs []ids.ID)
 		ContainerIDs: containerIDBytes,
 	})
 }
+
+// AppRequest message
+func (m Builder) AppRequest(chainID ids.ID, requestID uint32, deadline uint64, appBytes []byte) (Msg, error) {
+	return m.Pack(AppRequest, map[Field]interface{}{
+		ChainID:   chainID[:],
+		RequestID: requestID,
+		Deadline:  deadline,
+		AppBytes:  appBytes,
+	})
+}
+
+// AppResponse message
+func (m Builder) AppResponse(chainID ids.ID, requestID uint32, appBytes []byte) (Msg, error) {
+	return m.Pack(AppResponse, map[Field]interface{}{
+		ChainID:   chainID[:],
+		RequestID: requestID,
+		AppBytes:  appBytes,
+	})
+}
+
+// AppGossip message
+func (m Builder) AppGossip(chainID ids.ID, appBytes []byte) (Msg, error) {
+	return m.Pack(AppGossip, map[Field]interface{}{
+		ChainID:  chainID[:],
+		AppBytes: appBytes,
+	})
+}
@@ -45,6 +45,8 @@ func TestBuildVersion(t *testing.T) {
 		myTime,
 		ip,
 		myVersion,
+		0,
+		0,
 	)
 	assert.NoError(t, err)
 	assert.NotNil(t, msg)
@@ -64,6 +66,48 @@ func TestBuildVersion(t *testing.T) {
 	assert.Equal(t, myTime, parsedMsg.Get(MyTime))
 	assert.Equal(t, ip, parsedMsg.Get(IP))
 	assert.Equal(t, myVersion, parsedMsg.Get(VersionStr))
+	assert.Nil(t, parsedMsg.Get(Compressions))
+	assert.Nil(t, parsedMsg.Get(Features))
+}
+
+func TestBuildVersionWithCompressions(t *testing.T) {
+	ip := utils.IPDesc{
+		IP:   net.IPv6loopback,
+		Port: 12345,
+	}
+	compressions := byte(supportedCompressions)
+
+	msg, err := TestBuilder.Version(1, 3, 2, ip, "xD", compressions, 0)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, compressions, msg.Get(Compressions))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, Version, parsedMsg.Op())
+	assert.Equal(t, "xD", parsedMsg.Get(VersionStr))
+	assert.Equal(t, compressions, parsedMsg.Get(Compressions))
+	assert.Nil(t, parsedMsg.Get(Features))
+}
+
+func TestBuildVersionWithFeatures(t *testing.T) {
+	ip := utils.IPDesc{
+		IP:   net.IPv6loopback,
+		Port: 12345,
+	}
+	features := uint64(0x5)
+
+	msg, err := TestBuilder.Version(1, 3, 2, ip, "xD", 0, features)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, Version, parsedMsg.Op())
+	assert.Equal(t, byte(0), parsedMsg.Get(Compressions), "compressions should be sent so that features can follow them")
+	assert.Equal(t, features, parsedMsg.Get(Features))
 }
 
 func TestBuildGetPeerList(t *testing.T) {
@@ -97,6 +141,89 @@ func TestBuildPeerList(t *testing.T) {
 	assert.Equal(t, ips, parsedMsg.Get(Peers))
 }
 
+func TestBuildSignedPeerList(t *testing.T) {
+	claims := []IPClaim{
+		{
+			Cert:      []byte{1, 2, 3},
+			IP:        utils.IPDesc{IP: net.IPv6loopback, Port: 12345},
+			Timestamp: 5,
+			Signature: []byte{4, 5},
+		},
+		{
+			Cert:      []byte{6},
+			IP:        utils.IPDesc{IP: net.IPv6loopback, Port: 54321},
+			Timestamp: 6,
+			Signature: []byte{7, 8, 9},
+		},
+	}
+
+	msg, err := TestBuilder.SignedPeerList(claims)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, SignedPeerList, msg.Op())
+	assert.Equal(t, claims, msg.Get(SignedPeers))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, SignedPeerList, parsedMsg.Op())
+	assert.Nil(t, parsedMsg.Get(Peers))
+	assert.Equal(t, claims, parsedMsg.Get(SignedPeers))
+
+	_, err = TestBuilder.SignedPeerList(make([]IPClaim, maxSignedPeers+1))
+	assert.Error(t, err)
+}
+
+func TestBuildSignedIP(t *testing.T) {
+	myTime := uint64(1234)
+	ip := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 5678}
+	sig := []byte{1, 2, 3}
+
+	msg, err := TestBuilder.SignedIP(myTime, ip, sig)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, SignedIP, msg.Op())
+	assert.Equal(t, myTime, msg.Get(MyTime))
+	assert.Equal(t, ip, msg.Get(IP))
+	assert.Equal(t, sig, msg.Get(SigBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, SignedIP, parsedMsg.Op())
+	assert.Equal(t, myTime, parsedMsg.Get(MyTime))
+	assert.Equal(t, ip.String(), parsedMsg.Get(IP).(utils.IPDesc).String())
+	assert.Equal(t, sig, parsedMsg.Get(SigBytes))
+}
+
+func TestBuildPingPong(t *testing.T) {
+	for _, op := range []Op{Ping, Pong} {
+		build := TestBuilder.Ping
+		if op == Pong {
+			build = TestBuilder.Pong
+		}
+
+		msg, err := build(0)
+		assert.NoError(t, err)
+		assert.Equal(t, op, msg.Op())
+		assert.Equal(t, []byte{byte(op)}, msg.Bytes(), "messages without a nonce should be parsable by old peers")
+
+		parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+		assert.NoError(t, err)
+		assert.Equal(t, op, parsedMsg.Op())
+		assert.Nil(t, parsedMsg.Get(Nonce))
+
+		msg, err = build(7)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(7), msg.Get(Nonce))
+
+		parsedMsg, err = TestBuilder.Parse(msg.Bytes())
+		assert.NoError(t, err)
+		assert.Equal(t, op, parsedMsg.Op())
+		assert.Equal(t, uint32(7), parsedMsg.Get(Nonce))
+	}
+}
+
 func TestBuildGetAcceptedFrontier(t *testing.T) {
 	chainID := ids.Empty.Prefix(0)
 	requestID := uint32(5)
@@ -316,3 +443,69 @@ func TestBuildChits(t *testing.T) {
 	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
 	assert.Equal(t, containerIDs, parsedMsg.Get(ContainerIDs))
 }
+
+func TestBuildAppRequest(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	deadline := uint64(15)
+	appBytes := []byte("request")
+
+	msg, err := TestBuilder.AppRequest(chainID, requestID, deadline, appBytes)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, AppRequest, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, deadline, msg.Get(Deadline))
+	assert.Equal(t, appBytes, msg.Get(AppBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, AppRequest, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, deadline, parsedMsg.Get(Deadline))
+	assert.Equal(t, appBytes, parsedMsg.Get(AppBytes))
+}
+
+func TestBuildAppResponse(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	appBytes := []byte("response")
+
+	msg, err := TestBuilder.AppResponse(chainID, requestID, appBytes)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, AppResponse, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, appBytes, msg.Get(AppBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, AppResponse, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, appBytes, parsedMsg.Get(AppBytes))
+}
+
+func TestBuildAppGossip(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	appBytes := []byte("gossip")
+
+	msg, err := TestBuilder.AppGossip(chainID, appBytes)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, AppGossip, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, appBytes, msg.Get(AppBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, AppGossip, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, appBytes, parsedMsg.Get(AppBytes))
+}
@@ -12,8 +12,9 @@ import (
 )
 
 var (
-	errMissingField = errors.New("message missing field")
-	errBadOp        = errors.New("input field has invalid operation")
+	errMissingField    = errors.New("message missing field")
+	errBadOp           = errors.New("input field has invalid operation")
+	errNotCompressible = errors.New("message can't be compressed")
 )
 
 // Codec defines the serialization and deserialization of network messages
@@ -36,6 +37,14 @@ func (Codec) Pack(op Op, fields map[Field]interface{}) (Msg, error) {
 		}
 		field.Packer()(&p, data)
 	}
+	// Optional fields are trailing, so they are packed until one is missing
+	for _, field := range OptionalFields[op] {
+		data, ok := fields[field]
+		if !ok {
+			break
+		}
+		field.Packer()(&p, data)
+	}
 
 	return &msg{
 		op:     op,
@@ -58,10 +67,19 @@ func (Codec) Parse(b []byte) (Msg, error) {
 	for _, field := range message {
 		fields[field] = field.Unpacker()(&p)
 	}
+	for _, field := range OptionalFields[op] {
+		if p.Errored() || p.Offset == len(b) {
+			break
+		}
+		fields[field] = field.Unpacker()(&p)
+	}
 
 	if p.Offset != len(b) {
 		p.Add(fmt.Errorf("expected length %d got %d", len(b), p.Offset))
 	}
+	if !p.Errored() {
+		p.Add(decompressFields(fields))
+	}
 
 	return &msg{
 		op:     op,
@@ -69,3 +87,99 @@ func (Codec) Parse(b []byte) (Msg, error) {
 		bytes:  b,
 	}, p.Err
 }
+
+// decompressFields replaces the container bytes of [fields] with their
+// decompressed value, if they were compressed. At most DefaultMaxMessageSize
+// bytes are decompressed in total.
+func decompressFields(fields map[Field]interface{}) error {
+	compressed, ok := fields[Compressed]
+	if !ok {
+		return nil
+	}
+	compression := Compression(compressed.(byte))
+	if compression == NoCompression {
+		return nil
+	}
+
+	remaining := int(DefaultMaxMessageSize)
+	if container, ok := fields[ContainerBytes]; ok {
+		decompressed, err := decompress(compression, container.([]byte), remaining)
+		if err != nil {
+			return fmt.Errorf("couldn't decompress container: %w", err)
+		}
+		fields[ContainerBytes] = decompressed
+		return nil
+	}
+	if containers, ok := fields[MultiContainerBytes]; ok {
+		compressedContainers := containers.([][]byte)
+		decompressed := make([][]byte, len(compressedContainers))
+		for i, container := range compressedContainers {
+			decompressedContainer, err := decompress(compression, container, remaining)
+			if err != nil {
+				return fmt.Errorf("couldn't decompress container %d: %w", i, err)
+			}
+			remaining -= len(decompressedContainer)
+			decompressed[i] = decompressedContainer
+		}
+		fields[MultiContainerBytes] = decompressed
+		return nil
+	}
+	return errNotCompressible
+}
+
+// Compress returns [m] with its container bytes compressed with [compression].
+// If compressing wouldn't make [m] smaller, [m] is returned unchanged.
+func (c Codec) Compress(m Msg, compression Compression) (Msg, error) {
+	op := m.Op()
+	if !Compressible(op) {
+		return nil, errNotCompressible
+	}
+	if compression == NoCompression || len(m.Bytes()) < minCompressibleSize {
+		return m, nil
+	}
+
+	// The compressed message has the same fields as [m] when it is read by this
+	// node, but is written with compressed container bytes
+	fields := make(map[Field]interface{}, len(Messages[op])+1)
+	for _, field := range Messages[op] {
+		fields[field] = m.Get(field)
+	}
+	fields[Compressed] = byte(compression)
+	compressedFields := make(map[Field]interface{}, len(fields))
+	for field, value := range fields {
+		compressedFields[field] = value
+	}
+
+	switch op {
+	case MultiPut:
+		containers := m.Get(MultiContainerBytes).([][]byte)
+		compressedContainers := make([][]byte, len(containers))
+		for i, container := range containers {
+			compressedContainer, err := compress(compression, container)
+			if err != nil {
+				return nil, err
+			}
+			compressedContainers[i] = compressedContainer
+		}
+		compressedFields[MultiContainerBytes] = compressedContainers
+	default:
+		compressedContainer, err := compress(compression, m.Get(ContainerBytes).([]byte))
+		if err != nil {
+			return nil, err
+		}
+		compressedFields[ContainerBytes] = compressedContainer
+	}
+
+	compressedMsg, err := c.Pack(op, compressedFields)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressedMsg.Bytes()) >= len(m.Bytes()) {
+		return m, nil
+	}
+	return &msg{
+		op:     op,
+		fields: fields,
+		bytes:  compressedMsg.Bytes(),
+	}, nil
+}
@@ -25,6 +25,13 @@ const (
 	ContainerBytes                   // Used for gossiping
 	ContainerIDs                     // Used for querying
 	MultiContainerBytes              // Used in MultiPut
+	Compressions                     // Used in handshake
+	Compressed                       // Used for gossiping and MultiPut
+	AppBytes                         // Used for application messages
+	SigBytes                         // Used in handshake
+	SignedPeers                      // Used in handshake
+	Nonce                            // Used for measuring round trip times
+	Features                         // Used in handshake
 )
 
 // Packer returns the packer function that can be used to pack this field.
@@ -56,6 +63,20 @@ func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 		return wrappers.TryPackHashes
 	case MultiContainerBytes:
 		return wrappers.TryPack2DBytes
+	case Compressions:
+		return wrappers.TryPackByte
+	case Compressed:
+		return wrappers.TryPackByte
+	case AppBytes:
+		return wrappers.TryPackBytes
+	case SigBytes:
+		return wrappers.TryPackBytes
+	case SignedPeers:
+		return tryPackSignedPeers
+	case Nonce:
+		return wrappers.TryPackInt
+	case Features:
+		return wrappers.TryPackLong
 	default:
 		return nil
 	}
@@ -90,6 +111,20 @@ func (f Field) Unpacker() func(*wrappers.Packer) interface{} {
 		return wrappers.TryUnpackHashes
 	case MultiContainerBytes:
 		return wrappers.TryUnpack2DBytes
+	case Compressions:
+		return wrappers.TryUnpackByte
+	case Compressed:
+		return wrappers.TryUnpackByte
+	case AppBytes:
+		return wrappers.TryUnpackBytes
+	case SigBytes:
+		return wrappers.TryUnpackBytes
+	case SignedPeers:
+		return tryUnpackSignedPeers
+	case Nonce:
+		return wrappers.TryUnpackInt
+	case Features:
+		return wrappers.TryUnpackLong
 	default:
 		return nil
 	}
@@ -123,6 +158,20 @@ func (f Field) String() string {
 		return "Container IDs"
 	case MultiContainerBytes:
 		return "MultiContainerBytes"
+	case Compressions:
+		return "Compressions"
+	case Compressed:
+		return "Compressed"
+	case AppBytes:
+		return "AppBytes"
+	case SigBytes:
+		return "SigBytes"
+	case SignedPeers:
+		return "SignedPeers"
+	case Nonce:
+		return "Nonce"
+	case Features:
+		return "Features"
 	default:
 		return "Unknown Field"
 	}
@@ -167,6 +216,16 @@ func (op Op) String() string {
 		return "pull_query"
 	case Chits:
 		return "chits"
+	case AppRequest:
+		return "app_request"
+	case AppResponse:
+		return "app_response"
+	case AppGossip:
+		return "app_gossip"
+	case SignedIP:
+		return "signed_ip"
+	case SignedPeerList:
+		return "signed_peerlist"
 	default:
 		return "Unknown Op"
 	}
@@ -194,6 +253,13 @@ const (
 	PushQuery
 	PullQuery
 	Chits
+	// Application:
+	AppRequest
+	AppResponse
+	AppGossip
+	// Handshake:
+	SignedIP
+	SignedPeerList
 )
 
 // Defines the messages that can be sent/received with this network
@@ -219,5 +285,27 @@ var (
 		PushQuery: {ChainID, RequestID, Deadline, ContainerID, ContainerBytes},
 		PullQuery: {ChainID, RequestID, Deadline, ContainerID},
 		Chits:     {ChainID, RequestID, ContainerIDs},
+		// Application:
+		AppRequest:  {ChainID, RequestID, Deadline, AppBytes},
+		AppResponse: {ChainID, RequestID, AppBytes},
+		AppGossip:   {ChainID, AppBytes},
+		// Handshake:
+		SignedIP:       {MyTime, IP, SigBytes},
+		SignedPeerList: {SignedPeers},
+	}
+
+	// OptionalFields are the fields that may follow the fields in Messages.
+	// Peers that don't know about them never receive them, so a message without
+	// them must remain valid.
+	OptionalFields = map[Op][]Field{
+		// Handshake:
+		Version: {Compressions, Features},
+		Ping:    {Nonce},
+		Pong:    {Nonce},
+		// Bootstrapping:
+		MultiPut: {Compressed},
+		// Consensus:
+		Put:       {Compressed},
+		PushQuery: {Compressed},
 	}
 )
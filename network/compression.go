@@ -0,0 +1,139 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	// Messages smaller than this aren't worth compressing
+	minCompressibleSize = 1024
+
+	// Every algorithm this node can decompress
+	supportedCompressions = 1<<GzipCompression | 1<<FlateCompression
+)
+
+var (
+	errUnknownCompression = errors.New("unknown compression")
+	errDecompressedTooBig = errors.New("decompressed bytes are too large")
+
+	// Ops whose container bytes may be compressed
+	compressibleOps = map[Op]struct{}{
+		Put:       {},
+		MultiPut:  {},
+		PushQuery: {},
+	}
+)
+
+// Compression is an algorithm that the container bytes of a message may be
+// compressed with
+type Compression byte
+
+// Compression algorithms. These values are sent over the wire.
+const (
+	NoCompression Compression = iota
+	GzipCompression
+	FlateCompression
+)
+
+// ParseCompression returns the compression algorithm named [s]
+func ParseCompression(s string) (Compression, error) {
+	switch s {
+	case "none":
+		return NoCompression, nil
+	case "gzip":
+		return GzipCompression, nil
+	case "flate":
+		return FlateCompression, nil
+	default:
+		return NoCompression, fmt.Errorf("%w: %q", errUnknownCompression, s)
+	}
+}
+
+func (c Compression) String() string {
+	switch c {
+	case NoCompression:
+		return "none"
+	case GzipCompression:
+		return "gzip"
+	case FlateCompression:
+		return "flate"
+	default:
+		return "unknown"
+	}
+}
+
+// In returns true if [c] is in the set [compressions], which has bit i set if
+// Compression i is in the set. Every set contains NoCompression.
+func (c Compression) In(compressions byte) bool {
+	return c == NoCompression || (c < 8 && compressions&(1<<c) != 0)
+}
+
+// Compressible returns true if the container bytes of [op] messages may be
+// compressed
+func Compressible(op Op) bool {
+	_, ok := compressibleOps[op]
+	return ok
+}
+
+// compress [b] with [c]
+func compress(c Compression, b []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	var w io.WriteCloser
+	switch c {
+	case GzipCompression:
+		w = gzip.NewWriter(buf)
+	case FlateCompression:
+		fw, err := flate.NewWriter(buf, flate.DefaultCompression)
+		if err != nil {
+			return nil, err
+		}
+		w = fw
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnknownCompression, c)
+	}
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress [b], which was compressed with [c]. Errors if the result would be
+// longer than [maxSize] bytes, so that a small message can't decompress into an
+// arbitrarily large one.
+func decompress(c Compression, b []byte, maxSize int) ([]byte, error) {
+	var r io.ReadCloser
+	switch c {
+	case GzipCompression:
+		gr, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		r = gr
+	case FlateCompression:
+		r = flate.NewReader(bytes.NewReader(b))
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnknownCompression, c)
+	}
+	defer r.Close()
+
+	decompressed, err := ioutil.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(decompressed) > maxSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", errDecompressedTooBig, maxSize)
+	}
+	return decompressed, nil
+}
@@ -0,0 +1,164 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestParseCompression(t *testing.T) {
+	for _, compression := range []Compression{NoCompression, GzipCompression, FlateCompression} {
+		parsed, err := ParseCompression(compression.String())
+		assert.NoError(t, err)
+		assert.Equal(t, compression, parsed)
+	}
+	_, err := ParseCompression("zstd")
+	assert.Error(t, err)
+}
+
+func TestCompressionIn(t *testing.T) {
+	assert.True(t, NoCompression.In(0))
+	assert.False(t, GzipCompression.In(0))
+	assert.True(t, GzipCompression.In(supportedCompressions))
+	assert.True(t, FlateCompression.In(supportedCompressions))
+	assert.False(t, FlateCompression.In(1<<GzipCompression))
+}
+
+func TestCompressPut(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	containerID := ids.Empty.Prefix(1)
+	container := bytes.Repeat([]byte{1, 2, 3, 4}, 1024)
+
+	for _, compression := range []Compression{GzipCompression, FlateCompression} {
+		msg, err := TestBuilder.Put(chainID, 5, containerID, container)
+		assert.NoError(t, err)
+
+		compressedMsg, err := TestBuilder.Compress(msg, compression)
+		assert.NoError(t, err)
+		assert.Less(t, len(compressedMsg.Bytes()), len(msg.Bytes()))
+		assert.Equal(t, byte(compression), compressedMsg.Get(Compressed))
+		assert.Equal(t, container, compressedMsg.Get(ContainerBytes))
+
+		parsedMsg, err := TestBuilder.Parse(compressedMsg.Bytes())
+		assert.NoError(t, err)
+		assert.Equal(t, Put, parsedMsg.Op())
+		assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+		assert.Equal(t, uint32(5), parsedMsg.Get(RequestID))
+		assert.Equal(t, containerID[:], parsedMsg.Get(ContainerID))
+		assert.Equal(t, container, parsedMsg.Get(ContainerBytes))
+	}
+}
+
+func TestCompressPushQuery(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	containerID := ids.Empty.Prefix(1)
+	container := bytes.Repeat([]byte{1, 2, 3, 4}, 1024)
+
+	msg, err := TestBuilder.PushQuery(chainID, 5, 15, containerID, container)
+	assert.NoError(t, err)
+
+	compressedMsg, err := TestBuilder.Compress(msg, GzipCompression)
+	assert.NoError(t, err)
+	assert.Less(t, len(compressedMsg.Bytes()), len(msg.Bytes()))
+
+	parsedMsg, err := TestBuilder.Parse(compressedMsg.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, PushQuery, parsedMsg.Op())
+	assert.Equal(t, uint64(15), parsedMsg.Get(Deadline))
+	assert.Equal(t, container, parsedMsg.Get(ContainerBytes))
+}
+
+func TestCompressMultiPut(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	containers := [][]byte{
+		bytes.Repeat([]byte{1}, 2048),
+		{},
+		bytes.Repeat([]byte{2, 3}, 2048),
+	}
+
+	msg, err := TestBuilder.MultiPut(chainID, 5, containers)
+	assert.NoError(t, err)
+
+	compressedMsg, err := TestBuilder.Compress(msg, FlateCompression)
+	assert.NoError(t, err)
+	assert.Less(t, len(compressedMsg.Bytes()), len(msg.Bytes()))
+
+	parsedMsg, err := TestBuilder.Parse(compressedMsg.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, MultiPut, parsedMsg.Op())
+	assert.Equal(t, containers, parsedMsg.Get(MultiContainerBytes))
+}
+
+// Compressing a message that is small, or that doesn't shrink, returns the
+// message unchanged so it can still be parsed by any peer
+func TestCompressUnchanged(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	containerID := ids.Empty.Prefix(1)
+
+	msg, err := TestBuilder.Put(chainID, 5, containerID, []byte{2})
+	assert.NoError(t, err)
+	compressedMsg, err := TestBuilder.Compress(msg, GzipCompression)
+	assert.NoError(t, err)
+	assert.Equal(t, msg, compressedMsg)
+
+	// Random bytes don't compress
+	random := ids.Empty.Prefix(2)
+	container := []byte{}
+	for len(container) < 2*minCompressibleSize {
+		random = random.Prefix(3)
+		container = append(container, random[:]...)
+	}
+	msg, err = TestBuilder.Put(chainID, 5, containerID, container)
+	assert.NoError(t, err)
+	compressedMsg, err = TestBuilder.Compress(msg, GzipCompression)
+	assert.NoError(t, err)
+	assert.Equal(t, msg, compressedMsg)
+
+	msg, err = TestBuilder.Get(chainID, 5, 15, containerID)
+	assert.NoError(t, err)
+	_, err = TestBuilder.Compress(msg, GzipCompression)
+	assert.Error(t, err)
+}
+
+func TestDecompressTooLarge(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	containerID := ids.Empty.Prefix(1)
+
+	compressedContainer, err := compress(GzipCompression, make([]byte, DefaultMaxMessageSize+1))
+	assert.NoError(t, err)
+	msg, err := TestBuilder.Pack(Put, map[Field]interface{}{
+		ChainID:        chainID[:],
+		RequestID:      uint32(5),
+		ContainerID:    containerID[:],
+		ContainerBytes: compressedContainer,
+		Compressed:     byte(GzipCompression),
+	})
+	assert.NoError(t, err)
+
+	_, err = TestBuilder.Parse(msg.Bytes())
+	assert.True(t, errors.Is(err, errDecompressedTooBig))
+}
+
+func TestParseUnknownCompression(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	containerID := ids.Empty.Prefix(1)
+
+	msg, err := TestBuilder.Pack(Put, map[Field]interface{}{
+		ChainID:        chainID[:],
+		RequestID:      uint32(5),
+		ContainerID:    containerID[:],
+		ContainerBytes: []byte{2},
+		Compressed:     byte(FlateCompression + 1),
+	})
+	assert.NoError(t, err)
+
+	_, err = TestBuilder.Parse(msg.Bytes())
+	assert.True(t, errors.Is(err, errUnknownCompression))
+}
@@ -0,0 +1,139 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// maxSignedPeers is the maximum number of IP claims in a SignedPeerList
+	// message. Claims carry a certificate and a signature, so this keeps a
+	// SignedPeerList well under the maximum message size.
+	maxSignedPeers = 256
+
+	// maxCertLen is the maximum length of the certificate of a gossiped claim
+	maxCertLen = 16 * 1024
+
+	// maxSigLen is the maximum length of the signature of a claim
+	maxSigLen = 1024
+
+	// maxClaimAge is the age after which a claim is no longer accepted, so
+	// that a node's claim of an IP it has since left can't be replayed
+	// indefinitely. Nodes sign a new claim once theirs is half this old.
+	maxClaimAge = 24 * time.Hour
+
+	// unsignedClaimLen is the length of the bytes a claim's signature is over:
+	// an IP, a port and a timestamp
+	unsignedClaimLen = 16 + wrappers.ShortLen + wrappers.LongLen
+)
+
+var (
+	errBadClaimsType    = errors.New("wrong type passed as IP claims")
+	errUnsupportedKey   = errors.New("unsupported staking key type")
+	errTooManyClaims    = errors.New("too many IP claims")
+	errCertTooLong      = errors.New("certificate is too long")
+	errSignatureTooLong = errors.New("signature is too long")
+)
+
+// IPClaim is a node's claim, signed with its staking key, that it could be
+// reached at IP at Timestamp
+type IPClaim struct {
+	// Cert is the DER encoded staking certificate of the node that made the
+	// claim. It is only sent when the claim is gossiped, as the node that made
+	// the claim presents it during the TLS handshake.
+	Cert      []byte
+	IP        utils.IPDesc
+	Timestamp uint64
+	Signature []byte
+}
+
+// unsignedBytes returns the bytes that the claim's signature is over
+func (c *IPClaim) unsignedBytes() []byte {
+	p := wrappers.Packer{MaxSize: unsignedClaimLen}
+	p.PackIP(c.IP)
+	p.PackLong(c.Timestamp)
+	return p.Bytes
+}
+
+// sign the claim with [key]
+func (c *IPClaim) sign(key crypto.Signer) error {
+	sig, err := key.Sign(rand.Reader, hashing.ComputeHash256(c.unsignedBytes()), crypto.SHA256)
+	if err != nil {
+		return err
+	}
+	c.Signature = sig
+	return nil
+}
+
+// verify that the claim was signed with the key of [cert]
+func (c *IPClaim) verify(cert *x509.Certificate) error {
+	var algorithm x509.SignatureAlgorithm
+	switch cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		algorithm = x509.SHA256WithRSA
+	case *ecdsa.PublicKey:
+		algorithm = x509.ECDSAWithSHA256
+	default:
+		return fmt.Errorf("%w: %T", errUnsupportedKey, cert.PublicKey)
+	}
+	return cert.CheckSignature(algorithm, c.unsignedBytes(), c.Signature)
+}
+
+// tryPackSignedPeers attempts to pack the value as a list of gossiped IP claims
+func tryPackSignedPeers(p *wrappers.Packer, valIntf interface{}) {
+	claims, ok := valIntf.([]IPClaim)
+	if !ok {
+		p.Add(errBadClaimsType)
+		return
+	}
+	if len(claims) > maxSignedPeers {
+		p.Add(errTooManyClaims)
+		return
+	}
+	p.PackInt(uint32(len(claims)))
+	for _, claim := range claims {
+		p.PackBytes(claim.Cert)
+		p.PackIP(claim.IP)
+		p.PackLong(claim.Timestamp)
+		p.PackBytes(claim.Signature)
+	}
+}
+
+// tryUnpackSignedPeers attempts to unpack a list of gossiped IP claims
+func tryUnpackSignedPeers(p *wrappers.Packer) interface{} {
+	numClaims := p.UnpackInt()
+	if numClaims > maxSignedPeers {
+		p.Add(errTooManyClaims)
+		return []IPClaim(nil)
+	}
+	claims := []IPClaim(nil)
+	for i := uint32(0); i < numClaims && !p.Errored(); i++ {
+		claim := IPClaim{Cert: p.UnpackBytes()}
+		if len(claim.Cert) > maxCertLen {
+			p.Add(errCertTooLong)
+			break
+		}
+		claim.IP = p.UnpackIP()
+		claim.Timestamp = p.UnpackLong()
+		claim.Signature = p.UnpackBytes()
+		if len(claim.Signature) > maxSigLen {
+			p.Add(errSignatureTooLong)
+			break
+		}
+		claims = append(claims, claim)
+	}
+	return claims
+}
@@ -0,0 +1,182 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestCert(t *testing.T, key crypto.Signer) *x509.Certificate {
+	certTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(0),
+		NotBefore:             time.Date(2000, time.January, 0, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Now().AddDate(100, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, certTemplate, certTemplate, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestIPClaimSignVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []crypto.Signer{rsaKey, ecdsaKey} {
+		cert := newTestCert(t, key)
+		claim := IPClaim{
+			IP:        utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651},
+			Timestamp: 1000,
+		}
+		assert.NoError(t, claim.sign(key))
+		assert.NoError(t, claim.verify(cert))
+
+		movedClaim := claim
+		movedClaim.IP = utils.IPDesc{IP: net.IPv4(1, 2, 3, 5), Port: 9651}
+		assert.Error(t, movedClaim.verify(cert), "claim of a different IP should be invalid")
+
+		laterClaim := claim
+		laterClaim.Timestamp++
+		assert.Error(t, laterClaim.verify(cert), "claim at a different time should be invalid")
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim := IPClaim{
+		IP:        utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651},
+		Timestamp: 1000,
+	}
+	assert.NoError(t, claim.sign(otherKey))
+	assert.Error(t, claim.verify(newTestCert(t, rsaKey)), "claim signed by a different key should be invalid")
+}
+
+func TestTrackClaim(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := newTestCert(t, key)
+	nodeID := certToID(cert.Raw)
+
+	vdrs := validators.NewSet()
+	n := &network{
+		log:                logging.NoLog{},
+		id:                 ids.ShortEmpty,
+		ip:                 utils.NewDynamicIPDesc(net.IPv6loopback, 0),
+		vdrs:               vdrs,
+		maxClockDifference: time.Minute,
+		ipClaims:           make(map[ids.ShortID]uint64),
+	}
+	// Don't attempt to connect to the claimed IPs
+	n.closed.SetValue(true)
+	now := time.Now()
+	n.clock.Set(now)
+
+	newClaim := func(timestamp time.Time) *IPClaim {
+		claim := &IPClaim{
+			Cert:      cert.Raw,
+			IP:        utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651},
+			Timestamp: uint64(timestamp.Unix()),
+		}
+		if err := claim.sign(key); err != nil {
+			t.Fatal(err)
+		}
+		return claim
+	}
+
+	claim := newClaim(now)
+	n.trackClaim(claim)
+	assert.Zero(t, n.ipClaims[nodeID], "claims of non-validators should be ignored")
+
+	if err := vdrs.AddWeight(nodeID, 1); err != nil {
+		t.Fatal(err)
+	}
+	expiredClaim := newClaim(now.Add(-maxClaimAge - time.Second))
+	n.trackClaim(expiredClaim)
+	assert.Zero(t, n.ipClaims[nodeID], "expired claims should be ignored")
+
+	n.trackClaim(claim)
+	assert.Equal(t, claim.Timestamp, n.ipClaims[nodeID])
+
+	staleClaim := newClaim(now.Add(-time.Second))
+	n.trackClaim(staleClaim)
+	assert.Equal(t, claim.Timestamp, n.ipClaims[nodeID], "stale claims should be ignored")
+
+	futureClaim := newClaim(now.Add(2 * time.Minute))
+	n.trackClaim(futureClaim)
+	assert.Equal(t, claim.Timestamp, n.ipClaims[nodeID], "claims from the future should be ignored")
+
+	forgedClaim := newClaim(now.Add(time.Second))
+	forgedClaim.IP = utils.IPDesc{IP: net.IPv4(5, 6, 7, 8), Port: 9651}
+	n.trackClaim(forgedClaim)
+	assert.Equal(t, claim.Timestamp, n.ipClaims[nodeID], "claims with invalid signatures should be ignored")
+
+	newerClaim := newClaim(now.Add(time.Second))
+	n.trackClaim(newerClaim)
+	assert.Equal(t, newerClaim.Timestamp, n.ipClaims[nodeID])
+}
+
+func TestSignedIPRefresh(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := &network{
+		ip:     utils.NewDynamicIPDesc(net.IPv4(1, 2, 3, 4), 9651),
+		tlsKey: key,
+	}
+	now := time.Now()
+	n.clock.Set(now)
+
+	claim, err := n.signedIP()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(now.Unix()), claim.Timestamp)
+	assert.False(t, n.expired(claim))
+
+	n.clock.Set(now.Add(maxClaimAge / 4))
+	sameClaim, err := n.signedIP()
+	assert.NoError(t, err)
+	assert.Equal(t, claim, sameClaim, "recent claims should be reused")
+
+	later := now.Add(maxClaimAge/2 + time.Second)
+	n.clock.Set(later)
+	refreshedClaim, err := n.signedIP()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(later.Unix()), refreshedClaim.Timestamp, "claims should be refreshed before they expire")
+
+	n.clock.Set(now.Add(maxClaimAge + time.Second))
+	assert.True(t, n.expired(claim))
+	assert.False(t, n.expired(refreshedClaim))
+}
@@ -9,11 +9,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+const priorityLabel = "priority"
+
 type messageMetrics struct {
 	numSent, numFailed, numReceived prometheus.Counter
+
+	// Only set for compressible messages. Bytes of the messages sent to peers
+	// that support compression, before and after compression.
+	uncompressedBytes, compressedBytes prometheus.Counter
 }
 
 func (mm *messageMetrics) initialize(msgType Op, registerer prometheus.Registerer) error {
@@ -45,19 +52,59 @@ func (mm *messageMetrics) initialize(msgType Op, registerer prometheus.Registere
 		return fmt.Errorf("failed to register received statistics of %s due to %s",
 			msgType, err)
 	}
+
+	if !Compressible(msgType) {
+		return nil
+	}
+	mm.uncompressedBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_uncompressed_bytes", msgType),
+		Help:      fmt.Sprintf("Bytes of the %s messages sent to peers that support compression, before compression", msgType),
+	})
+	mm.compressedBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_compressed_bytes", msgType),
+		Help:      fmt.Sprintf("Bytes of the %s messages sent to peers that support compression, after compression", msgType),
+	})
+	if err := registerer.Register(mm.uncompressedBytes); err != nil {
+		return fmt.Errorf("failed to register uncompressed bytes statistics of %s due to %s",
+			msgType, err)
+	}
+	if err := registerer.Register(mm.compressedBytes); err != nil {
+		return fmt.Errorf("failed to register compressed bytes statistics of %s due to %s",
+			msgType, err)
+	}
 	return nil
 }
 
 type metrics struct {
 	numPeers prometheus.Gauge
 
+	// Bytes of the compressible messages sent to peers that support
+	// compression, before and after compression. Their ratio is the
+	// compression ratio.
+	uncompressedBytes, compressedBytes prometheus.Counter
+
+	// Round trip times to peers, measured with Ping and Pong, in milliseconds
+	rtt prometheus.Histogram
+
+	// Seconds spent waiting to read from and write to peers due to bandwidth
+	// limits
+	inboundThrottled, outboundThrottled prometheus.Counter
+
+	// Messages waiting to be sent to peers, and messages that were dropped
+	// instead of being sent, by priority
+	queued  *prometheus.GaugeVec
+	dropped *prometheus.CounterVec
+
 	getVersion, version,
-	getPeerlist, peerlist,
+	getPeerlist, peerlist, signedIP, signedPeerlist,
 	ping, pong,
 	getAcceptedFrontier, acceptedFrontier,
 	getAccepted, accepted,
 	get, getAncestors, put, multiPut,
-	pushQuery, pullQuery, chits messageMetrics
+	pushQuery, pullQuery, chits,
+	appRequest, appResponse, appGossip messageMetrics
 }
 
 func (m *metrics) initialize(registerer prometheus.Registerer) error {
@@ -68,16 +115,86 @@ func (m *metrics) initialize(registerer prometheus.Registerer) error {
 		Help:      "Number of network peers",
 	})
 
+	m.uncompressedBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "uncompressed_bytes",
+		Help:      "Bytes of the compressible messages sent to peers that support compression, before compression",
+	})
+	m.compressedBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "compressed_bytes",
+		Help:      "Bytes of the compressible messages sent to peers that support compression, after compression",
+	})
+
+	m.rtt = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: constants.PlatformName,
+		Name:      "peer_rtt",
+		Help:      "Round trip time to peers in milliseconds",
+		Buckets:   timer.MillisecondsBuckets,
+	})
+
+	m.inboundThrottled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "inbound_throttled",
+		Help:      "Seconds spent waiting to read from peers due to bandwidth limits",
+	})
+	m.outboundThrottled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "outbound_throttled",
+		Help:      "Seconds spent waiting to write to peers due to bandwidth limits",
+	})
+
+	m.queued = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: constants.PlatformName,
+		Name:      "send_queue_messages",
+		Help:      "Number of messages waiting to be sent to peers",
+	}, []string{priorityLabel})
+	m.dropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "send_queue_dropped",
+		Help:      "Number of messages dropped instead of being sent to peers",
+	}, []string{priorityLabel})
+
 	errs := wrappers.Errs{}
 	if err := registerer.Register(m.numPeers); err != nil {
 		errs.Add(fmt.Errorf("failed to register peers statistics due to %s",
 			err))
 	}
+	if err := registerer.Register(m.uncompressedBytes); err != nil {
+		errs.Add(fmt.Errorf("failed to register uncompressed bytes statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.compressedBytes); err != nil {
+		errs.Add(fmt.Errorf("failed to register compressed bytes statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.rtt); err != nil {
+		errs.Add(fmt.Errorf("failed to register peer rtt statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.inboundThrottled); err != nil {
+		errs.Add(fmt.Errorf("failed to register inbound throttled statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.outboundThrottled); err != nil {
+		errs.Add(fmt.Errorf("failed to register outbound throttled statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.queued); err != nil {
+		errs.Add(fmt.Errorf("failed to register send queue statistics due to %s",
+			err))
+	}
+	if err := registerer.Register(m.dropped); err != nil {
+		errs.Add(fmt.Errorf("failed to register dropped message statistics due to %s",
+			err))
+	}
 	errs.Add(
 		m.getVersion.initialize(GetVersion, registerer),
 		m.version.initialize(Version, registerer),
 		m.getPeerlist.initialize(GetPeerList, registerer),
 		m.peerlist.initialize(PeerList, registerer),
+		m.signedIP.initialize(SignedIP, registerer),
+		m.signedPeerlist.initialize(SignedPeerList, registerer),
 		m.ping.initialize(Ping, registerer),
 		m.pong.initialize(Pong, registerer),
 		m.getAcceptedFrontier.initialize(GetAcceptedFrontier, registerer),
@@ -91,6 +208,9 @@ func (m *metrics) initialize(registerer prometheus.Registerer) error {
 		m.pushQuery.initialize(PushQuery, registerer),
 		m.pullQuery.initialize(PullQuery, registerer),
 		m.chits.initialize(Chits, registerer),
+		m.appRequest.initialize(AppRequest, registerer),
+		m.appResponse.initialize(AppResponse, registerer),
+		m.appGossip.initialize(AppGossip, registerer),
 	)
 	return errs.Err
 }
@@ -105,6 +225,10 @@ func (m *metrics) message(msgType Op) *messageMetrics {
 		return &m.getPeerlist
 	case PeerList:
 		return &m.peerlist
+	case SignedIP:
+		return &m.signedIP
+	case SignedPeerList:
+		return &m.signedPeerlist
 	case Ping:
 		return &m.ping
 	case Pong:
@@ -131,7 +255,24 @@ func (m *metrics) message(msgType Op) *messageMetrics {
 		return &m.pullQuery
 	case Chits:
 		return &m.chits
+	case AppRequest:
+		return &m.appRequest
+	case AppResponse:
+		return &m.appResponse
+	case AppGossip:
+		return &m.appGossip
 	default:
 		return nil
 	}
 }
+
+// compressed records that a compressible message of [uncompressedLen] bytes was
+// sent as [compressedLen] bytes
+func (m *metrics) compressed(msgType Op, uncompressedLen, compressedLen int) {
+	m.uncompressedBytes.Add(float64(uncompressedLen))
+	m.compressedBytes.Add(float64(compressedLen))
+	if mm := m.message(msgType); mm != nil && mm.uncompressedBytes != nil {
+		mm.uncompressedBytes.Add(float64(uncompressedLen))
+		mm.compressedBytes.Add(float64(compressedLen))
+	}
+}
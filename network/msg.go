@@ -3,6 +3,10 @@
 
 package network
 
+import (
+	"sync"
+)
+
 // Msg represents a set of fields that can be serialized into a byte stream
 type Msg interface {
 	Op() Op
@@ -14,6 +18,12 @@ type msg struct {
 	op     Op
 	fields map[Field]interface{}
 	bytes  []byte
+
+	// [compressed] is this message with compressed container bytes. It is
+	// computed at most once, however many peers the message is sent to.
+	compressOnce sync.Once
+	compressed   Msg
+	compressErr  error
 }
 
 // Field returns the value of the specified field in this message
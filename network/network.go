@@ -4,6 +4,8 @@
 package network
 
 import (
+	"crypto"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/health"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
@@ -25,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/sampler"
@@ -51,6 +55,10 @@ const (
 	defaultReadBufferSize                            = 16 * 1024
 	defaultReadHandshakeTimeout                      = 15 * time.Second
 	defaultConnMeterCacheSize                        = 10000
+
+	// Each round trip time sample is weighted 1/rttSmoothingFactor in a peer's
+	// smoothed round trip time
+	rttSmoothingFactor = 8
 )
 
 var (
@@ -62,6 +70,17 @@ var (
 // Network Upgrade
 var minimumUnmaskedVersion = version.NewDefaultVersion(constants.PlatformName, 1, 1, 0)
 
+// Peers before this version disconnect when they receive a Version message that
+// reports features, so they are only sent one once they report a newer version.
+// They are assumed to support no features.
+var minimumFeaturesVersion = version.NewDefaultVersion(constants.PlatformName, 1, 3, 0)
+
+// Peers before this version disconnect when they receive a Version message that
+// reports compressions, and can't decompress messages, so compressions are only
+// sent to, and messages are only compressed for, peers that report a newer
+// version.
+var minimumCompressionsVersion = version.NewDefaultVersion(constants.PlatformName, 1, 3, 0)
+
 func init() { rand.Seed(time.Now().UnixNano()) }
 
 // Network defines the functionality of the networking library.
@@ -96,6 +115,39 @@ type Network interface {
 	// Return the IP of the node
 	IP() utils.IPDesc
 
+	// Add a peer to the allowlist and attempt to connect to it. Errors if
+	// allowlist-only mode is disabled. Thread safety must be managed internally
+	// to the network.
+	AllowPeer(nodeID ids.ShortID, ip utils.IPDesc) error
+
+	// Remove a peer from the allowlist and disconnect from it. Errors if
+	// allowlist-only mode is disabled or the peer isn't allowed. Thread safety
+	// must be managed internally to the network.
+	DisallowPeer(nodeID ids.ShortID) error
+
+	// Returns the peers on the allowlist, or nil if allowlist-only mode is
+	// disabled. Thread safety must be managed internally to the network.
+	AllowedPeers() []AllowedPeer
+
+	// Close the connection with [nodeID]. Errors if it isn't connected. Thread
+	// safety must be managed internally to the network.
+	Disconnect(nodeID ids.ShortID) error
+
+	// Ban [nodeID], or [ip] on any port, for [duration], closing any
+	// connections with it. Bans are persisted, so they outlive the node. Thread
+	// safety must be managed internally to the network.
+	BanNodeID(nodeID ids.ShortID, duration time.Duration) error
+	BanIP(ip net.IP, duration time.Duration) error
+
+	// Lift the ban of [nodeID] or [ip]. Errors if it isn't banned. Thread
+	// safety must be managed internally to the network.
+	UnbanNodeID(nodeID ids.ShortID) error
+	UnbanIP(ip net.IP) error
+
+	// Returns the NodeIDs and IPs that are banned. Thread safety must be
+	// managed internally to the network.
+	Bans() []Ban
+
 	// Has a health check
 	health.Checkable
 }
@@ -119,6 +171,7 @@ type network struct {
 	ip                                 utils.DynamicIPDesc
 	networkID                          uint32
 	version                            version.Version
+	compatibility                      version.Compatibility
 	parser                             version.Parser
 	listener                           net.Listener
 	dialer                             Dialer
@@ -177,14 +230,19 @@ type network struct {
 	// attempt to dial the IP again if gossiped to us).
 	peerAliasTimeout time.Duration
 
+	// compression is the algorithm that the container bytes of messages are
+	// compressed with, when sent to peers that support it. This node can
+	// decompress messages regardless.
+	compression Compression
+
 	// ensures the close of the network only happens once.
 	closeOnce sync.Once
 
 	// True if the node should restart if it detects it's disconnected from all peers
 	restartOnDisconnected bool
 
-	// Signals the connection checker to close when Network is shutdown.
-	// See restartOnDisconnect()
+	// Signals the connection checker and the upgrade enforcer to close when
+	// Network is shutdown. See restartOnDisconnect() and enforceUpgrades()
 	connectedCheckerCloser chan struct{}
 
 	// Used to monitor whether the node is connected to peers. If the node has
@@ -205,6 +263,36 @@ type network struct {
 	maskedValidators ids.ShortSet
 
 	benchlistManager benchlist.Manager
+
+	// tlsKey is the staking key this node signs its IP claims with. If it is
+	// nil, TLS is disabled, so this node neither makes nor verifies claims and
+	// gossips unsigned IPs instead.
+	tlsKey crypto.Signer
+
+	// claimLock must be held when accessing [myClaim]
+	claimLock sync.Mutex
+	// myClaim is this node's most recent claim of its IP
+	myClaim *IPClaim
+
+	// inboundThrottler and outboundThrottler limit the rate at which bytes are
+	// read from and written to peers
+	inboundThrottler, outboundThrottler *throttler
+
+	// ipClaims is the timestamp of the latest verified IP claim of each
+	// validator. Claims that aren't newer are stale. [stateLock] should be held
+	// when accessing it.
+	ipClaims map[ids.ShortID]uint64
+
+	// allowlistOnly is true if this node only connects to the peers in
+	// [allowlist] and never gossips its peer list
+	allowlistOnly bool
+	// allowlist is the static IP of each peer this node may connect to in
+	// allowlist-only mode. [stateLock] should be held when accessing it.
+	allowlist map[ids.ShortID]utils.IPDesc
+
+	// banlist is the set of NodeIDs and IPs this node refuses to connect to.
+	// [stateLock] should be held when accessing it.
+	banlist *banlist
 }
 
 // NewDefaultNetwork returns a new Network implementation with the provided
@@ -215,7 +303,7 @@ func NewDefaultNetwork(
 	id ids.ShortID,
 	ip utils.DynamicIPDesc,
 	networkID uint32,
-	version version.Version,
+	compatibility version.Compatibility,
 	parser version.Parser,
 	listener net.Listener,
 	dialer Dialer,
@@ -235,6 +323,11 @@ func NewDefaultNetwork(
 	healthConfig HealthConfig,
 	benchlistManager benchlist.Manager,
 	peerAliasTimeout time.Duration,
+	compression Compression,
+	tlsKey crypto.Signer,
+	bandwidthConfig BandwidthConfig,
+	allowlistConfig AllowlistConfig,
+	db database.Database,
 ) Network {
 	return NewNetwork(
 		registerer,
@@ -242,7 +335,7 @@ func NewDefaultNetwork(
 		id,
 		ip,
 		networkID,
-		version,
+		compatibility,
 		parser,
 		listener,
 		dialer,
@@ -279,6 +372,11 @@ func NewDefaultNetwork(
 		healthConfig,
 		benchlistManager,
 		peerAliasTimeout,
+		compression,
+		tlsKey,
+		bandwidthConfig,
+		allowlistConfig,
+		db,
 	)
 }
 
@@ -289,7 +387,7 @@ func NewNetwork(
 	id ids.ShortID,
 	ip utils.DynamicIPDesc,
 	networkID uint32,
-	version version.Version,
+	compatibility version.Compatibility,
 	parser version.Parser,
 	listener net.Listener,
 	dialer Dialer,
@@ -326,6 +424,11 @@ func NewNetwork(
 	healthConfig HealthConfig,
 	benchlistManager benchlist.Manager,
 	peerAliasTimeout time.Duration,
+	compression Compression,
+	tlsKey crypto.Signer,
+	bandwidthConfig BandwidthConfig,
+	allowlistConfig AllowlistConfig,
+	db database.Database,
 ) Network {
 	// #nosec G404
 	netw := &network{
@@ -333,7 +436,8 @@ func NewNetwork(
 		id:             id,
 		ip:             ip,
 		networkID:      networkID,
-		version:        version,
+		version:        compatibility.Version(),
+		compatibility:  compatibility,
 		parser:         parser,
 		listener:       listener,
 		dialer:         dialer,
@@ -380,8 +484,40 @@ func NewNetwork(
 		apricotPhase0Time:                  apricotPhase0Time,
 		healthConfig:                       healthConfig,
 		benchlistManager:                   benchlistManager,
+		compression:                        compression,
+		tlsKey:                             tlsKey,
+		ipClaims:                           make(map[ids.ShortID]uint64),
+		allowlistOnly:                      allowlistConfig.Enabled,
+		allowlist:                          make(map[ids.ShortID]utils.IPDesc, len(allowlistConfig.Peers)),
+	}
+	for _, peer := range allowlistConfig.Peers {
+		netw.allowlist[peer.ID] = peer.IP
+	}
+	netw.banlist = newBanlist(db)
+	numMalformed, err := netw.banlist.load(netw.clock.Time())
+	if numMalformed > 0 {
+		log.Warn("deleted %d malformed bans from the ban list", numMalformed)
+	}
+	if err != nil {
+		// New bans are still written to the database, which reports its
+		// failures to health
+		log.Error("failed to load the ban list, so some earlier bans aren't enforced: %s", err)
 	}
 	netw.sendFailRateCalculator = math.NewAverager(0, healthConfig.MaxSendFailRateHalflife, netw.clock.Time())
+	netw.inboundThrottler = newThrottler(
+		&netw.clock,
+		vdrs,
+		bandwidthConfig.InboundBytesPerSec,
+		bandwidthConfig.InboundPeerBytesPerSec,
+		bandwidthConfig.StakerPortion,
+	)
+	netw.outboundThrottler = newThrottler(
+		&netw.clock,
+		vdrs,
+		bandwidthConfig.OutboundBytesPerSec,
+		bandwidthConfig.OutboundPeerBytesPerSec,
+		bandwidthConfig.StakerPortion,
+	)
 
 	if err := netw.initialize(registerer); err != nil {
 		log.Warn("initializing network metrics failed with: %s", err)
@@ -733,6 +869,83 @@ func (n *network) Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint3
 	}
 }
 
+// AppRequest implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) AppRequest(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Duration, appBytes []byte) []ids.ShortID {
+	now := n.clock.Time()
+
+	msg, err := n.b.AppRequest(chainID, requestID, uint64(deadline), appBytes)
+	if err != nil {
+		n.log.Error("failed to build AppRequest(%s, %d): %s. len(appBytes): %d",
+			chainID,
+			requestID,
+			err,
+			len(appBytes))
+		n.sendFailRateCalculator.Observe(1, now)
+		return nil // Packing message failed
+	}
+
+	sentTo := make([]ids.ShortID, 0, validatorIDs.Len())
+	for _, peerElement := range n.getPeers(validatorIDs) {
+		peer := peerElement.peer
+		vID := peerElement.id
+		if peer == nil || !peer.connected.GetValue() || !peer.supports(version.AppMessagesFeature) || !peer.Send(msg) {
+			n.log.Debug("failed to send AppRequest(%s, %s, %d)",
+				vID,
+				chainID,
+				requestID)
+			n.log.Verbo("appBytes: %s", formatting.DumpBytes{Bytes: appBytes})
+			n.appRequest.numFailed.Inc()
+			n.sendFailRateCalculator.Observe(1, now)
+		} else {
+			n.appRequest.numSent.Inc()
+			n.sendFailRateCalculator.Observe(0, now)
+			sentTo = append(sentTo, vID)
+		}
+	}
+	return sentTo
+}
+
+// AppResponse implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) AppResponse(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appBytes []byte) {
+	now := n.clock.Time()
+
+	msg, err := n.b.AppResponse(chainID, requestID, appBytes)
+	if err != nil {
+		n.log.Error("failed to build AppResponse(%s, %d): %s. len(appBytes): %d",
+			chainID,
+			requestID,
+			err,
+			len(appBytes))
+		n.sendFailRateCalculator.Observe(1, now)
+		return
+	}
+
+	peer := n.getPeer(validatorID)
+	if peer == nil || !peer.connected.GetValue() || !peer.supports(version.AppMessagesFeature) || !peer.Send(msg) {
+		n.log.Debug("failed to send AppResponse(%s, %s, %d)",
+			validatorID,
+			chainID,
+			requestID)
+		n.log.Verbo("appBytes: %s", formatting.DumpBytes{Bytes: appBytes})
+		n.appResponse.numFailed.Inc()
+		n.sendFailRateCalculator.Observe(1, now)
+	} else {
+		n.appResponse.numSent.Inc()
+		n.sendFailRateCalculator.Observe(0, now)
+	}
+}
+
+// AppGossip implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) AppGossip(chainID ids.ID, appBytes []byte) {
+	if err := n.gossipAppBytes(chainID, appBytes); err != nil {
+		n.log.Debug("failed to AppGossip(%s): %s", chainID, err)
+		n.log.Verbo("appBytes:\n%s", formatting.DumpBytes{Bytes: appBytes})
+	}
+}
+
 // Gossip attempts to gossip the container to the network
 // assumes the stateLock is not held.
 func (n *network) Gossip(chainID, containerID ids.ID, container []byte) {
@@ -765,6 +978,10 @@ func (n *network) upgradeIncoming(remoteAddr string) (bool, error) {
 		return false, fmt.Errorf("unable to convert remote address %s to IPDesc: %w", remoteAddr, err)
 	}
 
+	if n.banlist.ipBanned(ip.IP, n.clock.Time()) {
+		return false, fmt.Errorf("%s is %w", ip.IP, errBanned)
+	}
+
 	str := ip.String()
 	if _, ok := n.connectedIPs[str]; ok {
 		return false, nil
@@ -786,7 +1003,16 @@ func (n *network) upgradeIncoming(remoteAddr string) (bool, error) {
 // to this node.
 // assumes the stateLock is not held.
 func (n *network) Dispatch() error {
-	go n.gossip() // Periodically gossip peers
+	if n.allowlistOnly {
+		n.log.Info("only connecting to the %d peers on the allowlist", len(n.AllowedPeers()))
+		n.stateLock.Lock()
+		for _, ip := range n.allowlist {
+			n.track(ip)
+		}
+		n.stateLock.Unlock()
+	} else {
+		go n.gossip() // Periodically gossip peers
+	}
 	go func() {
 		duration := time.Until(n.apricotPhase0Time)
 		time.Sleep(duration)
@@ -803,6 +1029,7 @@ func (n *network) Dispatch() error {
 		n.maskedValidators.Clear()
 		n.log.Verbo("The new staking set is:\n%s", n.vdrs)
 	}()
+	go n.enforceUpgrades()
 	for { // Continuously accept new connections
 		conn, err := n.listener.Accept() // Returns error when n.Close() is called
 		if err != nil {
@@ -879,13 +1106,18 @@ func (n *network) Peers(nodeIDs []ids.ShortID) []PeerID {
 		for _, peer := range n.peers {
 			if peer.connected.GetValue() {
 				peers = append(peers, PeerID{
-					IP:           peer.conn.RemoteAddr().String(),
-					PublicIP:     peer.getIP().String(),
-					ID:           peer.id.PrefixedString(constants.NodeIDPrefix),
-					Version:      peer.versionStr.GetValue().(string),
-					LastSent:     time.Unix(atomic.LoadInt64(&peer.lastSent), 0),
-					LastReceived: time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
-					Benched:      n.benchlistManager.GetBenched(peer.id),
+					IP:            peer.conn.RemoteAddr().String(),
+					PublicIP:      peer.getIP().String(),
+					ID:            peer.id.PrefixedString(constants.NodeIDPrefix),
+					Version:       peer.versionStr.GetValue().(string),
+					LastSent:      time.Unix(atomic.LoadInt64(&peer.lastSent), 0),
+					LastReceived:  time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
+					Benched:       n.benchlistManager.GetBenched(peer.id),
+					RTT:           time.Duration(atomic.LoadInt64(&peer.rtt)).String(),
+					Outbound:      peer.outbound,
+					BytesSent:     json.Uint64(atomic.LoadUint64(&peer.bytesSent)),
+					BytesReceived: json.Uint64(atomic.LoadUint64(&peer.bytesReceived)),
+					Features:      featureNames(peer.getFeatures()),
 				})
 			}
 		}
@@ -895,13 +1127,18 @@ func (n *network) Peers(nodeIDs []ids.ShortID) []PeerID {
 			peer, ok := n.peers[nodeID]
 			if ok && peer.connected.GetValue() {
 				peers = append(peers, PeerID{
-					IP:           peer.conn.RemoteAddr().String(),
-					PublicIP:     peer.getIP().String(),
-					ID:           peer.id.PrefixedString(constants.NodeIDPrefix),
-					Version:      peer.versionStr.GetValue().(string),
-					LastSent:     time.Unix(atomic.LoadInt64(&peer.lastSent), 0),
-					LastReceived: time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
-					Benched:      n.benchlistManager.GetBenched(peer.id),
+					IP:            peer.conn.RemoteAddr().String(),
+					PublicIP:      peer.getIP().String(),
+					ID:            peer.id.PrefixedString(constants.NodeIDPrefix),
+					Version:       peer.versionStr.GetValue().(string),
+					LastSent:      time.Unix(atomic.LoadInt64(&peer.lastSent), 0),
+					LastReceived:  time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
+					Benched:       n.benchlistManager.GetBenched(peer.id),
+					RTT:           time.Duration(atomic.LoadInt64(&peer.rtt)).String(),
+					Outbound:      peer.outbound,
+					BytesSent:     json.Uint64(atomic.LoadUint64(&peer.bytesSent)),
+					BytesReceived: json.Uint64(atomic.LoadUint64(&peer.bytesReceived)),
+					Features:      featureNames(peer.getFeatures()),
 				})
 			}
 		}
@@ -963,6 +1200,61 @@ func (n *network) IP() utils.IPDesc {
 	return n.ip.IP()
 }
 
+// PeerFeatures implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) PeerFeatures(validatorID ids.ShortID) version.Features {
+	if validatorID == n.id {
+		return version.CurrentFeatures
+	}
+
+	n.stateLock.RLock()
+	peer, ok := n.peers[validatorID]
+	n.stateLock.RUnlock()
+
+	if !ok || !peer.connected.GetValue() {
+		return 0
+	}
+	return peer.getFeatures()
+}
+
+// enforceUpgrades disconnects from the peers that become incompatible as each
+// scheduled upgrade takes effect. As during the handshake, beacons are allowed
+// to stay connected.
+// assumes the stateLock is not held.
+func (n *network) enforceUpgrades() {
+	for _, upgrade := range n.compatibility.Upgrades() {
+		timer := time.NewTimer(upgrade.Time.Sub(n.clock.Time()))
+		select {
+		case <-timer.C:
+		case <-n.connectedCheckerCloser:
+			timer.Stop()
+			return
+		}
+
+		n.log.Info("peers must now run at least %s", n.compatibility.MinimumVersion(n.clock.Time()))
+		for _, peer := range n.getAllPeers() {
+			peerVersion, ok := peer.versionStruct.GetValue().(version.Version)
+			if !ok || n.beacons.Contains(peer.id) {
+				continue
+			}
+			if err := n.compatibility.Compatible(peerVersion, n.clock.Time()); err != nil {
+				n.log.Debug("disconnecting from %s due to %s", peer.id, err)
+				peer.discardIP()
+			}
+		}
+	}
+}
+
+// featureNames returns the name of each feature in [features]
+func featureNames(features version.Features) []string {
+	list := features.List()
+	names := make([]string, len(list))
+	for i, feature := range list {
+		names[i] = feature.String()
+	}
+	return names
+}
+
 // assumes the stateLock is not held.
 func (n *network) gossipContainer(chainID, containerID ids.ID, container []byte) error {
 	now := n.clock.Time()
@@ -1000,6 +1292,49 @@ func (n *network) gossipContainer(chainID, containerID ids.ID, container []byte)
 	return nil
 }
 
+// assumes the stateLock is not held.
+func (n *network) gossipAppBytes(chainID ids.ID, appBytes []byte) error {
+	now := n.clock.Time()
+
+	msg, err := n.b.AppGossip(chainID, appBytes)
+	if err != nil {
+		n.sendFailRateCalculator.Observe(1, now)
+		return fmt.Errorf("attempted to pack too large of an AppGossip message.\nLength: %d", len(appBytes))
+	}
+
+	allPeers := n.getAllPeers()
+	peers := make([]*peer, 0, len(allPeers))
+	for _, peer := range allPeers {
+		if peer.connected.GetValue() && peer.supports(version.AppMessagesFeature) {
+			peers = append(peers, peer)
+		}
+	}
+
+	numToGossip := n.gossipSize
+	if numToGossip > len(peers) {
+		numToGossip = len(peers)
+	}
+
+	s := sampler.NewUniform()
+	if err := s.Initialize(uint64(len(peers))); err != nil {
+		return err
+	}
+	indices, err := s.Sample(numToGossip)
+	if err != nil {
+		return err
+	}
+	for _, index := range indices {
+		if peers[int(index)].Send(msg) {
+			n.appGossip.numSent.Inc()
+			n.sendFailRateCalculator.Observe(0, now)
+		} else {
+			n.sendFailRateCalculator.Observe(1, now)
+			n.appGossip.numFailed.Inc()
+		}
+	}
+	return nil
+}
+
 // assumes the stateLock is held.
 func (n *network) track(ip utils.IPDesc) {
 	if n.closed.GetValue() {
@@ -1019,6 +1354,12 @@ func (n *network) track(ip utils.IPDesc) {
 	if _, ok := n.myIPs[str]; ok {
 		return
 	}
+	if !n.allowedIP(ip) {
+		return
+	}
+	if n.banlist.ipBanned(ip.IP, n.clock.Time()) {
+		return
+	}
 	n.disconnectedIPs[str] = struct{}{}
 
 	go n.connectTo(ip)
@@ -1063,6 +1404,20 @@ func (n *network) gossip() {
 				len(ips))
 			continue
 		}
+		// Peers that support signed IP claims are only sent claims
+		signedMsg := msg
+		if n.tlsKey != nil {
+			n.refreshClaim()
+
+			claims := n.validatorClaims()
+			signedMsg, err = n.b.SignedPeerList(claims)
+			if err != nil {
+				n.log.Error("failed to build signed peer list to gossip: %s. len(claims): %d",
+					err,
+					len(claims))
+				continue
+			}
+		}
 
 		stakers := make([]*peer, 0, len(allPeers))
 		nonStakers := make([]*peer, 0, len(allPeers))
@@ -1098,7 +1453,7 @@ func (n *network) gossip() {
 			continue
 		}
 		for _, index := range stakerIndices {
-			stakers[int(index)].Send(msg)
+			stakers[int(index)].sendPeerListMsg(msg, signedMsg)
 		}
 
 		if err := s.Initialize(uint64(len(nonStakers))); err != nil {
@@ -1115,7 +1470,7 @@ func (n *network) gossip() {
 			continue
 		}
 		for _, index := range nonStakerIndices {
-			nonStakers[int(index)].Send(msg)
+			nonStakers[int(index)].sendPeerListMsg(msg, signedMsg)
 		}
 	}
 }
@@ -1204,7 +1559,7 @@ func (n *network) upgrade(p *peer, upgrader Upgrader) error {
 		return err
 	}
 
-	id, conn, err := upgrader.Upgrade(p.conn)
+	id, conn, cert, err := upgrader.Upgrade(p.conn)
 	if err != nil {
 		_ = p.conn.Close()
 		n.log.Verbo("failed to upgrade connection with %s", err)
@@ -1217,9 +1572,10 @@ func (n *network) upgrade(p *peer, upgrader Upgrader) error {
 		return err
 	}
 
-	p.sender = make(chan []byte, n.sendQueueSize)
+	p.sender = newSendQueue(int(n.sendQueueSize))
 	p.id = id
 	p.conn = conn
+	p.cert = cert
 
 	if err := n.tryAddPeer(p); err != nil {
 		_ = p.conn.Close()
@@ -1272,6 +1628,27 @@ func (n *network) tryAddPeer(p *peer) error {
 		return fmt.Errorf("duplicated connection from %s at %s", p.id.PrefixedString(constants.NodeIDPrefix), ip)
 	}
 
+	now := n.clock.Time()
+	if n.banlist.nodeIDBanned(p.id, now) || (!ip.IsZero() && n.banlist.ipBanned(ip.IP, now)) {
+		if !ip.IsZero() {
+			// Stop attempting to connect to the banned peer
+			str := ip.String()
+			delete(n.disconnectedIPs, str)
+			delete(n.retryDelay, str)
+		}
+		return fmt.Errorf("%s at %s is %w", p.id.PrefixedString(constants.NodeIDPrefix), ip, errBanned)
+	}
+
+	if !n.allowed(p.id) {
+		if !ip.IsZero() {
+			// An allowed peer may be reachable at this IP later, so keep
+			// attempting to connect to it
+			delete(n.disconnectedIPs, ip.String())
+			n.track(ip)
+		}
+		return fmt.Errorf("%w: %s at %s", errNotAllowed, p.id.PrefixedString(constants.NodeIDPrefix), ip)
+	}
+
 	n.peers[p.id] = p
 	n.numPeers.Set(float64(len(n.peers)))
 	p.Start()
@@ -1297,6 +1674,140 @@ func (n *network) validatorIPs() []utils.IPDesc {
 	return ips
 }
 
+// validatorClaims returns the IP claims of at most maxSignedPeers connected
+// validators. assumes the stateLock is not held.
+func (n *network) validatorClaims() []IPClaim {
+	n.stateLock.RLock()
+	defer n.stateLock.RUnlock()
+
+	claims := make([]IPClaim, 0, maxSignedPeers)
+	// Map iteration order is random, so a different subset of the claims is
+	// gossiped each time if there are too many
+	for _, peer := range n.peers {
+		if len(claims) == maxSignedPeers {
+			break
+		}
+		if !peer.connected.GetValue() || !n.vdrs.Contains(peer.id) {
+			continue
+		}
+		if claim, ok := peer.claim.GetValue().(*IPClaim); ok && !n.expired(claim) {
+			claims = append(claims, *claim)
+		}
+	}
+	return claims
+}
+
+// signedIP returns this node's claim of its current IP, signing a new one if
+// the IP changed or the claim should be refreshed before it expires. assumes
+// the stateLock is not held.
+func (n *network) signedIP() (*IPClaim, error) {
+	ip := n.ip.IP()
+
+	n.claimLock.Lock()
+	defer n.claimLock.Unlock()
+
+	refreshTime := n.clock.Time().Add(-maxClaimAge / 2)
+	if n.myClaim != nil && n.myClaim.IP.Equal(ip) && !n.madeBefore(n.myClaim, refreshTime) {
+		return n.myClaim, nil
+	}
+	claim := &IPClaim{
+		IP:        ip,
+		Timestamp: n.clock.Unix(),
+	}
+	if err := claim.sign(n.tlsKey); err != nil {
+		return nil, err
+	}
+	n.myClaim = claim
+	return claim, nil
+}
+
+// fromFuture returns true if [claim] was made later than any peer's clock
+// could allow
+func (n *network) fromFuture(claim *IPClaim) bool {
+	maxTimestamp := n.clock.Time().Add(n.maxClockDifference).Unix()
+	return claim.Timestamp > uint64(maxTimestamp)
+}
+
+// expired returns true if [claim] is too old to be accepted
+func (n *network) expired(claim *IPClaim) bool {
+	return n.madeBefore(claim, n.clock.Time().Add(-maxClaimAge))
+}
+
+// madeBefore returns true if [claim] was made before [t]
+func (n *network) madeBefore(claim *IPClaim, t time.Time) bool {
+	minTimestamp := t.Unix()
+	return minTimestamp > 0 && claim.Timestamp < uint64(minTimestamp)
+}
+
+// refreshClaim sends this node's claim of its IP to the connected peers that
+// support signed IP claims if a new claim was signed since it was last sent.
+// assumes the stateLock is not held.
+func (n *network) refreshClaim() {
+	n.claimLock.Lock()
+	oldClaim := n.myClaim
+	n.claimLock.Unlock()
+
+	claim, err := n.signedIP()
+	if err != nil {
+		n.log.Warn("failed to sign IP claim due to %s", err)
+		return
+	}
+	if oldClaim == nil || claim == oldClaim {
+		// Peers are sent the first claim during the handshake
+		return
+	}
+
+	for _, peer := range n.getAllPeers() {
+		if peer.connected.GetValue() && peer.supports(version.SignedIPsFeature) {
+			peer.SignedIP()
+		}
+	}
+}
+
+// trackClaim starts connecting to the IP of a gossiped [claim] if it is a fresh
+// claim made by a validator and is signed by the key of its certificate.
+// assumes the stateLock is not held.
+func (n *network) trackClaim(claim *IPClaim) {
+	nodeID := certToID(claim.Cert)
+	if nodeID == n.id || !n.vdrs.Contains(nodeID) {
+		return
+	}
+
+	n.stateLock.RLock()
+	latest := n.ipClaims[nodeID]
+	n.stateLock.RUnlock()
+	if claim.Timestamp <= latest || n.fromFuture(claim) || n.expired(claim) {
+		n.log.Verbo("ignoring stale IP claim of %s", nodeID)
+		return
+	}
+
+	cert, err := x509.ParseCertificate(claim.Cert)
+	if err != nil {
+		n.log.Debug("ignoring IP claim of %s with an invalid certificate: %s", nodeID, err)
+		return
+	}
+	if err := claim.verify(cert); err != nil {
+		n.log.Debug("ignoring IP claim of %s with an invalid signature: %s", nodeID, err)
+		return
+	}
+
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	// Another claim may have been verified while this one was
+	if claim.Timestamp <= n.ipClaims[nodeID] {
+		return
+	}
+	n.ipClaims[nodeID] = claim.Timestamp
+
+	ip := claim.IP
+	if !ip.Equal(n.ip.IP()) &&
+		!ip.IsZero() &&
+		(n.allowPrivateIPs || !ip.IsPrivate()) {
+		n.track(ip)
+	}
+}
+
 // should only be called after the peer is marked as connected. Should not be
 // called after disconnected is called with this peer.
 // assumes the stateLock is not held.
@@ -1338,7 +1849,7 @@ func (n *network) connected(p *peer) {
 		n.connectedIPs[str] = struct{}{}
 	}
 
-	n.router.Connected(p.id)
+	n.router.Connected(p.id, p.getFeatures())
 }
 
 // should only be called after the peer is marked as connected.
@@ -1353,6 +1864,8 @@ func (n *network) disconnected(p *peer) {
 
 	delete(n.peers, p.id)
 	n.numPeers.Set(float64(len(n.peers)))
+	n.inboundThrottler.remove(p.id)
+	n.outboundThrottler.remove(p.id)
 
 	p.releaseAllAliases()
 
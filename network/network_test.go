@@ -4,9 +4,11 @@
 package network
 
 import (
+	"crypto/x509"
 	"errors"
 	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -183,11 +186,15 @@ func (c *testConn) SetWriteDeadline(time.Time) error { return nil }
 
 type testHandler struct {
 	router.Router
-	connected    func(ids.ShortID)
-	disconnected func(ids.ShortID)
+	connected             func(ids.ShortID)
+	connectedWithFeatures func(ids.ShortID, version.Features)
+	disconnected          func(ids.ShortID)
 }
 
-func (h *testHandler) Connected(id ids.ShortID) {
+func (h *testHandler) Connected(id ids.ShortID, features version.Features) {
+	if h.connectedWithFeatures != nil {
+		h.connectedWithFeatures(id, features)
+	}
 	if h.connected != nil {
 		h.connected(id)
 	}
@@ -207,12 +214,12 @@ type testUpgrader struct {
 	idsLock sync.Mutex
 }
 
-func (u *testUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, error) {
+func (u *testUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, *x509.Certificate, error) {
 	u.idsLock.Lock()
 	defer u.idsLock.Unlock()
 	addr := conn.RemoteAddr()
 	str := addr.String()
-	return u.ids[str], conn, nil
+	return u.ids[str], conn, nil, nil
 }
 
 func (u *testUpgrader) Update(ip utils.DynamicIPDesc, id ids.ShortID) {
@@ -231,6 +238,7 @@ func TestNewDefaultNetwork(t *testing.T) {
 	id := ids.ShortID(hashing.ComputeHash160Array([]byte(ip.IP().String())))
 	networkID := uint32(0)
 	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	compatibility := newTestCompatibility(t, appVersion, appVersion, nil)
 	versionParser := version.NewDefaultParser()
 
 	listener := &testListener{
@@ -260,7 +268,7 @@ func TestNewDefaultNetwork(t *testing.T) {
 		id,
 		ip,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener,
 		caller,
@@ -280,6 +288,11 @@ func TestNewDefaultNetwork(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net)
 
@@ -296,6 +309,7 @@ func TestEstablishConnection(t *testing.T) {
 	log := logging.NoLog{}
 	networkID := uint32(0)
 	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	compatibility := newTestCompatibility(t, appVersion, appVersion, nil)
 	versionParser := version.NewDefaultParser()
 
 	ip0 := utils.NewDynamicIPDesc(
@@ -377,7 +391,7 @@ func TestEstablishConnection(t *testing.T) {
 		id0,
 		ip0,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener0,
 		caller0,
@@ -397,6 +411,11 @@ func TestEstablishConnection(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net0)
 
@@ -406,7 +425,7 @@ func TestEstablishConnection(t *testing.T) {
 		id1,
 		ip1,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener1,
 		caller1,
@@ -426,6 +445,11 @@ func TestEstablishConnection(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net1)
 
@@ -454,6 +478,7 @@ func TestDoubleTrack(t *testing.T) {
 	log := logging.NoLog{}
 	networkID := uint32(0)
 	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	compatibility := newTestCompatibility(t, appVersion, appVersion, nil)
 	versionParser := version.NewDefaultParser()
 
 	ip0 := utils.NewDynamicIPDesc(
@@ -535,7 +560,7 @@ func TestDoubleTrack(t *testing.T) {
 		id0,
 		ip0,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener0,
 		caller0,
@@ -555,6 +580,11 @@ func TestDoubleTrack(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net0)
 
@@ -564,7 +594,7 @@ func TestDoubleTrack(t *testing.T) {
 		id1,
 		ip1,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener1,
 		caller1,
@@ -584,6 +614,11 @@ func TestDoubleTrack(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net1)
 
@@ -613,6 +648,7 @@ func TestDoubleClose(t *testing.T) {
 	log := logging.NoLog{}
 	networkID := uint32(0)
 	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	compatibility := newTestCompatibility(t, appVersion, appVersion, nil)
 	versionParser := version.NewDefaultParser()
 
 	ip0 := utils.NewDynamicIPDesc(
@@ -694,7 +730,7 @@ func TestDoubleClose(t *testing.T) {
 		id0,
 		ip0,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener0,
 		caller0,
@@ -714,6 +750,11 @@ func TestDoubleClose(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net0)
 
@@ -723,7 +764,7 @@ func TestDoubleClose(t *testing.T) {
 		id1,
 		ip1,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener1,
 		caller1,
@@ -743,6 +784,11 @@ func TestDoubleClose(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net1)
 
@@ -777,6 +823,7 @@ func TestTrackConnected(t *testing.T) {
 	log := logging.NoLog{}
 	networkID := uint32(0)
 	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	compatibility := newTestCompatibility(t, appVersion, appVersion, nil)
 	versionParser := version.NewDefaultParser()
 
 	ip0 := utils.NewDynamicIPDesc(
@@ -858,7 +905,7 @@ func TestTrackConnected(t *testing.T) {
 		id0,
 		ip0,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener0,
 		caller0,
@@ -878,6 +925,11 @@ func TestTrackConnected(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net0)
 
@@ -887,7 +939,7 @@ func TestTrackConnected(t *testing.T) {
 		id1,
 		ip1,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener1,
 		caller1,
@@ -907,6 +959,11 @@ func TestTrackConnected(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net1)
 
@@ -937,6 +994,7 @@ func TestTrackConnectedRace(t *testing.T) {
 	log := logging.NoLog{}
 	networkID := uint32(0)
 	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	compatibility := newTestCompatibility(t, appVersion, appVersion, nil)
 	versionParser := version.NewDefaultParser()
 
 	ip0 := utils.NewDynamicIPDesc(
@@ -996,7 +1054,7 @@ func TestTrackConnectedRace(t *testing.T) {
 		id0,
 		ip0,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener0,
 		caller0,
@@ -1016,6 +1074,11 @@ func TestTrackConnectedRace(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net0)
 
@@ -1025,7 +1088,7 @@ func TestTrackConnectedRace(t *testing.T) {
 		id1,
 		ip1,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener1,
 		caller1,
@@ -1045,6 +1108,11 @@ func TestTrackConnectedRace(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net1)
 
@@ -1079,6 +1147,7 @@ func TestPeerAliasesTicker(t *testing.T) {
 	log := logging.NoLog{}
 	networkID := uint32(0)
 	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	compatibility := newTestCompatibility(t, appVersion, appVersion, nil)
 	versionParser := version.NewDefaultParser()
 
 	ip0 := utils.NewDynamicIPDesc(
@@ -1262,7 +1331,7 @@ func TestPeerAliasesTicker(t *testing.T) {
 		id0,
 		ip0,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener0,
 		caller0,
@@ -1282,6 +1351,11 @@ func TestPeerAliasesTicker(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net0)
 
@@ -1291,7 +1365,7 @@ func TestPeerAliasesTicker(t *testing.T) {
 		id1,
 		ip1,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener1,
 		caller1,
@@ -1311,6 +1385,11 @@ func TestPeerAliasesTicker(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net1)
 
@@ -1320,7 +1399,7 @@ func TestPeerAliasesTicker(t *testing.T) {
 		id1,
 		ip2,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener2,
 		caller2,
@@ -1340,6 +1419,11 @@ func TestPeerAliasesTicker(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net2)
 
@@ -1349,7 +1433,7 @@ func TestPeerAliasesTicker(t *testing.T) {
 		id2,
 		ip2,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener3,
 		caller3,
@@ -1369,6 +1453,11 @@ func TestPeerAliasesTicker(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net3)
 
@@ -1463,6 +1552,7 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 	log := logging.NoLog{}
 	networkID := uint32(0)
 	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	compatibility := newTestCompatibility(t, appVersion, appVersion, nil)
 	versionParser := version.NewDefaultParser()
 
 	ip0 := utils.NewDynamicIPDesc(
@@ -1668,7 +1758,7 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 		id0,
 		ip0,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener0,
 		caller0,
@@ -1688,6 +1778,11 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net0)
 
@@ -1697,7 +1792,7 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 		id1,
 		ip1,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener1,
 		caller1,
@@ -1717,6 +1812,11 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net1)
 
@@ -1726,7 +1826,7 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 		id1,
 		ip2,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener2,
 		caller2,
@@ -1746,6 +1846,11 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net2)
 
@@ -1755,7 +1860,7 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 		id2,
 		ip2,
 		networkID,
-		appVersion,
+		compatibility,
 		versionParser,
 		listener3,
 		caller3,
@@ -1775,6 +1880,11 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 		HealthConfig{},
 		benchlist.NewManager(&benchlist.Config{}),
 		defaultAliasTimeout,
+		NoCompression,
+		nil,
+		BandwidthConfig{},
+		AllowlistConfig{},
+		memdb.New(),
 	)
 	assert.NotNil(t, net3)
 
@@ -1867,3 +1977,251 @@ func TestPeerAliasesDisconnect(t *testing.T) {
 	err = net3.Close()
 	assert.NoError(t, err)
 }
+
+func newTestCompatibility(t *testing.T, v, minimum version.Version, upgrades []version.Upgrade) version.Compatibility {
+	compatibility, err := version.NewCompatibility(v, minimum, upgrades)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return compatibility
+}
+
+// startTestNetworkPair starts two networks that can dial each other, with
+// [compatibility0] and [compatibility1] as their version policies, that
+// compress messages with [compression]
+func startTestNetworkPair(
+	t *testing.T,
+	compatibility0, compatibility1 version.Compatibility,
+	compression Compression,
+	handler0, handler1 router.Router,
+) (Network, Network, ids.ShortID, ids.ShortID) {
+	ip0 := utils.NewDynamicIPDesc(net.IPv6loopback, 0)
+	id0 := ids.ShortID(hashing.ComputeHash160Array([]byte(ip0.IP().String())))
+	ip1 := utils.NewDynamicIPDesc(net.IPv6loopback, 1)
+	id1 := ids.ShortID(hashing.ComputeHash160Array([]byte(ip1.IP().String())))
+
+	listener0 := &testListener{
+		addr:    &net.TCPAddr{IP: net.IPv6loopback, Port: 0},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller0 := &testDialer{
+		addr:      &net.TCPAddr{IP: net.IPv6loopback, Port: 0},
+		outbounds: make(map[string]*testListener),
+	}
+	listener1 := &testListener{
+		addr:    &net.TCPAddr{IP: net.IPv6loopback, Port: 1},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller1 := &testDialer{
+		addr:      &net.TCPAddr{IP: net.IPv6loopback, Port: 1},
+		outbounds: make(map[string]*testListener),
+	}
+	caller0.outbounds[ip1.IP().String()] = listener1
+	caller1.outbounds[ip0.IP().String()] = listener0
+
+	vdrs := validators.NewSet()
+	newNetwork := func(id ids.ShortID, ip utils.DynamicIPDesc, compatibility version.Compatibility, listener net.Listener, dialer Dialer, handler router.Router) Network {
+		return NewDefaultNetwork(
+			prometheus.NewRegistry(),
+			logging.NoLog{},
+			id,
+			ip,
+			0,
+			compatibility,
+			version.NewDefaultParser(),
+			listener,
+			dialer,
+			NewIPUpgrader(),
+			NewIPUpgrader(),
+			vdrs,
+			vdrs,
+			handler,
+			time.Duration(0),
+			0,
+			nil,
+			false,
+			0,
+			0,
+			time.Now(),
+			defaultSendQueueSize,
+			HealthConfig{},
+			benchlist.NewManager(&benchlist.Config{}),
+			defaultAliasTimeout,
+			compression,
+			nil,
+			BandwidthConfig{},
+			AllowlistConfig{},
+			memdb.New(),
+		)
+	}
+	net0 := newNetwork(id0, ip0, compatibility0, listener0, caller0, handler0)
+	net1 := newNetwork(id1, ip1, compatibility1, listener1, caller1, handler1)
+
+	go func() {
+		err := net0.Dispatch()
+		assert.Error(t, err)
+	}()
+	go func() {
+		err := net1.Dispatch()
+		assert.Error(t, err)
+	}()
+
+	net0.Track(ip1.IP())
+	return net0, net1, id0, id1
+}
+
+func TestFeatureNegotiation(t *testing.T) {
+	// Peers may run versions that can't parse features, so they are only sent
+	// once the other side's version is known
+	v := version.NewDefaultVersion(constants.PlatformName, 1, 3, 0)
+	minimum := version.NewDefaultVersion(constants.PlatformName, 1, 0, 0)
+	compatibility := newTestCompatibility(t, v, minimum, nil)
+
+	var (
+		wg0, wg1             sync.WaitGroup
+		features0, features1 version.Features
+	)
+	wg0.Add(1)
+	wg1.Add(1)
+	handler0 := &testHandler{
+		connectedWithFeatures: func(_ ids.ShortID, features version.Features) {
+			features0 = features
+			wg0.Done()
+		},
+	}
+	handler1 := &testHandler{
+		connectedWithFeatures: func(_ ids.ShortID, features version.Features) {
+			features1 = features
+			wg1.Done()
+		},
+	}
+
+	net0, net1, id0, id1 := startTestNetworkPair(t, compatibility, compatibility, GzipCompression, handler0, handler1)
+	wg0.Wait()
+	wg1.Wait()
+
+	assert.Equal(t, version.CurrentFeatures, features0)
+	assert.Equal(t, version.CurrentFeatures, features1)
+	assert.Equal(t, version.CurrentFeatures, net0.PeerFeatures(id1))
+	assert.Equal(t, version.CurrentFeatures, net1.PeerFeatures(id0))
+	assert.Equal(t, version.CurrentFeatures, net0.PeerFeatures(id0))
+	assert.Equal(t, version.Features(0), net0.PeerFeatures(ids.ShortID{1}))
+
+	peers := net0.Peers(nil)
+	if assert.Len(t, peers, 1) {
+		assert.Equal(t, []string{"app_messages", "signed_ips", "ping_nonces"}, peers[0].Features)
+	}
+
+	// Compressions are reported once the peer's version is known
+	for _, pair := range []struct {
+		net Network
+		id  ids.ShortID
+	}{{net0, id1}, {net1, id0}} {
+		n := pair.net.(*network)
+		n.stateLock.RLock()
+		peer := n.peers[pair.id]
+		n.stateLock.RUnlock()
+		assert.Equal(t, uint32(supportedCompressions), atomic.LoadUint32(&peer.compressions))
+	}
+
+	assert.NoError(t, net0.Close())
+	assert.NoError(t, net1.Close())
+}
+
+func TestCompressionNegotiationWithOldPeer(t *testing.T) {
+	// Peers that can't parse compressions are never sent them, and so are never
+	// sent compressed messages
+	v := version.NewDefaultVersion(constants.PlatformName, 1, 3, 0)
+	old := version.NewDefaultVersion(constants.PlatformName, 1, 2, 3)
+	minimum := version.NewDefaultVersion(constants.PlatformName, 1, 0, 0)
+	compatibility0 := newTestCompatibility(t, v, minimum, nil)
+	compatibility1 := newTestCompatibility(t, old, minimum, nil)
+
+	net0, net1, id0, id1 := startTestNetworkPair(t, compatibility0, compatibility1, GzipCompression, &testHandler{}, &testHandler{})
+
+	getPeer := func(net Network, id ids.ShortID) *peer {
+		n := net.(*network)
+		n.stateLock.RLock()
+		defer n.stateLock.RUnlock()
+		return n.peers[id]
+	}
+
+	// The handshake doesn't finish, as the peer's code still waits for this
+	// node's features, so wait until this node has handled the peer's version
+	newPeer := getPeer(net0, id1)
+	for newPeer == nil || !newPeer.gotFeatures.GetValue() {
+		time.Sleep(10 * time.Millisecond)
+		newPeer = getPeer(net0, id1)
+	}
+	oldPeer := getPeer(net1, id0)
+
+	assert.False(t, newPeer.sentCompressions.GetValue())
+	assert.Equal(t, uint32(0), atomic.LoadUint32(&oldPeer.compressions))
+
+	// Even a peer that reports compressions isn't sent compressed messages if
+	// its version can't decompress them
+	atomic.StoreUint32(&newPeer.compressions, supportedCompressions)
+	msg, err := net0.(*network).b.Put(ids.Empty, 0, ids.Empty, make([]byte, 2*minCompressibleSize))
+	assert.NoError(t, err)
+	sentMsg, compressible := newPeer.compress(msg)
+	assert.False(t, compressible)
+	assert.Equal(t, msg, sentMsg)
+
+	assert.NoError(t, net0.Close())
+	assert.NoError(t, net1.Close())
+}
+
+func TestVersionUpgradeDisconnects(t *testing.T) {
+	v := version.NewDefaultVersion(constants.PlatformName, 1, 4, 0)
+	old := version.NewDefaultVersion(constants.PlatformName, 1, 3, 0)
+	upgradeTime := time.Now().Add(500 * time.Millisecond)
+	compatibility0 := newTestCompatibility(t, v, old, []version.Upgrade{{
+		Time:           upgradeTime,
+		MinimumVersion: v,
+	}})
+	compatibility1 := newTestCompatibility(t, old, old, nil)
+
+	var connected, disconnected sync.WaitGroup
+	connected.Add(1)
+	disconnected.Add(1)
+	handler0 := &testHandler{
+		connected:    func(ids.ShortID) { connected.Done() },
+		disconnected: func(ids.ShortID) { disconnected.Done() },
+	}
+
+	net0, net1, _, _ := startTestNetworkPair(t, compatibility0, compatibility1, NoCompression, handler0, &testHandler{})
+	connected.Wait()
+	disconnected.Wait()
+	assert.False(t, time.Now().Before(upgradeTime), "peers should only be disconnected once they're too old")
+
+	assert.NoError(t, net0.Close())
+	assert.NoError(t, net1.Close())
+}
+
+func TestEnforceUpgradesStopsOnClose(t *testing.T) {
+	v := version.NewDefaultVersion(constants.PlatformName, 1, 4, 0)
+	old := version.NewDefaultVersion(constants.PlatformName, 1, 3, 0)
+	n := &network{
+		log: logging.NoLog{},
+		compatibility: newTestCompatibility(t, v, old, []version.Upgrade{{
+			Time:           time.Now().Add(time.Hour),
+			MinimumVersion: v,
+		}}),
+		connectedCheckerCloser: make(chan struct{}),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		n.enforceUpgrades()
+		close(done)
+	}()
+	close(n.connectedCheckerCloser)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("should have stopped waiting for the upgrade once the network closed")
+	}
+}
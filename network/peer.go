@@ -4,6 +4,7 @@
 package network
 
 import (
+	"crypto/x509"
 	"encoding/binary"
 	"math"
 	"net"
@@ -36,6 +37,18 @@ type peer struct {
 	// on the connection's reader routine.
 	gotVersion utils.AtomicBool
 
+	// if the peer's features are known. They are either reported in a version
+	// message, or assumed to be empty for peers too old to report them. is only
+	// modified on the connection's reader routine.
+	gotFeatures utils.AtomicBool
+
+	// if this node's features have been sent to the peer
+	sentFeatures utils.AtomicBool
+
+	// if the compression algorithms this node can decompress have been sent to
+	// the peer
+	sentCompressions utils.AtomicBool
+
 	// if the gotPeerList message has been received and is valid. is only
 	// modified on the connection's reader routine.
 	gotPeerList utils.AtomicBool
@@ -56,9 +69,9 @@ type peer struct {
 	// lock to ensure that closing of the sender queue is handled safely
 	senderLock sync.Mutex
 
-	// queue of messages this connection is attempting to send the peer. Is
-	// closed when the connection is closed.
-	sender chan []byte
+	// queues of messages this connection is attempting to send the peer, by
+	// priority. Is closed when the connection is closed.
+	sender *sendQueue
 
 	// ip may or may not be set when the peer is first started. is only modified
 	// on the connection's reader routine.
@@ -67,6 +80,9 @@ type peer struct {
 	// ipLock must be held when accessing [ip].
 	ipLock sync.RWMutex
 
+	// outbound is true if this node dialed the peer
+	outbound bool
+
 	// aliases is a list of IPs other than [ip] that we have connected to
 	// this peer at.
 	aliases []alias
@@ -80,16 +96,47 @@ type peer struct {
 	// id should be set when the peer is first created.
 	id ids.ShortID
 
+	// cert is the certificate the peer presented during the TLS handshake. It
+	// is nil if TLS is disabled. It should be set when the peer is first
+	// created.
+	cert *x509.Certificate
+
+	// claim is the peer's latest verified *IPClaim, including its certificate
+	claim utils.AtomicInterface
+
 	// the connection object that is used to read/write messages from
 	conn net.Conn
 
 	// version that the peer reported during the handshake
 	versionStruct, versionStr utils.AtomicInterface
 
+	// set of compression algorithms the peer reported it can decompress during
+	// the handshake. Must only be accessed atomically.
+	compressions uint32
+
+	// set of features the peer reported during the handshake. Must only be
+	// accessed atomically.
+	features uint64
+
 	// unix time of the last message sent and received respectively
 	// Must only be accessed atomically
 	lastSent, lastReceived int64
 
+	// number of bytes sent to and received from the peer, including length
+	// prefixes. Must only be accessed atomically
+	bytesSent, bytesReceived uint64
+
+	// pingLock must be held when accessing [pingNonce] or [pingSent]
+	pingLock sync.Mutex
+	// nonce of the outstanding Ping, or 0 if there isn't one
+	pingNonce uint32
+	// time the outstanding Ping was sent
+	pingSent time.Time
+
+	// smoothed round trip time to the peer in nanoseconds, or 0 if it hasn't
+	// been measured. Must only be accessed atomically
+	rtt int64
+
 	tickerCloser chan struct{}
 
 	// ticker processes
@@ -102,6 +149,7 @@ func newPeer(net *network, conn net.Conn, ip utils.IPDesc) *peer {
 		net:          net,
 		conn:         conn,
 		ip:           ip,
+		outbound:     !ip.IsZero(),
 		tickerCloser: make(chan struct{}),
 	}
 	p.aliasTimer = timer.NewTimer(p.releaseExpiredAliases)
@@ -149,7 +197,7 @@ func (p *peer) requestFinishHandshake() {
 	for {
 		select {
 		case <-finishHandshakeTicker.C:
-			gotVersion := p.gotVersion.GetValue()
+			gotFeatures := p.gotFeatures.GetValue()
 			gotPeerList := p.gotPeerList.GetValue()
 			connected := p.connected.GetValue()
 			closed := p.closed.GetValue()
@@ -158,7 +206,7 @@ func (p *peer) requestFinishHandshake() {
 				return
 			}
 
-			if !gotVersion {
+			if !gotFeatures {
 				p.GetVersion()
 			}
 			if !gotPeerList {
@@ -203,8 +251,23 @@ func (p *peer) ReadMessages() {
 			return
 		}
 
+		atomic.AddUint64(&p.bytesReceived, uint64(read))
 		pendingBuffer.Bytes = append(pendingBuffer.Bytes, readBuffer[:read]...)
 
+		// Waiting before the next read pushes back on the peer once the
+		// connection's buffers fill up
+		if wait := p.net.inboundThrottler.throttle(p.id, read); wait > 0 {
+			p.net.inboundThrottled.Add(wait.Seconds())
+			if !p.wait(wait) {
+				return
+			}
+			// The read deadline may have passed while waiting
+			if err := p.conn.SetReadDeadline(p.net.clock.Time().Add(p.net.pingPongTimeout)); err != nil {
+				p.net.log.Verbo("error on setting the connection read timeout %s, closing the connection", err)
+				return
+			}
+		}
+
 		msgBytes := pendingBuffer.UnpackBytes()
 		if pendingBuffer.Errored() {
 			// if reading the bytes errored, then we haven't read the full
@@ -262,13 +325,25 @@ func (p *peer) WriteMessages() {
 
 	p.Version()
 
-	for msg := range p.sender {
+	for {
+		msg, priority, ok := p.sender.pop()
+		if !ok {
+			return
+		}
 		p.net.log.Verbo("sending new message to %s:\n%s",
 			p.id,
 			formatting.DumpBytes{Bytes: msg})
 
 		atomic.AddInt64(&p.pendingBytes, -int64(len(msg)))
 		atomic.AddInt64(&p.net.pendingBytes, -int64(len(msg)))
+		p.net.queued.WithLabelValues(priority.String()).Dec()
+
+		if wait := p.net.outboundThrottler.throttle(p.id, wrappers.IntLen+len(msg)); wait > 0 {
+			p.net.outboundThrottled.Add(wait.Seconds())
+			if !p.wait(wait) {
+				return
+			}
+		}
 
 		msgb := [wrappers.IntLen]byte{}
 		binary.BigEndian.PutUint32(msgb[:], uint32(len(msg)))
@@ -280,6 +355,7 @@ func (p *peer) WriteMessages() {
 					return
 				}
 				p.tickerOnce.Do(p.StartTicker)
+				atomic.AddUint64(&p.bytesSent, uint64(written))
 				byteSlice = byteSlice[written:]
 			}
 		}
@@ -289,25 +365,43 @@ func (p *peer) WriteMessages() {
 	}
 }
 
+// wait for [duration]. Returns false if the peer was closed first.
+func (p *peer) wait(duration time.Duration) bool {
+	t := time.NewTimer(duration)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-p.tickerCloser:
+		return false
+	}
+}
+
 // send assumes that the [stateLock] is not held.
 func (p *peer) Send(msg Msg) bool {
 	p.senderLock.Lock()
 	defer p.senderLock.Unlock()
 
-	// If the peer was closed then the sender channel was closed and we are
-	// unable to send this message without panicking. So drop the message.
+	// If the peer was closed then the sender queue was closed and this
+	// message can't be sent. So drop the message.
 	if p.closed.GetValue() {
 		p.net.log.Debug("dropping message to %s due to a closed connection", p.id)
 		return false
 	}
 
+	priority := priority(msg)
+	priorityLabel := priority.String()
+
 	// is it possible to send?
 	if dropMsg := p.dropMessagePeer(); dropMsg {
+		p.net.dropped.WithLabelValues(priorityLabel).Inc()
 		p.net.log.Debug("dropping message to %s due to a send queue with too many bytes", p.id)
 		return false
 	}
 
-	msgBytes := msg.Bytes()
+	sentMsg, compressible := p.compress(msg)
+	msgBytes := sentMsg.Bytes()
 	msgBytesLen := int64(len(msgBytes))
 
 	// lets assume send will be successful, we add to the network pending bytes
@@ -318,20 +412,28 @@ func (p *peer) Send(msg Msg) bool {
 	if dropMsg := p.dropMessage(newConnPendingBytes, newPendingBytes); dropMsg {
 		// we never sent the message, remove from pending totals
 		atomic.AddInt64(&p.net.pendingBytes, -msgBytesLen)
+		p.net.dropped.WithLabelValues(priorityLabel).Inc()
 		p.net.log.Debug("dropping message to %s due to a send queue with too many bytes", p.id)
 		return false
 	}
 
-	select {
-	case p.sender <- msgBytes:
-		atomic.AddInt64(&p.pendingBytes, msgBytesLen)
-		return true
-	default:
+	// The pending bytes are added first, as the message may be sent as soon as
+	// it's pushed
+	atomic.AddInt64(&p.pendingBytes, msgBytesLen)
+	p.net.queued.WithLabelValues(priorityLabel).Inc()
+	if !p.sender.push(priority, msgBytes) {
 		// we never sent the message, remove from pending totals
+		atomic.AddInt64(&p.pendingBytes, -msgBytesLen)
 		atomic.AddInt64(&p.net.pendingBytes, -msgBytesLen)
-		p.net.log.Debug("dropping message to %s due to a full send queue", p.id)
+		p.net.queued.WithLabelValues(priorityLabel).Dec()
+		p.net.dropped.WithLabelValues(priorityLabel).Inc()
+		p.net.log.Debug("dropping %s message to %s due to a full send queue", priorityLabel, p.id)
 		return false
 	}
+	if compressible {
+		p.net.metrics.compressed(msg.Op(), len(msg.Bytes()), len(msgBytes))
+	}
+	return true
 }
 
 // assumes the [stateLock] is not held
@@ -373,12 +475,18 @@ func (p *peer) handle(msg Msg) {
 	case PeerList:
 		p.peerList(msg)
 		return
+	case SignedIP:
+		p.signedIP(msg)
+		return
+	case SignedPeerList:
+		p.signedPeerList(msg)
+		return
 	}
 	if !p.connected.GetValue() {
 		p.net.log.Debug("dropping message from %s because the connection hasn't been established yet", p.id)
 
 		// attempt to finish the handshake
-		if !p.gotVersion.GetValue() {
+		if !p.gotFeatures.GetValue() {
 			p.GetVersion()
 		}
 		if !p.gotPeerList.GetValue() {
@@ -416,6 +524,12 @@ func (p *peer) handle(msg Msg) {
 		p.pullQuery(msg)
 	case Chits:
 		p.chits(msg)
+	case AppRequest:
+		p.appRequest(msg)
+	case AppResponse:
+		p.appResponse(msg)
+	case AppGossip:
+		p.appGossip(msg)
 	default:
 		p.net.log.Debug("dropping an unknown message from %s with op %s", p.id, op.String())
 	}
@@ -449,13 +563,44 @@ func (p *peer) close() {
 
 	p.senderLock.Lock()
 	// The locks guarantee here that the sender routine will read that the peer
-	// has been closed and will therefore not attempt to write on this channel.
-	close(p.sender)
+	// has been closed and will therefore not attempt to push onto this queue.
+	numMsgs, numBytes := p.sender.close()
 	p.senderLock.Unlock()
 
+	// The messages that were still queued will never be sent
+	atomic.AddInt64(&p.net.pendingBytes, -int64(numBytes))
+	for priority, num := range numMsgs {
+		p.net.queued.WithLabelValues(Priority(priority).String()).Sub(float64(num))
+	}
+
 	p.net.disconnected(p)
 }
 
+// compress returns [msg] with compressed container bytes, if this node
+// compresses messages, the peer can decompress them and it makes [msg] smaller.
+// Otherwise, [msg] is returned. Also returns whether [msg] was compressible.
+func (p *peer) compress(m Msg) (Msg, bool) {
+	compression := p.net.compression
+	if compression == NoCompression ||
+		!Compressible(m.Op()) ||
+		!p.canParseCompressions() ||
+		!compression.In(byte(atomic.LoadUint32(&p.compressions))) {
+		return m, false
+	}
+	cm, ok := m.(*msg)
+	if !ok {
+		return m, false
+	}
+	cm.compressOnce.Do(func() {
+		cm.compressed, cm.compressErr = p.net.b.Compress(m, compression)
+	})
+	if cm.compressErr != nil {
+		p.net.log.Warn("failed to compress %s message due to %s", m.Op(), cm.compressErr)
+		return m, false
+	}
+	return cm.compressed, true
+}
+
 // assumes the [stateLock] is not held
 func (p *peer) GetVersion() {
 	msg, err := p.net.b.GetVersion()
@@ -465,6 +610,20 @@ func (p *peer) GetVersion() {
 
 // assumes the [stateLock] is not held
 func (p *peer) Version() {
+	compressions, features := byte(0), version.Features(0)
+	sendCompressions := p.canParseCompressions()
+	if p.canParseFeatures() {
+		// Compressions precede features, so peers that can parse features can
+		// parse compressions
+		sendCompressions = true
+		features = version.CurrentFeatures
+		p.sentFeatures.SetValue(true)
+	}
+	if sendCompressions {
+		compressions = supportedCompressions
+		p.sentCompressions.SetValue(true)
+	}
+
 	p.net.stateLock.RLock()
 	msg, err := p.net.b.Version(
 		p.net.networkID,
@@ -472,6 +631,8 @@ func (p *peer) Version() {
 		p.net.clock.Unix(),
 		p.net.ip.IP(),
 		p.net.version.String(),
+		compressions,
+		uint64(features),
 	)
 	p.net.stateLock.RUnlock()
 	p.net.log.AssertNoError(err)
@@ -487,6 +648,16 @@ func (p *peer) GetPeerList() {
 
 // assumes the stateLock is not held
 func (p *peer) SendPeerList() {
+	if p.net.allowlistOnly {
+		// The handshake requires a PeerList, but the IPs of allowed peers are
+		// never shared
+		p.PeerList(nil)
+		return
+	}
+	if p.net.tlsKey != nil && p.supports(version.SignedIPsFeature) {
+		p.SignedPeerList(p.net.validatorClaims())
+		return
+	}
 	ips := p.net.validatorIPs()
 	p.PeerList(ips)
 }
@@ -501,9 +672,57 @@ func (p *peer) PeerList(peers []utils.IPDesc) {
 	p.Send(msg)
 }
 
+// assumes the [stateLock] is not held
+func (p *peer) SignedPeerList(claims []IPClaim) {
+	msg, err := p.net.b.SignedPeerList(claims)
+	if err != nil {
+		p.net.log.Warn("failed to send SignedPeerList message due to %s", err)
+		return
+	}
+	p.Send(msg)
+}
+
+// sendPeerListMsg sends [signedMsg] if the peer supports signed IP claims, and
+// [msg] otherwise. assumes the [stateLock] is not held
+func (p *peer) sendPeerListMsg(msg, signedMsg Msg) {
+	if p.supports(version.SignedIPsFeature) {
+		p.Send(signedMsg)
+	} else {
+		p.Send(msg)
+	}
+}
+
+// assumes the [stateLock] is not held
+func (p *peer) SignedIP() {
+	claim, err := p.net.signedIP()
+	if err != nil {
+		p.net.log.Warn("failed to sign IP claim due to %s", err)
+		return
+	}
+	msg, err := p.net.b.SignedIP(claim.Timestamp, claim.IP, claim.Signature)
+	if err != nil {
+		p.net.log.Warn("failed to send SignedIP message due to %s", err)
+		return
+	}
+	p.Send(msg)
+}
+
 // assumes the [stateLock] is not held
 func (p *peer) Ping() {
-	msg, err := p.net.b.Ping()
+	nonce := uint32(0)
+	if p.supports(version.PingNoncesFeature) {
+		p.pingLock.Lock()
+		// A Pong to an earlier Ping that hasn't arrived yet is ignored
+		p.pingNonce++
+		if p.pingNonce == 0 {
+			p.pingNonce++
+		}
+		nonce = p.pingNonce
+		p.pingSent = p.net.clock.Time()
+		p.pingLock.Unlock()
+	}
+
+	msg, err := p.net.b.Ping(nonce)
 	p.net.log.AssertNoError(err)
 	if p.Send(msg) {
 		p.net.ping.numSent.Inc()
@@ -513,8 +732,8 @@ func (p *peer) Ping() {
 }
 
 // assumes the [stateLock] is not held
-func (p *peer) Pong() {
-	msg, err := p.net.b.Pong()
+func (p *peer) Pong(nonce uint32) {
+	msg, err := p.net.b.Pong(nonce)
 	p.net.log.AssertNoError(err)
 	if p.Send(msg) {
 		p.net.pong.numSent.Inc()
@@ -529,6 +748,13 @@ func (p *peer) getVersion(_ Msg) { p.Version() }
 // assumes the [stateLock] is not held
 func (p *peer) version(msg Msg) {
 	if p.gotVersion.GetValue() {
+		// A peer that didn't know whether this node could parse its
+		// compressions or features reports them in a later version message
+		p.setCompressions(msg)
+		if features, ok := msg.Get(Features).(uint64); ok && !p.gotFeatures.GetValue() {
+			p.setFeatures(version.Features(features))
+			return
+		}
 		p.net.log.Verbo("dropping duplicated version message from %s", p.id)
 		return
 	}
@@ -588,7 +814,7 @@ func (p *peer) version(msg Msg) {
 		}
 	}
 
-	if err := p.net.version.Compatible(peerVersion); err != nil {
+	if err := p.net.compatibility.Compatible(peerVersion, p.net.clock.Time()); err != nil {
 		p.net.log.Debug("peer version not compatible due to %s", err)
 
 		if !p.net.beacons.Contains(p.id) {
@@ -617,29 +843,76 @@ func (p *peer) version(msg Msg) {
 		}
 	}
 
-	p.SendPeerList()
+	p.setCompressions(msg)
 
+	// The version must be set first so that the peer is only sent messages it
+	// can parse
 	p.versionStruct.SetValue(peerVersion)
 	p.versionStr.SetValue(peerVersion.String())
 	p.gotVersion.SetValue(true)
 
+	// Now that the peer's version is known, it may be able to parse this node's
+	// compressions and features
+	if (!p.sentCompressions.GetValue() && p.canParseCompressions()) ||
+		(!p.sentFeatures.GetValue() && p.canParseFeatures()) {
+		p.Version()
+	}
+
+	if features, ok := msg.Get(Features).(uint64); ok {
+		p.setFeatures(version.Features(features))
+	} else if peerVersion.Before(minimumFeaturesVersion) {
+		p.setFeatures(0)
+	}
+	// Otherwise, the peer reports its features once it receives this node's
+	// version
+}
+
+// setCompressions records the compression algorithms that the peer reported
+// in the version message [msg], if any
+func (p *peer) setCompressions(msg Msg) {
+	if compressions, ok := msg.Get(Compressions).(byte); ok {
+		atomic.StoreUint32(&p.compressions, uint32(compressions))
+	}
+}
+
+// setFeatures records the features the peer reported and finishes the
+// handshake, which must know which messages the peer can parse.
+// assumes the [stateLock] is not held
+func (p *peer) setFeatures(features version.Features) {
+	atomic.StoreUint64(&p.features, uint64(features))
+	p.gotFeatures.SetValue(true)
+
+	if p.net.tlsKey != nil && p.supports(version.SignedIPsFeature) {
+		p.SignedIP()
+	}
+	p.SendPeerList()
+
 	p.tryMarkConnected()
 }
 
 // assumes the [stateLock] is not held
 func (p *peer) getPeerList(_ Msg) {
-	if p.gotVersion.GetValue() {
+	if p.gotFeatures.GetValue() {
 		p.SendPeerList()
 	}
 }
 
 // assumes the [stateLock] is not held
 func (p *peer) peerList(msg Msg) {
-	ips := msg.Get(Peers).([]utils.IPDesc)
-
 	p.gotPeerList.SetValue(true)
 	p.tryMarkConnected()
 
+	if p.net.allowlistOnly {
+		// Only the static IPs of allowed peers are dialed
+		return
+	}
+
+	if p.net.tlsKey != nil {
+		// Unsigned IPs are ignored, as anyone could have claimed them
+		return
+	}
+
+	ips := msg.Get(Peers).([]utils.IPDesc)
 	for _, ip := range ips {
 		p.net.stateLock.Lock()
 		if !ip.Equal(p.net.ip.IP()) &&
@@ -653,10 +926,104 @@ func (p *peer) peerList(msg Msg) {
 }
 
 // assumes the [stateLock] is not held
-func (p *peer) ping(_ Msg) { p.Pong() }
+func (p *peer) signedPeerList(msg Msg) {
+	p.gotPeerList.SetValue(true)
+	p.tryMarkConnected()
+
+	if p.net.allowlistOnly || p.net.tlsKey == nil {
+		// Claims can only be verified if TLS is enabled
+		return
+	}
+
+	claims := msg.Get(SignedPeers).([]IPClaim)
+	for i := range claims {
+		p.net.trackClaim(&claims[i])
+	}
+}
+
+// assumes the [stateLock] is not held
+func (p *peer) signedIP(msg Msg) {
+	if p.net.tlsKey == nil || p.cert == nil {
+		p.net.log.Debug("dropping SignedIP message from %s as TLS is disabled", p.id)
+		return
+	}
+
+	claim := &IPClaim{
+		Cert:      p.cert.Raw,
+		IP:        msg.Get(IP).(utils.IPDesc),
+		Timestamp: msg.Get(MyTime).(uint64),
+		Signature: msg.Get(SigBytes).([]byte),
+	}
+	if err := claim.verify(p.cert); err != nil {
+		p.net.log.Debug("peer %s sent an IP claim with an invalid signature: %s", p.id, err)
+
+		p.discardIP()
+		return
+	}
+
+	if p.net.fromFuture(claim) {
+		p.net.log.Debug("dropping IP claim from %s made in the future", p.id)
+		return
+	}
+	if p.net.expired(claim) {
+		p.net.log.Debug("dropping expired IP claim from %s", p.id)
+		return
+	}
+
+	// A peer may reconnect with the claim it was last known by, but not with
+	// an older one
+	p.net.stateLock.Lock()
+	stale := claim.Timestamp < p.net.ipClaims[p.id]
+	if !stale && p.net.vdrs.Contains(p.id) {
+		p.net.ipClaims[p.id] = claim.Timestamp
+	}
+	p.net.stateLock.Unlock()
+
+	if stale {
+		p.net.log.Debug("dropping stale IP claim from %s", p.id)
+		return
+	}
+	p.claim.SetValue(claim)
+}
+
+// assumes the [stateLock] is not held
+func (p *peer) ping(msg Msg) {
+	nonce, _ := msg.Get(Nonce).(uint32)
+	p.Pong(nonce)
+}
 
 // assumes the [stateLock] is not held
-func (p *peer) pong(_ Msg) {}
+func (p *peer) pong(msg Msg) {
+	nonce, ok := msg.Get(Nonce).(uint32)
+	if !ok {
+		return
+	}
+
+	p.pingLock.Lock()
+	if nonce == 0 || nonce != p.pingNonce {
+		p.pingLock.Unlock()
+		p.net.log.Verbo("dropping pong from %s with unexpected nonce %d", p.id, nonce)
+		return
+	}
+	p.pingNonce = 0
+	sample := p.net.clock.Time().Sub(p.pingSent)
+	p.pingLock.Unlock()
+
+	if sample < 0 {
+		sample = 0
+	}
+	p.net.rtt.Observe(float64(sample.Milliseconds()))
+
+	// The smoothed round trip time is an exponentially weighted moving
+	// average, weighted as in RFC 6298
+	rtt := atomic.LoadInt64(&p.rtt)
+	if rtt == 0 {
+		rtt = int64(sample)
+	} else {
+		rtt += (int64(sample) - rtt) / rttSmoothingFactor
+	}
+	atomic.StoreInt64(&p.rtt, rtt)
+}
 
 // assumes the [stateLock] is not held
 func (p *peer) getAcceptedFrontier(msg Msg) {
@@ -843,10 +1210,71 @@ func (p *peer) chits(msg Msg) {
 	p.net.router.Chits(p.id, chainID, requestID, containerIDs)
 }
 
+// assumes the [stateLock] is not held
+func (p *peer) appRequest(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	deadline := p.net.clock.Time().Add(time.Duration(msg.Get(Deadline).(uint64)))
+	appBytes := msg.Get(AppBytes).([]byte)
+
+	p.net.router.AppRequest(p.id, chainID, requestID, deadline, appBytes)
+}
+
+// assumes the [stateLock] is not held
+func (p *peer) appResponse(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	appBytes := msg.Get(AppBytes).([]byte)
+
+	p.net.router.AppResponse(p.id, chainID, requestID, appBytes)
+}
+
+// assumes the [stateLock] is not held
+func (p *peer) appGossip(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	appBytes := msg.Get(AppBytes).([]byte)
+
+	p.net.router.AppGossip(p.id, chainID, appBytes)
+}
+
+// canParseCompressions returns true if the peer reported a version that can
+// parse a version message that reports compressions, and decompress messages
+func (p *peer) canParseCompressions() bool {
+	peerVersion, ok := p.versionStruct.GetValue().(version.Version)
+	return ok && !peerVersion.Before(minimumCompressionsVersion)
+}
+
+// canParseFeatures returns true if the peer can parse a version message that
+// reports features. Before the peer sends its version, this is only known if
+// every compatible version can.
+func (p *peer) canParseFeatures() bool {
+	peerVersion, ok := p.versionStruct.GetValue().(version.Version)
+	if !ok {
+		if p.net.beacons.Contains(p.id) {
+			// Beacons may connect with incompatible versions
+			return false
+		}
+		peerVersion = p.net.compatibility.MinimumVersion(p.net.clock.Time())
+	}
+	return !peerVersion.Before(minimumFeaturesVersion)
+}
+
+// supports returns true if the peer reported that it supports [feature]
+func (p *peer) supports(feature version.Feature) bool {
+	return p.getFeatures().Contains(feature)
+}
+
+func (p *peer) getFeatures() version.Features {
+	return version.Features(atomic.LoadUint64(&p.features))
+}
+
 // assumes the [stateLock] is held
 func (p *peer) tryMarkConnected() {
 	if !p.connected.GetValue() && // not already connected
-		p.gotVersion.GetValue() && // not waiting for version
+		p.gotFeatures.GetValue() && // not waiting for version or features
 		p.gotPeerList.GetValue() && // not waiting for peerlist
 		!p.closed.GetValue() { // and not already disconnected
 		p.net.connected(p)
@@ -892,6 +1320,16 @@ func (p *peer) getIP() utils.IPDesc {
 	return p.ip
 }
 
+// remoteIP returns the IP, without the port, of the other end of the
+// connection, or nil if it's unknown
+func (p *peer) remoteIP() net.IP {
+	ip, err := utils.ToIPDesc(p.conn.RemoteAddr().String())
+	if err != nil {
+		return nil
+	}
+	return ip.IP
+}
+
 // addAlias marks that we have found another
 // IP that we can connect to this peer at.
 //
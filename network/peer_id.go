@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
 )
 
 // PeerID ...
@@ -18,4 +19,13 @@ type PeerID struct {
 	LastSent     time.Time `json:"lastSent"`
 	LastReceived time.Time `json:"lastReceived"`
 	Benched      []ids.ID  `json:"benched"`
+	// Smoothed round trip time to the peer, measured with Ping and Pong. It is
+	// 0s if the peer's version doesn't support measuring it.
+	RTT           string      `json:"rtt"`
+	BytesSent     json.Uint64 `json:"bytesSent"`
+	BytesReceived json.Uint64 `json:"bytesReceived"`
+	// True if this node dialed the peer, false if the peer dialed this node
+	Outbound bool `json:"outbound"`
+	// Features the peer reported during the handshake
+	Features []string `json:"features"`
 }
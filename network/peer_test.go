@@ -0,0 +1,51 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestPeerRTT(t *testing.T) {
+	n := &network{log: logging.NoLog{}}
+	if err := n.initialize(prometheus.NewRegistry()); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	n.clock.Set(now)
+	p := newPeer(n, nil, utils.IPDesc{})
+
+	pong := func(nonce uint32) {
+		msg, err := n.b.Pong(nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.pong(msg)
+	}
+
+	p.pingNonce = 1
+	p.pingSent = now.Add(-100 * time.Millisecond)
+	pong(2)
+	assert.Zero(t, p.rtt, "pong with the wrong nonce should be ignored")
+	pong(0)
+	assert.Zero(t, p.rtt, "pong without a nonce should be ignored")
+
+	pong(1)
+	assert.Equal(t, int64(100*time.Millisecond), p.rtt, "first sample should be the round trip time")
+
+	pong(1)
+	assert.Equal(t, int64(100*time.Millisecond), p.rtt, "duplicate pong should be ignored")
+
+	p.pingNonce = 2
+	p.pingSent = now.Add(-900 * time.Millisecond)
+	pong(2)
+	assert.Equal(t, int64(200*time.Millisecond), p.rtt, "later samples should be smoothed")
+}
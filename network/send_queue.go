@@ -0,0 +1,194 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// Priority of a message sent to a peer. Each priority has its own queue, and
+// the queues share the connection in proportion to their weights.
+type Priority int
+
+// Priorities, from most to least urgent
+const (
+	// Consensus replies, and handshake messages that keep the connection alive
+	ReplyPriority Priority = iota
+	// Consensus queries
+	QueryPriority
+	// Bootstrapping requests and replies
+	BootstrapPriority
+	// Gossip
+	GossipPriority
+
+	numPriorities
+)
+
+const (
+	// Bytes a queue may send each round per unit of weight
+	sendQuantum = 16 * 1024
+)
+
+// Share of the connection of each priority, relative to the others
+var priorityWeights = [numPriorities]int{
+	ReplyPriority:     8,
+	QueryPriority:     4,
+	BootstrapPriority: 2,
+	GossipPriority:    1,
+}
+
+func (p Priority) String() string {
+	switch p {
+	case ReplyPriority:
+		return "reply"
+	case QueryPriority:
+		return "query"
+	case BootstrapPriority:
+		return "bootstrap"
+	case GossipPriority:
+		return "gossip"
+	default:
+		return "unknown"
+	}
+}
+
+// priority returns the priority that [msg] is sent with
+func priority(msg Msg) Priority {
+	switch msg.Op() {
+	case Chits, AppResponse:
+		return ReplyPriority
+	case Put:
+		if requestID, _ := msg.Get(RequestID).(uint32); requestID == constants.GossipMsgRequestID {
+			return GossipPriority
+		}
+		return ReplyPriority
+	case PushQuery, PullQuery, Get, AppRequest:
+		return QueryPriority
+	case GetAcceptedFrontier, AcceptedFrontier, GetAccepted, Accepted, GetAncestors, MultiPut:
+		return BootstrapPriority
+	case PeerList, SignedPeerList, AppGossip:
+		return GossipPriority
+	default:
+		return ReplyPriority
+	}
+}
+
+// sendQueue holds the messages waiting to be sent to a peer. Messages are
+// dequeued with deficit round robin, so each priority gets a share of the bytes
+// sent in proportion to its weight while it has messages waiting.
+type sendQueue struct {
+	lock sync.Mutex
+	cond *sync.Cond
+
+	// maximum number of messages waiting in each queue
+	maxLen int
+
+	queues [numPriorities][][]byte
+	// number of bytes each queue may send before the next queue's turn
+	deficits [numPriorities]int
+	// queue whose turn it is
+	current Priority
+	// true if [current] has been given its quantum this turn
+	credited bool
+
+	closed bool
+}
+
+func newSendQueue(maxLen int) *sendQueue {
+	q := &sendQueue{maxLen: maxLen}
+	q.cond = sync.NewCond(&q.lock)
+	return q
+}
+
+// push [msg] onto the queue of priority [p]. Returns false if that queue is
+// full or the sendQueue is closed.
+func (q *sendQueue) push(p Priority, msg []byte) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.closed || len(q.queues[p]) >= q.maxLen {
+		return false
+	}
+	q.queues[p] = append(q.queues[p], msg)
+	q.cond.Signal()
+	return true
+}
+
+// pop the next message to send and its priority, waiting until there is one.
+// Returns false if the sendQueue was closed.
+func (q *sendQueue) pop() ([]byte, Priority, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for !q.closed && q.empty() {
+		q.cond.Wait()
+	}
+	if q.closed {
+		return nil, 0, false
+	}
+
+	for {
+		queue := q.queues[q.current]
+		if len(queue) == 0 {
+			// Empty queues don't save up their turns
+			q.deficits[q.current] = 0
+			q.nextTurn()
+			continue
+		}
+		if !q.credited {
+			q.deficits[q.current] += priorityWeights[q.current] * sendQuantum
+			q.credited = true
+		}
+		msg := queue[0]
+		if len(msg) > q.deficits[q.current] {
+			q.nextTurn()
+			continue
+		}
+
+		p := q.current
+		q.deficits[p] -= len(msg)
+		queue[0] = nil
+		q.queues[p] = queue[1:]
+		if len(q.queues[p]) == 0 {
+			q.queues[p] = nil
+		}
+		return msg, p, true
+	}
+}
+
+// close the queue, returning the number of messages of each priority and the
+// total number of bytes that will never be sent
+func (q *sendQueue) close() ([numPriorities]int, int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	numMsgs := [numPriorities]int{}
+	numBytes := 0
+	for p, queue := range q.queues {
+		numMsgs[p] = len(queue)
+		for _, msg := range queue {
+			numBytes += len(msg)
+		}
+		q.queues[p] = nil
+	}
+	q.closed = true
+	q.cond.Broadcast()
+	return numMsgs, numBytes
+}
+
+func (q *sendQueue) nextTurn() {
+	q.current = (q.current + 1) % numPriorities
+	q.credited = false
+}
+
+func (q *sendQueue) empty() bool {
+	for _, queue := range q.queues {
+		if len(queue) != 0 {
+			return false
+		}
+	}
+	return true
+}
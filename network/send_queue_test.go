@@ -0,0 +1,134 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestPriority(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	containerID := ids.Empty.Prefix(1)
+
+	chits, err := TestBuilder.Chits(chainID, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ReplyPriority, priority(chits))
+
+	put, err := TestBuilder.Put(chainID, 1, containerID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ReplyPriority, priority(put))
+
+	gossip, err := TestBuilder.Put(chainID, constants.GossipMsgRequestID, containerID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, GossipPriority, priority(gossip))
+
+	pushQuery, err := TestBuilder.PushQuery(chainID, 1, 0, containerID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, QueryPriority, priority(pushQuery))
+
+	multiPut, err := TestBuilder.MultiPut(chainID, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, BootstrapPriority, priority(multiPut))
+
+	signedPeerList, err := TestBuilder.SignedPeerList(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, GossipPriority, priority(signedPeerList))
+
+	ping, err := TestBuilder.Ping(0)
+	assert.NoError(t, err)
+	assert.Equal(t, ReplyPriority, priority(ping))
+}
+
+func TestSendQueueMaxLen(t *testing.T) {
+	q := newSendQueue(1)
+	assert.True(t, q.push(GossipPriority, []byte{1}))
+	assert.False(t, q.push(GossipPriority, []byte{2}), "full queue should drop messages")
+	assert.True(t, q.push(ReplyPriority, []byte{3}), "each priority should have its own queue")
+}
+
+func TestSendQueueWeightedFair(t *testing.T) {
+	q := newSendQueue(1000)
+	msg := make([]byte, sendQuantum)
+	for i := 0; i < 100; i++ {
+		assert.True(t, q.push(GossipPriority, msg))
+		assert.True(t, q.push(ReplyPriority, msg))
+	}
+
+	// Each round, replies send 8 quanta for every quantum of gossip
+	sent := [numPriorities]int{}
+	for i := 0; i < 9*10; i++ {
+		_, p, ok := q.pop()
+		assert.True(t, ok)
+		sent[p]++
+	}
+	assert.Equal(t, 80, sent[ReplyPriority])
+	assert.Equal(t, 10, sent[GossipPriority])
+}
+
+func TestSendQueueReplyNotDelayed(t *testing.T) {
+	q := newSendQueue(1000)
+	for i := 0; i < 100; i++ {
+		assert.True(t, q.push(GossipPriority, make([]byte, 1024)))
+	}
+	_, p, ok := q.pop()
+	assert.True(t, ok)
+	assert.Equal(t, GossipPriority, p)
+
+	reply := []byte{1}
+	assert.True(t, q.push(ReplyPriority, reply))
+
+	// The reply is sent as soon as gossip uses up its turn, rather than after
+	// all the queued gossip
+	for i := 0; i < sendQuantum/1024; i++ {
+		msg, p, ok := q.pop()
+		assert.True(t, ok)
+		if p == ReplyPriority {
+			assert.Equal(t, reply, msg)
+			return
+		}
+	}
+	t.Fatal("reply was delayed behind gossip")
+}
+
+func TestSendQueueLargeMessage(t *testing.T) {
+	q := newSendQueue(1)
+	msg := make([]byte, 10*sendQuantum)
+	assert.True(t, q.push(GossipPriority, msg))
+
+	popped, p, ok := q.pop()
+	assert.True(t, ok)
+	assert.Equal(t, GossipPriority, p)
+	assert.Len(t, popped, len(msg), "messages larger than a quantum should eventually be sent")
+}
+
+func TestSendQueueClose(t *testing.T) {
+	q := newSendQueue(10)
+	assert.True(t, q.push(GossipPriority, []byte{1, 2}))
+	assert.True(t, q.push(ReplyPriority, []byte{3}))
+
+	numMsgs, numBytes := q.close()
+	assert.Equal(t, 1, numMsgs[GossipPriority])
+	assert.Equal(t, 1, numMsgs[ReplyPriority])
+	assert.Equal(t, 3, numBytes)
+
+	_, _, ok := q.pop()
+	assert.False(t, ok, "closed queue shouldn't return messages")
+	assert.False(t, q.push(ReplyPriority, []byte{4}), "closed queue shouldn't accept messages")
+
+	// Closing unblocks a waiting pop
+	q = newSendQueue(10)
+	done := make(chan struct{})
+	go func() {
+		_, _, ok := q.pop()
+		assert.False(t, ok)
+		close(done)
+	}()
+	q.close()
+	<-done
+}
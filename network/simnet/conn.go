@@ -0,0 +1,317 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Number of writes that may be in flight on a pipe before writes block
+const maxInFlight = 1024
+
+var _ net.Error = timeoutError{}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// segment is a write in flight
+type segment struct {
+	data    []byte
+	arrival time.Time
+	// If true, the writer closed the connection after its prior writes
+	eof bool
+}
+
+// pipe carries bytes in one direction of a connection, from the host [from] to
+// the host [to]
+type pipe struct {
+	net      *Network
+	from, to net.IP
+
+	// Time at which the last write has been sent, and the time at which it
+	// arrives. Guarded by the lock of [net].
+	busyUntil, lastArrival time.Time
+
+	// Writes in flight, delivered by relay
+	segments chan segment
+	// Closed when the pipe is torn down
+	done     chan struct{}
+	doneOnce sync.Once
+
+	lock sync.Mutex
+	cond *sync.Cond
+	// Bytes that arrived but haven't been read
+	buf []byte
+	// True if the writer closed the connection and every write has arrived
+	eof bool
+	// Set if the pipe was torn down
+	err           error
+	readDeadline  time.Time
+	deadlineTimer *time.Timer
+}
+
+func newPipe(n *Network, from, to net.IP) *pipe {
+	p := &pipe{
+		net:      n,
+		from:     from,
+		to:       to,
+		segments: make(chan segment, maxInFlight),
+		done:     make(chan struct{}),
+	}
+	p.cond = sync.NewCond(&p.lock)
+	go p.relay()
+	return p
+}
+
+// relay delivers the writes in flight once they arrive
+func (p *pipe) relay() {
+	for {
+		select {
+		case seg := <-p.segments:
+			timer := time.NewTimer(time.Until(seg.arrival))
+			select {
+			case <-timer.C:
+			case <-p.done:
+				timer.Stop()
+				return
+			}
+			p.deliver(seg)
+			if seg.eof {
+				// Nothing is written after the writer closes
+				return
+			}
+		case <-p.done:
+			return
+		}
+	}
+}
+
+func (p *pipe) deliver(seg segment) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.err != nil {
+		return
+	}
+	p.buf = append(p.buf, seg.data...)
+	p.eof = p.eof || seg.eof
+	p.cond.Broadcast()
+}
+
+// send [seg] over the pipe
+func (p *pipe) send(seg segment) error {
+	select {
+	case p.segments <- seg:
+		return nil
+	case <-p.done:
+		return p.getErr()
+	}
+}
+
+func (p *pipe) read(b []byte) (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for {
+		switch {
+		case p.err != nil:
+			return 0, p.err
+		case len(p.buf) > 0:
+			n := copy(b, p.buf)
+			p.buf = p.buf[n:]
+			return n, nil
+		case p.eof:
+			return 0, io.EOF
+		case !p.readDeadline.IsZero() && !time.Now().Before(p.readDeadline):
+			return 0, timeoutError{}
+		}
+		p.cond.Wait()
+	}
+}
+
+func (p *pipe) setReadDeadline(t time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.readDeadline = t
+	if p.deadlineTimer != nil {
+		p.deadlineTimer.Stop()
+		p.deadlineTimer = nil
+	}
+	if !t.IsZero() {
+		p.deadlineTimer = time.AfterFunc(time.Until(t), func() {
+			p.lock.Lock()
+			p.cond.Broadcast()
+			p.lock.Unlock()
+		})
+	}
+	p.cond.Broadcast()
+}
+
+// shutdown tears down the pipe. Bytes that haven't been read are discarded, and
+// later reads and writes fail with [err].
+func (p *pipe) shutdown(err error) {
+	p.lock.Lock()
+	if p.err == nil {
+		p.err = err
+	}
+	p.buf = nil
+	if p.deadlineTimer != nil {
+		p.deadlineTimer.Stop()
+	}
+	p.cond.Broadcast()
+	p.lock.Unlock()
+
+	p.doneOnce.Do(func() { close(p.done) })
+}
+
+func (p *pipe) getErr() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.err
+}
+
+// conn is one end of a connection. It reads from [in] and writes to [out].
+type conn struct {
+	net           *Network
+	local, remote *net.TCPAddr
+	in, out       *pipe
+	// The other end of the connection
+	peer *conn
+
+	// Serializes writes, so that each one is sent as a whole
+	writeLock sync.Mutex
+
+	lock          sync.Mutex
+	closed        bool
+	writeDeadline time.Time
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	n, err := c.in.read(b)
+	if err != nil && c.isClosed() {
+		return 0, errClosed
+	}
+	return n, err
+}
+
+// Write [b] to the connection. It returns once [b] has been sent, which may
+// take a while if bandwidth is limited, but doesn't wait for [b] to arrive. The
+// write deadline is only checked before [b] is sent.
+func (c *conn) Write(b []byte) (int, error) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if c.isClosed() {
+		return 0, errClosed
+	}
+	c.lock.Lock()
+	deadline := c.writeDeadline
+	c.lock.Unlock()
+	if !deadline.IsZero() && !time.Now().Before(deadline) {
+		return 0, timeoutError{}
+	}
+	if err := c.out.getErr(); err != nil {
+		return 0, err
+	}
+
+	sent, arrival, err := c.net.schedule(c.out, len(b))
+	if err != nil {
+		c.reset()
+		return 0, err
+	}
+	err = c.out.send(segment{
+		data:    append([]byte(nil), b...),
+		arrival: arrival,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	wait := time.Until(sent)
+	if wait <= 0 {
+		return len(b), nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return len(b), nil
+	case <-c.out.done:
+		return 0, c.out.getErr()
+	}
+}
+
+// Close the connection. Bytes already written are still delivered to the other
+// end, which then reads io.EOF.
+func (c *conn) Close() error {
+	c.lock.Lock()
+	if c.closed {
+		c.lock.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.lock.Unlock()
+
+	// The other end's writes fail, as if the connection was reset
+	c.in.shutdown(errReset)
+	go func() {
+		_, arrival, err := c.net.schedule(c.out, 0)
+		if err != nil {
+			c.out.shutdown(errReset)
+			return
+		}
+		_ = c.out.send(segment{
+			arrival: arrival,
+			eof:     true,
+		})
+	}()
+	c.net.removeConn(c)
+	return nil
+}
+
+// reset both ends of the connection. Reads and writes on either end fail.
+func (c *conn) reset() {
+	c.in.shutdown(errReset)
+	c.out.shutdown(errReset)
+	c.net.removeConn(c)
+	c.net.removeConn(c.peer)
+}
+
+func (c *conn) isClosed() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.closed
+}
+
+func (c *conn) LocalAddr() net.Addr  { return c.local }
+func (c *conn) RemoteAddr() net.Addr { return c.remote }
+
+func (c *conn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	c.in.setReadDeadline(t)
+	return nil
+}
+
+func (c *conn) SetWriteDeadline(t time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.writeDeadline = t
+	return nil
+}
@@ -0,0 +1,365 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simnet is an in-process simulation of a TCP/IP network. Its
+// listeners and dialers can be given to network.NewNetwork so that many nodes
+// can run in one process, over links whose latency, bandwidth and packet loss
+// are configurable, and between which partitions can be introduced.
+package simnet
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+const (
+	// First port assigned to the outbound connections of a host
+	firstEphemeralPort = 49152
+
+	// Number of connections a listener may have that haven't been accepted
+	acceptBacklog = 128
+
+	// Max number of retransmissions of a single write. Bounds the delay of a
+	// write when the loss rate is close to 1.
+	maxRetransmits = 16
+)
+
+var (
+	errRefused     = errors.New("connection refused")
+	errUnreachable = errors.New("host unreachable")
+	errAddrInUse   = errors.New("address already in use")
+	errReset       = errors.New("connection reset by peer")
+	errClosed      = errors.New("use of closed network connection")
+	errInvalidLoss = errors.New("loss rate must be in [0, 1)")
+)
+
+// LinkConfig describes a link between two hosts. It applies to each direction
+// independently.
+type LinkConfig struct {
+	// Time it takes a byte to cross the link, once it's been sent
+	Latency time.Duration
+
+	// Max bytes per second sent over the link. If 0, bandwidth is unlimited.
+	BytesPerSec uint64
+
+	// Portion, in [0, 1), of writes that are lost. Like TCP, a lost write is
+	// retransmitted after [RetransmitDelay], and writes are still delivered in
+	// order, so loss shows up as delay rather than as corrupted streams.
+	LossRate float64
+
+	// Time before a lost write is retransmitted
+	RetransmitDelay time.Duration
+}
+
+// Verify that the config is valid
+func (c LinkConfig) Verify() error {
+	if c.LossRate < 0 || c.LossRate >= 1 {
+		return fmt.Errorf("%w: %f", errInvalidLoss, c.LossRate)
+	}
+	return nil
+}
+
+// Network is a simulated network of hosts, each identified by an IP. It's
+// safe for concurrent use.
+type Network struct {
+	lock sync.Mutex
+	rng  *rand.Rand
+
+	defaultLink LinkConfig
+	// Links that don't use [defaultLink], keyed by linkKey
+	links map[string]LinkConfig
+
+	// Partition group of each host, keyed by net.IP.String(). Hosts that
+	// aren't in the map are in group 0.
+	groups map[string]int
+
+	// Listeners keyed by utils.IPDesc.String()
+	listeners map[string]*listener
+	// Next ephemeral port of each host, keyed by net.IP.String()
+	nextPorts map[string]uint16
+	// Connections that are open
+	conns map[*conn]struct{}
+}
+
+// NewNetwork returns a network whose links are described by [defaultLink],
+// unless set otherwise with SetLink. Packet loss is decided by a source seeded
+// with [seed].
+func NewNetwork(defaultLink LinkConfig, seed int64) (*Network, error) {
+	if err := defaultLink.Verify(); err != nil {
+		return nil, err
+	}
+	return &Network{
+		rng:         rand.New(rand.NewSource(seed)), // #nosec G404
+		defaultLink: defaultLink,
+		links:       make(map[string]LinkConfig),
+		groups:      make(map[string]int),
+		listeners:   make(map[string]*listener),
+		nextPorts:   make(map[string]uint16),
+		conns:       make(map[*conn]struct{}),
+	}, nil
+}
+
+// Listen for connections to [ip]
+func (n *Network) Listen(ip utils.IPDesc) (net.Listener, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	key := ip.String()
+	if _, ok := n.listeners[key]; ok {
+		return nil, fmt.Errorf("%w: %s", errAddrInUse, key)
+	}
+	l := &listener{
+		net:     n,
+		addr:    &net.TCPAddr{IP: ip.IP, Port: int(ip.Port)},
+		key:     key,
+		inbound: make(chan net.Conn, acceptBacklog),
+		closed:  make(chan struct{}),
+	}
+	n.listeners[key] = l
+	return l, nil
+}
+
+// Dialer returns a dialer whose connections originate from the host [ip]
+func (n *Network) Dialer(ip net.IP) network.Dialer {
+	return &dialer{
+		net: n,
+		ip:  ip,
+	}
+}
+
+// SetLink sets the config of the links between hosts [a] and [b], in both
+// directions. It applies to writes made on existing connections too.
+func (n *Network) SetLink(a, b net.IP, config LinkConfig) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.links[linkKey(a, b)] = config
+	n.links[linkKey(b, a)] = config
+	return nil
+}
+
+// Partition the hosts into [groups]. Hosts in different groups can't reach
+// each other: dials between them fail, and connections between them are reset.
+// Hosts that aren't in any group form a group of their own. Replaces any
+// previous partition.
+func (n *Network) Partition(groups ...[]net.IP) {
+	n.lock.Lock()
+	n.groups = make(map[string]int)
+	for i, group := range groups {
+		for _, ip := range group {
+			n.groups[ip.String()] = i + 1
+		}
+	}
+	toReset := []*conn(nil)
+	for c := range n.conns {
+		if !n.reachable(c.local.IP, c.remote.IP) {
+			toReset = append(toReset, c)
+		}
+	}
+	n.lock.Unlock()
+
+	for _, c := range toReset {
+		c.reset()
+	}
+}
+
+// Heal the partition, so that every host can reach every other host again
+func (n *Network) Heal() { n.Partition() }
+
+// dial [ip] from the host [from]
+func (n *Network) dial(from net.IP, ip utils.IPDesc) (net.Conn, error) {
+	n.lock.Lock()
+	l, ok := n.listeners[ip.String()]
+	if !ok {
+		n.lock.Unlock()
+		return nil, fmt.Errorf("dial %s: %w", ip, errRefused)
+	}
+	if !n.reachable(from, ip.IP) {
+		n.lock.Unlock()
+		return nil, fmt.Errorf("dial %s: %w", ip, errUnreachable)
+	}
+	rtt := n.link(from, ip.IP).Latency + n.link(ip.IP, from).Latency
+	local := &net.TCPAddr{IP: from, Port: int(n.ephemeralPort(from))}
+	n.lock.Unlock()
+
+	// Wait for the handshake to cross the link and back
+	time.Sleep(rtt)
+
+	n.lock.Lock()
+	if !n.reachable(from, ip.IP) {
+		n.lock.Unlock()
+		return nil, fmt.Errorf("dial %s: %w", ip, errUnreachable)
+	}
+	client, server := n.newConnPair(local, l.addr)
+	n.lock.Unlock()
+
+	select {
+	case <-l.closed:
+	case l.inbound <- server:
+		return client, nil
+	default:
+	}
+	client.reset()
+	return nil, fmt.Errorf("dial %s: %w", ip, errRefused)
+}
+
+// newConnPair returns the two ends of a new connection between [local] and
+// [remote].
+// assumes the lock is held.
+func (n *Network) newConnPair(local, remote *net.TCPAddr) (*conn, *conn) {
+	toRemote := newPipe(n, local.IP, remote.IP)
+	toLocal := newPipe(n, remote.IP, local.IP)
+	client := &conn{
+		net:    n,
+		local:  local,
+		remote: remote,
+		in:     toLocal,
+		out:    toRemote,
+	}
+	server := &conn{
+		net:    n,
+		local:  remote,
+		remote: local,
+		in:     toRemote,
+		out:    toLocal,
+	}
+	client.peer = server
+	server.peer = client
+	n.conns[client] = struct{}{}
+	n.conns[server] = struct{}{}
+	return client, server
+}
+
+// ephemeralPort returns an unused port of [ip] for an outbound connection.
+// assumes the lock is held.
+func (n *Network) ephemeralPort(ip net.IP) uint16 {
+	key := ip.String()
+	port := n.nextPorts[key]
+	if port < firstEphemeralPort {
+		port = firstEphemeralPort
+	}
+	n.nextPorts[key] = port + 1
+	return port
+}
+
+// link returns the config of the link from [from] to [to].
+// assumes the lock is held.
+func (n *Network) link(from, to net.IP) LinkConfig {
+	if config, ok := n.links[linkKey(from, to)]; ok {
+		return config
+	}
+	return n.defaultLink
+}
+
+// reachable returns true if [a] and [b] are in the same partition group.
+// assumes the lock is held.
+func (n *Network) reachable(a, b net.IP) bool {
+	return n.groups[a.String()] == n.groups[b.String()]
+}
+
+// schedule a write of [size] bytes on [p], returning the time at which the
+// write has been sent and the time at which it arrives.
+// assumes the lock is not held.
+func (n *Network) schedule(p *pipe, size int) (time.Time, time.Time, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if !n.reachable(p.from, p.to) {
+		return time.Time{}, time.Time{}, errUnreachable
+	}
+
+	link := n.link(p.from, p.to)
+	now := time.Now()
+	start := now
+	if p.busyUntil.After(start) {
+		start = p.busyUntil
+	}
+	sent := start
+	if link.BytesPerSec > 0 {
+		sent = start.Add(time.Duration(uint64(size) * uint64(time.Second) / link.BytesPerSec))
+	}
+	p.busyUntil = sent
+
+	arrival := sent.Add(link.Latency)
+	for i := 0; i < maxRetransmits && n.rng.Float64() < link.LossRate; i++ {
+		arrival = arrival.Add(link.RetransmitDelay)
+	}
+	// Later writes can't overtake earlier ones
+	if arrival.Before(p.lastArrival) {
+		arrival = p.lastArrival
+	}
+	p.lastArrival = arrival
+	return sent, arrival, nil
+}
+
+func (n *Network) removeConn(c *conn) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	delete(n.conns, c)
+}
+
+func (n *Network) removeListener(l *listener) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.listeners[l.key] == l {
+		delete(n.listeners, l.key)
+	}
+}
+
+func linkKey(from, to net.IP) string { return from.String() + "->" + to.String() }
+
+type dialer struct {
+	net *Network
+	ip  net.IP
+}
+
+func (d *dialer) Dial(ip utils.IPDesc) (net.Conn, error) { return d.net.dial(d.ip, ip) }
+
+type listener struct {
+	net     *Network
+	addr    *net.TCPAddr
+	key     string
+	inbound chan net.Conn
+	once    sync.Once
+	closed  chan struct{}
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.inbound:
+		return c, nil
+	case <-l.closed:
+		return nil, errClosed
+	}
+}
+
+func (l *listener) Close() error {
+	l.once.Do(func() {
+		close(l.closed)
+		l.net.removeListener(l)
+	})
+	// Connections that were never accepted are reset
+	for {
+		select {
+		case c := <-l.inbound:
+			_ = c.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (l *listener) Addr() net.Addr { return l.addr }
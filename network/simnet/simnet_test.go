@@ -0,0 +1,208 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+var (
+	ip0 = utils.IPDesc{IP: net.IPv4(10, 0, 0, 1), Port: 9651}
+	ip1 = utils.IPDesc{IP: net.IPv4(10, 0, 0, 2), Port: 9651}
+	ip2 = utils.IPDesc{IP: net.IPv4(10, 0, 0, 3), Port: 9651}
+)
+
+// connect [from] to a listener at [to], returning both ends of the connection
+func connect(t *testing.T, n *Network, from net.IP, to utils.IPDesc) (net.Conn, net.Conn) {
+	l, err := n.Listen(to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	client, err := n.Dialer(from).Dial(to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestDial(t *testing.T) {
+	n, err := NewNetwork(LinkConfig{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = n.Dialer(ip0.IP).Dial(ip1)
+	assert.True(t, errors.Is(err, errRefused), "dials without a listener should be refused")
+
+	l, err := n.Listen(ip1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = n.Listen(ip1)
+	assert.True(t, errors.Is(err, errAddrInUse))
+
+	client, err := n.Dialer(ip0.IP).Dial(ip1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ip1.String(), client.RemoteAddr().String())
+	assert.Equal(t, client.LocalAddr().String(), server.RemoteAddr().String())
+	assert.Equal(t, ip1.String(), l.Addr().String())
+
+	_, err = client.Write([]byte("hello"))
+	assert.NoError(t, err)
+	b := make([]byte, 16)
+	read, err := server.Read(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(b[:read]))
+
+	assert.NoError(t, client.Close())
+	_, err = server.Read(b)
+	assert.Equal(t, io.EOF, err, "closing a connection should end the other end's stream")
+	_, err = client.Write([]byte("hello"))
+	assert.True(t, errors.Is(err, errClosed))
+
+	assert.NoError(t, l.Close())
+	_, err = l.Accept()
+	assert.True(t, errors.Is(err, errClosed))
+	_, err = n.Dialer(ip0.IP).Dial(ip1)
+	assert.True(t, errors.Is(err, errRefused), "dials to a closed listener should be refused")
+}
+
+func TestLatency(t *testing.T) {
+	latency := 50 * time.Millisecond
+	n, err := NewNetwork(LinkConfig{Latency: latency}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, server := connect(t, n, ip0.IP, ip1)
+
+	start := time.Now()
+	_, err = client.Write([]byte("ping"))
+	assert.NoError(t, err)
+	assert.Less(t, int64(time.Since(start)), int64(latency), "writes shouldn't wait for the bytes to arrive")
+
+	b := make([]byte, 4)
+	_, err = io.ReadFull(server, b)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(latency))
+}
+
+func TestBandwidth(t *testing.T) {
+	n, err := NewNetwork(LinkConfig{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, n.SetLink(ip0.IP, ip1.IP, LinkConfig{BytesPerSec: 10000}))
+	client, server := connect(t, n, ip0.IP, ip1)
+
+	go func() {
+		_, _ = io.Copy(ioutil.Discard, server)
+	}()
+
+	// 1000 bytes at 10000 bytes per second take 100ms to send
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		_, err := client.Write(make([]byte, 100))
+		assert.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(100*time.Millisecond))
+	_ = client.Close()
+}
+
+func TestLossPreservesOrder(t *testing.T) {
+	n, err := NewNetwork(LinkConfig{
+		LossRate:        0.5,
+		RetransmitDelay: time.Millisecond,
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, server := connect(t, n, ip0.IP, ip1)
+
+	for i := 0; i < 100; i++ {
+		_, err := client.Write([]byte{byte(i)})
+		assert.NoError(t, err)
+	}
+	b := make([]byte, 100)
+	_, err = io.ReadFull(server, b)
+	assert.NoError(t, err)
+	for i := range b {
+		assert.Equal(t, byte(i), b[i], "lost writes should be retransmitted in order")
+	}
+
+	_, err = NewNetwork(LinkConfig{LossRate: 1}, 0)
+	assert.True(t, errors.Is(err, errInvalidLoss))
+}
+
+func TestPartition(t *testing.T) {
+	n, err := NewNetwork(LinkConfig{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, server := connect(t, n, ip0.IP, ip1)
+
+	l, err := n.Listen(ip2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	n.Partition([]net.IP{ip0.IP}, []net.IP{ip1.IP})
+	b := make([]byte, 1)
+	_, err = server.Read(b)
+	assert.True(t, errors.Is(err, errReset), "connections across a partition should be reset")
+	_, err = client.Write(b)
+	assert.True(t, errors.Is(err, errReset))
+
+	_, err = n.Dialer(ip0.IP).Dial(ip2)
+	assert.True(t, errors.Is(err, errUnreachable), "hosts outside every group shouldn't be reachable from a group")
+	c, err := n.Dialer(ip1.IP).Dial(ip2)
+	assert.True(t, errors.Is(err, errUnreachable))
+	assert.Nil(t, c)
+
+	n.Heal()
+	c, err = n.Dialer(ip0.IP).Dial(ip2)
+	assert.NoError(t, err)
+	assert.NoError(t, c.Close())
+}
+
+func TestReadDeadline(t *testing.T) {
+	n, err := NewNetwork(LinkConfig{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, server := connect(t, n, ip0.IP, ip1)
+	defer client.Close()
+
+	assert.NoError(t, server.SetReadDeadline(time.Now().Add(10*time.Millisecond)))
+	_, err = server.Read(make([]byte, 1))
+	netErr, ok := err.(net.Error)
+	assert.True(t, ok && netErr.Timeout(), "reads should time out at the deadline")
+
+	assert.NoError(t, server.SetReadDeadline(time.Time{}))
+	_, err = client.Write([]byte{1})
+	assert.NoError(t, err)
+	_, err = server.Read(make([]byte, 1))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,188 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+// BandwidthConfig limits the rate at which bytes are read from and written to
+// peers. A rate of 0 is unlimited.
+type BandwidthConfig struct {
+	// Max bytes per second read from all peers together
+	InboundBytesPerSec uint64
+
+	// Max bytes per second read from any single peer
+	InboundPeerBytesPerSec uint64
+
+	// Max bytes per second written to all peers together
+	OutboundBytesPerSec uint64
+
+	// Max bytes per second written to any single peer
+	OutboundPeerBytesPerSec uint64
+
+	// Portion of the bytes per second of all peers together that is reserved
+	// for stakers and split between them by stake. Stakers that have used
+	// their reservation share the remainder with non-stakers. Must be in [0,1)
+	StakerPortion float64
+}
+
+// tokenBucket refills at [rate] tokens per second, up to [burst] tokens. Tokens
+// may be taken before they are available, in which case the taker must wait
+// for the bucket to refill.
+type tokenBucket struct {
+	rate, burst float64
+	tokens      float64
+	lastRefill  time.Time
+}
+
+func newTokenBucket(rate float64, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:       rate,
+		burst:      rate,
+		tokens:     rate,
+		lastRefill: now,
+	}
+}
+
+// setRate of the bucket to [rate] tokens per second, refilling it first at the
+// old rate
+func (b *tokenBucket) setRate(rate float64, now time.Time) {
+	b.refill(now)
+	b.rate = rate
+	b.burst = rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.lastRefill); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.lastRefill = now
+	}
+}
+
+// available returns the number of tokens in the bucket at [now]
+func (b *tokenBucket) available(now time.Time) float64 {
+	b.refill(now)
+	return b.tokens
+}
+
+// take [n] tokens at [now] and return how long the taker must wait until the
+// bucket would have had them
+func (b *tokenBucket) take(n float64, now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens -= n
+	if b.tokens >= 0 || b.rate <= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// throttler limits the rate at which bytes are transferred in one direction
+type throttler struct {
+	lock  sync.Mutex
+	clock *timer.Clock
+	vdrs  validators.Set
+
+	// rate of each peer's bucket, or 0 if peers aren't limited individually
+	peerBytesPerSec float64
+	peers           map[ids.ShortID]*tokenBucket
+
+	// rate split between the stakers' buckets by stake, or 0 if all peers
+	// together aren't limited
+	stakerBytesPerSec float64
+	stakers           map[ids.ShortID]*tokenBucket
+
+	// shared by non-stakers and stakers that have used their reservation.
+	// nil if all peers together aren't limited.
+	pool *tokenBucket
+}
+
+// newThrottler returns a throttler that limits all peers together to
+// [bytesPerSec], of which [stakerPortion] is reserved for stakers, and each
+// peer to [peerBytesPerSec]
+func newThrottler(
+	clock *timer.Clock,
+	vdrs validators.Set,
+	bytesPerSec,
+	peerBytesPerSec uint64,
+	stakerPortion float64,
+) *throttler {
+	t := &throttler{
+		clock:           clock,
+		vdrs:            vdrs,
+		peerBytesPerSec: float64(peerBytesPerSec),
+		peers:           make(map[ids.ShortID]*tokenBucket),
+		stakers:         make(map[ids.ShortID]*tokenBucket),
+	}
+	if bytesPerSec != 0 {
+		t.stakerBytesPerSec = stakerPortion * float64(bytesPerSec)
+		t.pool = newTokenBucket(float64(bytesPerSec)-t.stakerBytesPerSec, clock.Time())
+	}
+	return t
+}
+
+// throttle records that [numBytes] are being transferred with [nodeID] and
+// returns how long to wait before transferring them
+func (t *throttler) throttle(nodeID ids.ShortID, numBytes int) time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.clock.Time()
+	n := float64(numBytes)
+
+	wait := time.Duration(0)
+	if t.peerBytesPerSec != 0 {
+		bucket, ok := t.peers[nodeID]
+		if !ok {
+			bucket = newTokenBucket(t.peerBytesPerSec, now)
+			t.peers[nodeID] = bucket
+		}
+		wait = bucket.take(n, now)
+	}
+	if t.pool == nil {
+		return wait
+	}
+
+	// Stakers use their reservation first, which is weighted by their current
+	// stake
+	if weight, ok := t.vdrs.GetWeight(nodeID); ok && t.stakerBytesPerSec != 0 {
+		rate := t.stakerBytesPerSec * float64(weight) / float64(t.vdrs.Weight())
+		bucket, ok := t.stakers[nodeID]
+		if !ok {
+			bucket = newTokenBucket(rate, now)
+			t.stakers[nodeID] = bucket
+		} else {
+			bucket.setRate(rate, now)
+		}
+		if bucket.available(now) >= n {
+			bucket.take(n, now)
+			return wait
+		}
+	}
+
+	if poolWait := t.pool.take(n, now); poolWait > wait {
+		wait = poolWait
+	}
+	return wait
+}
+
+// remove the buckets of [nodeID], which disconnected
+func (t *throttler) remove(nodeID ids.ShortID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.peers, nodeID)
+	delete(t.stakers, nodeID)
+}
@@ -0,0 +1,79 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(100, now)
+
+	assert.Zero(t, b.take(100, now), "should be able to take a full bucket")
+	assert.Equal(t, time.Second/2, b.take(50, now), "should wait for the bucket to refill")
+
+	now = now.Add(time.Second / 2)
+	assert.Zero(t, b.available(now))
+
+	now = now.Add(time.Hour)
+	assert.Equal(t, float64(100), b.available(now), "bucket shouldn't refill past its burst")
+}
+
+func TestThrottlerUnlimited(t *testing.T) {
+	clock := &timer.Clock{}
+	th := newThrottler(clock, validators.NewSet(), 0, 0, 0.5)
+	assert.Zero(t, th.throttle(ids.GenerateTestShortID(), 1<<30))
+}
+
+func TestThrottlerPeerLimit(t *testing.T) {
+	clock := &timer.Clock{}
+	clock.Set(time.Now())
+	th := newThrottler(clock, validators.NewSet(), 0, 100, 0.5)
+
+	peer0 := ids.GenerateTestShortID()
+	peer1 := ids.GenerateTestShortID()
+	assert.Zero(t, th.throttle(peer0, 100))
+	assert.Equal(t, time.Second, th.throttle(peer0, 100))
+	assert.Zero(t, th.throttle(peer1, 100), "peers should be limited separately")
+
+	th.remove(peer0)
+	assert.Zero(t, th.throttle(peer0, 100), "disconnected peers should be forgotten")
+}
+
+func TestThrottlerStakerReservation(t *testing.T) {
+	clock := &timer.Clock{}
+	clock.Set(time.Now())
+	vdrs := validators.NewSet()
+	bigStaker := ids.GenerateTestShortID()
+	smallStaker := ids.GenerateTestShortID()
+	nonStaker := ids.GenerateTestShortID()
+	if err := vdrs.AddWeight(bigStaker, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := vdrs.AddWeight(smallStaker, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// 400 bytes per second are reserved for stakers and 600 are shared
+	th := newThrottler(clock, vdrs, 1000, 0, 0.4)
+
+	assert.Zero(t, th.throttle(bigStaker, 300), "staker should be able to use its reservation")
+	assert.Zero(t, th.throttle(smallStaker, 100), "staker should be able to use its reservation")
+	assert.Equal(t, float64(600), th.pool.available(clock.Time()))
+
+	assert.Zero(t, th.throttle(smallStaker, 100), "staker should share the remainder")
+	assert.Zero(t, th.throttle(nonStaker, 500), "non-staker should share the remainder")
+	assert.Equal(t, time.Second/2, th.throttle(nonStaker, 300), "shared bandwidth should be limited")
+
+	clock.Set(clock.Time().Add(time.Second))
+	assert.Zero(t, th.throttle(bigStaker, 300), "reservation should refill")
+}
@@ -5,6 +5,7 @@ package network
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"net"
 
@@ -18,8 +19,9 @@ var (
 
 // Upgrader ...
 type Upgrader interface {
-	// Must be thread safe
-	Upgrade(net.Conn) (ids.ShortID, net.Conn, error)
+	// Must be thread safe. Returns the peer's certificate, if it presented
+	// one.
+	Upgrade(net.Conn) (ids.ShortID, net.Conn, *x509.Certificate, error)
 }
 
 type ipUpgrader struct{}
@@ -27,11 +29,11 @@ type ipUpgrader struct{}
 // NewIPUpgrader ...
 func NewIPUpgrader() Upgrader { return ipUpgrader{} }
 
-func (ipUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, error) {
+func (ipUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, *x509.Certificate, error) {
 	addr := conn.RemoteAddr()
 	str := addr.String()
 	id := ids.ShortID(hashing.ComputeHash160Array([]byte(str)))
-	return id, conn, nil
+	return id, conn, nil, nil
 }
 
 type tlsServerUpgrader struct {
@@ -45,21 +47,18 @@ func NewTLSServerUpgrader(config *tls.Config) Upgrader {
 	}
 }
 
-func (t tlsServerUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, error) {
+func (t tlsServerUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, *x509.Certificate, error) {
 	encConn := tls.Server(conn, t.config)
 	if err := encConn.Handshake(); err != nil {
-		return ids.ShortID{}, nil, err
+		return ids.ShortID{}, nil, nil, err
 	}
 
 	connState := encConn.ConnectionState()
 	if len(connState.PeerCertificates) == 0 {
-		return ids.ShortID{}, nil, errNoCert
+		return ids.ShortID{}, nil, nil, errNoCert
 	}
 	peerCert := connState.PeerCertificates[0]
-	id := ids.ShortID(
-		hashing.ComputeHash160Array(
-			hashing.ComputeHash256(peerCert.Raw)))
-	return id, encConn, nil
+	return certToID(peerCert.Raw), encConn, peerCert, nil
 }
 
 type tlsClientUpgrader struct {
@@ -73,19 +72,24 @@ func NewTLSClientUpgrader(config *tls.Config) Upgrader {
 	}
 }
 
-func (t tlsClientUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, error) {
+func (t tlsClientUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, *x509.Certificate, error) {
 	encConn := tls.Client(conn, t.config)
 	if err := encConn.Handshake(); err != nil {
-		return ids.ShortID{}, nil, err
+		return ids.ShortID{}, nil, nil, err
 	}
 
 	connState := encConn.ConnectionState()
 	if len(connState.PeerCertificates) == 0 {
-		return ids.ShortID{}, nil, errNoCert
+		return ids.ShortID{}, nil, nil, errNoCert
 	}
 	peerCert := connState.PeerCertificates[0]
-	id := ids.ShortID(
+	return certToID(peerCert.Raw), encConn, peerCert, nil
+}
+
+// certToID returns the node ID of the node with the DER encoded certificate
+// [certBytes]
+func certToID(certBytes []byte) ids.ShortID {
+	return ids.ShortID(
 		hashing.ComputeHash160Array(
-			hashing.ComputeHash256(peerCert.Raw)))
-	return id, encConn, nil
+			hashing.ComputeHash256(certBytes)))
 }
@@ -0,0 +1,57 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// benchlistListener logs each validator that is benched on any chain, and
+// counts them
+type benchlistListener struct {
+	log        logging.Logger
+	numBenched prometheus.Counter
+}
+
+// newBenchlistListener returns a benchlistListener that registers its metric
+// with [registerer] under [namespace]
+func newBenchlistListener(log logging.Logger, namespace string, registerer prometheus.Registerer) (*benchlistListener, error) {
+	l := &benchlistListener{
+		log: log,
+		numBenched: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "benchlist_benched",
+			Help:      "Number of times a validator was benched on any chain",
+		}),
+	}
+	if err := registerer.Register(l.numBenched); err != nil {
+		return nil, fmt.Errorf("failed to register benched statistics due to %w", err)
+	}
+	return l, nil
+}
+
+// Benched implements the benchlist.Listener interface
+func (l *benchlistListener) Benched(chainID ids.ID, validator benchlist.BenchedValidator) {
+	l.numBenched.Inc()
+
+	if validator.Manual {
+		l.log.Info("validator %s was benched manually on chain %s until %s",
+			validator.ValidatorID,
+			chainID,
+			validator.Until)
+		return
+	}
+	l.log.Info("validator %s was benched on chain %s until %s after %d requests failed since %s",
+		validator.ValidatorID,
+		chainID,
+		validator.Until,
+		validator.Failures,
+		validator.FirstFailure)
+}
@@ -0,0 +1,68 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestInitBenchlistRegistersListener(t *testing.T) {
+	vdrs := validators.NewSet()
+	for i := byte(0); i < 3; i++ {
+		if err := vdrs.AddWeight(ids.ShortID{i}, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	n := &Node{
+		Log:    logging.NoLog{},
+		DB:     memdb.New(),
+		vdrs:   validators.NewManager(),
+		Config: &Config{},
+	}
+	if err := n.vdrs.Set(constants.PrimaryNetworkID, vdrs); err != nil {
+		t.Fatal(err)
+	}
+	registry := prometheus.NewRegistry()
+	n.Config.ConsensusParams.Metrics = registry
+	n.Config.BenchlistConfig = benchlist.Config{
+		Threshold:  1,
+		Duration:   time.Hour,
+		MaxPortion: 0.5,
+	}
+	if err := n.initBenchlist(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := snow.DefaultContextTest()
+	ctx.SubnetID = constants.PrimaryNetworkID
+	if err := n.benchlistManager.RegisterChain(ctx, "chain"); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, n.benchlistManager.Bench(ctx.ChainID, ids.ShortID{0}, time.Hour))
+	assert.NoError(t, n.benchlistManager.Bench(ctx.ChainID, ids.ShortID{1}, time.Hour))
+
+	metrics, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	numBenched := float64(0)
+	for _, metric := range metrics {
+		if metric.GetName() == "avalanche_benchlist_benched" {
+			numBenched = metric.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	assert.Equal(t, float64(2), numBenched, "the node's listener should count the benched validators")
+}
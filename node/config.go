@@ -4,8 +4,10 @@
 package node
 
 import (
+	"net"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/nat"
@@ -14,9 +16,12 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/dynamicip"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/proposervm"
 )
 
 // Config contains all of the configurations of an Avalanche node.
@@ -45,6 +50,27 @@ type Config struct {
 	// Path to database
 	DBPath string
 
+	// Version of the database, which is part of [DBPath]
+	DBVersion string
+
+	// If non-empty, the database backup to restore to [DBPath] before starting
+	DBRestorePath string
+
+	// If true, the database migrations are only dry run
+	DBMigrationDryRun bool
+
+	// Directory that backups of the database are written to
+	DBBackupDir string
+
+	// Directory that captures of chains are written to, and the maximum size
+	// of a capture. If [CaptureMaxBytes] is 0, there is no limit.
+	CaptureDir      string
+	CaptureMaxBytes uint64
+
+	// Manages the versions of the database at [DBPath]. Nil if [DBEnabled] is
+	// false.
+	DBManager *manager.Manager
+
 	// If false, uses an in memory database
 	DBEnabled bool
 
@@ -70,6 +96,12 @@ type Config struct {
 	NetworkConfig       timer.AdaptiveTimeoutConfig
 	NetworkHealthConfig network.HealthConfig
 
+	// Timeouts of VM-defined application requests
+	AppTimeoutConfig timer.AdaptiveTimeoutConfig
+
+	// Timeouts of types of requests that differ from the defaults above
+	RequestTimeoutConfigs map[constants.MsgType]timer.AdaptiveTimeoutConfig
+
 	// Benchlist Configuration
 	BenchlistConfig benchlist.Config
 
@@ -114,6 +146,7 @@ type Config struct {
 	// Router that is used to handle incoming consensus messages
 	ConsensusRouter          router.Router
 	RouterHealthConfig       router.HealthConfig
+	RouterSchedulerConfig    router.SchedulerConfig
 	ConsensusGossipFrequency time.Duration
 	ConsensusShutdownTimeout time.Duration
 
@@ -143,6 +176,42 @@ type Config struct {
 	// Max number of times to retry bootstrap
 	RetryBootstrapMaxAttempts int
 
+	// If positive, byte budget of each chain's vertex cache
+	VertexCacheBytes int
+
+	// If positive, byte budget of the caches of VMs that support it
+	VMCacheBytes int
+
 	// Peer alias configuration
 	PeerAliasTimeout time.Duration
+
+	// Algorithm that large consensus messages are compressed with, when sent to
+	// peers that support it
+	NetworkCompression network.Compression
+
+	// Limits on the bytes per second read from and written to peers
+	BandwidthConfig network.BandwidthConfig
+
+	// Peers this node may connect to, if it only connects to allowed peers
+	AllowlistConfig network.AllowlistConfig
+
+	// Oldest version of the peers this node connects to, until the first of
+	// [VersionUpgrades] takes effect
+	MinimumCompatibleVersion version.Version
+
+	// Scheduled raises of the oldest version of the peers this node connects
+	// to, sorted by time
+	VersionUpgrades []version.Upgrade
+
+	// IDs or aliases of snowman VMs --> Proposer windows of their chains.
+	// Requires [EnableP2PTLS].
+	ProposerWindows map[string]proposervm.Config
+
+	// If non-nil, peers connect to this node through [Listener] rather than
+	// through a TCP listener on the staking port
+	Listener net.Listener
+
+	// If non-nil, this node connects to peers through [Dialer] rather than
+	// over TCP
+	Dialer network.Dialer
 }
@@ -4,6 +4,7 @@
 package node
 
 import (
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
@@ -26,6 +27,7 @@ import (
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/corruptabledb"
 	"github.com/ava-labs/avalanchego/database/meterdb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/genesis"
@@ -67,9 +69,15 @@ var (
 	genesisHashKey = []byte("genesisID")
 
 	// Version is the version of this code
-	Version                 = version.NewDefaultVersion(constants.PlatformName, 1, 2, 3)
+	Version = version.NewDefaultVersion(constants.PlatformName, 1, 3, 0)
+	// MinimumCompatibleVersion is the oldest version of the peers this node
+	// connects to by default
+	MinimumCompatibleVersion = version.NewDefaultVersion(constants.PlatformName, 1, 0, 0)
+
 	versionParser           = version.NewDefaultParser()
 	beaconConnectionTimeout = 1 * time.Minute
+
+	errInvalidTLSKey = errors.New("staking key can't be used to sign")
 )
 
 // Node is an instance of an Avalanche node.
@@ -85,6 +93,9 @@ type Node struct {
 	// Storage for this node
 	DB database.Database
 
+	// Wraps the node's database to halt on the first unexpected error
+	corruptableDB *corruptabledb.Database
+
 	// Handles calls to Keystore API
 	keystoreServer keystore.Keystore
 
@@ -112,6 +123,11 @@ type Node struct {
 	// Net runs the networking stack
 	Net network.Network
 
+	// Staking key and DER encoded certificate that this node signs with. Nil
+	// if TLS is disabled.
+	stakingKey  crypto.Signer
+	stakingCert []byte
+
 	// this node's initial connections to the network
 	beacons validators.Set
 
@@ -148,18 +164,35 @@ type Node struct {
  */
 
 func (n *Node) initNetworking() error {
-	listener, err := net.Listen(TCP, fmt.Sprintf(":%d", n.Config.StakingIP.Port))
-	if err != nil {
-		return err
+	listener := n.Config.Listener
+	if listener == nil {
+		var err error
+		listener, err = net.Listen(TCP, fmt.Sprintf(":%d", n.Config.StakingIP.Port))
+		if err != nil {
+			return err
+		}
+	}
+	dialer := n.Config.Dialer
+	if dialer == nil {
+		dialer = network.NewDialer(TCP)
 	}
-	dialer := network.NewDialer(TCP)
 
-	var serverUpgrader, clientUpgrader network.Upgrader
+	var (
+		serverUpgrader, clientUpgrader network.Upgrader
+		tlsKey                         crypto.Signer
+	)
 	if n.Config.EnableP2PTLS {
 		cert, err := tls.LoadX509KeyPair(n.Config.StakingCertFile, n.Config.StakingKeyFile)
 		if err != nil {
 			return err
 		}
+		key, ok := cert.PrivateKey.(crypto.Signer)
+		if !ok {
+			return errInvalidTLSKey
+		}
+		tlsKey = key
+		n.stakingKey = key
+		n.stakingCert = cert.Certificate[0]
 
 		// #nosec G402
 		tlsConfig := &tls.Config{
@@ -189,8 +222,9 @@ func (n *Node) initNetworking() error {
 	}
 
 	// Configure benchlist
-	n.Config.BenchlistConfig.Validators = n.vdrs
-	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
+	if err := n.initBenchlist(); err != nil {
+		return err
+	}
 
 	consensusRouter := n.Config.ConsensusRouter
 	if !n.Config.EnableStaking {
@@ -230,13 +264,22 @@ func (n *Node) initNetworking() error {
 		}
 	}
 
+	compatibility, err := version.NewCompatibility(
+		Version,
+		n.Config.MinimumCompatibleVersion,
+		n.Config.VersionUpgrades,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid version compatibility policy: %w", err)
+	}
+
 	n.Net = network.NewDefaultNetwork(
 		n.Config.ConsensusParams.Metrics,
 		n.Log,
 		n.ID,
 		n.Config.StakingIP,
 		n.Config.NetworkID,
-		Version,
+		compatibility,
 		versionParser,
 		listener,
 		dialer,
@@ -256,6 +299,11 @@ func (n *Node) initNetworking() error {
 		n.Config.NetworkHealthConfig,
 		n.benchlistManager,
 		n.Config.PeerAliasTimeout,
+		n.Config.NetworkCompression,
+		tlsKey,
+		n.Config.BandwidthConfig,
+		n.Config.AllowlistConfig,
+		prefixdb.New([]byte("network"), n.DB),
 	)
 
 	n.nodeCloser = utils.HandleSignals(func(os.Signal) {
@@ -266,15 +314,30 @@ func (n *Node) initNetworking() error {
 	return nil
 }
 
+// initBenchlist initializes the benchlist manager, which benches validators on
+// the node's validator sets. Assumes n.vdrs is initialized.
+func (n *Node) initBenchlist() error {
+	n.Config.BenchlistConfig.Validators = n.vdrs
+	n.Config.BenchlistConfig.DB = prefixdb.New([]byte("benchlist"), n.DB)
+	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
+
+	listener, err := newBenchlistListener(n.Log, constants.PlatformName, n.Config.ConsensusParams.Metrics)
+	if err != nil {
+		return err
+	}
+	n.benchlistManager.RegisterListener(listener)
+	return nil
+}
+
 type insecureValidatorManager struct {
 	router.Router
 	vdrs   validators.Set
 	weight uint64
 }
 
-func (i *insecureValidatorManager) Connected(vdrID ids.ShortID) {
+func (i *insecureValidatorManager) Connected(vdrID ids.ShortID, features version.Features) {
 	_ = i.vdrs.AddWeight(vdrID, i.weight)
-	i.Router.Connected(vdrID)
+	i.Router.Connected(vdrID, features)
 }
 
 func (i *insecureValidatorManager) Disconnected(vdrID ids.ShortID) {
@@ -292,23 +355,23 @@ type beaconManager struct {
 	weight         uint64
 }
 
-func (b *beaconManager) Connected(vdrID ids.ShortID) {
+func (b *beaconManager) Connected(vdrID ids.ShortID, features version.Features) {
 	weight, ok := b.beacons.GetWeight(vdrID)
 	if !ok {
-		b.Router.Connected(vdrID)
+		b.Router.Connected(vdrID, features)
 		return
 	}
 	weight, err := math.Add64(weight, b.weight)
 	if err != nil {
 		b.timer.Cancel()
-		b.Router.Connected(vdrID)
+		b.Router.Connected(vdrID, features)
 		return
 	}
 	b.weight = weight
 	if b.weight >= b.requiredWeight {
 		b.timer.Cancel()
 	}
-	b.Router.Connected(vdrID)
+	b.Router.Connected(vdrID, features)
 }
 
 func (b *beaconManager) Disconnected(vdrID ids.ShortID) {
@@ -378,7 +441,10 @@ func (n *Node) Dispatch() error {
  */
 
 func (n *Node) initDatabase(db database.Database) error {
-	n.DB = db
+	// Stop using the database after an unexpected error, rather than
+	// continuing on state that may be inconsistent
+	n.corruptableDB = corruptabledb.New(db)
+	n.DB = n.corruptableDB
 
 	rawExpectedGenesisHash := hashing.ComputeHash256(n.Config.GenesisBytes)
 
@@ -524,14 +590,27 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	// Set Prometheus metrics info
 	n.Config.NetworkConfig.MetricsNamespace = constants.PlatformName
 	n.Config.NetworkConfig.Registerer = n.Config.ConsensusParams.Metrics
+	n.Config.AppTimeoutConfig.MetricsNamespace = fmt.Sprintf("%s_app", constants.PlatformName)
+	n.Config.AppTimeoutConfig.Registerer = n.Config.ConsensusParams.Metrics
 
 	// Manages network timeouts
 	timeoutManager := &timeout.Manager{}
-	if err := timeoutManager.Initialize(&n.Config.NetworkConfig, n.benchlistManager); err != nil {
+	err = timeoutManager.Initialize(
+		&n.Config.NetworkConfig,
+		&n.Config.AppTimeoutConfig,
+		n.Config.RequestTimeoutConfigs,
+		n.benchlistManager,
+	)
+	if err != nil {
 		return err
 	}
 	go n.Log.RecoverAndPanic(timeoutManager.Dispatch)
 
+	// Reports the current timeout of each type of request on each chain
+	if err := n.healthService.RegisterCheck("timeouts", timeoutManager.HealthCheck); err != nil {
+		return fmt.Errorf("couldn't register timeouts health check: %w", err)
+	}
+
 	// Routes incoming messages from peers to the appropriate chain
 	err = n.Config.ConsensusRouter.Initialize(
 		n.ID,
@@ -542,6 +621,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		criticalChains,
 		n.Shutdown,
 		n.Config.RouterHealthConfig,
+		n.Config.RouterSchedulerConfig,
 		n.Config.NetworkConfig.MetricsNamespace,
 		n.Config.NetworkConfig.Registerer,
 	)
@@ -561,6 +641,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		DecisionEvents:            n.DecisionDispatcher,
 		ConsensusEvents:           n.ConsensusDispatcher,
 		DB:                        n.DB,
+		DBVersion:                 n.Config.DBVersion,
 		Router:                    n.Config.ConsensusRouter,
 		Net:                       n.Net,
 		ConsensusParams:           n.Config.ConsensusParams,
@@ -580,6 +661,12 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		WhitelistedSubnets:        n.Config.WhitelistedSubnets,
 		RetryBootstrap:            n.Config.RetryBootstrap,
 		RetryBootstrapMaxAttempts: n.Config.RetryBootstrapMaxAttempts,
+		VertexCacheBytes:          n.Config.VertexCacheBytes,
+		CaptureDir:                n.Config.CaptureDir,
+		CaptureMaxBytes:           n.Config.CaptureMaxBytes,
+		ProposerWindows:           n.Config.ProposerWindows,
+		StakingKey:                n.stakingKey,
+		StakingCert:               n.stakingCert,
 	})
 
 	vdrs := n.vdrs
@@ -609,14 +696,17 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			MaxStakeDuration:   n.Config.MaxStakeDuration,
 			StakeMintingPeriod: n.Config.StakeMintingPeriod,
 			ApricotPhase0Time:  n.Config.ApricotPhase0Time,
+			CacheBytes:         n.Config.VMCacheBytes,
 		}),
 		n.vmManager.RegisterVMFactory(avm.ID, &avm.Factory{
 			CreationFee: n.Config.CreationTxFee,
 			Fee:         n.Config.TxFee,
+			CacheBytes:  n.Config.VMCacheBytes,
 		}),
 		n.vmManager.RegisterVMFactory(evm.ID, &rpcchainvm.Factory{
-			Path:   filepath.Join(n.Config.PluginDir, "evm"),
-			Config: n.Config.CorethConfig,
+			Path:       filepath.Join(n.Config.PluginDir, "evm"),
+			Config:     n.Config.CorethConfig,
+			CacheBytes: n.Config.VMCacheBytes,
 		}),
 		n.vmManager.RegisterVMFactory(timestampvm.ID, &timestampvm.Factory{}),
 		n.vmManager.RegisterVMFactory(secp256k1fx.ID, &secp256k1fx.Factory{}),
@@ -691,7 +781,17 @@ func (n *Node) initAdminAPI() error {
 		return nil
 	}
 	n.Log.Info("initializing admin API")
-	service, err := admin.NewService(n.Log, n.chainManager, &n.APIServer)
+	service, err := admin.NewService(
+		n.Log,
+		n.chainManager,
+		&n.APIServer,
+		n.Net,
+		n.benchlistManager,
+		n.DB,
+		n.Config.NetworkID,
+		n.Config.DBVersion,
+		n.Config.DBBackupDir,
+	)
 	if err != nil {
 		return err
 	}
@@ -711,6 +811,7 @@ func (n *Node) initInfoAPI() error {
 		n.Config.NetworkID,
 		n.chainManager,
 		n.Net,
+		n.benchlistManager,
 		n.Config.CreationTxFee,
 		n.Config.TxFee,
 	)
@@ -768,6 +869,20 @@ func (n *Node) initHealthAPI() error {
 		return fmt.Errorf("couldn't register router health check")
 	}
 
+	// Fails once the database has returned an unexpected error
+	err = n.healthService.RegisterCheck("databaseIntegrity", n.corruptableDB.HealthCheck)
+	if err != nil {
+		return fmt.Errorf("couldn't register database integrity health check: %w", err)
+	}
+
+	// Register the database migrations with the health service
+	if n.Config.DBManager != nil {
+		err = n.healthService.RegisterCheck("database", n.Config.DBManager.HealthCheck)
+		if err != nil {
+			return fmt.Errorf("couldn't register database health check: %w", err)
+		}
+	}
+
 	handler, err := n.healthService.Handler()
 	if err != nil {
 		return err
@@ -903,6 +1018,11 @@ func (n *Node) Initialize(
 	return nil
 }
 
+// IsBootstrapped returns true if the chain [chainID] is done bootstrapping
+func (n *Node) IsBootstrapped(chainID ids.ID) bool {
+	return n.chainManager != nil && n.chainManager.IsBootstrapped(chainID)
+}
+
 // Shutdown this node
 // May be called multiple times
 func (n *Node) Shutdown() {
@@ -0,0 +1,392 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package nodetest runs networks of full nodes in a single process. The nodes
+// are connected by a simulated network, and validate a generated local
+// genesis, so that bootstrapping and consensus can be exercised by ordinary Go
+// tests.
+package nodetest
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/nat"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/simnet"
+	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/avm"
+)
+
+const (
+	stakingPort = 9651
+
+	// Max number of nodes, as each one is given an IP in 10.0.0.0/24
+	maxNodes = 254
+)
+
+var (
+	errNumNodes    = fmt.Errorf("number of nodes must be in [1, %d]", maxNodes)
+	errInvalidCert = errors.New("invalid staking certificate")
+)
+
+// Config of a network of nodes
+type Config struct {
+	// Number of nodes. Each one is an initial validator of the network, with
+	// equal stake.
+	NumNodes int
+
+	// Links between the nodes, unless set otherwise through [Network.Sim]
+	Link simnet.LinkConfig
+
+	// Seed of the simulated packet loss
+	Seed int64
+
+	// Directory the nodes' staking keys and logs are written to
+	Dir string
+
+	// Level of the logs written to [Dir]. Nothing is displayed.
+	LogLevel logging.Level
+}
+
+// Network of nodes running in this process
+type Network struct {
+	// Simulated network the nodes are connected by
+	Sim *simnet.Network
+
+	// Nodes of the network. Node 0 bootstraps alone, and every other node
+	// bootstraps from it.
+	Nodes []*node.Node
+
+	// Genesis of the network
+	GenesisBytes []byte
+
+	// ID of the X-Chain
+	XChainID ids.ID
+
+	dbs          []database.Database
+	logFactories []logging.Factory
+
+	// Serves the APIs of each node
+	apis []*httptest.Server
+
+	// Returns once every node is done dispatching
+	dispatched sync.WaitGroup
+}
+
+// IP returns the IP that node [i] listens for peers at
+func IP(i int) utils.IPDesc {
+	return utils.IPDesc{
+		IP:   net.IPv4(10, 0, 0, byte(i+1)),
+		Port: stakingPort,
+	}
+}
+
+// NewNetwork initializes and starts the nodes of a network described by
+// [config]
+func NewNetwork(config Config) (*Network, error) {
+	if config.NumNodes < 1 || config.NumNodes > maxNodes {
+		return nil, fmt.Errorf("%w: %d", errNumNodes, config.NumNodes)
+	}
+	sim, err := simnet.NewNetwork(config.Link, config.Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate the staking key of each node
+	nodeIDs := make([]ids.ShortID, config.NumNodes)
+	for i := range nodeIDs {
+		dir := nodeDir(config.Dir, i)
+		if err := staking.GenerateStakingKeyCert(filepath.Join(dir, "staker.key"), filepath.Join(dir, "staker.crt")); err != nil {
+			return nil, err
+		}
+		nodeIDs[i], err = nodeID(filepath.Join(dir, "staker.crt"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	genesisBytes, avaxAssetID, err := newGenesis(nodeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't build genesis: %w", err)
+	}
+	createAVMTx, err := genesis.VMGenesis(genesisBytes, avm.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Network{
+		Sim:          sim,
+		GenesisBytes: genesisBytes,
+		XChainID:     createAVMTx.ID(),
+	}
+	for i := 0; i < config.NumNodes; i++ {
+		if err := n.startNode(config, i, nodeIDs[0], genesisBytes, avaxAssetID); err != nil {
+			n.Shutdown()
+			return nil, fmt.Errorf("couldn't start node %d: %w", i, err)
+		}
+	}
+	return n, nil
+}
+
+// startNode initializes and dispatches node [i], which bootstraps from node 0
+// unless it is node 0
+func (n *Network) startNode(config Config, i int, beaconID ids.ShortID, genesisBytes []byte, avaxAssetID ids.ID) error {
+	ip := IP(i)
+	listener, err := n.Sim.Listen(ip)
+	if err != nil {
+		return err
+	}
+
+	nodeConfig, err := newNodeConfig(config, i)
+	if err != nil {
+		_ = listener.Close()
+		return err
+	}
+	nodeConfig.GenesisBytes = genesisBytes
+	nodeConfig.AvaxAssetID = avaxAssetID
+	nodeConfig.Listener = listener
+	nodeConfig.Dialer = n.Sim.Dialer(ip.IP)
+	if i != 0 {
+		nodeConfig.BootstrapPeers = []*node.Peer{{
+			IP: IP(0),
+			ID: beaconID,
+		}}
+	}
+
+	logFactory := logging.NewFactory(nodeConfig.LoggingConfig)
+	n.logFactories = append(n.logFactories, logFactory)
+	log, err := logFactory.Make()
+	if err != nil {
+		_ = listener.Close()
+		return err
+	}
+
+	db := memdb.New()
+	n.dbs = append(n.dbs, db)
+	nd := &node.Node{}
+	if err := nd.Initialize(nodeConfig, db, log, logFactory, restarter{node: nd}); err != nil {
+		// Stops the components that were already started
+		nd.Shutdown()
+		_ = listener.Close()
+		return err
+	}
+	n.Nodes = append(n.Nodes, nd)
+	n.apis = append(n.apis, httptest.NewServer(nd.APIServer.Handler()))
+
+	n.dispatched.Add(1)
+	go func() {
+		defer n.dispatched.Done()
+		if err := nd.Dispatch(); err != nil {
+			log.Debug("node dispatch returned: %s", err)
+		}
+	}()
+	return nil
+}
+
+// URI returns the URI that the APIs of node [i] are served at
+func (n *Network) URI(i int) string { return n.apis[i].URL }
+
+// Connected returns true if every node is connected to every other node
+func (n *Network) Connected() bool {
+	for _, nd := range n.Nodes {
+		if len(nd.Net.Peers(nil)) != len(n.Nodes)-1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Bootstrapped returns true if every node finished bootstrapping [chainID]
+func (n *Network) Bootstrapped(chainID ids.ID) bool {
+	for _, nd := range n.Nodes {
+		if !nd.IsBootstrapped(chainID) {
+			return false
+		}
+	}
+	return true
+}
+
+// Shutdown every node and wait for them to be done shutting down
+func (n *Network) Shutdown() {
+	for _, api := range n.apis {
+		api.Close()
+	}
+	for _, nd := range n.Nodes {
+		nd.Shutdown()
+	}
+	n.dispatched.Wait()
+	for _, db := range n.dbs {
+		_ = db.Close()
+	}
+	for _, logFactory := range n.logFactories {
+		logFactory.Close()
+	}
+}
+
+// newNodeConfig returns the config of node [i]. The defaults are those of the
+// node's command line flags, except that consensus samples every node.
+func newNodeConfig(config Config, i int) (*node.Config, error) {
+	dir := nodeDir(config.Dir, i)
+
+	loggingConfig, err := logging.DefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	loggingConfig.Directory = filepath.Join(dir, "logs")
+	loggingConfig.LogLevel = config.LogLevel
+	loggingConfig.DisplayLevel = logging.Off
+
+	// Plugins aren't built in tests, so the C-Chain isn't run
+	pluginDir := filepath.Join(dir, "plugins")
+
+	k := config.NumNodes
+	if k > 20 {
+		k = 20
+	}
+	alpha := k/2 + 1
+
+	nodeConfig := &node.Config{
+		Params:                  *genesis.GetParams(constants.LocalID),
+		Nat:                     nat.NewNoRouter(),
+		NetworkID:               constants.LocalID,
+		EnableCrypto:            true,
+		StakingIP:               utils.NewDynamicIPDesc(IP(i).IP, stakingPort),
+		EnableP2PTLS:            true,
+		EnableStaking:           true,
+		StakingKeyFile:          filepath.Join(dir, "staker.key"),
+		StakingCertFile:         filepath.Join(dir, "staker.crt"),
+		DisabledStakingWeight:   1,
+		MaxNonStakerPendingMsgs: router.DefaultMaxNonStakerPendingMsgs,
+		StakerMSGPortion:        router.DefaultStakerPortion,
+		StakerCPUPortion:        router.DefaultStakerPortion,
+		SendQueueSize:           4096,
+		MaxPendingMsgs:          4096,
+		HealthCheckFreq:         30 * time.Second,
+		HTTPHost:                "127.0.0.1",
+		// Picks a free port
+		HTTPPort:       0,
+		InfoAPIEnabled: true,
+		// Lets tests issue transactions from users of the nodes' wallets
+		KeystoreAPIEnabled: true,
+		LoggingConfig:      loggingConfig,
+		PluginDir:          pluginDir,
+		ConsensusParams: avalanche.Parameters{
+			Parameters: snowball.Parameters{
+				K:                     k,
+				Alpha:                 alpha,
+				BetaVirtuous:          15,
+				BetaRogue:             20,
+				ConcurrentRepolls:     4,
+				OptimalProcessing:     50,
+				MaxOutstandingItems:   1024,
+				MaxItemProcessingTime: 2 * time.Minute,
+			},
+			Parents:   5,
+			BatchSize: 30,
+		},
+		ConsensusRouter:            &router.ChainRouter{},
+		ConsensusGossipFrequency:   10 * time.Second,
+		ConsensusShutdownTimeout:   5 * time.Second,
+		ConnMeterResetDuration:     0,
+		ConnMeterMaxConns:          5,
+		DisconnectedCheckFreq:      10 * time.Second,
+		DisconnectedRestartTimeout: time.Minute,
+		RetryBootstrap:             true,
+		RetryBootstrapMaxAttempts:  50,
+		PeerAliasTimeout:           10 * time.Minute,
+		NetworkCompression:         network.NoCompression,
+		MinimumCompatibleVersion:   node.MinimumCompatibleVersion,
+	}
+	nodeConfig.WhitelistedSubnets.Add(constants.PrimaryNetworkID)
+	nodeConfig.NetworkConfig.InitialTimeout = 5 * time.Second
+	nodeConfig.NetworkConfig.MinimumTimeout = 2 * time.Second
+	nodeConfig.NetworkConfig.MaximumTimeout = 10 * time.Second
+	nodeConfig.NetworkConfig.TimeoutHalflife = 5 * time.Minute
+	nodeConfig.NetworkConfig.TimeoutCoefficient = 2
+	nodeConfig.AppTimeoutConfig = nodeConfig.NetworkConfig
+	nodeConfig.NetworkHealthConfig = network.HealthConfig{
+		MaxTimeSinceMsgSent:          time.Minute,
+		MaxTimeSinceMsgReceived:      time.Minute,
+		MaxPortionSendQueueBytesFull: 0.9,
+		MinConnectedPeers:            1,
+		MaxSendFailRate:              0.9,
+		MaxSendFailRateHalflife:      10 * time.Second,
+	}
+	nodeConfig.RouterHealthConfig = router.HealthConfig{
+		MaxDropRate:                       1,
+		MaxOutstandingRequests:            1024,
+		MaxTimeSinceNoOutstandingRequests: 5 * time.Minute,
+		MaxRunTimeRequests:                10 * time.Second,
+		MaxDropRateHalflife:               10 * time.Second,
+	}
+	nodeConfig.BenchlistConfig.Threshold = 10
+	nodeConfig.BenchlistConfig.Duration = 30 * time.Minute
+	nodeConfig.BenchlistConfig.MinimumFailingDuration = 5 * time.Minute
+	nodeConfig.BenchlistConfig.MaxPortion = (1.0 - (float64(alpha) / float64(k))) / 3.0
+	return nodeConfig, nil
+}
+
+// newGenesis returns the genesis of a local network whose initial validators
+// are [nodeIDs], whose stake starts now
+func newGenesis(nodeIDs []ids.ShortID) ([]byte, ids.ID, error) {
+	config := genesis.LocalConfig
+	config.StartTime = uint64(time.Now().Unix())
+	config.InitialStakers = make([]genesis.Staker, len(nodeIDs))
+	rewardAddress := genesis.LocalConfig.InitialStakers[0].RewardAddress
+	for i, nodeID := range nodeIDs {
+		config.InitialStakers[i] = genesis.Staker{
+			NodeID:        nodeID,
+			RewardAddress: rewardAddress,
+			DelegationFee: 1000000,
+		}
+	}
+	return genesis.FromConfig(&config)
+}
+
+// nodeID returns the ID of the node whose staking certificate is at [certPath]
+func nodeID(certPath string) (ids.ShortID, error) {
+	certBytes, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	block, _ := pem.Decode(certBytes)
+	if block == nil {
+		return ids.ShortID{}, errInvalidCert
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	return ids.ToShortID(hashing.PubkeyBytesToAddress(cert.Raw))
+}
+
+func nodeDir(dir string, i int) string { return filepath.Join(dir, fmt.Sprintf("node%d", i)) }
+
+// restarter shuts a node down rather than restarting it, as nodes can't be
+// restarted in place
+type restarter struct {
+	node *node.Node
+}
+
+func (r restarter) Restart() { r.node.Shutdown() }
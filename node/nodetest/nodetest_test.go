@@ -0,0 +1,147 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Full nodes trip the race detector on known races in some of their
+// components, so these tests don't run with it.
+
+//go:build !race
+// +build !race
+
+package nodetest
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/simnet"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/avm"
+)
+
+const (
+	// Key funded on the X-Chain by the local genesis
+	fundedKey = "PrivateKey-ewoqjP7PxY4yr3iLTpLisriqt94hdyDFNgchSxGGztUrTXtNN"
+	// Address that holds nothing on the X-Chain in the local genesis
+	recipient = "X-local1g65uqn6t77p656w64023nh8nd9updzmxyymev2"
+
+	sendAmount     = 1000
+	requestTimeout = 30 * time.Second
+)
+
+var user = api.UserPass{
+	Username: "nodetest",
+	Password: "ynWx7nVbZ8wJsgHp",
+}
+
+// send [sendAmount] nAVAX to [recipient] from the funded key, through node
+// [i]'s wallet, and return the ID of the transaction
+func send(t *testing.T, n *Network, i int) ids.ID {
+	if _, err := keystore.NewClient(n.URI(i), requestTimeout).CreateUser(user); err != nil {
+		t.Fatal(err)
+	}
+	xChain := avm.NewClient(n.URI(i), "X", requestTimeout)
+	from, err := xChain.ImportKey(user, fundedKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txID, err := xChain.Send(user, nil, from, sendAmount, "AVAX", recipient, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return txID
+}
+
+// accepted returns true if every node accepted the X-Chain transaction [txID]
+func accepted(n *Network, txID ids.ID) bool {
+	for i := range n.Nodes {
+		status, err := avm.NewClient(n.URI(i), "X", requestTimeout).GetTxStatus(txID)
+		if err != nil || status != choices.Accepted {
+			return false
+		}
+	}
+	return true
+}
+
+// waitFor [condition] to be true, failing the test if it isn't within
+// [timeout]
+func waitFor(t *testing.T, timeout time.Duration, msg string, condition func() bool) {
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", msg)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestNetworkBootstraps(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts full nodes")
+	}
+
+	dir, err := ioutil.TempDir("", "nodetest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	n, err := NewNetwork(Config{
+		NumNodes: 3,
+		Link: simnet.LinkConfig{
+			Latency:         5 * time.Millisecond,
+			LossRate:        0.01,
+			RetransmitDelay: 20 * time.Millisecond,
+		},
+		Dir:      dir,
+		LogLevel: logging.Info,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer n.Shutdown()
+
+	waitFor(t, time.Minute, "the nodes to connect", n.Connected)
+	waitFor(t, 2*time.Minute, "the P-Chain to bootstrap", func() bool {
+		return n.Bootstrapped(constants.PlatformChainID)
+	})
+	waitFor(t, 2*time.Minute, "the X-Chain to bootstrap", func() bool {
+		return n.Bootstrapped(n.XChainID)
+	})
+
+	txID := send(t, n, 0)
+	waitFor(t, time.Minute, "every node to accept the transaction", func() bool {
+		return accepted(n, txID)
+	})
+
+	// Isolate node 2, then let it rejoin
+	n.Sim.Partition([]net.IP{IP(2).IP})
+	waitFor(t, time.Minute, "node 2 to be disconnected", func() bool {
+		return len(n.Nodes[2].Net.Peers(nil)) == 0
+	})
+	n.Sim.Heal()
+	waitFor(t, 2*time.Minute, "node 2 to reconnect", n.Connected)
+
+	// A transaction issued through the node that rejoined is accepted by every
+	// node, which then agree on the state of the X-Chain
+	txID = send(t, n, 2)
+	waitFor(t, time.Minute, "every node to accept the transaction issued after the heal", func() bool {
+		return accepted(n, txID)
+	})
+	for i := range n.Nodes {
+		balance, err := avm.NewClient(n.URI(i), "X", requestTimeout).GetBalance(recipient, "AVAX", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if balance.Balance != 2*sendAmount {
+			t.Fatalf("node %d reports a balance of %d, but %d was sent", i, balance.Balance, 2*sendAmount)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/evm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/timestampvm"
+)
+
+const (
+	capturePathKey                 = "capture"
+	backupDirKey                   = "backup"
+	networkNameKey                 = "network-id"
+	genesisConfigFileKey           = "genesis"
+	vmPluginKey                    = "vm-plugin"
+	vmConfigKey                    = "vm-config"
+	vmIDKey                        = "vm-id"
+	subnetIDKey                    = "subnet-id"
+	chainGenesisKey                = "chain-genesis"
+	snowSampleSizeKey              = "snow-sample-size"
+	snowQuorumSizeKey              = "snow-quorum-size"
+	snowVirtuousCommitThresholdKey = "snow-virtuous-commit-threshold"
+	snowRogueCommitThresholdKey    = "snow-rogue-commit-threshold"
+	snowConcurrentRepollsKey       = "snow-concurrent-repolls"
+	snowOptimalProcessingKey       = "snow-optimal-processing"
+	snowMaxProcessingKey           = "snow-max-processing"
+	snowMaxTimeProcessingKey       = "snow-max-time-processing"
+	snowEpochFirstTransitionKey    = "snow-epoch-first-transition"
+	snowEpochDurationKey           = "snow-epoch-duration"
+
+	usage = "--capture <file> --backup <dir> [flags]"
+
+	// divergenceContext is the number of decisions printed from the first
+	// decision that differs
+	divergenceContext = 3
+)
+
+var (
+	errDiverged      = errors.New("replay diverged from the captured chain")
+	errWrongState    = errors.New("backup isn't the state the capture started from")
+	errUnknownChain  = errors.New("chain wasn't created in the genesis")
+	errNoInProcessVM = errors.New("VM doesn't run in process")
+
+	// Names of the VMs that can be passed to --vm-id instead of their IDs
+	vmAliases = map[string]ids.ID{
+		"platform":  platformvm.ID,
+		"avm":       avm.ID,
+		"evm":       evm.ID,
+		"timestamp": timestampvm.ID,
+	}
+)
+
+// config of a replay
+type config struct {
+	capturePath          string
+	backupDir            string
+	networkID            uint32
+	genesisConfigFile    string
+	vmPlugin             string
+	vmConfig             string
+	vmID                 ids.ID
+	subnetID             ids.ID
+	chainGenesisFile     string
+	params               snowball.Parameters
+	epochFirstTransition time.Time
+	epochDuration        time.Duration
+}
+
+// main is the entry point of the offline replay tool. It replays a capture of
+// a chain, taken with the admin API's startCapture, into a fresh engine whose
+// VM runs on the backup that was taken when the capture started, and reports
+// whether the engine made the same decisions as the captured chain. The VM
+// must have the last accepted block recorded in the capture before the replay
+// starts.
+//
+// Only Snowman chains that don't have proposer windows can be replayed. The
+// P-chain and timestamp VM chains run in process, and other VMs run as a
+// plugin. Chains that weren't created in the genesis must be described by
+// their subnet, VM and genesis data. The backup is opened read-only;
+// everything the engine and the VM write is kept in memory.
+func main() {
+	fs := pflag.NewFlagSet("replaytool", pflag.ExitOnError)
+	fs.String(capturePathKey, "", "Capture of the chain to replay")
+	fs.String(backupDirKey, "", "Directory of a database backup taken when the capture started")
+	fs.String(networkNameKey, constants.MainnetName, "Network ID of the node that took the capture")
+	fs.String(genesisConfigFileKey, "", "Genesis config file of the node (ignored for standard networks)")
+	fs.String(vmPluginKey, "", "Plugin binary of the chain's VM. If not provided, the VM must run in process")
+	fs.String(vmConfigKey, "", "Config the VM's plugin is started with")
+	fs.String(vmIDKey, "", "ID or name of the chain's VM, if the chain wasn't created in the genesis")
+	fs.String(subnetIDKey, "", "Subnet of the chain, if the chain wasn't created in the genesis")
+	fs.String(chainGenesisKey, "", "File with the chain's genesis data, if the chain wasn't created in the genesis")
+	fs.Int(snowSampleSizeKey, 20, "Number of nodes to query for each network poll")
+	fs.Int(snowQuorumSizeKey, 14, "Alpha value to use for required number positive results")
+	fs.Int(snowVirtuousCommitThresholdKey, 15, "Beta value to use for virtuous transactions")
+	fs.Int(snowRogueCommitThresholdKey, 20, "Beta value to use for rogue transactions")
+	fs.Int(snowConcurrentRepollsKey, 4, "Minimum number of concurrent polls for finalizing consensus")
+	fs.Int(snowOptimalProcessingKey, 50, "Optimal number of processing vertices in consensus")
+	fs.Int(snowMaxProcessingKey, 1024, "Maximum number of processing items to be considered healthy")
+	fs.Duration(snowMaxTimeProcessingKey, 2*time.Minute, "Maximum amount of time an item should be processing and still be healthy")
+	fs.Int64(snowEpochFirstTransitionKey, 1607626800, "Unix timestamp of the first epoch transaction, in seconds")
+	fs.Duration(snowEpochDurationKey, 6*time.Hour, "Duration of each epoch")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s %s\n", filepath.Base(os.Args[0]), usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+
+	config, err := parseConfig(fs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "parsing parameters returned with error %s\n", err)
+		os.Exit(2)
+	}
+	if err := replay(config); err != nil {
+		fmt.Fprintf(os.Stderr, "replay failed with: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func parseConfig(fs *pflag.FlagSet) (*config, error) {
+	c := &config{}
+	var err error
+	for key, dest := range map[string]*string{
+		capturePathKey:       &c.capturePath,
+		backupDirKey:         &c.backupDir,
+		genesisConfigFileKey: &c.genesisConfigFile,
+		vmPluginKey:          &c.vmPlugin,
+		vmConfigKey:          &c.vmConfig,
+		chainGenesisKey:      &c.chainGenesisFile,
+	} {
+		if *dest, err = fs.GetString(key); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range []string{capturePathKey, backupDirKey} {
+		if !fs.Changed(key) {
+			return nil, fmt.Errorf("--%s must be provided", key)
+		}
+	}
+
+	if fs.Changed(vmIDKey) {
+		vmID, err := fs.GetString(vmIDKey)
+		if err != nil {
+			return nil, err
+		}
+		if alias, ok := vmAliases[vmID]; ok {
+			c.vmID = alias
+		} else if c.vmID, err = ids.FromString(vmID); err != nil {
+			return nil, fmt.Errorf("couldn't parse --%s: %w", vmIDKey, err)
+		}
+	}
+	if fs.Changed(subnetIDKey) {
+		subnetID, err := fs.GetString(subnetIDKey)
+		if err != nil {
+			return nil, err
+		}
+		if c.subnetID, err = ids.FromString(subnetID); err != nil {
+			return nil, fmt.Errorf("couldn't parse --%s: %w", subnetIDKey, err)
+		}
+	}
+
+	networkName, err := fs.GetString(networkNameKey)
+	if err != nil {
+		return nil, err
+	}
+	if c.networkID, err = constants.NetworkID(networkName); err != nil {
+		return nil, err
+	}
+
+	for key, dest := range map[string]*int{
+		snowSampleSizeKey:              &c.params.K,
+		snowQuorumSizeKey:              &c.params.Alpha,
+		snowVirtuousCommitThresholdKey: &c.params.BetaVirtuous,
+		snowRogueCommitThresholdKey:    &c.params.BetaRogue,
+		snowConcurrentRepollsKey:       &c.params.ConcurrentRepolls,
+		snowOptimalProcessingKey:       &c.params.OptimalProcessing,
+		snowMaxProcessingKey:           &c.params.MaxOutstandingItems,
+	} {
+		if *dest, err = fs.GetInt(key); err != nil {
+			return nil, err
+		}
+	}
+	if c.params.MaxItemProcessingTime, err = fs.GetDuration(snowMaxTimeProcessingKey); err != nil {
+		return nil, err
+	}
+	if err := c.params.Verify(); err != nil {
+		return nil, err
+	}
+
+	epochFirstTransition, err := fs.GetInt64(snowEpochFirstTransitionKey)
+	if err != nil {
+		return nil, err
+	}
+	c.epochFirstTransition = time.Unix(epochFirstTransition, 0)
+	if c.epochDuration, err = fs.GetDuration(snowEpochDurationKey); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,418 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database/backup"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/networking/capture"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/triggers"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+	"github.com/ava-labs/avalanchego/vms/timestampvm"
+
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+	smbootstrap "github.com/ava-labs/avalanchego/snow/engine/snowman/bootstrap"
+)
+
+// replay the capture described by [c] and print whether the engine made the
+// same decisions as the captured chain
+func replay(c *config) error {
+	hdr, vdrs, err := scanCapture(c.capturePath)
+	if err != nil {
+		return err
+	}
+	chainID := hdr.ChainID
+
+	backupDB, metadata, err := backup.OpenReadOnly(c.backupDir, c.networkID, constants.DatabaseVersion, logging.NoLog{})
+	if err != nil {
+		return err
+	}
+	defer backupDB.Close()
+	fmt.Printf("replaying chain %s from the backup taken at %s\n", chainID, metadata.Timestamp.UTC())
+
+	// Writes are kept in memory, so the backup isn't modified
+	db := versiondb.New(backupDB)
+
+	replayer := &router.Replayer{}
+	ctx, chain, err := c.newContext(chainID, db, replayer)
+	if err != nil {
+		return err
+	}
+
+	engine, appHandler, err := c.newEngine(ctx, db, chain, vdrs, replayer)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx.Lock.Lock()
+		defer ctx.Lock.Unlock()
+
+		if err := engine.Shutdown(); err != nil {
+			fmt.Fprintf(os.Stderr, "couldn't shut down the engine: %s\n", err)
+		}
+	}()
+	if err := verifyLastAccepted(engine, hdr.LastAccepted); err != nil {
+		return err
+	}
+
+	file, err := os.Open(c.capturePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	reader, err := capture.NewReader(file)
+	if err != nil {
+		return err
+	}
+	result, err := replayer.Replay(reader, engine, appHandler)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("delivered %d messages, the captured chain made %d decisions and the replay made %d\n",
+		result.NumMessages, len(result.Expected), len(result.Actual))
+	if result.Matches() {
+		fmt.Println("the replay made the same decisions as the captured chain")
+		return nil
+	}
+	fmt.Printf("decision %d differs\n", result.Divergence)
+	for i := result.Divergence; i < result.Divergence+divergenceContext; i++ {
+		if i < len(result.Expected) {
+			fmt.Printf("  expected %s\n", result.Expected[i])
+		}
+		if i < len(result.Actual) {
+			fmt.Printf("  actual   %s\n", result.Actual[i])
+		}
+	}
+	return errDiverged
+}
+
+// scanCapture returns the header of the capture at [path], and the validators
+// the captured chain sampled or sent requests to. The engine is given the
+// samples of the captured chain, so the weights of the validators don't affect
+// the replay.
+func scanCapture(path string) (capture.Header, validators.Set, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return capture.Header{}, nil, err
+	}
+	defer file.Close()
+	reader, err := capture.NewReader(file)
+	if err != nil {
+		return capture.Header{}, nil, err
+	}
+
+	vdrs := validators.NewSet()
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			return reader.Header(), vdrs, nil
+		}
+		if err != nil {
+			return capture.Header{}, nil, err
+		}
+		for _, vdrID := range rec.ValidatorIDs {
+			if !vdrs.Contains(vdrID) {
+				if err := vdrs.AddWeight(vdrID, 1); err != nil {
+					return capture.Header{}, nil, err
+				}
+			}
+		}
+	}
+}
+
+// verifyLastAccepted returns an error if the last accepted block of the VM
+// that [engine] runs isn't [lastAccepted], the last accepted block of the
+// captured chain when the capture started
+func verifyLastAccepted(engine *smeng.Transitive, lastAccepted ids.ID) error {
+	engine.Ctx.Lock.Lock()
+	defer engine.Ctx.Lock.Unlock()
+
+	vmLastAccepted, err := engine.VM.LastAccepted()
+	if err != nil {
+		return fmt.Errorf("couldn't get the VM's last accepted block: %w", err)
+	}
+	if vmLastAccepted != lastAccepted {
+		return fmt.Errorf("%w: the VM's last accepted block is %s but the capture started at %s",
+			errWrongState, vmLastAccepted, lastAccepted)
+	}
+	return nil
+}
+
+// chainInfo is how a chain was created
+type chainInfo struct {
+	subnetID    ids.ID
+	vmID        ids.ID
+	genesisData []byte
+}
+
+// newContext returns the context of the chain [chainID], and how the chain was
+// created. If the chain wasn't created in the genesis of the network, it's
+// described by the flags.
+func (c *config) newContext(chainID ids.ID, db *versiondb.Database, replayer *router.Replayer) (*snow.Context, *chainInfo, error) {
+	genesisBytes, avaxAssetID, err := genesis.Genesis(c.networkID, c.genesisConfigFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, chainAliases, _, err := genesis.Aliases(genesisBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	gen := &platformvm.Genesis{}
+	if _, err := platformvm.GenesisCodec.Unmarshal(genesisBytes, gen); err != nil {
+		return nil, nil, err
+	}
+	if err := gen.Initialize(); err != nil {
+		return nil, nil, err
+	}
+
+	aliaser := &ids.Aliaser{}
+	aliaser.Initialize()
+	subnets := subnetLookup{constants.PlatformChainID: constants.PrimaryNetworkID}
+	var (
+		chain    *chainInfo
+		xChainID ids.ID
+	)
+	if chainID == constants.PlatformChainID {
+		chain = &chainInfo{
+			subnetID:    constants.PrimaryNetworkID,
+			vmID:        platformvm.ID,
+			genesisData: genesisBytes,
+		}
+	}
+	for _, tx := range gen.Chains {
+		unsignedTx, ok := tx.UnsignedTx.(*platformvm.UnsignedCreateChainTx)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected genesis chain type %T", tx.UnsignedTx)
+		}
+		subnets[tx.ID()] = unsignedTx.SubnetID
+		if unsignedTx.VMID == avm.ID {
+			xChainID = tx.ID()
+		}
+		if tx.ID() == chainID {
+			chain = &chainInfo{
+				subnetID:    unsignedTx.SubnetID,
+				vmID:        unsignedTx.VMID,
+				genesisData: unsignedTx.GenesisData,
+			}
+		}
+	}
+	if chain == nil {
+		if chain, err = c.flagChainInfo(); err != nil {
+			return nil, nil, fmt.Errorf("%w of network %d, so chain %s must be described: %s",
+				errUnknownChain, c.networkID, chainID, err)
+		}
+		subnets[chainID] = chain.subnetID
+	}
+	for id, aliases := range chainAliases {
+		for _, alias := range aliases {
+			if err := aliaser.Alias(id, alias); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	ks := &keystore.Keystore{}
+	if err := ks.Initialize(logging.NoLog{}, prefixdb.New([]byte("keystore"), db)); err != nil {
+		return nil, nil, err
+	}
+	sharedMemory := &atomic.Memory{}
+	if err := sharedMemory.Initialize(logging.NoLog{}, prefixdb.New([]byte("shared memory"), db)); err != nil {
+		return nil, nil, err
+	}
+	consensusDispatcher := &triggers.EventDispatcher{}
+	consensusDispatcher.Initialize(logging.NoLog{})
+
+	return &snow.Context{
+		NetworkID:            c.networkID,
+		SubnetID:             chain.subnetID,
+		ChainID:              chainID,
+		XChainID:             xChainID,
+		AVAXAssetID:          avaxAssetID,
+		Log:                  logging.NoLog{},
+		DecisionDispatcher:   replayer,
+		ConsensusDispatcher:  consensusDispatcher,
+		Keystore:             ks.NewBlockchainKeyStore(chainID),
+		SharedMemory:         sharedMemory.NewSharedMemory(chainID),
+		BCLookup:             aliaser,
+		SNLookup:             subnets,
+		Namespace:            fmt.Sprintf("%s_replay", constants.PlatformName),
+		Metrics:              prometheus.NewRegistry(),
+		EpochFirstTransition: c.epochFirstTransition,
+		EpochDuration:        c.epochDuration,
+	}, chain, nil
+}
+
+// flagChainInfo returns how a chain that wasn't created in the genesis was
+// created, as described by the flags
+func (c *config) flagChainInfo() (*chainInfo, error) {
+	switch {
+	case c.subnetID == ids.Empty:
+		return nil, fmt.Errorf("--%s must be provided", subnetIDKey)
+	case c.vmID == ids.Empty:
+		return nil, fmt.Errorf("--%s must be provided", vmIDKey)
+	case c.chainGenesisFile == "":
+		return nil, fmt.Errorf("--%s must be provided", chainGenesisKey)
+	}
+	genesisData, err := ioutil.ReadFile(c.chainGenesisFile)
+	if err != nil {
+		return nil, err
+	}
+	return &chainInfo{
+		subnetID:    c.subnetID,
+		vmID:        c.vmID,
+		genesisData: genesisData,
+	}, nil
+}
+
+// newVMFactory returns the factory of the VM [vmID]. The VM runs as a plugin
+// if one was provided, and otherwise in process.
+func (c *config) newVMFactory(vmID ids.ID) (vms.VMFactory, error) {
+	if c.vmPlugin != "" {
+		return &rpcchainvm.Factory{
+			Path:   c.vmPlugin,
+			Config: c.vmConfig,
+		}, nil
+	}
+	switch vmID {
+	case platformvm.ID:
+		params := genesis.GetParams(c.networkID)
+		return &platformvm.Factory{
+			ChainManager:       chains.MockManager{},
+			Validators:         validators.NewManager(),
+			StakingEnabled:     true,
+			CreationFee:        params.CreationTxFee,
+			Fee:                params.TxFee,
+			UptimePercentage:   params.UptimeRequirement,
+			MinValidatorStake:  params.MinValidatorStake,
+			MaxValidatorStake:  params.MaxValidatorStake,
+			MinDelegatorStake:  params.MinDelegatorStake,
+			MinDelegationFee:   params.MinDelegationFee,
+			MinStakeDuration:   params.MinStakeDuration,
+			MaxStakeDuration:   params.MaxStakeDuration,
+			StakeMintingPeriod: params.StakeMintingPeriod,
+			ApricotPhase0Time:  params.ApricotPhase0Time,
+		}, nil
+	case timestampvm.ID:
+		return &timestampvm.Factory{}, nil
+	default:
+		return nil, fmt.Errorf("%w: --%s must be provided for VM %s", errNoInProcessVM, vmPluginKey, vmID)
+	}
+}
+
+// newEngine returns a Snowman engine that runs [chain] with the context [ctx]
+// on [db], and the VM if it handles application-level messages. The engine
+// doesn't bootstrap, as the VM's state is already the state the captured chain
+// was in.
+func (c *config) newEngine(
+	ctx *snow.Context,
+	db *versiondb.Database,
+	chain *chainInfo,
+	vdrs validators.Set,
+	replayer *router.Replayer,
+) (*smeng.Transitive, common.AppHandler, error) {
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	factory, err := c.newVMFactory(chain.vmID)
+	if err != nil {
+		return nil, nil, err
+	}
+	vmIntf, err := factory.New(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't start the VM: %w", err)
+	}
+	vm, ok := vmIntf.(block.ChainVM)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected a %T but the VM is a %T", (block.ChainVM)(nil), vmIntf)
+	}
+
+	chainDB := prefixdb.New(ctx.ChainID[:], db)
+	blocked, err := queue.New(prefixdb.New([]byte("bs"), chainDB))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Nothing is sent; the captured responses are delivered instead
+	appHandler, isAppHandler := vm.(common.AppHandler)
+	if isAppHandler {
+		appHandler.SetAppSender(replayer.AppSender(noopSender{}))
+	}
+	msgChan := make(chan common.Message, 1)
+	if err := vm.Initialize(ctx, prefixdb.New([]byte("vm"), chainDB), chain.genesisData, msgChan, nil); err != nil {
+		return nil, nil, fmt.Errorf("error during vm's Initialize: %w", err)
+	}
+
+	params := c.params
+	params.Namespace = ctx.Namespace
+	params.Metrics = ctx.Metrics
+	engine := &smeng.Transitive{}
+	err = engine.Initialize(smeng.Config{
+		Config: smbootstrap.Config{
+			Config: common.Config{
+				Ctx:        ctx,
+				Validators: replayer.Validators(vdrs),
+				Beacons:    validators.NewSet(),
+				SampleK:    params.K,
+				Alpha:      1,
+				Sender:     replayer.Sender(noopSender{}),
+				Subnet:     bootstrappedSubnet{},
+				Delay:      &router.Delay{},
+			},
+			Blocked: blocked,
+			VM:      vm,
+		},
+		Params:    params,
+		Consensus: &snowman.Topological{},
+	})
+	if err != nil {
+		errs := wrappers.Errs{}
+		errs.Add(err, vm.Shutdown())
+		return nil, nil, fmt.Errorf("error initializing snowman engine: %w", errs.Err)
+	}
+	return engine, appHandler, nil
+}
+
+// subnetLookup maps the chains created in the genesis to their subnets
+type subnetLookup map[ids.ID]ids.ID
+
+func (l subnetLookup) SubnetID(chainID ids.ID) (ids.ID, error) {
+	subnetID, ok := l[chainID]
+	if !ok {
+		return ids.ID{}, fmt.Errorf("unknown chain %s", chainID)
+	}
+	return subnetID, nil
+}
+
+// bootstrappedSubnet is a subnet whose chains are all bootstrapped
+type bootstrappedSubnet struct{}
+
+func (bootstrappedSubnet) IsBootstrapped() bool { return true }
+func (bootstrappedSubnet) Bootstrapped(ids.ID)  {}
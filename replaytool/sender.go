@@ -0,0 +1,34 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+var (
+	_ common.Sender    = noopSender{}
+	_ common.AppSender = noopSender{}
+)
+
+// noopSender drops every message. While replaying, the captured responses to
+// the engine's requests are delivered instead.
+type noopSender struct{}
+
+func (noopSender) GetAcceptedFrontier(ids.ShortSet, uint32)          {}
+func (noopSender) AcceptedFrontier(ids.ShortID, uint32, []ids.ID)    {}
+func (noopSender) GetAccepted(ids.ShortSet, uint32, []ids.ID)        {}
+func (noopSender) Accepted(ids.ShortID, uint32, []ids.ID)            {}
+func (noopSender) Get(ids.ShortID, uint32, ids.ID)                   {}
+func (noopSender) GetAncestors(ids.ShortID, uint32, ids.ID)          {}
+func (noopSender) Put(ids.ShortID, uint32, ids.ID, []byte)           {}
+func (noopSender) MultiPut(ids.ShortID, uint32, [][]byte)            {}
+func (noopSender) PushQuery(ids.ShortSet, uint32, ids.ID, []byte)    {}
+func (noopSender) PullQuery(ids.ShortSet, uint32, ids.ID)            {}
+func (noopSender) Chits(ids.ShortID, uint32, []ids.ID)               {}
+func (noopSender) Gossip(ids.ID, []byte)                             {}
+func (noopSender) SendAppRequest(ids.ShortSet, uint32, []byte) error { return nil }
+func (noopSender) SendAppResponse(ids.ShortID, uint32, []byte) error { return nil }
+func (noopSender) SendAppGossip([]byte) error                        { return nil }
@@ -15,3 +15,11 @@ GIT_COMMIT=$( git rev-list -1 HEAD )
 # Build aVALANCHE
 echo "Building Avalanche..."
 go build -ldflags "-X main.GitCommit=$GIT_COMMIT" -o "$BUILD_DIR/avalanchego" "$AVALANCHE_PATH/main/"*.go
+
+# Build the offline database tool
+echo "Building the database tool..."
+go build -o "$BUILD_DIR/dbtool" "$AVALANCHE_PATH/dbtool/"*.go
+
+# Build the offline replay tool
+echo "Building the replay tool..."
+go build -o "$BUILD_DIR/replaytool" "$AVALANCHE_PATH/replaytool/"*.go
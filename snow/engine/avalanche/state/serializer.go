@@ -7,8 +7,10 @@ package state
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
@@ -23,6 +25,10 @@ import (
 const (
 	dbCacheSize = 10000
 	idCacheSize = 1000
+
+	// cacheEntryOverhead is the size charged for every entry of a byte
+	// budgeted cache, in addition to the bytes of the vertex, if any
+	cacheEntryOverhead = 64
 )
 
 var (
@@ -32,6 +38,10 @@ var (
 
 // Serializer manages the state of multiple vertices
 type Serializer struct {
+	// If positive, the vertices and statuses read from the database are
+	// cached up to this many bytes, rather than up to a number of entries
+	CacheBytes int
+
 	ctx   *snow.Context
 	vm    vertex.DAGVM
 	state *prefixedState
@@ -40,12 +50,26 @@ type Serializer struct {
 }
 
 // Initialize implements the avalanche.State interface
-func (s *Serializer) Initialize(ctx *snow.Context, vm vertex.DAGVM, db database.Database) {
+func (s *Serializer) Initialize(ctx *snow.Context, vm vertex.DAGVM, db database.Database) error {
 	s.ctx = ctx
 	s.vm = vm
 
 	vdb := versiondb.New(db)
-	dbCache := &cache.LRU{Size: dbCacheSize}
+	var dbCache cache.Cacher = &cache.LRU{Size: dbCacheSize}
+	if s.CacheBytes > 0 {
+		sizedCache, err := metercacher.New(
+			fmt.Sprintf("%s_vtx_cache", ctx.Namespace),
+			ctx.Metrics,
+			&cache.SizedLRU{
+				MaxSize:  s.CacheBytes,
+				SizeFunc: cacheEntrySize,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't initialize vertex cache: %w", err)
+		}
+		dbCache = sizedCache
+	}
 	rawState := &state{
 		serializer: s,
 		dbCache:    dbCache,
@@ -55,6 +79,16 @@ func (s *Serializer) Initialize(ctx *snow.Context, vm vertex.DAGVM, db database.
 	s.db = vdb
 
 	s.edge.Add(s.state.Edge()...)
+	return nil
+}
+
+// cacheEntrySize returns the size of a vertex, status or miss cached in the
+// state's database cache
+func cacheEntrySize(value interface{}) int {
+	if vtx, ok := value.(vertex.StatelessVertex); ok && vtx != nil {
+		return cacheEntryOverhead + len(vtx.Bytes())
+	}
+	return cacheEntryOverhead
 }
 
 // Parse implements the avalanche.State interface
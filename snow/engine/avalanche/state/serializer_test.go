@@ -0,0 +1,61 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
+)
+
+func TestSerializerCacheBytes(t *testing.T) {
+	vm := vertex.TestVM{}
+	vm.T = t
+	vm.Default(true)
+
+	registry := prometheus.NewRegistry()
+	ctx := snow.DefaultContextTest()
+	ctx.Namespace = "test"
+	ctx.Metrics = registry
+
+	s := &Serializer{CacheBytes: 1024}
+	if err := s.Initialize(ctx, &vm, memdb.New()); err != nil {
+		t.Fatal(err)
+	}
+
+	vtx, err := vertex.Build(ids.Empty, 1, 0, []ids.ID{{1}}, [][]byte{{0}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.state.SetVertex(vtx); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.state.SetStatus(vtx.ID(), choices.Accepted); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The cache holds the vertex, its status and the edge read on
+	// initialization
+	expected := float64(3*cacheEntryOverhead + len(vtx.Bytes()))
+	for _, metric := range metrics {
+		if metric.GetName() != "test_vtx_cache_bytes" {
+			continue
+		}
+		if value := metric.GetMetric()[0].GetGauge().GetValue(); value != expected {
+			t.Fatalf("vertex cache holds %f bytes but expected %f", value, expected)
+		}
+		return
+	}
+	t.Fatal("missing vertex cache bytes metric")
+}
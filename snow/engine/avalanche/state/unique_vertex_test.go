@@ -24,7 +24,9 @@ func newSerializer(t *testing.T, parse func([]byte) (snowstorm.Tx, error)) *Seri
 	baseDB := memdb.New()
 	ctx := snow.DefaultContextTest()
 	s := &Serializer{}
-	s.Initialize(ctx, &vm, baseDB)
+	if err := s.Initialize(ctx, &vm, baseDB); err != nil {
+		t.Fatal(err)
+	}
 	return s
 }
 
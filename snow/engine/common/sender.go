@@ -86,3 +86,20 @@ type Gossiper interface {
 	// Gossip gossips the provided container throughout the network
 	Gossip(containerID ids.ID, container []byte)
 }
+
+// AppSender sends application-level messages to the instances of a VM that run
+// the same chain on other nodes. See AppHandler.
+type AppSender interface {
+	// SendAppRequest sends [request] to every node in [nodeIDs]. Each node
+	// either responds, which calls AppResponse, or the request fails, which
+	// calls AppRequestFailed. [requestID] should be unique among this VM's
+	// outstanding requests.
+	SendAppRequest(nodeIDs ids.ShortSet, requestID uint32, request []byte) error
+
+	// SendAppResponse responds with [response] to the request [requestID] that
+	// [nodeID] sent.
+	SendAppResponse(nodeID ids.ShortID, requestID uint32, response []byte) error
+
+	// SendAppGossip gossips [msg] to a sample of the nodes that run this VM.
+	SendAppGossip(msg []byte) error
+}
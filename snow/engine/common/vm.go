@@ -6,6 +6,7 @@ package common
 import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/health"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 )
 
@@ -66,6 +67,51 @@ type VM interface {
 	CreateHandlers() (map[string]*HTTPHandler, error)
 }
 
+// AppHandler is optionally implemented by a VM that exchanges application-level
+// messages with the instances of the VM that run the same chain on other
+// nodes. The contents of the messages are opaque to the node.
+//
+// The methods are called with the chain's context lock held. A returned error
+// is treated as fatal and shuts down the chain, so a VM should only return an
+// error if it can't continue. Malformed messages should be dropped.
+type AppHandler interface {
+	// SetAppSender is called before Initialize with the sender this VM uses to
+	// send application-level messages.
+	SetAppSender(appSender AppSender)
+
+	// Notify this VM of a request from [nodeID].
+	//
+	// This function can be called by any node. It is not safe to assume that
+	// [requestID] is unique or that [request] is well-formed. However, the
+	// nodeID is assumed to be authenticated.
+	//
+	// This VM may respond by calling SendAppResponse with the same requestID
+	// before the request's deadline.
+	AppRequest(nodeID ids.ShortID, requestID uint32, request []byte) error
+
+	// Notify this VM that a request it sent to [nodeID] with SendAppRequest
+	// will not be responded to. This happens if the request timed out or
+	// couldn't be sent.
+	//
+	// This is called exactly once for every request that isn't responded to.
+	AppRequestFailed(nodeID ids.ShortID, requestID uint32) error
+
+	// Notify this VM of a response from [nodeID] to a request it sent with
+	// SendAppRequest.
+	//
+	// This is called at most once for every request that was sent, and only
+	// if AppRequestFailed isn't called for it. It is not safe to assume that
+	// [response] is well-formed.
+	AppResponse(nodeID ids.ShortID, requestID uint32, response []byte) error
+
+	// Notify this VM of a message gossiped by [nodeID].
+	//
+	// This function can be called by any node. It is not safe to assume that
+	// [msg] is well-formed. However, the nodeID is assumed to be
+	// authenticated.
+	AppGossip(nodeID ids.ShortID, msg []byte) error
+}
+
 // StaticVM describes the functionality that allows a user to interact with a VM
 // statically.
 type StaticVM interface {
@@ -2,20 +2,30 @@ package benchlist
 
 import (
 	"container/heap"
+	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/prometheus/client_golang/prometheus"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
+var (
+	errNotBenched          = errors.New("not benched")
+	errNonPositiveDuration = errors.New("bench duration must be positive")
+	errMalformedBenchInDB  = errors.New("malformed bench entry in database")
+)
+
 // If a peer consistently does not respond to queries, it will
 // increase latencies on the network whenever that peer is polled.
 // If we cannot terminate the poll early, then the poll will wait
@@ -35,6 +45,37 @@ type Benchlist interface {
 	// IsBenched returns true if messages to [validatorID]
 	// should not be sent over the network and should immediately fail.
 	IsBenched(validatorID ids.ShortID) bool
+	// Bench [validatorID] for [duration], regardless of its failures and of
+	// the maximum portion of stake that may be benched. If it's already
+	// benched, it's benched for [duration] from now instead.
+	Bench(validatorID ids.ShortID, duration time.Duration) error
+	// Unbench [validatorID]
+	Unbench(validatorID ids.ShortID) error
+	// Benched returns the benched validators, ordered by when they leave the
+	// bench
+	Benched() []BenchedValidator
+}
+
+// Listener is notified when a validator is benched on a chain
+type Listener interface {
+	Benched(chainID ids.ID, validator BenchedValidator)
+}
+
+// BenchedValidator describes a validator on the bench
+type BenchedValidator struct {
+	ValidatorID ids.ShortID
+	// Time the validator was benched
+	Since time.Time
+	// Time the validator leaves the bench
+	Until time.Time
+	// Number of consecutive requests to the validator that failed before it
+	// was benched, and the time the first of them failed. Zero if the
+	// validator was benched manually.
+	Failures     int
+	FirstFailure time.Time
+	// True if the validator was benched through Bench rather than for failing
+	// requests
+	Manual bool
 }
 
 // Data about a validator who is benched
@@ -42,6 +83,22 @@ type benchData struct {
 	benchedUntil time.Time
 	validatorID  ids.ShortID
 	index        int
+
+	benchedAt    time.Time
+	failures     int
+	firstFailure time.Time
+	manual       bool
+}
+
+func (d *benchData) benchedValidator() BenchedValidator {
+	return BenchedValidator{
+		ValidatorID:  d.validatorID,
+		Since:        d.benchedAt,
+		Until:        d.benchedUntil,
+		Failures:     d.failures,
+		FirstFailure: d.firstFailure,
+		Manual:       d.manual,
+	}
 }
 
 // Implements heap.Interface. Each element is a benched validator
@@ -83,6 +140,16 @@ type benchlist struct {
 	log     logging.Logger
 	metrics metrics
 
+	// Chain this benchlist is for
+	chainID ids.ID
+
+	// Stores the benched validators, so they stay benched across restarts.
+	// Keyed by validator ID.
+	db database.Database
+
+	// Notified when a validator is benched. May be nil.
+	listener Listener
+
 	// Fires when the next validator should leave the bench
 	// Calls [update] when it fires
 	timer *timer.Timer
@@ -117,10 +184,15 @@ type benchlist struct {
 	maxPortion float64
 }
 
-// NewBenchlist returns a new Benchlist
+// NewBenchlist returns a new Benchlist for chain [chainID]. Validators that
+// are benched are stored in [db], and validators in [db] that are still
+// benched are put back on the bench. [listener] may be nil.
 func NewBenchlist(
+	chainID ids.ID,
 	log logging.Logger,
 	validators validators.Set,
+	db database.Database,
+	listener Listener,
 	threshold int,
 	minimumFailingDuration,
 	duration time.Duration,
@@ -132,7 +204,10 @@ func NewBenchlist(
 		return nil, fmt.Errorf("max portion of benched stake must be in [0,1) but got %f", maxPortion)
 	}
 	benchlist := &benchlist{
+		chainID:                chainID,
 		log:                    log,
+		db:                     db,
+		listener:               listener,
 		failureStreaks:         make(map[ids.ShortID]failureStreak),
 		benchlistSet:           ids.ShortSet{},
 		vdrs:                   validators,
@@ -141,9 +216,57 @@ func NewBenchlist(
 		duration:               duration,
 		maxPortion:             maxPortion,
 	}
+	if err := benchlist.metrics.Initialize(registerer, namespace); err != nil {
+		return nil, err
+	}
 	benchlist.timer = timer.NewTimer(benchlist.update)
 	go benchlist.timer.Dispatch()
-	return benchlist, benchlist.metrics.Initialize(registerer, namespace)
+
+	if err := benchlist.load(); err != nil {
+		benchlist.timer.Stop()
+		return nil, fmt.Errorf("couldn't load benched validators: %w", err)
+	}
+	return benchlist, nil
+}
+
+// load the benched validators in [b.db], deleting those that have left the
+// bench since they were stored
+func (b *benchlist) load() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.clock.Time()
+	expired := [][]byte(nil)
+	it := b.db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		data, err := parseBenchData(it.Key(), it.Value())
+		if err != nil {
+			return err
+		}
+		if !data.benchedUntil.After(now) {
+			// Copy the key, as the iterator may reuse it
+			expired = append(expired, append([]byte(nil), it.Key()...))
+			continue
+		}
+		b.benchlistSet.Add(data.validatorID)
+		heap.Push(&b.benchedQueue, data)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for _, key := range expired {
+		if err := b.db.Delete(key); err != nil {
+			return err
+		}
+	}
+	if b.benchedQueue.Len() > 0 {
+		b.log.Info("%d validators are still benched", b.benchedQueue.Len())
+	}
+	b.setNextLeaveTime()
+	b.updateMetrics()
+	return nil
 }
 
 // Update removes benched validators whose time on the bench is over
@@ -173,13 +296,21 @@ func (b *benchlist) remove(validator *benchData) {
 	b.log.Debug("removing validator %s from benchlist", id)
 	heap.Remove(&b.benchedQueue, validator.index)
 	b.benchlistSet.Remove(id)
+	if err := b.db.Delete(id[:]); err != nil {
+		b.log.Error("couldn't delete benched validator %s: %s", id, err)
+	}
 
-	// Update metrics
+	b.updateMetrics()
+}
+
+// Update the metrics to match the benched validators
+// Assumes [b.lock] is held
+func (b *benchlist) updateMetrics() {
 	b.metrics.numBenched.Set(float64(b.benchedQueue.Len()))
 	benchedStake, err := b.vdrs.SubsetWeight(b.benchlistSet)
 	if err != nil {
 		// This should never happen
-		b.log.Error("couldn't get benched stake: %w", err)
+		b.log.Error("couldn't get benched stake: %s", err)
 		return
 	}
 	b.metrics.weightBenched.Set(float64(benchedStake))
@@ -240,11 +371,21 @@ func (b *benchlist) RegisterResponse(validatorID ids.ShortID) {
 // RegisterResponse notes that a request to validator [validatorID] timed out
 func (b *benchlist) RegisterFailure(validatorID ids.ShortID) {
 	b.lock.Lock()
-	defer b.lock.Unlock()
+	benched, isBenched := b.registerFailure(validatorID)
+	b.lock.Unlock()
+
+	if isBenched {
+		b.notify(benched)
+	}
+}
 
+// registerFailure notes that a request to validator [validatorID] timed out.
+// Returns the validator and true if it was benched as a result.
+// Assumes [b.lock] is held
+func (b *benchlist) registerFailure(validatorID ids.ShortID) (BenchedValidator, bool) {
 	if b.benchlistSet.Contains(validatorID) {
 		// This validator is benched. Ignore failures until they're not.
-		return
+		return BenchedValidator{}, false
 	}
 
 	failureStreak := b.failureStreaks[validatorID]
@@ -259,33 +400,33 @@ func (b *benchlist) RegisterFailure(validatorID ids.ShortID) {
 	b.failureStreaks[validatorID] = failureStreak
 
 	if failureStreak.consecutive >= b.threshold && now.After(failureStreak.firstFailure.Add(b.minimumFailingDuration)) {
-		b.bench(validatorID)
+		return b.bench(validatorID, failureStreak)
 	}
-
+	return BenchedValidator{}, false
 }
 
 // Assumes [b.lock] is held
 // Assumes [validatorID] is not already benched
-func (b *benchlist) bench(validatorID ids.ShortID) {
+func (b *benchlist) bench(validatorID ids.ShortID, failureStreak failureStreak) (BenchedValidator, bool) {
 	benchedStake, err := b.vdrs.SubsetWeight(b.benchlistSet)
 	if err != nil {
 		// This should never happen
 		b.log.Error("couldn't get benched stake: %w. Resetting benchlist", err)
-		return
+		return BenchedValidator{}, false
 	}
 
 	validatorStake, isVdr := b.vdrs.GetWeight(validatorID)
 	if !isVdr {
 		// We might want to bench a non-validator because they don't respond to
 		// my Get requests, but we choose to only bench validators.
-		return
+		return BenchedValidator{}, false
 	}
 
 	newBenchedStake, err := safemath.Add64(benchedStake, validatorStake)
 	if err != nil {
 		// This should never happen
 		b.log.Error("overflow calculating new benched stake with validator %s", validatorID)
-		return
+		return BenchedValidator{}, false
 	}
 
 	totalStake := b.vdrs.Weight()
@@ -298,7 +439,7 @@ func (b *benchlist) bench(validatorID ids.ShortID) {
 			float64(newBenchedStake),
 			maxBenchedStake,
 		)
-		return
+		return BenchedValidator{}, false
 	}
 
 	// Validator is benched for between [b.duration]/2 and [b.duration]
@@ -310,23 +451,146 @@ func (b *benchlist) bench(validatorID ids.ShortID) {
 	benchedUntil := minBenchedUntil.Add(time.Duration(rand.Float64() * float64(diff))) // #nosec G404
 
 	// Add to benchlist times with randomized delay
-	b.benchlistSet.Add(validatorID)
-	delete(b.failureStreaks, validatorID)
-	heap.Push(
-		&b.benchedQueue,
-		&benchData{validatorID: validatorID, benchedUntil: benchedUntil},
-	)
+	data := &benchData{
+		validatorID:  validatorID,
+		benchedUntil: benchedUntil,
+		benchedAt:    now,
+		failures:     failureStreak.consecutive,
+		firstFailure: failureStreak.firstFailure,
+	}
+	b.add(data)
 	b.log.Debug(
 		"benching validator %s for %s after %d consecutive failed queries.",
 		validatorID,
 		benchedUntil.Sub(now),
 		b.threshold,
 	)
+	return data.benchedValidator(), true
+}
+
+// add [data] to the bench and store it
+// Assumes [b.lock] is held
+// Assumes [data.validatorID] is not already benched
+func (b *benchlist) add(data *benchData) {
+	b.benchlistSet.Add(data.validatorID)
+	delete(b.failureStreaks, data.validatorID)
+	heap.Push(&b.benchedQueue, data)
+	if err := b.db.Put(data.validatorID[:], data.bytes()); err != nil {
+		// The validator is still benched until the node restarts
+		b.log.Error("couldn't store benched validator %s: %s", data.validatorID, err)
+	}
 
 	// Set [b.timer] to fire when next validator should leave bench
 	b.setNextLeaveTime()
 
 	// Update metrics
-	b.metrics.numBenched.Set(float64(b.benchedQueue.Len()))
-	b.metrics.weightBenched.Set(float64(newBenchedStake))
+	b.updateMetrics()
+}
+
+// Bench implements the Benchlist interface
+func (b *benchlist) Bench(validatorID ids.ShortID, duration time.Duration) error {
+	if duration <= 0 {
+		return errNonPositiveDuration
+	}
+
+	b.lock.Lock()
+	if data := b.benchData(validatorID); data != nil {
+		b.remove(data)
+	}
+	now := b.clock.Time()
+	data := &benchData{
+		validatorID:  validatorID,
+		benchedUntil: now.Add(duration),
+		benchedAt:    now,
+		manual:       true,
+	}
+	b.add(data)
+	benched := data.benchedValidator()
+	b.lock.Unlock()
+
+	b.log.Info("benched validator %s for %s", validatorID, duration)
+	b.notify(benched)
+	return nil
+}
+
+// Unbench implements the Benchlist interface
+func (b *benchlist) Unbench(validatorID ids.ShortID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	data := b.benchData(validatorID)
+	if data == nil {
+		return fmt.Errorf("validator %s is %w", validatorID, errNotBenched)
+	}
+	b.remove(data)
+	b.setNextLeaveTime()
+	b.log.Info("unbenched validator %s", validatorID)
+	return nil
+}
+
+// Benched implements the Benchlist interface
+func (b *benchlist) Benched() []BenchedValidator {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	benched := make([]BenchedValidator, len(b.benchedQueue))
+	for i, data := range b.benchedQueue {
+		benched[i] = data.benchedValidator()
+	}
+	sort.Slice(benched, func(i, j int) bool { return benched[i].Until.Before(benched[j].Until) })
+	return benched
+}
+
+// benchData returns the data of [validatorID] if it's benched, or nil
+// Assumes [b.lock] is held
+func (b *benchlist) benchData(validatorID ids.ShortID) *benchData {
+	if !b.benchlistSet.Contains(validatorID) {
+		return nil
+	}
+	for _, data := range b.benchedQueue {
+		if data.validatorID == validatorID {
+			return data
+		}
+	}
+	return nil
+}
+
+// notify the listener that [benched] was benched
+// Assumes [b.lock] is not held
+func (b *benchlist) notify(benched BenchedValidator) {
+	if b.listener != nil {
+		b.listener.Benched(b.chainID, benched)
+	}
+}
+
+// bytes returns the value [d] is stored with
+func (d *benchData) bytes() []byte {
+	p := wrappers.Packer{MaxSize: 3*wrappers.LongLen + wrappers.IntLen + wrappers.BoolLen}
+	p.PackLong(uint64(d.benchedUntil.Unix()))
+	p.PackLong(uint64(d.benchedAt.Unix()))
+	p.PackLong(uint64(d.firstFailure.Unix()))
+	p.PackInt(uint32(d.failures))
+	p.PackBool(d.manual)
+	return p.Bytes
+}
+
+// parseBenchData returns the benched validator stored at [key] with [value]
+func parseBenchData(key, value []byte) (*benchData, error) {
+	validatorID, err := ids.ToShortID(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errMalformedBenchInDB, err)
+	}
+	p := wrappers.Packer{Bytes: value}
+	data := &benchData{
+		validatorID:  validatorID,
+		benchedUntil: time.Unix(int64(p.UnpackLong()), 0),
+		benchedAt:    time.Unix(int64(p.UnpackLong()), 0),
+		firstFailure: time.Unix(int64(p.UnpackLong()), 0),
+		failures:     int(p.UnpackInt()),
+		manual:       p.UnpackBool(),
+	}
+	if p.Errored() {
+		return nil, fmt.Errorf("%w: %s", errMalformedBenchInDB, p.Err)
+	}
+	return data, nil
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -41,8 +42,11 @@ func TestBenchlistAdd(t *testing.T) {
 	duration := time.Minute
 	maxPortion := 0.5
 	benchIntf, err := NewBenchlist(
+		ids.Empty,
 		logging.NoLog{},
 		vdrs,
+		memdb.New(),
+		nil,
 		threshold,
 		minimumFailingDuration,
 		duration,
@@ -176,8 +180,11 @@ func TestBenchlistMaxStake(t *testing.T) {
 	// Shouldn't bench more than 2550 (5100/2)
 	maxPortion := 0.5
 	benchIntf, err := NewBenchlist(
+		ids.Empty,
 		logging.NoLog{},
 		vdrs,
+		memdb.New(),
+		nil,
 		threshold,
 		minimumFailingDuration,
 		duration,
@@ -306,8 +313,11 @@ func TestBenchlistRemove(t *testing.T) {
 	duration := 2 * time.Second
 	maxPortion := 0.76 // can bench 3 of the 5 validators
 	benchIntf, err := NewBenchlist(
+		ids.Empty,
 		logging.NoLog{},
 		vdrs,
+		memdb.New(),
+		nil,
 		threshold,
 		minimumFailingDuration,
 		duration,
@@ -393,3 +403,169 @@ func TestBenchlistRemove(t *testing.T) {
 	)
 
 }
+
+type testListener struct {
+	chainIDs []ids.ID
+	benched  []BenchedValidator
+}
+
+func (l *testListener) Benched(chainID ids.ID, validator BenchedValidator) {
+	l.chainIDs = append(l.chainIDs, chainID)
+	l.benched = append(l.benched, validator)
+}
+
+// Test that validators can be benched and unbenched manually, and that
+// listeners are notified when validators are benched
+func TestBenchlistManualBench(t *testing.T) {
+	vdrs := validators.NewSet()
+	vdr0 := validators.GenerateRandomValidator(1000)
+	vdr1 := validators.GenerateRandomValidator(1000)
+	errs := wrappers.Errs{}
+	errs.Add(
+		vdrs.AddWeight(vdr0.ID(), vdr0.Weight()),
+		vdrs.AddWeight(vdr1.ID(), vdr1.Weight()),
+	)
+	if errs.Errored() {
+		t.Fatal(errs.Err)
+	}
+
+	chainID := ids.GenerateTestID()
+	listener := &testListener{}
+	threshold := 3
+	// No validator would be benched for failing requests
+	maxPortion := 0.1
+	benchIntf, err := NewBenchlist(
+		chainID,
+		logging.NoLog{},
+		vdrs,
+		memdb.New(),
+		listener,
+		threshold,
+		minimumFailingDuration,
+		time.Minute,
+		maxPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := benchIntf.(*benchlist)
+	defer b.timer.Stop()
+	now := time.Now()
+	b.lock.Lock()
+	b.clock.Set(now)
+	b.lock.Unlock()
+
+	assert.Error(t, b.Bench(vdr0.ID(), 0))
+	assert.NoError(t, b.Bench(vdr0.ID(), time.Hour))
+	assert.True(t, b.IsBenched(vdr0.ID()))
+	assert.False(t, b.IsBenched(vdr1.ID()))
+
+	// Benching again replaces the time the validator leaves the bench
+	assert.NoError(t, b.Bench(vdr0.ID(), 2*time.Hour))
+	benched := b.Benched()
+	assert.Len(t, benched, 1)
+	assert.Equal(t, vdr0.ID(), benched[0].ValidatorID)
+	assert.True(t, benched[0].Until.Equal(now.Add(2*time.Hour)))
+	assert.True(t, benched[0].Manual)
+	assert.Equal(t, 0, benched[0].Failures)
+
+	assert.Len(t, listener.benched, 2)
+	assert.Equal(t, chainID, listener.chainIDs[0])
+	assert.Equal(t, vdr0.ID(), listener.benched[0].ValidatorID)
+
+	assert.NoError(t, b.Unbench(vdr0.ID()))
+	assert.False(t, b.IsBenched(vdr0.ID()))
+	assert.Len(t, b.Benched(), 0)
+	assert.Error(t, b.Unbench(vdr0.ID()))
+}
+
+// Test that benched validators stay benched after the benchlist is recreated
+// with the same database
+func TestBenchlistPersists(t *testing.T) {
+	vdrs := validators.NewSet()
+	vdr0 := validators.GenerateRandomValidator(1000)
+	vdr1 := validators.GenerateRandomValidator(1000)
+	vdr2 := validators.GenerateRandomValidator(1000)
+	errs := wrappers.Errs{}
+	errs.Add(
+		vdrs.AddWeight(vdr0.ID(), vdr0.Weight()),
+		vdrs.AddWeight(vdr1.ID(), vdr1.Weight()),
+		vdrs.AddWeight(vdr2.ID(), vdr2.Weight()),
+	)
+	if errs.Errored() {
+		t.Fatal(errs.Err)
+	}
+
+	db := memdb.New()
+	threshold := 3
+	duration := time.Hour
+	newBenchlist := func() *benchlist {
+		benchIntf, err := NewBenchlist(
+			ids.Empty,
+			logging.NoLog{},
+			vdrs,
+			db,
+			nil,
+			threshold,
+			minimumFailingDuration,
+			duration,
+			0.5,
+			"",
+			prometheus.NewRegistry(),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return benchIntf.(*benchlist)
+	}
+
+	b := newBenchlist()
+	now := time.Now()
+	b.lock.Lock()
+	b.clock.Set(now)
+	b.lock.Unlock()
+
+	// Bench vdr0 for failing requests
+	for i := 0; i < threshold; i++ {
+		b.RegisterFailure(vdr0.ID())
+	}
+	now = now.Add(minimumFailingDuration).Add(time.Second)
+	b.lock.Lock()
+	b.clock.Set(now)
+	b.lock.Unlock()
+	b.RegisterFailure(vdr0.ID())
+	assert.True(t, b.IsBenched(vdr0.ID()))
+
+	// vdr1 leaves the bench before the benchlist is recreated
+	assert.NoError(t, b.Bench(vdr1.ID(), time.Second))
+	// vdr2 is unbenched before the benchlist is recreated
+	assert.NoError(t, b.Bench(vdr2.ID(), duration))
+	assert.NoError(t, b.Unbench(vdr2.ID()))
+	expected := b.Benched()
+	b.timer.Stop()
+
+	b = newBenchlist()
+	defer b.timer.Stop()
+	assert.True(t, b.IsBenched(vdr0.ID()))
+	assert.True(t, b.IsBenched(vdr1.ID()))
+	assert.False(t, b.IsBenched(vdr2.ID()))
+	b.lock.Lock()
+	b.clock.Set(now.Add(2 * time.Second))
+	b.lock.Unlock()
+	b.update()
+	assert.False(t, b.IsBenched(vdr1.ID()))
+
+	benched := b.Benched()
+	assert.Len(t, benched, 1)
+	assert.Equal(t, vdr0.ID(), benched[0].ValidatorID)
+	assert.Equal(t, threshold+1, benched[0].Failures)
+	assert.False(t, benched[0].Manual)
+	assert.Equal(t, expected[1].Until.Unix(), benched[0].Until.Unix())
+
+	// Validators that left the bench were deleted from the database
+	has, err := db.Has(vdr1.ID().Bytes())
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
@@ -5,6 +5,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -12,6 +15,8 @@ import (
 
 var (
 	errUnknownValidators = errors.New("unknown validator set for provided chain")
+	errUnknownChain      = errors.New("unknown chain")
+	errDisabled          = errors.New("benchlisting is disabled")
 )
 
 // Manager provides an interface for a benchlist to register whether
@@ -35,6 +40,17 @@ type Manager interface {
 	// [validatorID] is benched. If called on an id.ShortID that does
 	// not map to a validator, it will return an empty array.
 	GetBenched(validatorID ids.ShortID) []ids.ID
+	// Bench [validatorID] on chain [chainID] for [duration], regardless of its
+	// failures and of the maximum portion of stake that may be benched
+	Bench(chainID ids.ID, validatorID ids.ShortID, duration time.Duration) error
+	// Unbench [validatorID] on chain [chainID]
+	Unbench(chainID ids.ID, validatorID ids.ShortID) error
+	// Benched returns the validators that are benched on chain [chainID],
+	// ordered by when they leave the bench
+	Benched(chainID ids.ID) ([]BenchedValidator, error)
+	// RegisterListener registers [listener] to be notified when a validator
+	// is benched on any chain
+	RegisterListener(listener Listener)
 }
 
 // Config defines the configuration for a benchlist
@@ -45,6 +61,9 @@ type Config struct {
 	Duration               time.Duration
 	MaxPortion             float64
 	PeerSummaryEnabled     bool
+	// Stores benched validators, so they stay benched across restarts. If
+	// nil, benched validators are only kept in memory.
+	DB database.Database
 }
 
 type manager struct {
@@ -52,6 +71,10 @@ type manager struct {
 	// Chain ID --> benchlist for that chain.
 	// Each benchlist is safe for concurrent access.
 	chainBenchlists map[ids.ID]Benchlist
+	// Stores the benched validators of each chain under the chain's ID
+	db database.Database
+	// Notified when a validator is benched on any chain
+	listeners listeners
 
 	lock sync.RWMutex
 }
@@ -63,9 +86,14 @@ func NewManager(config *Config) Manager {
 	if config.MaxPortion <= 0 {
 		return NewNoBenchlist()
 	}
+	db := config.DB
+	if db == nil {
+		db = memdb.New()
+	}
 	return &manager{
 		config:          config,
 		chainBenchlists: make(map[ids.ID]Benchlist),
+		db:              db,
 	}
 }
 
@@ -114,8 +142,11 @@ func (m *manager) RegisterChain(ctx *snow.Context, namespace string) error {
 	}
 
 	benchlist, err := NewBenchlist(
+		ctx.ChainID,
 		ctx.Log,
 		vdrs,
+		prefixdb.New(ctx.ChainID[:], m.db),
+		&m.listeners,
 		m.config.Threshold,
 		m.config.MinimumFailingDuration,
 		m.config.Duration,
@@ -155,13 +186,79 @@ func (m *manager) RegisterFailure(chainID ids.ID, validatorID ids.ShortID) {
 	benchlist.RegisterFailure(validatorID)
 }
 
+// Bench implements the Manager interface
+func (m *manager) Bench(chainID ids.ID, validatorID ids.ShortID, duration time.Duration) error {
+	m.lock.RLock()
+	benchlist, exists := m.chainBenchlists[chainID]
+	m.lock.RUnlock()
+
+	if !exists {
+		return errUnknownChain
+	}
+	return benchlist.Bench(validatorID, duration)
+}
+
+// Unbench implements the Manager interface
+func (m *manager) Unbench(chainID ids.ID, validatorID ids.ShortID) error {
+	m.lock.RLock()
+	benchlist, exists := m.chainBenchlists[chainID]
+	m.lock.RUnlock()
+
+	if !exists {
+		return errUnknownChain
+	}
+	return benchlist.Unbench(validatorID)
+}
+
+// Benched implements the Manager interface
+func (m *manager) Benched(chainID ids.ID) ([]BenchedValidator, error) {
+	m.lock.RLock()
+	benchlist, exists := m.chainBenchlists[chainID]
+	m.lock.RUnlock()
+
+	if !exists {
+		return nil, errUnknownChain
+	}
+	return benchlist.Benched(), nil
+}
+
+// RegisterListener implements the Manager interface
+func (m *manager) RegisterListener(listener Listener) { m.listeners.add(listener) }
+
+// listeners notifies each of its listeners when a validator is benched
+type listeners struct {
+	lock      sync.RWMutex
+	listeners []Listener
+}
+
+func (l *listeners) add(listener Listener) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.listeners = append(l.listeners, listener)
+}
+
+// Benched implements the Listener interface
+func (l *listeners) Benched(chainID ids.ID, validator BenchedValidator) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	for _, listener := range l.listeners {
+		listener.Benched(chainID, validator)
+	}
+}
+
 type noBenchlist struct{}
 
 // NewNoBenchlist returns an empty benchlist that will never stop any queries
 func NewNoBenchlist() Manager { return &noBenchlist{} }
 
-func (noBenchlist) RegisterChain(*snow.Context, string) error { return nil }
-func (noBenchlist) RegisterResponse(ids.ID, ids.ShortID)      {}
-func (noBenchlist) RegisterFailure(ids.ID, ids.ShortID)       {}
-func (noBenchlist) IsBenched(ids.ShortID, ids.ID) bool        { return false }
-func (noBenchlist) GetBenched(ids.ShortID) []ids.ID           { return nil }
+func (noBenchlist) RegisterChain(*snow.Context, string) error      { return nil }
+func (noBenchlist) RegisterResponse(ids.ID, ids.ShortID)           {}
+func (noBenchlist) RegisterFailure(ids.ID, ids.ShortID)            {}
+func (noBenchlist) IsBenched(ids.ShortID, ids.ID) bool             { return false }
+func (noBenchlist) GetBenched(ids.ShortID) []ids.ID                { return nil }
+func (noBenchlist) Bench(ids.ID, ids.ShortID, time.Duration) error { return errDisabled }
+func (noBenchlist) Unbench(ids.ID, ids.ShortID) error              { return errDisabled }
+func (noBenchlist) Benched(ids.ID) ([]BenchedValidator, error)     { return nil, nil }
+func (noBenchlist) RegisterListener(Listener)                      {}
@@ -0,0 +1,128 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+var errNoPath = errors.New("no capture file provided")
+
+// Capture records the events of a chain to a file until it's stopped. It
+// records decisions when it's registered as an Acceptor and Rejector of the
+// chain's decision events.
+type Capture struct {
+	clock timer.Clock
+
+	lock       sync.Mutex
+	file       *os.File
+	writer     *Writer
+	numRecords int
+	stopped    bool
+	// True once a record didn't fit in the maximum size of the capture. No
+	// more records are written after that, so the capture is still a prefix of
+	// the chain's events.
+	full bool
+	// The first error that occurred while writing the capture
+	err error
+}
+
+// Start capturing events to a new file at [path] that starts with [hdr]. The
+// start time of [hdr] is set to the current time. The file grows to at most
+// [maxSize] bytes, unless it's 0.
+func Start(path string, hdr Header, maxSize uint64) (*Capture, error) {
+	if path == "" {
+		return nil, errNoPath
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+	c := &Capture{file: file}
+	hdr.Start = c.clock.Time()
+	c.writer, err = NewWriter(file, hdr, maxSize)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Record [r]. Records are ignored once the capture is stopped or full.
+func (c *Capture) Record(r Record) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.stopped || c.full || c.err != nil {
+		return
+	}
+	err := c.writer.Write(r)
+	switch {
+	case err == nil:
+		c.numRecords++
+	case errors.Is(err, errCaptureTooLarge):
+		c.full = true
+	default:
+		c.err = err
+	}
+}
+
+// Accept implements the triggers.Acceptor interface
+func (c *Capture) Accept(_ *snow.Context, containerID ids.ID, container []byte) error {
+	c.Record(Record{
+		Kind:        AcceptKind,
+		Time:        c.clock.Time(),
+		ContainerID: containerID,
+		Container:   container,
+	})
+	return nil
+}
+
+// Reject implements the triggers.Rejector interface
+func (c *Capture) Reject(_ *snow.Context, containerID ids.ID, container []byte) error {
+	c.Record(Record{
+		Kind:        RejectKind,
+		Time:        c.clock.Time(),
+		ContainerID: containerID,
+		Container:   container,
+	})
+	return nil
+}
+
+// Path of the capture file
+func (c *Capture) Path() string { return c.file.Name() }
+
+// Full returns true if records were dropped because the capture reached its
+// maximum size
+func (c *Capture) Full() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.full
+}
+
+// Stop capturing and close the file. Returns the number of records written,
+// and the first error that occurred while writing them.
+func (c *Capture) Stop() (int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.stopped {
+		return c.numRecords, c.err
+	}
+	c.stopped = true
+	if err := c.writer.Flush(); c.err == nil {
+		c.err = err
+	}
+	if err := c.file.Close(); c.err == nil {
+		c.err = err
+	}
+	return c.numRecords, c.err
+}
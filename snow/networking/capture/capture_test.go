@@ -0,0 +1,151 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestCapture(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "capture.bin")
+
+	hdr := Header{
+		ChainID:      ids.GenerateTestID(),
+		LastAccepted: ids.GenerateTestID(),
+	}
+	c, err := Start(path, hdr, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, path, c.Path())
+
+	// Existing files aren't overwritten
+	_, err = Start(path, hdr, 0)
+	assert.Error(t, err)
+
+	containerID := ids.GenerateTestID()
+	c.Record(Record{Kind: MessageKind, Op: constants.PutMsg, ContainerID: containerID})
+	assert.NoError(t, c.Accept(nil, containerID, []byte{1}))
+	numRecords, err := c.Stop()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, numRecords)
+
+	// Records are ignored once the capture is stopped
+	assert.NoError(t, c.Reject(nil, containerID, []byte{1}))
+	numRecords, err = c.Stop()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, numRecords)
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+	r, err := NewReader(file)
+	assert.NoError(t, err)
+	assert.Equal(t, hdr.ChainID, r.Header().ChainID)
+	assert.Equal(t, hdr.LastAccepted, r.Header().LastAccepted)
+	assert.False(t, r.Header().Start.IsZero())
+
+	record, err := r.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, MessageKind, record.Kind)
+	assert.Equal(t, constants.PutMsg, record.Op)
+	record, err = r.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, AcceptKind, record.Kind)
+	assert.Equal(t, containerID, record.ContainerID)
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestCaptureMaxSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "capture.bin")
+
+	// Leave room for the header and a record with a small container
+	const maxSize = 512
+	c, err := Start(path, Header{ChainID: ids.GenerateTestID()}, maxSize)
+	assert.NoError(t, err)
+	containerID := ids.GenerateTestID()
+	assert.NoError(t, c.Accept(nil, containerID, []byte{1}))
+	assert.False(t, c.Full())
+
+	// Records that don't fit are dropped, and so is every later record, even
+	// if it would fit
+	assert.NoError(t, c.Accept(nil, ids.GenerateTestID(), make([]byte, maxSize)))
+	assert.True(t, c.Full())
+	assert.NoError(t, c.Reject(nil, containerID, []byte{1}))
+	numRecords, err := c.Stop()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numRecords)
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(maxSize))
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+	r, err := NewReader(file)
+	assert.NoError(t, err)
+	record, err := r.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, AcceptKind, record.Kind)
+	assert.Equal(t, containerID, record.ContainerID)
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestValidatorsRecordSamples(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	vdrs := validators.NewSet()
+	vdrID := ids.GenerateTestShortID()
+	assert.NoError(t, vdrs.AddWeight(vdrID, 1))
+	recorder := &Recorder{}
+	recordingVdrs := NewValidators(vdrs, recorder)
+
+	// Samples aren't recorded unless a capture is running
+	_, err = recordingVdrs.Sample(1)
+	assert.NoError(t, err)
+
+	c, err := Start(filepath.Join(dir, "capture.bin"), Header{ChainID: ids.GenerateTestID()}, 0)
+	assert.NoError(t, err)
+	assert.True(t, recorder.Start(c))
+	assert.False(t, recorder.Start(c), "only one capture may run at a time")
+	sample, err := recordingVdrs.Sample(1)
+	assert.NoError(t, err)
+	assert.Len(t, sample, 1)
+	recorder.Stop(c)
+	_, err = recordingVdrs.Sample(1)
+	assert.NoError(t, err)
+
+	numRecords, err := c.Stop()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numRecords)
+
+	file, err := os.Open(c.Path())
+	assert.NoError(t, err)
+	defer file.Close()
+	r, err := NewReader(file)
+	assert.NoError(t, err)
+	record, err := r.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, SampleKind, record.Kind)
+	assert.Equal(t, []ids.ShortID{vdrID}, record.ValidatorIDs)
+}
@@ -0,0 +1,146 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	codecVersion = 0
+
+	// Maximum size of a serialized record. Records hold at most one message,
+	// so this leaves room for the largest message a peer may send.
+	maxRecordSize = 1 << 22
+)
+
+var (
+	errRecordTooLarge  = errors.New("record is too large")
+	errCaptureTooLarge = errors.New("capture would exceed its maximum size")
+
+	c codec.Manager
+)
+
+func init() {
+	lc := linearcodec.NewDefault()
+	c = codec.NewManager(maxRecordSize)
+	if err := c.RegisterCodec(codecVersion, lc); err != nil {
+		panic(err)
+	}
+}
+
+// Writer writes a capture. Each entry is a serialized header or record,
+// prefixed with its length.
+type Writer struct {
+	w *bufio.Writer
+	// Number of bytes written, and the most that may be written. If
+	// [maxSize] is 0, there is no limit.
+	size, maxSize uint64
+}
+
+// NewWriter writes [hdr] to [w] and returns a Writer that writes records
+// after it. At most [maxSize] bytes are written, unless it's 0.
+func NewWriter(w io.Writer, hdr Header, maxSize uint64) (*Writer, error) {
+	writer := &Writer{
+		w:       bufio.NewWriter(w),
+		maxSize: maxSize,
+	}
+	return writer, writer.write(&header{
+		ChainID:      hdr.ChainID,
+		LastAccepted: hdr.LastAccepted,
+		Start:        toUnixNano(hdr.Start),
+	})
+}
+
+// Write [r] to the capture. If writing [r] would exceed the maximum size of
+// the capture, nothing is written and an error is returned.
+func (w *Writer) Write(r Record) error {
+	rec := newRecord(r)
+	return w.write(&rec)
+}
+
+// Flush writes any buffered records to the underlying writer
+func (w *Writer) Flush() error { return w.w.Flush() }
+
+func (w *Writer) write(value interface{}) error {
+	bytes, err := c.Marshal(codecVersion, value)
+	if err != nil {
+		return err
+	}
+	entrySize := uint64(wrappers.IntLen + len(bytes))
+	if w.maxSize != 0 && w.size+entrySize > w.maxSize {
+		return fmt.Errorf("%w of %d bytes", errCaptureTooLarge, w.maxSize)
+	}
+	lenBytes := make([]byte, wrappers.IntLen)
+	binary.BigEndian.PutUint32(lenBytes, uint32(len(bytes)))
+	if _, err := w.w.Write(lenBytes); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(bytes); err != nil {
+		return err
+	}
+	w.size += entrySize
+	return nil
+}
+
+// Reader reads a capture written by a Writer
+type Reader struct {
+	r   *bufio.Reader
+	hdr Header
+}
+
+// NewReader reads the header of the capture in [r]
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{r: bufio.NewReader(r)}
+	hdr := header{}
+	if err := reader.read(&hdr); err != nil {
+		return nil, fmt.Errorf("couldn't read capture header: %w", err)
+	}
+	reader.hdr = Header{
+		ChainID:      hdr.ChainID,
+		LastAccepted: hdr.LastAccepted,
+		Start:        fromUnixNano(hdr.Start),
+	}
+	return reader, nil
+}
+
+// Header of the capture
+func (r *Reader) Header() Header { return r.hdr }
+
+// Read the next record. Returns io.EOF once every record has been read.
+func (r *Reader) Read() (Record, error) {
+	rec := record{}
+	if err := r.read(&rec); err != nil {
+		return Record{}, err
+	}
+	return rec.Record(), nil
+}
+
+func (r *Reader) read(dest interface{}) error {
+	lenBytes := make([]byte, wrappers.IntLen)
+	if _, err := io.ReadFull(r.r, lenBytes); err != nil {
+		return err
+	}
+	size := binary.BigEndian.Uint32(lenBytes)
+	if size > maxRecordSize {
+		return fmt.Errorf("%w: %d bytes", errRecordTooLarge, size)
+	}
+	bytes := make([]byte, size)
+	if _, err := io.ReadFull(r.r, bytes); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	_, err := c.Unmarshal(bytes, dest)
+	return err
+}
@@ -0,0 +1,105 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestWriterReader(t *testing.T) {
+	hdr := Header{
+		ChainID:      ids.GenerateTestID(),
+		LastAccepted: ids.GenerateTestID(),
+		Start:        time.Unix(0, 1234),
+	}
+	records := []Record{
+		{
+			Kind:        MessageKind,
+			Time:        time.Unix(5, 0),
+			Op:          constants.PushQueryMsg,
+			ValidatorID: ids.GenerateTestShortID(),
+			RequestID:   7,
+			Received:    time.Unix(4, 0),
+			Deadline:    time.Unix(6, 0),
+			ContainerID: ids.GenerateTestID(),
+			Container:   []byte{1, 2, 3},
+		},
+		{
+			Kind:        MessageKind,
+			Time:        time.Unix(7, 0),
+			Op:          constants.GetFailedMsg,
+			ValidatorID: ids.GenerateTestShortID(),
+			RequestID:   8,
+		},
+		{
+			Kind:        AcceptKind,
+			Time:        time.Unix(8, 0),
+			ContainerID: ids.GenerateTestID(),
+			Container:   []byte{4},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, hdr, 0)
+	assert.NoError(t, err)
+	for _, r := range records {
+		assert.NoError(t, w.Write(r))
+	}
+	assert.NoError(t, w.Flush())
+
+	r, err := NewReader(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, hdr.ChainID, r.Header().ChainID)
+	assert.True(t, hdr.Start.Equal(r.Header().Start))
+	for _, expected := range records {
+		read, err := r.Read()
+		assert.NoError(t, err)
+		assert.Equal(t, expected.Kind, read.Kind)
+		assert.True(t, expected.Time.Equal(read.Time))
+		assert.Equal(t, expected.Op, read.Op)
+		assert.Equal(t, expected.ValidatorID, read.ValidatorID)
+		assert.Equal(t, expected.RequestID, read.RequestID)
+		assert.True(t, expected.Received.Equal(read.Received))
+		assert.True(t, expected.Deadline.Equal(read.Deadline))
+		assert.Equal(t, expected.ContainerID, read.ContainerID)
+		assert.Equal(t, len(expected.Container), len(read.Container))
+	}
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReaderRejectsBadRecords(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, Header{ChainID: ids.GenerateTestID()}, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write(Record{Kind: AcceptKind}))
+	assert.NoError(t, w.Flush())
+	capture := buf.Bytes()
+
+	// A record that was cut off
+	r, err := NewReader(bytes.NewReader(capture[:len(capture)-1]))
+	assert.NoError(t, err)
+	_, err = r.Read()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	// A record that claims to be too large
+	tooLarge := make([]byte, 4)
+	binary.BigEndian.PutUint32(tooLarge, maxRecordSize+1)
+	r, err = NewReader(bytes.NewReader(append(capture, tooLarge...)))
+	assert.NoError(t, err)
+	_, err = r.Read()
+	assert.NoError(t, err)
+	_, err = r.Read()
+	assert.True(t, errors.Is(err, errRecordTooLarge))
+}
@@ -0,0 +1,175 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// Kind of event that a record describes
+type Kind uint8
+
+// Kinds of records
+const (
+	// MessageKind is a message delivered to the engine, or to the VM if it's an
+	// application-level message. This includes the messages that report that
+	// a request timed out, such as GetFailed.
+	MessageKind Kind = iota
+	// AcceptKind is a container being accepted
+	AcceptKind
+	// RejectKind is a container being rejected
+	RejectKind
+	// SampleKind is the validators the engine sampled, such as to poll them
+	SampleKind
+	// RequestKind is a request the engine sent
+	RequestKind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case MessageKind:
+		return "message"
+	case AcceptKind:
+		return "accept"
+	case RejectKind:
+		return "reject"
+	case SampleKind:
+		return "sample"
+	case RequestKind:
+		return "request"
+	default:
+		return "unknown"
+	}
+}
+
+// Header is written at the start of a capture
+type Header struct {
+	// Chain that the capture is of
+	ChainID ids.ID
+	// Last accepted block of the chain when the capture started. Empty if the
+	// chain isn't linear.
+	LastAccepted ids.ID
+	// Time the capture started
+	Start time.Time
+}
+
+// Record is an event on the captured chain
+type Record struct {
+	Kind Kind
+	// Time the event happened. For messages, this is the time the message was
+	// delivered.
+	Time time.Time
+
+	// The fields below are set depending on the type of the message. For
+	// decisions, only [ContainerID] and [Container] are set. For samples, only
+	// [ValidatorIDs] is set. Requests set [ValidatorIDs] rather than
+	// [ValidatorID].
+	Op           constants.MsgType
+	ValidatorID  ids.ShortID
+	ValidatorIDs []ids.ShortID
+	RequestID    uint32
+	Received     time.Time // Time the message was received. Zero for internal messages.
+	Deadline     time.Time // Time the message had to be responded to, if it's a request
+	ContainerID  ids.ID
+	Container    []byte
+	ContainerIDs []ids.ID
+	Containers   [][]byte
+	AppBytes     []byte
+	Notification uint32
+}
+
+func (r Record) String() string {
+	switch r.Kind {
+	case MessageKind:
+		return fmt.Sprintf("(%s from %s, RequestID: %d, at %s)", r.Op, r.ValidatorID, r.RequestID, r.Time.UTC())
+	case SampleKind:
+		return fmt.Sprintf("(%s of %v at %s)", r.Kind, r.ValidatorIDs, r.Time.UTC())
+	case RequestKind:
+		return fmt.Sprintf("(%s %s to %v, RequestID: %d, at %s)", r.Kind, r.Op, r.ValidatorIDs, r.RequestID, r.Time.UTC())
+	default:
+		return fmt.Sprintf("(%s %s at %s)", r.Kind, r.ContainerID, r.Time.UTC())
+	}
+}
+
+// header is the serialized form of a Header
+type header struct {
+	ChainID      ids.ID `serialize:"true"`
+	LastAccepted ids.ID `serialize:"true"`
+	Start        int64  `serialize:"true"`
+}
+
+// record is the serialized form of a Record. Times are in nanoseconds since
+// the Unix epoch, or 0 if they're zero.
+type record struct {
+	Kind         uint8         `serialize:"true"`
+	Time         int64         `serialize:"true"`
+	Op           uint8         `serialize:"true"`
+	ValidatorID  ids.ShortID   `serialize:"true"`
+	ValidatorIDs []ids.ShortID `serialize:"true"`
+	RequestID    uint32        `serialize:"true"`
+	Received     int64         `serialize:"true"`
+	Deadline     int64         `serialize:"true"`
+	ContainerID  ids.ID        `serialize:"true"`
+	Container    []byte        `serialize:"true"`
+	ContainerIDs []ids.ID      `serialize:"true"`
+	Containers   [][]byte      `serialize:"true"`
+	AppBytes     []byte        `serialize:"true"`
+	Notification uint32        `serialize:"true"`
+}
+
+func newRecord(r Record) record {
+	return record{
+		Kind:         uint8(r.Kind),
+		Time:         toUnixNano(r.Time),
+		Op:           uint8(r.Op),
+		ValidatorID:  r.ValidatorID,
+		ValidatorIDs: r.ValidatorIDs,
+		RequestID:    r.RequestID,
+		Received:     toUnixNano(r.Received),
+		Deadline:     toUnixNano(r.Deadline),
+		ContainerID:  r.ContainerID,
+		Container:    r.Container,
+		ContainerIDs: r.ContainerIDs,
+		Containers:   r.Containers,
+		AppBytes:     r.AppBytes,
+		Notification: r.Notification,
+	}
+}
+
+func (r record) Record() Record {
+	return Record{
+		Kind:         Kind(r.Kind),
+		Time:         fromUnixNano(r.Time),
+		Op:           constants.MsgType(r.Op),
+		ValidatorID:  r.ValidatorID,
+		ValidatorIDs: r.ValidatorIDs,
+		RequestID:    r.RequestID,
+		Received:     fromUnixNano(r.Received),
+		Deadline:     fromUnixNano(r.Deadline),
+		ContainerID:  r.ContainerID,
+		Container:    r.Container,
+		ContainerIDs: r.ContainerIDs,
+		Containers:   r.Containers,
+		AppBytes:     r.AppBytes,
+		Notification: r.Notification,
+	}
+}
+
+func toUnixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnixNano(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
@@ -0,0 +1,59 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"sync"
+)
+
+// Recorder passes the events of a chain to the capture of the chain that is
+// running, if any. The handler, the sender and the validators of a chain share
+// a Recorder, so that a capture holds every event of the chain in the order
+// they happened. The zero value is ready to be used.
+type Recorder struct {
+	lock    sync.Mutex
+	capture *Capture
+}
+
+// Start recording events to [c]. Returns false if a capture is already
+// running, as only one capture may run at a time.
+func (r *Recorder) Start(c *Capture) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.capture != nil {
+		return false
+	}
+	r.capture = c
+	return true
+}
+
+// Stop recording events to [c]
+func (r *Recorder) Stop(c *Capture) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.capture == c {
+		r.capture = nil
+	}
+}
+
+// Capturing returns true if a capture is running
+func (r *Recorder) Capturing() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.capture != nil
+}
+
+// Record [rec] if a capture is running
+func (r *Recorder) Record(rec Record) {
+	r.lock.Lock()
+	c := r.capture
+	r.lock.Unlock()
+
+	if c != nil {
+		c.Record(rec)
+	}
+}
@@ -0,0 +1,49 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+// set is embedded under this name, as validators.Set has a method named Set
+type set = validators.Set
+
+// validatorSet records the validators that are sampled from it while a
+// capture is running. Engines sample the validators they poll at random, so
+// replaying a capture requires the engine to be given the same samples.
+type validatorSet struct {
+	set
+
+	clock    timer.Clock
+	recorder *Recorder
+}
+
+// NewValidators returns [vdrs], but records its samples to [recorder]
+func NewValidators(vdrs validators.Set, recorder *Recorder) validators.Set {
+	return &validatorSet{
+		set:      vdrs,
+		recorder: recorder,
+	}
+}
+
+// Sample implements the validators.Set interface
+func (s *validatorSet) Sample(size int) ([]validators.Validator, error) {
+	sample, err := s.set.Sample(size)
+	if err != nil || !s.recorder.Capturing() {
+		return sample, err
+	}
+	vdrIDs := make([]ids.ShortID, len(sample))
+	for i, vdr := range sample {
+		vdrIDs[i] = vdr.ID()
+	}
+	s.recorder.Record(Record{
+		Kind:         SampleKind,
+		Time:         s.clock.Time(),
+		ValidatorIDs: vdrIDs,
+	})
+	return sample, nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
 )
 
 const (
@@ -53,10 +54,12 @@ type ChainRouter struct {
 	gossiper         *timer.Repeater
 	intervalNotifier *timer.Repeater
 	closeTimeout     time.Duration
-	peers            ids.ShortSet
+	peers            map[ids.ShortID]version.Features // features of each connected peer, including this node
 	criticalChains   ids.Set
 	onFatal          func()
 	metrics          routerMetrics
+	// Decides which chain processes a message next
+	scheduler *scheduler
 	// Parameters for doing health checks
 	healthConfig HealthConfig
 	// aggregator of requests based on their time
@@ -84,6 +87,7 @@ func (cr *ChainRouter) Initialize(
 	criticalChains ids.Set,
 	onFatal func(),
 	healthConfig HealthConfig,
+	schedulerConfig SchedulerConfig,
 	metricsNamespace string,
 	metricsRegisterer prometheus.Registerer,
 ) error {
@@ -96,7 +100,7 @@ func (cr *ChainRouter) Initialize(
 	cr.criticalChains = criticalChains
 	cr.onFatal = onFatal
 	cr.timedRequests = linkedhashmap.New()
-	cr.peers.Add(nodeID)
+	cr.peers = map[ids.ShortID]version.Features{nodeID: version.CurrentFeatures}
 	// Set up meter to count dropped messages
 	cr.dropRateCalculator = math.NewAverager(0, cr.healthConfig.MaxDropRateHalflife, cr.clock.Time())
 	cr.healthConfig = healthConfig
@@ -114,6 +118,12 @@ func (cr *ChainRouter) Initialize(
 		return fmt.Errorf("couldn't register metric: %w", err)
 	}
 
+	scheduler, err := newScheduler(schedulerConfig, defaultCPUInterval, metricsNamespace, metricsRegisterer)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize scheduler: %w", err)
+	}
+	cr.scheduler = scheduler
+
 	go log.RecoverAndPanic(cr.gossiper.Dispatch)
 	go log.RecoverAndPanic(cr.intervalNotifier.Dispatch)
 	return nil
@@ -139,6 +149,9 @@ func (cr *ChainRouter) RegisterRequest(
 	msgType constants.MsgType,
 ) {
 	uniqueRequestID := createRequestID(validatorID, chainID, requestID)
+	if msgType == constants.AppRequestMsg {
+		uniqueRequestID = createAppRequestID(validatorID, chainID, requestID)
+	}
 	cr.lock.Lock()
 	if cr.timedRequests.Len() == 0 {
 		cr.lastTimeNoOutstanding = cr.clock.Time()
@@ -163,9 +176,11 @@ func (cr *ChainRouter) RegisterRequest(
 		timeoutHandler = func() { cr.GetAcceptedFailed(validatorID, chainID, requestID) }
 	case constants.GetAcceptedFrontierMsg:
 		timeoutHandler = func() { cr.GetAcceptedFrontierFailed(validatorID, chainID, requestID) }
+	case constants.AppRequestMsg:
+		timeoutHandler = func() { cr.AppRequestFailed(validatorID, chainID, requestID) }
 	default:
 		// This should never happen
-		cr.log.Error("expected message type to be one of GetMsg, PullQueryMsg, PushQueryMsg, GetAcceptedFrontierMsg, GetAcceptedMsg but got %s", msgType)
+		cr.log.Error("expected message type to be one of GetMsg, PullQueryMsg, PushQueryMsg, GetAcceptedFrontierMsg, GetAcceptedMsg, AppRequestMsg but got %s", msgType)
 		return
 	}
 	cr.timeoutManager.RegisterRequest(validatorID, chainID, msgType, uniqueRequestID, timeoutHandler)
@@ -182,8 +197,9 @@ func (cr *ChainRouter) Shutdown() {
 	cr.gossiper.Stop()
 	cr.intervalNotifier.Stop()
 
-	for _, chain := range prevChains {
+	for chainID, chain := range prevChains {
 		chain.Shutdown()
+		cr.scheduler.removeChain(chainID)
 	}
 
 	ticker := time.NewTicker(cr.closeTimeout)
@@ -210,6 +226,8 @@ func (cr *ChainRouter) AddChain(chain *Handler) {
 	chainID := chain.Context().ChainID
 	cr.log.Debug("registering chain %s with chain router", chainID)
 	chain.toClose = func() { cr.RemoveChain(chainID) }
+	chain.scheduler = cr.scheduler
+	cr.scheduler.addChain(chain.Context())
 	cr.chains[chainID] = chain
 
 	for validatorID := range cr.peers {
@@ -231,6 +249,7 @@ func (cr *ChainRouter) RemoveChain(chainID ids.ID) {
 	cr.lock.Unlock()
 
 	chain.Shutdown()
+	cr.scheduler.removeChain(chainID)
 
 	ticker := time.NewTicker(cr.closeTimeout)
 	select {
@@ -734,12 +753,128 @@ func (cr *ChainRouter) QueryFailed(validatorID ids.ShortID, chainID ids.ID, requ
 	chain.QueryFailed(validatorID, requestID)
 }
 
+// AppRequest routes an incoming VM-defined request from the validator with ID
+// [validatorID] to the consensus engine working on the chain with ID [chainID]
+func (cr *ChainRouter) AppRequest(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, appBytes []byte) {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	// Get the chain, if it exists
+	chain, exists := cr.chains[chainID]
+	if !exists {
+		cr.log.Debug("AppRequest(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		cr.log.Verbo("appBytes:\n%s", formatting.DumpBytes{Bytes: appBytes})
+		return
+	}
+
+	// Pass the message to the chain. It's OK if we drop this.
+	dropped := !chain.AppRequest(validatorID, requestID, deadline, appBytes)
+	if dropped {
+		cr.registerMsgDrop(chain.ctx.IsBootstrapped())
+	} else {
+		cr.registerMsgSuccess(chain.ctx.IsBootstrapped())
+	}
+}
+
+// AppResponse routes an incoming response to a VM-defined request from the
+// validator with ID [validatorID] to the consensus engine working on the chain
+// with ID [chainID]
+func (cr *ChainRouter) AppResponse(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appBytes []byte) {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	// Get the chain, if it exists
+	chain, exists := cr.chains[chainID]
+	if !exists {
+		cr.log.Debug("AppResponse(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		cr.log.Verbo("appBytes:\n%s", formatting.DumpBytes{Bytes: appBytes})
+		return
+	}
+
+	uniqueRequestID := createAppRequestID(validatorID, chainID, requestID)
+
+	// Mark that an outstanding request has been fulfilled
+	requestIntf, exists := cr.timedRequests.Get(uniqueRequestID)
+	if !exists {
+		// We didn't request this message. Ignore.
+		return
+	}
+	request := requestIntf.(requestEntry)
+	if request.msgType != constants.AppRequestMsg {
+		// We got back a reply of wrong type. Ignore.
+		return
+	}
+	cr.timedRequests.Delete(uniqueRequestID)
+
+	// Calculate how long it took [validatorID] to reply
+	latency := cr.clock.Time().Sub(request.time)
+
+	// Tell the timeout manager we got a response
+	cr.timeoutManager.RegisterResponse(validatorID, chainID, uniqueRequestID, constants.AppRequestMsg, latency)
+
+	// Pass the response to the chain
+	dropped := !chain.AppResponse(validatorID, requestID, appBytes)
+	if dropped {
+		// We weren't able to pass the response to the chain
+		chain.AppRequestFailed(validatorID, requestID)
+		cr.registerMsgDrop(chain.ctx.IsBootstrapped())
+	} else {
+		cr.registerMsgSuccess(chain.ctx.IsBootstrapped())
+	}
+}
+
+// AppRequestFailed routes an incoming notification from the validator with ID
+// [validatorID] that a VM-defined request won't be responded to
+func (cr *ChainRouter) AppRequestFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32) {
+	uniqueRequestID := createAppRequestID(validatorID, chainID, requestID)
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	// Remove the outstanding request
+	cr.removeRequest(uniqueRequestID)
+
+	// Get the chain, if it exists
+	chain, exists := cr.chains[chainID]
+	if !exists {
+		cr.log.Debug("AppRequestFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		return
+	}
+
+	// Pass the response to the chain
+	chain.AppRequestFailed(validatorID, requestID)
+}
+
+// AppGossip routes an incoming VM-defined gossip message from the validator
+// with ID [validatorID] to the consensus engine working on the chain with ID
+// [chainID]
+func (cr *ChainRouter) AppGossip(validatorID ids.ShortID, chainID ids.ID, appBytes []byte) {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	// Get the chain, if it exists
+	chain, exists := cr.chains[chainID]
+	if !exists {
+		cr.log.Verbo("AppGossip(%s, %s) dropped due to unknown chain. appBytes:\n%s",
+			validatorID, chainID, formatting.DumpBytes{Bytes: appBytes},
+		)
+		return
+	}
+
+	// It's OK to drop this message.
+	dropped := !chain.AppGossip(validatorID, appBytes)
+	if dropped {
+		cr.registerMsgDrop(chain.ctx.IsBootstrapped())
+	} else {
+		cr.registerMsgSuccess(chain.ctx.IsBootstrapped())
+	}
+}
+
 // Connected routes an incoming notification that a validator was just connected
-func (cr *ChainRouter) Connected(validatorID ids.ShortID) {
+func (cr *ChainRouter) Connected(validatorID ids.ShortID, features version.Features) {
 	cr.lock.Lock()
 	defer cr.lock.Unlock()
 
-	cr.peers.Add(validatorID)
+	cr.peers[validatorID] = features
 	for _, chain := range cr.chains {
 		chain.Connected(validatorID)
 	}
@@ -750,12 +885,21 @@ func (cr *ChainRouter) Disconnected(validatorID ids.ShortID) {
 	cr.lock.Lock()
 	defer cr.lock.Unlock()
 
-	cr.peers.Remove(validatorID)
+	delete(cr.peers, validatorID)
 	for _, chain := range cr.chains {
 		chain.Disconnected(validatorID)
 	}
 }
 
+// PeerFeatures returns the features reported by [validatorID], or none if it
+// isn't connected
+func (cr *ChainRouter) PeerFeatures(validatorID ids.ShortID) version.Features {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	return cr.peers[validatorID]
+}
+
 // Gossip accepted containers
 func (cr *ChainRouter) Gossip() {
 	cr.lock.Lock()
@@ -774,6 +918,7 @@ func (cr *ChainRouter) EndInterval() {
 	for _, chain := range cr.chains {
 		chain.endInterval()
 	}
+	cr.scheduler.endInterval()
 }
 
 // HealthCheck returns results of router health checks. Returns:
@@ -839,3 +984,13 @@ func createRequestID(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 type routerMetrics struct {
 	outstandingRequests prometheus.Gauge
 }
+
+// createAppRequestID is like createRequestID, but for application requests.
+// VMs choose their request IDs independently of the consensus engines, so the
+// IDs of application requests must not collide with the IDs of other requests.
+func createAppRequestID(validatorID ids.ShortID, chainID ids.ID, requestID uint32) ids.ID {
+	p := wrappers.Packer{Bytes: make([]byte, wrappers.IntLen+wrappers.ByteLen)}
+	p.PackInt(requestID)
+	p.PackByte(byte(constants.AppRequestMsg))
+	return hashing.ByteArraysToHash256Array(validatorID[:], chainID[:], p.Bytes)
+}
@@ -34,14 +34,22 @@ func TestShutdown(t *testing.T) {
 		TimeoutHalflife:    5 * time.Minute,
 		MetricsNamespace:   "",
 		Registerer:         prometheus.NewRegistry(),
-	}, benchlist)
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Millisecond,
+		MinimumTimeout:     time.Millisecond,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutCoefficient: 1.25,
+		TimeoutHalflife:    5 * time.Minute,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist)
 	if err != nil {
 		t.Fatal(err)
 	}
 	go tm.Dispatch()
 
 	chainRouter := ChainRouter{}
-	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
+	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil, HealthConfig{}, SchedulerConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
 
 	engine := common.EngineTest{T: t}
@@ -99,14 +107,22 @@ func TestShutdownTimesOut(t *testing.T) {
 		TimeoutHalflife:    5 * time.Minute,
 		MetricsNamespace:   "",
 		Registerer:         prometheus.NewRegistry(),
-	}, benchlist)
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Second,
+		MinimumTimeout:     500 * time.Millisecond,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutCoefficient: 1.25,
+		TimeoutHalflife:    5 * time.Minute,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist)
 	if err != nil {
 		t.Fatal(err)
 	}
 	go tm.Dispatch()
 
 	chainRouter := ChainRouter{}
-	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Millisecond, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
+	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Millisecond, ids.Set{}, nil, HealthConfig{}, SchedulerConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
 
 	engine := common.EngineTest{T: t}
@@ -174,7 +190,15 @@ func TestRouterTimeout(t *testing.T) {
 		TimeoutHalflife:    5 * time.Minute,
 		MetricsNamespace:   "",
 		Registerer:         prometheus.NewRegistry(),
-	}, benchlist.NewNoBenchlist())
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     10 * time.Millisecond,
+		MinimumTimeout:     10 * time.Millisecond,
+		MaximumTimeout:     maxTimeout,
+		TimeoutCoefficient: 1,
+		TimeoutHalflife:    5 * time.Minute,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist.NewNoBenchlist())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -182,7 +206,7 @@ func TestRouterTimeout(t *testing.T) {
 
 	// Create a router
 	chainRouter := ChainRouter{}
-	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Millisecond, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
+	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Millisecond, ids.Set{}, nil, HealthConfig{}, SchedulerConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
 
 	// Create an engine and handler
@@ -275,7 +299,15 @@ func TestRouterClearTimeouts(t *testing.T) {
 		TimeoutHalflife:    5 * time.Minute,
 		MetricsNamespace:   "",
 		Registerer:         prometheus.NewRegistry(),
-	}, benchlist.NewNoBenchlist())
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     3 * time.Second,
+		MinimumTimeout:     3 * time.Second,
+		MaximumTimeout:     5 * time.Minute,
+		TimeoutCoefficient: 1,
+		TimeoutHalflife:    5 * time.Minute,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist.NewNoBenchlist())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -283,7 +315,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 
 	// Create a router
 	chainRouter := ChainRouter{}
-	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Millisecond, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
+	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Millisecond, ids.Set{}, nil, HealthConfig{}, SchedulerConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
 
 	// Create an engine and handler
@@ -335,3 +367,99 @@ func TestRouterClearTimeouts(t *testing.T) {
 
 	assert.Equal(t, chainRouter.timedRequests.Len(), 0)
 }
+
+// Application requests have their own timeouts, and their request IDs don't
+// collide with the request IDs of the consensus engine
+func TestRouterAppRequestTimeout(t *testing.T) {
+	// Create a timeout manager
+	tm := timeout.Manager{}
+	err := tm.Initialize(&timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Minute,
+		MinimumTimeout:     time.Minute,
+		MaximumTimeout:     time.Minute,
+		TimeoutCoefficient: 1,
+		TimeoutHalflife:    5 * time.Minute,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     10 * time.Millisecond,
+		MinimumTimeout:     10 * time.Millisecond,
+		MaximumTimeout:     25 * time.Millisecond,
+		TimeoutCoefficient: 1,
+		TimeoutHalflife:    5 * time.Minute,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist.NewNoBenchlist())
+	if err != nil {
+		t.Fatal(err)
+	}
+	go tm.Dispatch()
+
+	// Create a router
+	chainRouter := ChainRouter{}
+	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Millisecond, ids.Set{}, nil, HealthConfig{}, SchedulerConfig{}, "", prometheus.NewRegistry())
+	assert.NoError(t, err)
+
+	// Create an engine and handler
+	engine := common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = snow.DefaultContextTest
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+		&Delay{},
+	)
+
+	failed := make(chan uint32, 2)
+	responded := make(chan uint32, 1)
+	handler.SetAppHandler(&testAppHandler{
+		appRequestFailedF: func(_ ids.ShortID, requestID uint32) error {
+			failed <- requestID
+			return nil
+		},
+		appResponseF: func(_ ids.ShortID, requestID uint32, _ []byte) error {
+			responded <- requestID
+			return nil
+		},
+	})
+
+	chainRouter.AddChain(handler)
+	go handler.Dispatch()
+
+	vID := ids.GenerateTestShortID()
+	chainID := handler.ctx.ChainID
+	chainRouter.RegisterRequest(vID, chainID, 0, constants.GetMsg)
+	chainRouter.RegisterRequest(vID, chainID, 0, constants.AppRequestMsg)
+	chainRouter.RegisterRequest(vID, chainID, 1, constants.AppRequestMsg)
+
+	// A response to the consensus request doesn't fulfill the application
+	// request with the same ID, and vice versa
+	chainRouter.AppResponse(vID, chainID, 1, nil)
+	chainRouter.Put(vID, chainID, 0, ids.GenerateTestID(), nil)
+
+	select {
+	case requestID := <-responded:
+		assert.Equal(t, uint32(1), requestID)
+	case <-time.After(time.Second):
+		t.Fatalf("AppResponse wasn't passed to the VM")
+	}
+	select {
+	case requestID := <-failed:
+		assert.Equal(t, uint32(0), requestID)
+	case <-time.After(time.Second):
+		t.Fatalf("AppRequest didn't time out")
+	}
+
+	chainRouter.lock.Lock()
+	defer chainRouter.lock.Unlock()
+	assert.Equal(t, 0, chainRouter.timedRequests.Len())
+}
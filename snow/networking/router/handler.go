@@ -4,6 +4,7 @@
 package router
 
 import (
+	"errors"
 	"math"
 	"sync"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/capture"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -89,6 +91,8 @@ const (
 	maxSleepDuration = 100 * time.Millisecond
 )
 
+var errCapturing = errors.New("the chain's messages are already being captured")
+
 // Handler passes incoming messages from the network to the consensus engine
 // (Actually, it receives the incoming messages from a ChainRouter, but same difference)
 type Handler struct {
@@ -112,11 +116,22 @@ type Handler struct {
 
 	ctx    *snow.Context
 	engine common.Engine
+	// appHandler is the chain's VM if it handles application-level messages.
+	// Otherwise, application-level messages are dropped.
+	appHandler common.AppHandler
 
 	toClose func()
 	closing utils.AtomicBool
 
 	delay *Delay
+
+	// scheduler decides when this chain may process a message, so that
+	// chains share the CPU fairly. nil if this chain isn't scheduled.
+	scheduler *scheduler
+
+	// recorder records the messages delivered to the engine while the chain
+	// is being captured
+	recorder *capture.Recorder
 }
 
 // Initialize this consensus handler
@@ -140,6 +155,7 @@ func (h *Handler) Initialize(
 	h.reliableMsgsSema = make(chan struct{}, 1)
 	h.closed = make(chan struct{})
 	h.msgChan = msgChan
+	h.recorder = &capture.Recorder{}
 
 	// Defines the maximum current percentage of expected CPU utilization for
 	// a message to be placed in the queue at the corresponding index
@@ -195,6 +211,15 @@ func (h *Handler) Engine() common.Engine { return h.engine }
 // SetEngine sets the engine for this handler to dispatch to
 func (h *Handler) SetEngine(engine common.Engine) { h.engine = engine }
 
+// SetAppHandler sets the VM that application-level messages are dispatched to
+func (h *Handler) SetAppHandler(appHandler common.AppHandler) { h.appHandler = appHandler }
+
+// SetRecorder sets the recorder that the messages delivered to the engine are
+// recorded to while the chain is being captured. The chain's sender and
+// validators should record to the same recorder. Must be called before the
+// handler is dispatched.
+func (h *Handler) SetRecorder(recorder *capture.Recorder) { h.recorder = recorder }
+
 // Dispatch waits for incoming messages from the network
 // and, when they arrive, sends them to the consensus engine
 func (h *Handler) Dispatch() {
@@ -265,7 +290,13 @@ func (h *Handler) dispatchMsg(msg message) {
 		}
 	}
 
+	if h.scheduler != nil {
+		h.scheduler.acquire(h.ctx.ChainID)
+	}
 	startTime := h.clock.Time()
+	if h.scheduler != nil {
+		defer h.scheduler.release(h.ctx.ChainID, startTime)
+	}
 
 	h.ctx.Lock.Lock()
 	defer h.ctx.Lock.Unlock()
@@ -275,19 +306,17 @@ func (h *Handler) dispatchMsg(msg message) {
 	} else {
 		h.ctx.Log.Debug("Forwarding message to consensus: %s", msg)
 	}
+	h.record(msg, startTime)
 
-	var (
-		err error
-	)
+	err := deliver(h.engine, h.appHandler, msg)
+	endTime := h.clock.Time()
 	switch msg.messageType {
 	case constants.NotifyMsg:
-		err = h.engine.Notify(msg.notification)
-		h.notify.Observe(float64(h.clock.Time().Sub(startTime)))
+		h.notify.Observe(float64(endTime.Sub(startTime)))
 	case constants.GossipMsg:
-		err = h.engine.Gossip()
-		h.gossip.Observe(float64(h.clock.Time().Sub(startTime)))
+		h.gossip.Observe(float64(endTime.Sub(startTime)))
 	default:
-		err = h.handleValidatorMsg(msg, startTime)
+		h.observeValidatorMsg(msg, startTime, endTime)
 	}
 
 	if msg.IsPeriodic() {
@@ -481,6 +510,51 @@ func (h *Handler) QueryFailed(validatorID ids.ShortID, requestID uint32) {
 	})
 }
 
+// AppRequest passes an AppRequest message received from the network to the
+// VM
+func (h *Handler) AppRequest(validatorID ids.ShortID, requestID uint32, deadline time.Time, appBytes []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.AppRequestMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		deadline:    deadline,
+		appBytes:    appBytes,
+		received:    h.clock.Time(),
+	})
+}
+
+// AppResponse passes an AppResponse message received from the network to the
+// VM
+func (h *Handler) AppResponse(validatorID ids.ShortID, requestID uint32, appBytes []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.AppResponseMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		appBytes:    appBytes,
+		received:    h.clock.Time(),
+	})
+}
+
+// AppRequestFailed passes an AppRequestFailed message to the VM
+func (h *Handler) AppRequestFailed(validatorID ids.ShortID, requestID uint32) {
+	h.sendReliableMsg(message{
+		messageType: constants.AppRequestFailedMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+	})
+}
+
+// AppGossip passes an AppGossip message received from the network to the VM
+func (h *Handler) AppGossip(validatorID ids.ShortID, appBytes []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.AppGossipMsg,
+		validatorID: validatorID,
+		requestID:   constants.GossipMsgRequestID,
+		appBytes:    appBytes,
+		received:    h.clock.Time(),
+	})
+}
+
 // Connected passes a new connection notification to the consensus engine
 func (h *Handler) Connected(validatorID ids.ShortID) {
 	h.sendReliableMsg(message{
@@ -516,6 +590,26 @@ func (h *Handler) Notify(msg common.Message) {
 	})
 }
 
+// StartCapture records every message delivered to the engine to [c] until
+// StopCapture is called. Only one capture may run at a time.
+func (h *Handler) StartCapture(c *capture.Capture) error {
+	if !h.recorder.Start(c) {
+		return errCapturing
+	}
+	return nil
+}
+
+// StopCapture stops recording messages to [c]
+func (h *Handler) StopCapture(c *capture.Capture) { h.recorder.Stop(c) }
+
+// record [msg], which was delivered at [deliveryTime], if messages are being
+// captured
+func (h *Handler) record(msg message, deliveryTime time.Time) {
+	if h.recorder.Capturing() {
+		h.recorder.Record(msg.record(deliveryTime))
+	}
+}
+
 // Shutdown asynchronously shuts down the dispatcher.
 // The handler should never be invoked again after calling
 // Shutdown.
@@ -540,57 +634,87 @@ func (h *Handler) shutdownDispatch() {
 	close(h.closed)
 }
 
-func (h *Handler) handleValidatorMsg(msg message, startTime time.Time) error {
-	var (
-		err error
-	)
+// observeValidatorMsg records that processing [msg], a message from a
+// validator, took from [startTime] to [endTime]
+func (h *Handler) observeValidatorMsg(msg message, startTime, endTime time.Time) {
+	timeConsumed := endTime.Sub(startTime)
+
+	histogram := h.getMSGHistogram(msg.messageType)
+	histogram.Observe(float64(timeConsumed))
+
+	h.cpuTracker.UtilizeTime(msg.validatorID, startTime, endTime)
+	h.serviceQueue.UtilizeCPU(msg.validatorID, timeConsumed)
+}
+
+// deliver [msg] to [engine], or to [appHandler] if it's an application-level
+// message. Assumes the context lock is held.
+func deliver(engine common.Engine, appHandler common.AppHandler, msg message) error {
 	switch msg.messageType {
+	case constants.NotifyMsg:
+		return engine.Notify(msg.notification)
+	case constants.GossipMsg:
+		return engine.Gossip()
 	case constants.GetAcceptedFrontierMsg:
-		err = h.engine.GetAcceptedFrontier(msg.validatorID, msg.requestID)
+		return engine.GetAcceptedFrontier(msg.validatorID, msg.requestID)
 	case constants.AcceptedFrontierMsg:
-		err = h.engine.AcceptedFrontier(msg.validatorID, msg.requestID, msg.containerIDs)
+		return engine.AcceptedFrontier(msg.validatorID, msg.requestID, msg.containerIDs)
 	case constants.GetAcceptedFrontierFailedMsg:
-		err = h.engine.GetAcceptedFrontierFailed(msg.validatorID, msg.requestID)
+		return engine.GetAcceptedFrontierFailed(msg.validatorID, msg.requestID)
 	case constants.GetAcceptedMsg:
-		err = h.engine.GetAccepted(msg.validatorID, msg.requestID, msg.containerIDs)
+		return engine.GetAccepted(msg.validatorID, msg.requestID, msg.containerIDs)
 	case constants.AcceptedMsg:
-		err = h.engine.Accepted(msg.validatorID, msg.requestID, msg.containerIDs)
+		return engine.Accepted(msg.validatorID, msg.requestID, msg.containerIDs)
 	case constants.GetAcceptedFailedMsg:
-		err = h.engine.GetAcceptedFailed(msg.validatorID, msg.requestID)
+		return engine.GetAcceptedFailed(msg.validatorID, msg.requestID)
 	case constants.GetAncestorsMsg:
-		err = h.engine.GetAncestors(msg.validatorID, msg.requestID, msg.containerID)
+		return engine.GetAncestors(msg.validatorID, msg.requestID, msg.containerID)
 	case constants.GetAncestorsFailedMsg:
-		err = h.engine.GetAncestorsFailed(msg.validatorID, msg.requestID)
+		return engine.GetAncestorsFailed(msg.validatorID, msg.requestID)
 	case constants.MultiPutMsg:
-		err = h.engine.MultiPut(msg.validatorID, msg.requestID, msg.containers)
+		return engine.MultiPut(msg.validatorID, msg.requestID, msg.containers)
 	case constants.GetMsg:
-		err = h.engine.Get(msg.validatorID, msg.requestID, msg.containerID)
+		return engine.Get(msg.validatorID, msg.requestID, msg.containerID)
 	case constants.GetFailedMsg:
-		err = h.engine.GetFailed(msg.validatorID, msg.requestID)
+		return engine.GetFailed(msg.validatorID, msg.requestID)
 	case constants.PutMsg:
-		err = h.engine.Put(msg.validatorID, msg.requestID, msg.containerID, msg.container)
+		return engine.Put(msg.validatorID, msg.requestID, msg.containerID, msg.container)
 	case constants.PushQueryMsg:
-		err = h.engine.PushQuery(msg.validatorID, msg.requestID, msg.containerID, msg.container)
+		return engine.PushQuery(msg.validatorID, msg.requestID, msg.containerID, msg.container)
 	case constants.PullQueryMsg:
-		err = h.engine.PullQuery(msg.validatorID, msg.requestID, msg.containerID)
+		return engine.PullQuery(msg.validatorID, msg.requestID, msg.containerID)
 	case constants.QueryFailedMsg:
-		err = h.engine.QueryFailed(msg.validatorID, msg.requestID)
+		return engine.QueryFailed(msg.validatorID, msg.requestID)
 	case constants.ChitsMsg:
-		err = h.engine.Chits(msg.validatorID, msg.requestID, msg.containerIDs)
+		return engine.Chits(msg.validatorID, msg.requestID, msg.containerIDs)
 	case constants.ConnectedMsg:
-		err = h.engine.Connected(msg.validatorID)
+		return engine.Connected(msg.validatorID)
 	case constants.DisconnectedMsg:
-		err = h.engine.Disconnected(msg.validatorID)
+		return engine.Disconnected(msg.validatorID)
+	case constants.AppRequestMsg, constants.AppResponseMsg,
+		constants.AppRequestFailedMsg, constants.AppGossipMsg:
+		return deliverAppMsg(engine.Context(), appHandler, msg)
+	default:
+		return nil
 	}
-	endTime := h.clock.Time()
-	timeConsumed := endTime.Sub(startTime)
-
-	histogram := h.getMSGHistogram(msg.messageType)
-	histogram.Observe(float64(timeConsumed))
+}
 
-	h.cpuTracker.UtilizeTime(msg.validatorID, startTime, endTime)
-	h.serviceQueue.UtilizeCPU(msg.validatorID, timeConsumed)
-	return err
+// deliverAppMsg passes an application-level message to the VM. Assumes the
+// context lock is held.
+func deliverAppMsg(ctx *snow.Context, appHandler common.AppHandler, msg message) error {
+	if appHandler == nil {
+		ctx.Log.Verbo("dropping %s because the VM doesn't handle application messages", msg.messageType)
+		return nil
+	}
+	switch msg.messageType {
+	case constants.AppRequestMsg:
+		return appHandler.AppRequest(msg.validatorID, msg.requestID, msg.appBytes)
+	case constants.AppResponseMsg:
+		return appHandler.AppResponse(msg.validatorID, msg.requestID, msg.appBytes)
+	case constants.AppRequestFailedMsg:
+		return appHandler.AppRequestFailed(msg.validatorID, msg.requestID)
+	default:
+		return appHandler.AppGossip(msg.validatorID, msg.appBytes)
+	}
 }
 
 func (h *Handler) sendReliableMsg(msg message) {
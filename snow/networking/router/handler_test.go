@@ -189,3 +189,112 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 	case <-closed:
 	}
 }
+
+// testAppHandler calls the function of each message type, if it's set
+type testAppHandler struct {
+	appRequestF       func(nodeID ids.ShortID, requestID uint32, request []byte) error
+	appRequestFailedF func(nodeID ids.ShortID, requestID uint32) error
+	appResponseF      func(nodeID ids.ShortID, requestID uint32, response []byte) error
+	appGossipF        func(nodeID ids.ShortID, msg []byte) error
+}
+
+func (h *testAppHandler) SetAppSender(common.AppSender) {}
+
+func (h *testAppHandler) AppRequest(nodeID ids.ShortID, requestID uint32, request []byte) error {
+	if h.appRequestF == nil {
+		return nil
+	}
+	return h.appRequestF(nodeID, requestID, request)
+}
+
+func (h *testAppHandler) AppRequestFailed(nodeID ids.ShortID, requestID uint32) error {
+	if h.appRequestFailedF == nil {
+		return nil
+	}
+	return h.appRequestFailedF(nodeID, requestID)
+}
+
+func (h *testAppHandler) AppResponse(nodeID ids.ShortID, requestID uint32, response []byte) error {
+	if h.appResponseF == nil {
+		return nil
+	}
+	return h.appResponseF(nodeID, requestID, response)
+}
+
+func (h *testAppHandler) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	if h.appGossipF == nil {
+		return nil
+	}
+	return h.appGossipF(nodeID, msg)
+}
+
+func TestHandlerDispatchesAppMessages(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(true)
+	engine.ContextF = snow.DefaultContextTest
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+		&Delay{},
+	)
+
+	called := make(chan string, 4)
+	nodeID := ids.GenerateTestShortID()
+	handler.SetAppHandler(&testAppHandler{
+		appRequestF: func(n ids.ShortID, requestID uint32, request []byte) error {
+			if n != nodeID || requestID != 1 || string(request) != "request" {
+				t.Fatalf("wrong AppRequest")
+			}
+			called <- "request"
+			return nil
+		},
+		appRequestFailedF: func(n ids.ShortID, requestID uint32) error {
+			if n != nodeID || requestID != 2 {
+				t.Fatalf("wrong AppRequestFailed")
+			}
+			called <- "failed"
+			return nil
+		},
+		appResponseF: func(n ids.ShortID, requestID uint32, response []byte) error {
+			if n != nodeID || requestID != 3 || string(response) != "response" {
+				t.Fatalf("wrong AppResponse")
+			}
+			called <- "response"
+			return nil
+		},
+		appGossipF: func(n ids.ShortID, msg []byte) error {
+			if n != nodeID || string(msg) != "gossip" {
+				t.Fatalf("wrong AppGossip")
+			}
+			called <- "gossip"
+			return nil
+		},
+	})
+	go handler.Dispatch()
+
+	handler.AppRequest(nodeID, 1, time.Time{}, []byte("request"))
+	handler.AppRequestFailed(nodeID, 2)
+	handler.AppResponse(nodeID, 3, []byte("response"))
+	handler.AppGossip(nodeID, []byte("gossip"))
+
+	received := map[string]bool{}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for len(received) < 4 {
+		select {
+		case <-ticker.C:
+			t.Fatalf("Calling app handler timed out")
+		case msg := <-called:
+			received[msg] = true
+		}
+	}
+}
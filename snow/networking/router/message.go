@@ -10,6 +10,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/capture"
 	"github.com/ava-labs/avalanchego/utils/constants"
 )
 
@@ -21,6 +22,7 @@ type message struct {
 	container    []byte
 	containers   [][]byte
 	containerIDs []ids.ID
+	appBytes     []byte
 	notification common.Message
 	received     time.Time // Time this message was received
 	deadline     time.Time // Time this message must be responded to
@@ -49,6 +51,8 @@ func (m message) String() string {
 		sb.WriteString(fmt.Sprintf(", ContainerID: %s)", m.containerID))
 	case constants.MultiPutMsg:
 		sb.WriteString(fmt.Sprintf(", NumContainers: %d)", len(m.containers)))
+	case constants.AppRequestMsg, constants.AppResponseMsg, constants.AppGossipMsg:
+		sb.WriteString(fmt.Sprintf(", NumAppBytes: %d)", len(m.appBytes)))
 	case constants.NotifyMsg:
 		sb.WriteString(fmt.Sprintf(", Notification: %s)", m.notification))
 	default:
@@ -57,3 +61,40 @@ func (m message) String() string {
 
 	return sb.String()
 }
+
+// record returns the capture record of this message, which was delivered at
+// [deliveryTime]
+func (m message) record(deliveryTime time.Time) capture.Record {
+	return capture.Record{
+		Kind:         capture.MessageKind,
+		Time:         deliveryTime,
+		Op:           m.messageType,
+		ValidatorID:  m.validatorID,
+		RequestID:    m.requestID,
+		Received:     m.received,
+		Deadline:     m.deadline,
+		ContainerID:  m.containerID,
+		Container:    m.container,
+		ContainerIDs: m.containerIDs,
+		Containers:   m.containers,
+		AppBytes:     m.appBytes,
+		Notification: uint32(m.notification),
+	}
+}
+
+// newMessage returns the message captured in [r]
+func newMessage(r capture.Record) message {
+	return message{
+		messageType:  r.Op,
+		validatorID:  r.ValidatorID,
+		requestID:    r.RequestID,
+		containerID:  r.ContainerID,
+		container:    r.Container,
+		containers:   r.Containers,
+		containerIDs: r.ContainerIDs,
+		appBytes:     r.AppBytes,
+		notification: common.Message(r.Notification),
+		received:     r.Received,
+		deadline:     r.Deadline,
+	}
+}
@@ -43,6 +43,7 @@ type metrics struct {
 	get, put, getFailed,
 	pushQuery, pullQuery, chits, queryFailed,
 	connected, disconnected,
+	appRequest, appResponse, appRequestFailed, appGossip,
 	notify,
 	gossip,
 	cpu,
@@ -110,6 +111,10 @@ func (m *metrics) Initialize(namespace string, registerer prometheus.Registerer)
 	m.queryFailed = initHistogram(namespace, "query_failed", registerer, &errs)
 	m.connected = initHistogram(namespace, "connected", registerer, &errs)
 	m.disconnected = initHistogram(namespace, "disconnected", registerer, &errs)
+	m.appRequest = initHistogram(namespace, "app_request", registerer, &errs)
+	m.appResponse = initHistogram(namespace, "app_response", registerer, &errs)
+	m.appRequestFailed = initHistogram(namespace, "app_request_failed", registerer, &errs)
+	m.appGossip = initHistogram(namespace, "app_gossip", registerer, &errs)
 	m.notify = initHistogram(namespace, "notify", registerer, &errs)
 	m.gossip = initHistogram(namespace, "gossip", registerer, &errs)
 
@@ -197,6 +202,14 @@ func (m *metrics) getMSGHistogram(msg constants.MsgType) prometheus.Histogram {
 		return m.connected
 	case constants.DisconnectedMsg:
 		return m.disconnected
+	case constants.AppRequestMsg:
+		return m.appRequest
+	case constants.AppResponseMsg:
+		return m.appResponse
+	case constants.AppRequestFailedMsg:
+		return m.appRequestFailed
+	case constants.AppGossipMsg:
+		return m.appGossip
 	default:
 		panic(fmt.Sprintf("unknown message type %s", msg))
 	}
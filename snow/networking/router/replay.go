@@ -0,0 +1,348 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/capture"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var (
+	_ snow.EventDispatcher = &Replayer{}
+	_ validators.Set       = &replayValidators{}
+	_ common.Sender        = &replaySender{}
+	_ common.AppSender     = &replayAppSender{}
+)
+
+// ReplayResult describes whether replaying a capture led the engine to the
+// same decisions as the captured chain
+type ReplayResult struct {
+	// Number of messages delivered to the engine
+	NumMessages int
+	// Decisions made by the captured chain
+	Expected []capture.Record
+	// Decisions made by the engine while replaying
+	Actual []capture.Record
+	// Index of the first decision that differs, or -1 if the decisions match
+	Divergence int
+}
+
+// Matches returns true if the engine made the same decisions as the captured
+// chain
+func (r *ReplayResult) Matches() bool { return r.Divergence == -1 }
+
+// Replayer feeds the messages of a capture to a fresh engine, and checks that
+// the engine makes the same decisions as the captured chain.
+//
+// The engine's VM should be in the state the captured chain's VM was in when
+// the capture started, such as a copy of a database backup taken at that
+// time, and the Replayer must be the DecisionDispatcher of the engine's
+// context. The engine should sample the validators returned by Validators and
+// send its requests with the sender returned by Sender, and the VM should send
+// its requests with the sender returned by AppSender. While the engine
+// handles a message, it's given the validators that the captured chain
+// sampled while handling the message, and the request IDs of the captured
+// chain's requests are mapped to the request IDs of the engine's, so that the
+// captured responses and timeouts reach the requests they answer.
+type Replayer struct {
+	lock      sync.Mutex
+	decisions []capture.Record
+
+	// Samples and requests of the captured chain that the engine hasn't
+	// made yet, while the engine handles a message
+	samples  [][]ids.ShortID
+	requests []capture.Record
+	// Request ID of a captured request --> Request ID of the engine's request
+	requestIDs map[uint32]uint32
+}
+
+// Issue implements the snow.EventDispatcher interface
+func (r *Replayer) Issue(*snow.Context, ids.ID, []byte) {}
+
+// Accept implements the snow.EventDispatcher interface
+func (r *Replayer) Accept(_ *snow.Context, containerID ids.ID, container []byte) {
+	r.decide(capture.AcceptKind, containerID, container)
+}
+
+// Reject implements the snow.EventDispatcher interface
+func (r *Replayer) Reject(_ *snow.Context, containerID ids.ID, container []byte) {
+	r.decide(capture.RejectKind, containerID, container)
+}
+
+func (r *Replayer) decide(kind capture.Kind, containerID ids.ID, container []byte) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.decisions = append(r.decisions, capture.Record{
+		Kind:        kind,
+		ContainerID: containerID,
+		Container:   container,
+	})
+}
+
+// Validators returns [vdrs], but while the engine handles a message, its
+// samples are the validators the captured chain sampled
+func (r *Replayer) Validators(vdrs validators.Set) validators.Set {
+	return &replayValidators{
+		vdrSet:   vdrs,
+		replayer: r,
+	}
+}
+
+// Sender returns [sender], but the requests sent with it are matched to the
+// requests of the captured chain
+func (r *Replayer) Sender(sender common.Sender) common.Sender {
+	return &replaySender{
+		Sender:   sender,
+		replayer: r,
+	}
+}
+
+// AppSender returns [sender], but the application-level requests sent with it
+// are matched to the requests of the captured chain
+func (r *Replayer) AppSender(sender common.AppSender) common.AppSender {
+	return &replayAppSender{
+		AppSender: sender,
+		replayer:  r,
+	}
+}
+
+// sample returns the next sample of the captured chain, if there is one
+func (r *Replayer) sample() ([]ids.ShortID, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.samples) == 0 {
+		return nil, false
+	}
+	sample := r.samples[0]
+	r.samples = r.samples[1:]
+	return sample, true
+}
+
+// request maps the next request of the captured chain of type [op] to the
+// engine's request [requestID]
+func (r *Replayer) request(op constants.MsgType, requestID uint32) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for i, request := range r.requests {
+		if request.Op != op {
+			continue
+		}
+		r.requestIDs[request.RequestID] = requestID
+		r.requests = append(r.requests[:i], r.requests[i+1:]...)
+		return
+	}
+}
+
+// Replay the capture in [reader] by delivering its messages to [engine], and
+// its application-level messages to [appHandler], in the order they were
+// delivered to the captured chain. [appHandler] may be nil.
+func (r *Replayer) Replay(
+	reader *capture.Reader,
+	engine common.Engine,
+	appHandler common.AppHandler,
+) (*ReplayResult, error) {
+	ctx := engine.Context()
+	if chainID := reader.Header().ChainID; chainID != ctx.ChainID {
+		return nil, fmt.Errorf("capture is of chain %s, but the engine runs chain %s", chainID, ctx.ChainID)
+	}
+
+	r.lock.Lock()
+	r.requestIDs = make(map[uint32]uint32)
+	r.lock.Unlock()
+
+	var (
+		result   = &ReplayResult{}
+		msg      *capture.Record
+		samples  [][]ids.ShortID
+		requests []capture.Record
+	)
+	for numRecords := 0; ; numRecords++ {
+		rec, err := reader.Read()
+		done := err == io.EOF
+		if err != nil && !done {
+			return nil, fmt.Errorf("couldn't read record %d: %w", numRecords, err)
+		}
+
+		// The samples and requests that follow a message were made while
+		// handling it, so they're read before the message is delivered
+		if !done {
+			switch rec.Kind {
+			case capture.MessageKind:
+			case capture.SampleKind:
+				samples = append(samples, rec.ValidatorIDs)
+				continue
+			case capture.RequestKind:
+				requests = append(requests, rec)
+				continue
+			default:
+				result.Expected = append(result.Expected, rec)
+				continue
+			}
+		}
+
+		// Samples and requests made before the first message can't be
+		// replayed, as they weren't made while handling a message
+		if msg != nil {
+			if err := r.deliver(engine, appHandler, *msg, samples, requests); err != nil {
+				return nil, fmt.Errorf("engine failed to handle %s: %w", msg, err)
+			}
+			result.NumMessages++
+		}
+		if done {
+			break
+		}
+		msg = &rec
+		samples = nil
+		requests = nil
+	}
+
+	r.lock.Lock()
+	result.Actual = r.decisions
+	r.decisions = nil
+	r.lock.Unlock()
+
+	result.Divergence = -1
+	for i, expected := range result.Expected {
+		if i >= len(result.Actual) {
+			result.Divergence = i
+			break
+		}
+		actual := result.Actual[i]
+		if actual.Kind != expected.Kind || actual.ContainerID != expected.ContainerID {
+			result.Divergence = i
+			break
+		}
+	}
+	if result.Divergence == -1 && len(result.Actual) > len(result.Expected) {
+		result.Divergence = len(result.Expected)
+	}
+	return result, nil
+}
+
+// deliver the captured message [rec] to the engine, which is given [samples]
+// and [requests] while it handles the message
+func (r *Replayer) deliver(
+	engine common.Engine,
+	appHandler common.AppHandler,
+	rec capture.Record,
+	samples [][]ids.ShortID,
+	requests []capture.Record,
+) error {
+	r.lock.Lock()
+	r.samples = samples
+	r.requests = requests
+	if isResponse(rec.Op) {
+		if requestID, ok := r.requestIDs[rec.RequestID]; ok {
+			rec.RequestID = requestID
+		}
+	}
+	r.lock.Unlock()
+
+	ctx := engine.Context()
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	return deliver(engine, appHandler, newMessage(rec))
+}
+
+// isResponse returns true if [op] answers a request sent by the engine
+func isResponse(op constants.MsgType) bool {
+	switch op {
+	case constants.AcceptedFrontierMsg, constants.GetAcceptedFrontierFailedMsg,
+		constants.AcceptedMsg, constants.GetAcceptedFailedMsg,
+		constants.MultiPutMsg, constants.GetAncestorsFailedMsg,
+		constants.PutMsg, constants.GetFailedMsg,
+		constants.ChitsMsg, constants.QueryFailedMsg,
+		constants.AppResponseMsg, constants.AppRequestFailedMsg:
+		return true
+	default:
+		return false
+	}
+}
+
+// vdrSet is embedded under this name, as validators.Set has a method named Set
+type vdrSet = validators.Set
+
+// replayValidators samples the validators that the captured chain sampled
+type replayValidators struct {
+	vdrSet
+
+	replayer *Replayer
+}
+
+// Sample implements the validators.Set interface
+func (v *replayValidators) Sample(size int) ([]validators.Validator, error) {
+	vdrIDs, ok := v.replayer.sample()
+	if !ok {
+		return v.vdrSet.Sample(size)
+	}
+	sample := make([]validators.Validator, len(vdrIDs))
+	for i, vdrID := range vdrIDs {
+		weight, _ := v.GetWeight(vdrID)
+		sample[i] = validators.NewValidator(vdrID, weight)
+	}
+	return sample, nil
+}
+
+// replaySender maps the requests the engine sends to the requests of the
+// captured chain
+type replaySender struct {
+	common.Sender
+
+	replayer *Replayer
+}
+
+func (s *replaySender) GetAcceptedFrontier(validatorIDs ids.ShortSet, requestID uint32) {
+	s.replayer.request(constants.GetAcceptedFrontierMsg, requestID)
+	s.Sender.GetAcceptedFrontier(validatorIDs, requestID)
+}
+
+func (s *replaySender) GetAccepted(validatorIDs ids.ShortSet, requestID uint32, containerIDs []ids.ID) {
+	s.replayer.request(constants.GetAcceptedMsg, requestID)
+	s.Sender.GetAccepted(validatorIDs, requestID, containerIDs)
+}
+
+func (s *replaySender) GetAncestors(validatorID ids.ShortID, requestID uint32, containerID ids.ID) {
+	s.replayer.request(constants.GetAncestorsMsg, requestID)
+	s.Sender.GetAncestors(validatorID, requestID, containerID)
+}
+
+func (s *replaySender) Get(validatorID ids.ShortID, requestID uint32, containerID ids.ID) {
+	s.replayer.request(constants.GetMsg, requestID)
+	s.Sender.Get(validatorID, requestID, containerID)
+}
+
+func (s *replaySender) PushQuery(validatorIDs ids.ShortSet, requestID uint32, containerID ids.ID, container []byte) {
+	s.replayer.request(constants.PushQueryMsg, requestID)
+	s.Sender.PushQuery(validatorIDs, requestID, containerID, container)
+}
+
+func (s *replaySender) PullQuery(validatorIDs ids.ShortSet, requestID uint32, containerID ids.ID) {
+	s.replayer.request(constants.PullQueryMsg, requestID)
+	s.Sender.PullQuery(validatorIDs, requestID, containerID)
+}
+
+// replayAppSender maps the requests the VM sends to the requests of the
+// captured chain
+type replayAppSender struct {
+	common.AppSender
+
+	replayer *Replayer
+}
+
+func (s *replayAppSender) SendAppRequest(nodeIDs ids.ShortSet, requestID uint32, request []byte) error {
+	s.replayer.request(constants.AppRequestMsg, requestID)
+	return s.AppSender.SendAppRequest(nodeIDs, requestID, request)
+}
@@ -0,0 +1,287 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/capture"
+	"github.com/ava-labs/avalanchego/snow/triggers"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// newDecidingEngine returns an engine that accepts every container it's sent,
+// and rejects [rejectedID] if a Get request for it fails
+func newDecidingEngine(t *testing.T, ctx *snow.Context, rejectedID ids.ID, called chan<- struct{}) *common.EngineTest {
+	engine := &common.EngineTest{T: t}
+	engine.Default(true)
+	engine.ContextF = func() *snow.Context { return ctx }
+	engine.PutF = func(_ ids.ShortID, _ uint32, containerID ids.ID, container []byte) error {
+		ctx.DecisionDispatcher.Accept(ctx, containerID, container)
+		called <- struct{}{}
+		return nil
+	}
+	engine.GetFailedF = func(ids.ShortID, uint32) error {
+		if rejectedID != ids.Empty {
+			ctx.DecisionDispatcher.Reject(ctx, rejectedID, nil)
+		}
+		called <- struct{}{}
+		return nil
+	}
+	return engine
+}
+
+func TestCaptureAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "capture.bin")
+
+	chainID := ids.GenerateTestID()
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = chainID
+	dispatcher := &triggers.EventDispatcher{}
+	dispatcher.Initialize(ctx.Log)
+	ctx.DecisionDispatcher = dispatcher
+
+	c, err := capture.Start(path, capture.Header{ChainID: chainID}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dispatcher.RegisterChain(chainID, "capture", c); err != nil {
+		t.Fatal(err)
+	}
+
+	called := make(chan struct{}, 1)
+	engine := newDecidingEngine(t, ctx, ids.Empty, called)
+	handler := &Handler{}
+	vdrs := validators.NewSet()
+	vdr := ids.GenerateTestShortID()
+	if err := vdrs.AddWeight(vdr, 1); err != nil {
+		t.Fatal(err)
+	}
+	handler.Initialize(
+		engine,
+		vdrs,
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+		&Delay{},
+	)
+	if err := handler.StartCapture(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := handler.StartCapture(c); err != errCapturing {
+		t.Fatalf("expected %s but got %v", errCapturing, err)
+	}
+	go handler.Dispatch()
+
+	// Messages are sent one at a time so that they're delivered in order
+	firstID := ids.GenerateTestID()
+	secondID := ids.GenerateTestID()
+	send := []func(){
+		func() { handler.Put(vdr, 1, firstID, []byte{1}) },
+		func() { handler.GetFailed(vdr, 2) },
+		func() { handler.Put(vdr, 3, secondID, []byte{2}) },
+	}
+	for _, f := range send {
+		f()
+		select {
+		case <-called:
+		case <-time.After(5 * time.Second):
+			t.Fatal("engine was never called")
+		}
+	}
+	handler.StopCapture(c)
+	if err := dispatcher.DeregisterChain(chainID, "capture"); err != nil {
+		t.Fatal(err)
+	}
+	numRecords, err := c.Stop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numRecords != 5 {
+		t.Fatalf("expected %d records but got %d", 5, numRecords)
+	}
+
+	// Replaying into an engine that behaves the same way makes the same
+	// decisions
+	replayer := &Replayer{}
+	replayCtx := snow.DefaultContextTest()
+	replayCtx.ChainID = chainID
+	replayCtx.DecisionDispatcher = replayer
+	replayCalled := make(chan struct{}, 3)
+	result := replay(t, path, replayer, newDecidingEngine(t, replayCtx, ids.Empty, replayCalled))
+	if result.NumMessages != 3 {
+		t.Fatalf("expected %d messages but got %d", 3, result.NumMessages)
+	}
+	if len(result.Expected) != 2 {
+		t.Fatalf("expected %d decisions but got %d", 2, len(result.Expected))
+	}
+	if !result.Matches() {
+		t.Fatalf("replay diverged at decision %d", result.Divergence)
+	}
+
+	// Replaying into an engine that rejects a container when a request fails
+	// makes a different decision
+	divergingCalled := make(chan struct{}, 3)
+	result = replay(t, path, replayer, newDecidingEngine(t, replayCtx, ids.GenerateTestID(), divergingCalled))
+	if result.Matches() {
+		t.Fatal("replay should have diverged")
+	}
+	if result.Divergence != 1 {
+		t.Fatalf("expected divergence at decision %d but got %d", 1, result.Divergence)
+	}
+}
+
+func TestReplayRejectsOtherChain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "capture.bin")
+
+	c, err := capture.Start(path, capture.Header{ChainID: ids.GenerateTestID()}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	reader, err := capture.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine := &common.EngineTest{T: t}
+	engine.Default(true)
+	engine.ContextF = snow.DefaultContextTest
+	if _, err := (&Replayer{}).Replay(reader, engine, nil); err == nil {
+		t.Fatal("should have refused to replay another chain's capture")
+	}
+}
+
+// replay the capture at [path] into [engine]
+func replay(t *testing.T, path string, replayer *Replayer, engine common.Engine) *ReplayResult {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	reader, err := capture.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := replayer.Replay(reader, engine, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestReplayFeedsSamplesAndRequests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "capture.bin")
+
+	chainID := ids.GenerateTestID()
+	c, err := capture.Start(path, capture.Header{ChainID: chainID}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The captured chain queried the second validator, with request ID 7,
+	// after it was sent the container
+	vdrIDs := []ids.ShortID{ids.GenerateTestShortID(), ids.GenerateTestShortID(), ids.GenerateTestShortID()}
+	containerID := ids.GenerateTestID()
+	for _, rec := range []capture.Record{
+		{Kind: capture.MessageKind, Op: constants.PutMsg, ValidatorID: vdrIDs[0], RequestID: 1, ContainerID: containerID, Container: []byte{1}},
+		{Kind: capture.SampleKind, ValidatorIDs: []ids.ShortID{vdrIDs[1]}},
+		{Kind: capture.RequestKind, Op: constants.PullQueryMsg, ValidatorIDs: []ids.ShortID{vdrIDs[1]}, RequestID: 7, ContainerID: containerID},
+		{Kind: capture.MessageKind, Op: constants.ChitsMsg, ValidatorID: vdrIDs[1], RequestID: 7, ContainerIDs: []ids.ID{containerID}},
+	} {
+		c.Record(rec)
+	}
+	if err := c.Accept(nil, containerID, []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer := &Replayer{}
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = chainID
+	ctx.DecisionDispatcher = replayer
+
+	vdrs := validators.NewSet()
+	for _, vdrID := range vdrIDs {
+		if err := vdrs.AddWeight(vdrID, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	replayVdrs := replayer.Validators(vdrs)
+	sender := &common.SenderTest{T: t}
+	replaySender := replayer.Sender(sender)
+
+	// The engine uses different request IDs than the captured chain
+	const queryRequestID = 100
+	engine := &common.EngineTest{T: t}
+	engine.Default(true)
+	engine.ContextF = func() *snow.Context { return ctx }
+	engine.PutF = func(_ ids.ShortID, _ uint32, containerID ids.ID, _ []byte) error {
+		sample, err := replayVdrs.Sample(1)
+		if err != nil {
+			return err
+		}
+		if len(sample) != 1 || sample[0].ID() != vdrIDs[1] {
+			t.Fatalf("expected the captured sample %s but got %v", vdrIDs[1], sample)
+		}
+		queried := ids.ShortSet{}
+		queried.Add(sample[0].ID())
+		replaySender.PullQuery(queried, queryRequestID, containerID)
+		return nil
+	}
+	engine.ChitsF = func(_ ids.ShortID, requestID uint32, votes []ids.ID) error {
+		if requestID != queryRequestID {
+			t.Fatalf("expected request ID %d but got %d", queryRequestID, requestID)
+		}
+		ctx.DecisionDispatcher.Accept(ctx, votes[0], nil)
+		return nil
+	}
+
+	result := replay(t, path, replayer, engine)
+	if result.NumMessages != 2 {
+		t.Fatalf("expected %d messages but got %d", 2, result.NumMessages)
+	}
+	if !result.Matches() {
+		t.Fatalf("replay diverged at decision %d", result.Divergence)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -29,12 +30,15 @@ type Router interface {
 		criticalChains ids.Set,
 		onFatal func(),
 		healthConfig HealthConfig,
+		schedulerConfig SchedulerConfig,
 		metricsNamespace string,
 		metricsRegisterer prometheus.Registerer,
 	) error
 	Shutdown()
 	AddChain(chain *Handler)
 	RemoveChain(chainID ids.ID)
+	// PeerFeatures returns the features reported by a connected peer
+	PeerFeatures(validatorID ids.ShortID) version.Features
 	health.Checkable
 }
 
@@ -53,6 +57,9 @@ type ExternalRouter interface {
 	PushQuery(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID, container []byte)
 	PullQuery(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, containerID ids.ID)
 	Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes []ids.ID)
+	AppRequest(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, appBytes []byte)
+	AppResponse(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appBytes []byte)
+	AppGossip(validatorID ids.ShortID, chainID ids.ID, appBytes []byte)
 }
 
 // InternalRouter deals with messages internal to this node
@@ -62,6 +69,7 @@ type InternalRouter interface {
 	GetFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 	GetAncestorsFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
 	QueryFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
-	Connected(validatorID ids.ShortID)
+	AppRequestFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32)
+	Connected(validatorID ids.ShortID, features version.Features)
 	Disconnected(validatorID ids.ShortID)
 }
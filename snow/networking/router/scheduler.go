@@ -0,0 +1,201 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/uptime"
+)
+
+const (
+	// DefaultChainWeight is the weight of chains that aren't given one
+	DefaultChainWeight = 1
+)
+
+// SchedulerConfig describes how chains share the node's CPU time. Chains are
+// identified by their ID or any of their aliases.
+type SchedulerConfig struct {
+	// Maximum number of chains that may process messages at the same time.
+	// If 0, chains are never made to wait.
+	MaxConcurrentChains int
+
+	// Weights of chains' shares of CPU time, relative to each other. Chains
+	// that aren't listed have weight [DefaultChainWeight]. A weight must be
+	// positive.
+	Weights map[string]uint64
+
+	// Chains that may always process messages, ahead of any other chain
+	PriorityChains []string
+}
+
+// scheduler decides which chain processes a message next. Priority chains
+// never wait. When more chains have messages than may process them at the same
+// time, the next chain to run is the one that has recently used the least CPU
+// time relative to its weight.
+type scheduler struct {
+	lock    sync.Mutex
+	clock   timer.Clock
+	config  SchedulerConfig
+	tracker tracker.ChainTracker
+
+	chains map[ids.ID]*scheduledChain
+	// Number of chains that are processing a message
+	running int
+	// Number of times a chain has started waiting, used to order chains that
+	// have used the same CPU time
+	numWaits uint64
+}
+
+type scheduledChain struct {
+	weight   uint64
+	priority bool
+	// Closed once this chain may run. nil if this chain isn't waiting.
+	turn chan struct{}
+	// Value of [numWaits] when this chain started waiting
+	waitNum uint64
+}
+
+func newScheduler(
+	config SchedulerConfig,
+	halflife time.Duration,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*scheduler, error) {
+	chainTracker, err := tracker.NewChainTracker(uptime.ContinuousFactory{}, halflife, namespace, registerer)
+	if err != nil {
+		return nil, err
+	}
+	return &scheduler{
+		config:  config,
+		tracker: chainTracker,
+		chains:  make(map[ids.ID]*scheduledChain),
+	}, nil
+}
+
+// addChain starts scheduling the chain of [ctx]
+func (s *scheduler) addChain(ctx *snow.Context) {
+	alias := ctx.ChainID.String()
+	if ctx.BCLookup != nil {
+		if primaryAlias, err := ctx.BCLookup.PrimaryAlias(ctx.ChainID); err == nil {
+			alias = primaryAlias
+		}
+	}
+
+	chain := &scheduledChain{weight: DefaultChainWeight}
+	for name, weight := range s.config.Weights {
+		if weight > 0 && refersTo(ctx, name) {
+			chain.weight = weight
+		}
+	}
+	for _, name := range s.config.PriorityChains {
+		if refersTo(ctx, name) {
+			chain.priority = true
+		}
+	}
+
+	s.lock.Lock()
+	s.chains[ctx.ChainID] = chain
+	s.lock.Unlock()
+
+	s.tracker.RegisterChain(ctx.ChainID, alias)
+}
+
+// removeChain stops scheduling [chainID]
+func (s *scheduler) removeChain(chainID ids.ID) {
+	s.lock.Lock()
+	chain, exists := s.chains[chainID]
+	if exists && chain.turn != nil {
+		// Don't leave the chain's dispatcher waiting
+		close(chain.turn)
+		chain.turn = nil
+		s.running++
+	}
+	delete(s.chains, chainID)
+	s.lock.Unlock()
+
+	s.tracker.RemoveChain(chainID)
+}
+
+// acquire blocks until [chainID] may process a message. Every call to acquire
+// must be followed by a call to release.
+func (s *scheduler) acquire(chainID ids.ID) {
+	s.lock.Lock()
+	chain, exists := s.chains[chainID]
+	if !exists || chain.priority || s.config.MaxConcurrentChains <= 0 || s.running < s.config.MaxConcurrentChains {
+		s.running++
+		s.lock.Unlock()
+		return
+	}
+	turn := make(chan struct{})
+	chain.turn = turn
+	chain.waitNum = s.numWaits
+	s.numWaits++
+	s.lock.Unlock()
+
+	<-turn
+}
+
+// release marks that [chainID] finished processing a message, which it
+// started at [startTime], and lets the next waiting chain run
+func (s *scheduler) release(chainID ids.ID, startTime time.Time) {
+	endTime := s.clock.Time()
+	s.tracker.UtilizeTime(chainID, startTime, endTime)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.running--
+	if s.running >= s.config.MaxConcurrentChains {
+		return
+	}
+	if next := s.next(endTime); next != nil {
+		close(next.turn)
+		next.turn = nil
+		s.running++
+	}
+}
+
+// endInterval updates the CPU time metrics
+func (s *scheduler) endInterval() { s.tracker.EndInterval(s.clock.Time()) }
+
+// next returns the waiting chain that should run next, or nil if no chain is
+// waiting
+// assumes the lock is held
+func (s *scheduler) next(currentTime time.Time) *scheduledChain {
+	var (
+		next      *scheduledChain
+		nextUsage float64
+	)
+	for chainID, chain := range s.chains {
+		if chain.turn == nil {
+			continue
+		}
+		usage := s.tracker.Utilization(chainID, currentTime) / float64(chain.weight)
+		if next == nil || usage < nextUsage || (usage == nextUsage && chain.waitNum < next.waitNum) {
+			next = chain
+			nextUsage = usage
+		}
+	}
+	return next
+}
+
+// refersTo returns true if [name] is the ID or an alias of the chain of [ctx]
+func refersTo(ctx *snow.Context, name string) bool {
+	if name == ctx.ChainID.String() {
+		return true
+	}
+	if ctx.BCLookup == nil {
+		return false
+	}
+	chainID, err := ctx.BCLookup.Lookup(name)
+	return err == nil && chainID == ctx.ChainID
+}
@@ -0,0 +1,166 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+)
+
+// newTestChain returns the context of a chain with ID [chainID] and alias
+// [alias]
+func newTestChain(t *testing.T, chainID ids.ID, alias string) *snow.Context {
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = chainID
+	if err := ctx.BCLookup.(*ids.Aliaser).Alias(chainID, alias); err != nil {
+		t.Fatal(err)
+	}
+	return ctx
+}
+
+// waitUntilWaiting blocks until [chainID] is waiting for its turn
+func waitUntilWaiting(t *testing.T, s *scheduler, chainID ids.ID) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s.lock.Lock()
+		waiting := s.chains[chainID].turn != nil
+		s.lock.Unlock()
+		if waiting {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("chain %s never waited", chainID)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// acquireAsync calls acquire for [chainID] and reports on the returned
+// channel once it returns
+func acquireAsync(s *scheduler, chainID ids.ID) <-chan ids.ID {
+	acquired := make(chan ids.ID, 1)
+	go func() {
+		s.acquire(chainID)
+		acquired <- chainID
+	}()
+	return acquired
+}
+
+func TestSchedulerPrefersLeastUsageByWeight(t *testing.T) {
+	s, err := newScheduler(SchedulerConfig{
+		MaxConcurrentChains: 1,
+		Weights:             map[string]uint64{"heavy": 4},
+	}, time.Minute, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	s.clock.Set(now)
+
+	runningCtx := newTestChain(t, ids.ID{1}, "running")
+	lightCtx := newTestChain(t, ids.ID{2}, "light")
+	heavyCtx := newTestChain(t, ids.ID{3}, "heavy")
+	s.addChain(runningCtx)
+	s.addChain(lightCtx)
+	s.addChain(heavyCtx)
+
+	// The heavy chain used twice as much CPU time as the light chain, but its
+	// weight is 4 times as large
+	s.tracker.UtilizeTime(lightCtx.ChainID, now.Add(-time.Second), now)
+	s.tracker.UtilizeTime(heavyCtx.ChainID, now.Add(-2*time.Second), now)
+
+	s.acquire(runningCtx.ChainID)
+	lightAcquired := acquireAsync(s, lightCtx.ChainID)
+	waitUntilWaiting(t, s, lightCtx.ChainID)
+	heavyAcquired := acquireAsync(s, heavyCtx.ChainID)
+	waitUntilWaiting(t, s, heavyCtx.ChainID)
+
+	s.release(runningCtx.ChainID, now)
+	select {
+	case <-heavyAcquired:
+	case <-lightAcquired:
+		t.Fatal("the light chain should have waited for the heavy chain")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no chain was scheduled")
+	}
+
+	s.release(heavyCtx.ChainID, now)
+	select {
+	case <-lightAcquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the light chain was never scheduled")
+	}
+	s.release(lightCtx.ChainID, now)
+}
+
+func TestSchedulerPriorityChainsDontWait(t *testing.T) {
+	s, err := newScheduler(SchedulerConfig{
+		MaxConcurrentChains: 1,
+		PriorityChains:      []string{"P"},
+	}, time.Minute, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runningCtx := newTestChain(t, ids.ID{1}, "X")
+	priorityCtx := newTestChain(t, ids.ID{2}, "P")
+	s.addChain(runningCtx)
+	s.addChain(priorityCtx)
+
+	s.acquire(runningCtx.ChainID)
+	select {
+	case <-acquireAsync(s, priorityCtx.ChainID):
+	case <-time.After(5 * time.Second):
+		t.Fatal("the priority chain should have run without waiting")
+	}
+
+	// Neither chain may start again until both have finished
+	waitingAcquired := acquireAsync(s, runningCtx.ChainID)
+	waitUntilWaiting(t, s, runningCtx.ChainID)
+	s.release(priorityCtx.ChainID, s.clock.Time())
+	s.release(runningCtx.ChainID, s.clock.Time())
+	select {
+	case <-waitingAcquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the chain was never scheduled")
+	}
+}
+
+func TestSchedulerRemoveWaitingChain(t *testing.T) {
+	s, err := newScheduler(SchedulerConfig{
+		MaxConcurrentChains: 1,
+	}, time.Minute, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runningCtx := newTestChain(t, ids.ID{1}, "running")
+	removedCtx := newTestChain(t, ids.ID{2}, "removed")
+	s.addChain(runningCtx)
+	s.addChain(removedCtx)
+
+	s.acquire(runningCtx.ChainID)
+	removedAcquired := acquireAsync(s, removedCtx.ChainID)
+	waitUntilWaiting(t, s, removedCtx.ChainID)
+
+	s.removeChain(removedCtx.ChainID)
+	select {
+	case <-removedAcquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("removing the chain should have stopped it from waiting")
+	}
+	s.release(removedCtx.ChainID, s.clock.Time())
+	s.release(runningCtx.ChainID, s.clock.Time())
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.running != 0 {
+		t.Fatalf("%d chains should be running, but %d are", 0, s.running)
+	}
+}
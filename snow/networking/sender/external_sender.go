@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/version"
 )
 
 // ExternalSender sends consensus messages to other validators
@@ -38,4 +39,15 @@ type ExternalSender interface {
 	Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes []ids.ID)
 
 	Gossip(chainID ids.ID, containerID ids.ID, container []byte)
+
+	// Send a VM-defined request to validators in [validatorIDs].
+	// The validator should reply by [deadline].
+	// Returns the IDs of validators that may receive the message.
+	AppRequest(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Duration, appBytes []byte) []ids.ShortID
+	AppResponse(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appBytes []byte)
+	AppGossip(chainID ids.ID, appBytes []byte)
+
+	// PeerFeatures returns the features [validatorID] reported during the
+	// handshake. If we're not connected to [validatorID], returns none.
+	PeerFeatures(validatorID ids.ShortID) version.Features
 }
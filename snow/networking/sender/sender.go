@@ -9,9 +9,12 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/capture"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -25,6 +28,11 @@ type Sender struct {
 	// Request message type --> Counts how many of that request
 	// have failed because the validator was benched
 	failedDueToBench map[constants.MsgType]prometheus.Counter
+
+	// recorder records the requests sent while the chain is being captured.
+	// nil if the chain can't be captured.
+	recorder *capture.Recorder
+	clock    timer.Clock
 }
 
 // Initialize this sender
@@ -73,14 +81,36 @@ func (s *Sender) Initialize(
 // Context of this sender
 func (s *Sender) Context() *snow.Context { return s.ctx }
 
+// SetRecorder sets the recorder that requests are recorded to while the chain
+// is being captured
+func (s *Sender) SetRecorder(recorder *capture.Recorder) { s.recorder = recorder }
+
+// recordRequest records the request [r] to [validatorIDs] if the chain is being
+// captured
+func (s *Sender) recordRequest(r capture.Record, validatorIDs ...ids.ShortID) {
+	if s.recorder == nil || !s.recorder.Capturing() {
+		return
+	}
+	ids.SortShortIDs(validatorIDs)
+	r.Kind = capture.RequestKind
+	r.Time = s.clock.Time()
+	r.ValidatorIDs = validatorIDs
+	s.recorder.Record(r)
+}
+
 // GetAcceptedFrontier ...
 func (s *Sender) GetAcceptedFrontier(validatorIDs ids.ShortSet, requestID uint32) {
+	s.recordRequest(capture.Record{
+		Op:        constants.GetAcceptedFrontierMsg,
+		RequestID: requestID,
+	}, validatorIDs.List()...)
+
 	// Sending a message to myself. No need to send it over the network.
 	// Just put it right into the router. Asynchronously to avoid deadlock.
 	if validatorIDs.Contains(s.ctx.NodeID) {
 		validatorIDs.Remove(s.ctx.NodeID)
 		// Note that this timeout duration won't exactly match the one that gets registered. That's OK.
-		timeoutDuration := s.timeouts.TimeoutDuration()
+		timeoutDuration := s.timeouts.TimeoutDuration(s.ctx.ChainID, constants.GetAcceptedFrontierMsg)
 		// Tell the router to expect a reply message from this validator
 		s.router.RegisterRequest(s.ctx.NodeID, s.ctx.ChainID, requestID, constants.GetAcceptedFrontierMsg)
 		go s.router.GetAcceptedFrontier(s.ctx.NodeID, s.ctx.ChainID, requestID, time.Now().Add(timeoutDuration))
@@ -92,7 +122,7 @@ func (s *Sender) GetAcceptedFrontier(validatorIDs ids.ShortSet, requestID uint32
 		if s.timeouts.IsBenched(validatorID, s.ctx.ChainID) {
 			s.failedDueToBench[constants.GetAcceptedFrontierMsg].Inc() // update metric
 			validatorIDs.Remove(validatorID)
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.GetAcceptedFrontierMsg)
 			// Immediately register a failure. Do so asynchronously to avoid deadlock.
 			go s.router.GetAcceptedFrontierFailed(s.ctx.NodeID, s.ctx.ChainID, requestID)
 		}
@@ -101,7 +131,7 @@ func (s *Sender) GetAcceptedFrontier(validatorIDs ids.ShortSet, requestID uint32
 	// Try to send the messages over the network.
 	// [sentTo] are the IDs of validators who may receive the message.
 	// Note that this timeout duration won't exactly match the one that gets registered. That's OK.
-	timeoutDuration := s.timeouts.TimeoutDuration()
+	timeoutDuration := s.timeouts.TimeoutDuration(s.ctx.ChainID, constants.GetAcceptedFrontierMsg)
 	sentTo := s.sender.GetAcceptedFrontier(validatorIDs, s.ctx.ChainID, requestID, timeoutDuration)
 
 	// Tell the router to expect a reply message from these validators
@@ -115,7 +145,7 @@ func (s *Sender) GetAcceptedFrontier(validatorIDs ids.ShortSet, requestID uint32
 	for validatorID := range validatorIDs {
 		// Note: The call to RegisterRequestToUnreachableValidator is not strictly necessary.
 		// This call causes the reported network latency look larger than it actually is.
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.GetAcceptedFrontierMsg)
 		go s.router.GetAcceptedFrontierFailed(validatorID, s.ctx.ChainID, requestID)
 	}
 }
@@ -131,12 +161,18 @@ func (s *Sender) AcceptedFrontier(validatorID ids.ShortID, requestID uint32, con
 
 // GetAccepted ...
 func (s *Sender) GetAccepted(validatorIDs ids.ShortSet, requestID uint32, containerIDs []ids.ID) {
+	s.recordRequest(capture.Record{
+		Op:           constants.GetAcceptedMsg,
+		RequestID:    requestID,
+		ContainerIDs: containerIDs,
+	}, validatorIDs.List()...)
+
 	// Sending a message to myself. No need to send it over the network.
 	// Just put it right into the router. Asynchronously to avoid deadlock.
 	if validatorIDs.Contains(s.ctx.NodeID) {
 		validatorIDs.Remove(s.ctx.NodeID)
 		// Note that this timeout duration won't exactly match the one that gets registered. That's OK.
-		timeoutDuration := s.timeouts.TimeoutDuration()
+		timeoutDuration := s.timeouts.TimeoutDuration(s.ctx.ChainID, constants.GetAcceptedMsg)
 		// Tell the router to expect a reply message from this validator
 		s.router.RegisterRequest(s.ctx.NodeID, s.ctx.ChainID, requestID, constants.GetAcceptedMsg)
 		go s.router.GetAccepted(s.ctx.NodeID, s.ctx.ChainID, requestID, time.Now().Add(timeoutDuration), containerIDs)
@@ -148,7 +184,7 @@ func (s *Sender) GetAccepted(validatorIDs ids.ShortSet, requestID uint32, contai
 		if s.timeouts.IsBenched(validatorID, s.ctx.ChainID) {
 			s.failedDueToBench[constants.GetAcceptedMsg].Inc() // update metric
 			validatorIDs.Remove(validatorID)
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.GetAcceptedMsg)
 			// Immediately register a failure. Do so asynchronously to avoid deadlock.
 			go s.router.GetAcceptedFailed(validatorID, s.ctx.ChainID, requestID)
 		}
@@ -157,7 +193,7 @@ func (s *Sender) GetAccepted(validatorIDs ids.ShortSet, requestID uint32, contai
 	// Try to send the messages over the network.
 	// [sentTo] are the IDs of validators who may receive the message.
 	// Note that this timeout duration won't exactly match the one that gets registered. That's OK.
-	timeoutDuration := s.timeouts.TimeoutDuration()
+	timeoutDuration := s.timeouts.TimeoutDuration(s.ctx.ChainID, constants.GetAcceptedMsg)
 	sentTo := s.sender.GetAccepted(validatorIDs, s.ctx.ChainID, requestID, timeoutDuration, containerIDs)
 
 	// Tell the router to expect a reply message from these validators
@@ -169,7 +205,7 @@ func (s *Sender) GetAccepted(validatorIDs ids.ShortSet, requestID uint32, contai
 
 	// Register failures for validators we didn't even send a request to.
 	for validatorID := range validatorIDs {
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.GetAcceptedMsg)
 		go s.router.GetAcceptedFailed(validatorID, s.ctx.ChainID, requestID)
 	}
 }
@@ -186,6 +222,12 @@ func (s *Sender) Accepted(validatorID ids.ShortID, requestID uint32, containerID
 // GetAncestors sends a GetAncestors message
 func (s *Sender) GetAncestors(validatorID ids.ShortID, requestID uint32, containerID ids.ID) {
 	s.ctx.Log.Verbo("Sending GetAncestors to validator %s. RequestID: %d. ContainerID: %s", validatorID, requestID, containerID)
+	s.recordRequest(capture.Record{
+		Op:          constants.GetAncestorsMsg,
+		RequestID:   requestID,
+		ContainerID: containerID,
+	}, validatorID)
+
 	// Sending a GetAncestors to myself will always fail
 	if validatorID == s.ctx.NodeID {
 		go s.router.GetAncestorsFailed(validatorID, s.ctx.ChainID, requestID)
@@ -196,13 +238,13 @@ func (s *Sender) GetAncestors(validatorID ids.ShortID, requestID uint32, contain
 	// so we don't even bother sending requests to them. We just have them immediately fail.
 	if s.timeouts.IsBenched(validatorID, s.ctx.ChainID) {
 		s.failedDueToBench[constants.GetAncestorsMsg].Inc() // update metric
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.GetAncestorsMsg)
 		go s.router.GetAncestorsFailed(validatorID, s.ctx.ChainID, requestID)
 		return
 	}
 
 	// Note that this timeout duration won't exactly match the one that gets registered. That's OK.
-	timeoutDuration := s.timeouts.TimeoutDuration()
+	timeoutDuration := s.timeouts.TimeoutDuration(s.ctx.ChainID, constants.GetAncestorsMsg)
 	sent := s.sender.GetAncestors(validatorID, s.ctx.ChainID, requestID, timeoutDuration, containerID)
 
 	if sent {
@@ -210,7 +252,7 @@ func (s *Sender) GetAncestors(validatorID ids.ShortID, requestID uint32, contain
 		s.router.RegisterRequest(validatorID, s.ctx.ChainID, requestID, constants.GetAncestorsMsg)
 		return
 	}
-	s.timeouts.RegisterRequestToUnreachableValidator()
+	s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.GetAncestorsMsg)
 	go s.router.GetAncestorsFailed(validatorID, s.ctx.ChainID, requestID)
 }
 
@@ -228,6 +270,11 @@ func (s *Sender) MultiPut(validatorID ids.ShortID, requestID uint32, containers
 // specified container.
 func (s *Sender) Get(validatorID ids.ShortID, requestID uint32, containerID ids.ID) {
 	s.ctx.Log.Verbo("Sending Get to validator %s. RequestID: %d. ContainerID: %s", validatorID, requestID, containerID)
+	s.recordRequest(capture.Record{
+		Op:          constants.GetMsg,
+		RequestID:   requestID,
+		ContainerID: containerID,
+	}, validatorID)
 
 	// Sending a Get to myself will always fail
 	if validatorID == s.ctx.NodeID {
@@ -239,13 +286,13 @@ func (s *Sender) Get(validatorID ids.ShortID, requestID uint32, containerID ids.
 	// so we don't even bother sending requests to them. We just have them immediately fail.
 	if s.timeouts.IsBenched(validatorID, s.ctx.ChainID) {
 		s.failedDueToBench[constants.GetMsg].Inc() // update metric
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.GetMsg)
 		go s.router.GetFailed(validatorID, s.ctx.ChainID, requestID)
 		return
 	}
 
 	// Note that this timeout duration won't exactly match the one that gets registered. That's OK.
-	timeoutDuration := s.timeouts.TimeoutDuration()
+	timeoutDuration := s.timeouts.TimeoutDuration(s.ctx.ChainID, constants.GetMsg)
 	sent := s.sender.Get(validatorID, s.ctx.ChainID, requestID, timeoutDuration, containerID)
 
 	if sent {
@@ -253,7 +300,7 @@ func (s *Sender) Get(validatorID ids.ShortID, requestID uint32, containerID ids.
 		s.router.RegisterRequest(validatorID, s.ctx.ChainID, requestID, constants.GetMsg)
 		return
 	}
-	s.timeouts.RegisterRequestToUnreachableValidator()
+	s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.GetMsg)
 	go s.router.GetFailed(validatorID, s.ctx.ChainID, requestID)
 }
 
@@ -272,9 +319,15 @@ func (s *Sender) Put(validatorID ids.ShortID, requestID uint32, containerID ids.
 // their preferred frontier given the existence of the specified container.
 func (s *Sender) PushQuery(validatorIDs ids.ShortSet, requestID uint32, containerID ids.ID, container []byte) {
 	s.ctx.Log.Verbo("Sending PushQuery to validators %v. RequestID: %d. ContainerID: %s", validatorIDs, requestID, containerID)
+	s.recordRequest(capture.Record{
+		Op:          constants.PushQueryMsg,
+		RequestID:   requestID,
+		ContainerID: containerID,
+		Container:   container,
+	}, validatorIDs.List()...)
 
 	// Note that this timeout duration won't exactly match the one that gets registered. That's OK.
-	timeoutDuration := s.timeouts.TimeoutDuration()
+	timeoutDuration := s.timeouts.TimeoutDuration(s.ctx.ChainID, constants.PushQueryMsg)
 
 	// Sending a message to myself. No need to send it over the network.
 	// Just put it right into the router. Do so asynchronously to avoid deadlock.
@@ -291,7 +344,7 @@ func (s *Sender) PushQuery(validatorIDs ids.ShortSet, requestID uint32, containe
 		if s.timeouts.IsBenched(validatorID, s.ctx.ChainID) {
 			s.failedDueToBench[constants.PushQueryMsg].Inc() // update metric
 			validatorIDs.Remove(validatorID)
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.PushQueryMsg)
 			// Immediately register a failure. Do so asynchronously to avoid deadlock.
 			go s.router.QueryFailed(validatorID, s.ctx.ChainID, requestID)
 		}
@@ -311,7 +364,7 @@ func (s *Sender) PushQuery(validatorIDs ids.ShortSet, requestID uint32, containe
 
 	// Register failures for validators we didn't even send a request to.
 	for validatorID := range validatorIDs {
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.PushQueryMsg)
 		go s.router.QueryFailed(validatorID, s.ctx.ChainID, requestID)
 	}
 }
@@ -322,9 +375,14 @@ func (s *Sender) PushQuery(validatorIDs ids.ShortSet, requestID uint32, containe
 // their preferred frontier.
 func (s *Sender) PullQuery(validatorIDs ids.ShortSet, requestID uint32, containerID ids.ID) {
 	s.ctx.Log.Verbo("Sending PullQuery. RequestID: %d. ContainerID: %s", requestID, containerID)
+	s.recordRequest(capture.Record{
+		Op:          constants.PullQueryMsg,
+		RequestID:   requestID,
+		ContainerID: containerID,
+	}, validatorIDs.List()...)
 
 	// Note that this timeout duration won't exactly match the one that gets registered. That's OK.
-	timeoutDuration := s.timeouts.TimeoutDuration()
+	timeoutDuration := s.timeouts.TimeoutDuration(s.ctx.ChainID, constants.PullQueryMsg)
 
 	// Sending a message to myself. No need to send it over the network.
 	// Just put it right into the router. Do so asynchronously to avoid deadlock.
@@ -341,7 +399,7 @@ func (s *Sender) PullQuery(validatorIDs ids.ShortSet, requestID uint32, containe
 		if s.timeouts.IsBenched(validatorID, s.ctx.ChainID) {
 			s.failedDueToBench[constants.PullQueryMsg].Inc() // update metric
 			validatorIDs.Remove(validatorID)
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.PullQueryMsg)
 			// Immediately register a failure. Do so asynchronously to avoid deadlock.
 			go s.router.QueryFailed(validatorID, s.ctx.ChainID, requestID)
 		}
@@ -360,7 +418,7 @@ func (s *Sender) PullQuery(validatorIDs ids.ShortSet, requestID uint32, containe
 
 	// Register failures for validators we didn't even send a request to.
 	for validatorID := range validatorIDs {
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID, constants.PullQueryMsg)
 		go s.router.QueryFailed(validatorID, s.ctx.ChainID, requestID)
 	}
 }
@@ -382,3 +440,70 @@ func (s *Sender) Gossip(containerID ids.ID, container []byte) {
 	s.ctx.Log.Verbo("Gossiping %s", containerID)
 	s.sender.Gossip(s.ctx.ChainID, containerID, container)
 }
+
+// SendAppRequest implements the common.AppSender interface. Unlike consensus
+// requests, application requests are sent to benched validators too, since
+// the benchlist only tracks how responsive validators are to consensus.
+func (s *Sender) SendAppRequest(nodeIDs ids.ShortSet, requestID uint32, request []byte) error {
+	s.recordRequest(capture.Record{
+		Op:        constants.AppRequestMsg,
+		RequestID: requestID,
+		AppBytes:  request,
+	}, nodeIDs.List()...)
+
+	// Don't modify the caller's set
+	validatorIDs := ids.ShortSet{}
+	validatorIDs.Union(nodeIDs)
+
+	// Sending a message to myself. No need to send it over the network.
+	// Just put it right into the router. Asynchronously to avoid deadlock.
+	if validatorIDs.Contains(s.ctx.NodeID) {
+		validatorIDs.Remove(s.ctx.NodeID)
+		// Note that this timeout duration won't exactly match the one that gets registered. That's OK.
+		timeoutDuration := s.timeouts.TimeoutDuration(s.ctx.ChainID, constants.AppRequestMsg)
+		// Tell the router to expect a reply message from this validator
+		s.router.RegisterRequest(s.ctx.NodeID, s.ctx.ChainID, requestID, constants.AppRequestMsg)
+		go s.router.AppRequest(s.ctx.NodeID, s.ctx.ChainID, requestID, time.Now().Add(timeoutDuration), request)
+	}
+
+	// Try to send the messages over the network.
+	// [sentTo] are the IDs of validators who may receive the message.
+	timeoutDuration := s.timeouts.TimeoutDuration(s.ctx.ChainID, constants.AppRequestMsg)
+	sentTo := s.sender.AppRequest(validatorIDs, s.ctx.ChainID, requestID, timeoutDuration, request)
+
+	// Tell the router to expect a reply message from these validators
+	for _, validatorID := range sentTo {
+		vID := validatorID // Prevent overwrite in next loop iteration
+		s.router.RegisterRequest(vID, s.ctx.ChainID, requestID, constants.AppRequestMsg)
+		validatorIDs.Remove(vID)
+	}
+
+	// Register failures for validators we didn't even send a request to.
+	for validatorID := range validatorIDs {
+		go s.router.AppRequestFailed(validatorID, s.ctx.ChainID, requestID)
+	}
+	return nil
+}
+
+// SendAppResponse implements the common.AppSender interface
+func (s *Sender) SendAppResponse(nodeID ids.ShortID, requestID uint32, response []byte) error {
+	if nodeID == s.ctx.NodeID {
+		go s.router.AppResponse(nodeID, s.ctx.ChainID, requestID, response)
+	} else {
+		s.sender.AppResponse(nodeID, s.ctx.ChainID, requestID, response)
+	}
+	return nil
+}
+
+// SendAppGossip implements the common.AppSender interface
+func (s *Sender) SendAppGossip(msg []byte) error {
+	s.ctx.Log.Verbo("Gossiping %d application bytes", len(msg))
+	s.sender.AppGossip(s.ctx.ChainID, msg)
+	return nil
+}
+
+// PeerFeatures returns the features [validatorID] reported during the
+// handshake, or none if it isn't connected
+func (s *Sender) PeerFeatures(validatorID ids.ShortID) version.Features {
+	return s.sender.PeerFeatures(validatorID)
+}
@@ -54,14 +54,22 @@ func TestTimeout(t *testing.T) {
 		TimeoutCoefficient: 1.25,
 		MetricsNamespace:   "",
 		Registerer:         prometheus.NewRegistry(),
-	}, benchlist)
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Millisecond,
+		MinimumTimeout:     time.Millisecond,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutHalflife:    5 * time.Minute,
+		TimeoutCoefficient: 1.25,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist)
 	if err != nil {
 		t.Fatal(err)
 	}
 	go tm.Dispatch()
 
 	chainRouter := router.ChainRouter{}
-	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
+	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, router.SchedulerConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
 
 	sender := Sender{}
@@ -126,14 +134,22 @@ func TestReliableMessages(t *testing.T) {
 		TimeoutCoefficient: 1.25,
 		MetricsNamespace:   "",
 		Registerer:         prometheus.NewRegistry(),
-	}, benchlist)
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Millisecond,
+		MinimumTimeout:     time.Millisecond,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutHalflife:    5 * time.Minute,
+		TimeoutCoefficient: 1.25,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist)
 	if err != nil {
 		t.Fatal(err)
 	}
 	go tm.Dispatch()
 
 	chainRouter := router.ChainRouter{}
-	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
+	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, router.SchedulerConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
 
 	sender := Sender{}
@@ -209,14 +225,22 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		TimeoutCoefficient: 1.25,
 		MetricsNamespace:   "",
 		Registerer:         prometheus.NewRegistry(),
-	}, benchlist)
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     10 * time.Millisecond,
+		MinimumTimeout:     10 * time.Millisecond,
+		MaximumTimeout:     10 * time.Millisecond, // Timeout fires immediately
+		TimeoutHalflife:    5 * time.Minute,
+		TimeoutCoefficient: 1.25,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist)
 	if err != nil {
 		t.Fatal(err)
 	}
 	go tm.Dispatch()
 
 	chainRouter := router.ChainRouter{}
-	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
+	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, router.SchedulerConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
 
 	sender := Sender{}
@@ -273,3 +297,108 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		<-await
 	}
 }
+
+// testAppHandler reports the application requests that failed
+type testAppHandler struct {
+	common.AppHandler
+
+	requestFailedF func(nodeID ids.ShortID, requestID uint32) error
+}
+
+func (h *testAppHandler) AppRequestFailed(nodeID ids.ShortID, requestID uint32) error {
+	return h.requestFailedF(nodeID, requestID)
+}
+
+func TestAppRequestTimeout(t *testing.T) {
+	benchlist := benchlist.NewNoBenchlist()
+	tm := timeout.Manager{}
+	err := tm.Initialize(&timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     10 * time.Second,
+		MinimumTimeout:     10 * time.Second,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutHalflife:    5 * time.Minute,
+		TimeoutCoefficient: 1.25,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Millisecond,
+		MinimumTimeout:     time.Millisecond,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutHalflife:    5 * time.Minute,
+		TimeoutCoefficient: 1.25,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go tm.Dispatch()
+
+	chainRouter := router.ChainRouter{}
+	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, router.SchedulerConfig{}, "", prometheus.NewRegistry())
+	assert.NoError(t, err)
+
+	// Only one of the validators is connected
+	connectedVdr := ids.ShortID{255}
+	externalSender := &ExternalSenderTest{T: t}
+	externalSender.AppRequestF = func(validatorIDs ids.ShortSet, _ ids.ID, _ uint32, _ time.Duration, _ []byte) []ids.ShortID {
+		if validatorIDs.Contains(connectedVdr) {
+			return []ids.ShortID{connectedVdr}
+		}
+		return nil
+	}
+
+	sender := Sender{}
+	err = sender.Initialize(snow.DefaultContextTest(), externalSender, &chainRouter, &tm, "", prometheus.NewRegistry())
+	assert.NoError(t, err)
+
+	engine := common.EngineTest{T: t}
+	engine.Default(true)
+	engine.CantConnected = false
+
+	engine.ContextF = snow.DefaultContextTest
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	failedVDRs := ids.ShortSet{}
+	appHandler := &testAppHandler{
+		requestFailedF: func(validatorID ids.ShortID, _ uint32) error {
+			failedVDRs.Add(validatorID)
+			wg.Done()
+			return nil
+		},
+	}
+
+	handler := router.Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		1,
+		router.DefaultMaxNonStakerPendingMsgs,
+		router.DefaultStakerPortion,
+		router.DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+		&router.Delay{},
+	)
+	handler.SetAppHandler(appHandler)
+	go handler.Dispatch()
+
+	chainRouter.AddChain(&handler)
+
+	vdrIDs := ids.ShortSet{}
+	vdrIDs.Add(connectedVdr)
+	vdrIDs.Add(ids.ShortID{254})
+
+	err = sender.SendAppRequest(vdrIDs, 0, []byte("request"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, vdrIDs.Len(), "the caller's set shouldn't be modified")
+
+	wg.Wait()
+
+	if !failedVDRs.Equals(vdrIDs) {
+		t.Fatalf("Application requests should have failed")
+	}
+}
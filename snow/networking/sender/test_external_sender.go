@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/version"
 )
 
 // ExternalSenderTest is a test sender
@@ -20,7 +21,9 @@ type ExternalSenderTest struct {
 	CantGetAncestors, CantMultiPut,
 	CantGet, CantPut,
 	CantPullQuery, CantPushQuery, CantChits,
-	CantGossip bool
+	CantGossip,
+	CantAppRequest, CantAppResponse, CantAppGossip,
+	CantPeerFeatures bool
 
 	GetAcceptedFrontierF func(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Duration) []ids.ShortID
 	AcceptedFrontierF    func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerIDs []ids.ID)
@@ -39,6 +42,12 @@ type ExternalSenderTest struct {
 	ChitsF     func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, votes []ids.ID)
 
 	GossipF func(chainID ids.ID, containerID ids.ID, container []byte)
+
+	AppRequestF  func(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Duration, appBytes []byte) []ids.ShortID
+	AppResponseF func(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appBytes []byte)
+	AppGossipF   func(chainID ids.ID, appBytes []byte)
+
+	PeerFeaturesF func(validatorID ids.ShortID) version.Features
 }
 
 // Default set the default callable value to [cant]
@@ -60,6 +69,12 @@ func (s *ExternalSenderTest) Default(cant bool) {
 	s.CantChits = cant
 
 	s.CantGossip = cant
+
+	s.CantAppRequest = cant
+	s.CantAppResponse = cant
+	s.CantAppGossip = cant
+
+	s.CantPeerFeatures = cant
 }
 
 // GetAcceptedFrontier calls GetAcceptedFrontierF if it was initialized. If it
@@ -235,3 +250,61 @@ func (s *ExternalSenderTest) Gossip(chainID ids.ID, containerID ids.ID, containe
 		s.B.Fatalf("Unexpectedly called Gossip")
 	}
 }
+
+// AppRequest calls AppRequestF if it was initialized. If it wasn't initialized
+// and this function shouldn't be called and testing was initialized, then
+// testing will fail.
+func (s *ExternalSenderTest) AppRequest(vdrs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Duration, appBytes []byte) []ids.ShortID {
+	switch {
+	case s.AppRequestF != nil:
+		return s.AppRequestF(vdrs, chainID, requestID, deadline, appBytes)
+	case s.CantAppRequest && s.T != nil:
+		s.T.Fatalf("Unexpectedly called AppRequest")
+	case s.CantAppRequest && s.B != nil:
+		s.B.Fatalf("Unexpectedly called AppRequest")
+	}
+	return nil
+}
+
+// AppResponse calls AppResponseF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *ExternalSenderTest) AppResponse(vdr ids.ShortID, chainID ids.ID, requestID uint32, appBytes []byte) {
+	switch {
+	case s.AppResponseF != nil:
+		s.AppResponseF(vdr, chainID, requestID, appBytes)
+	case s.CantAppResponse && s.T != nil:
+		s.T.Fatalf("Unexpectedly called AppResponse")
+	case s.CantAppResponse && s.B != nil:
+		s.B.Fatalf("Unexpectedly called AppResponse")
+	}
+}
+
+// AppGossip calls AppGossipF if it was initialized. If it wasn't initialized
+// and this function shouldn't be called and testing was initialized, then
+// testing will fail.
+func (s *ExternalSenderTest) AppGossip(chainID ids.ID, appBytes []byte) {
+	switch {
+	case s.AppGossipF != nil:
+		s.AppGossipF(chainID, appBytes)
+	case s.CantAppGossip && s.T != nil:
+		s.T.Fatalf("Unexpectedly called AppGossip")
+	case s.CantAppGossip && s.B != nil:
+		s.B.Fatalf("Unexpectedly called AppGossip")
+	}
+}
+
+// PeerFeatures calls PeerFeaturesF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *ExternalSenderTest) PeerFeatures(validatorID ids.ShortID) version.Features {
+	switch {
+	case s.PeerFeaturesF != nil:
+		return s.PeerFeaturesF(validatorID)
+	case s.CantPeerFeatures && s.T != nil:
+		s.T.Fatalf("Unexpectedly called PeerFeatures")
+	case s.CantPeerFeatures && s.B != nil:
+		s.B.Fatalf("Unexpectedly called PeerFeatures")
+	}
+	return 0
+}
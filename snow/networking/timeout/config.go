@@ -0,0 +1,107 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package timeout
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+// requestOps are the types of requests that have timeouts, and the names
+// their metrics and configs are reported under
+var requestOps = map[constants.MsgType]string{
+	constants.GetAcceptedFrontierMsg: "get_accepted_frontier",
+	constants.GetAcceptedMsg:         "get_accepted",
+	constants.GetAncestorsMsg:        "get_ancestors",
+	constants.GetMsg:                 "get",
+	constants.PushQueryMsg:           "push_query",
+	constants.PullQueryMsg:           "pull_query",
+	constants.AppRequestMsg:          "app_request",
+}
+
+// opConfig is the JSON representation of the timeout config of a type of
+// request. Unset fields keep their default value.
+type opConfig struct {
+	InitialTimeout     string  `json:"initialTimeout"`
+	MinimumTimeout     string  `json:"minimumTimeout"`
+	MaximumTimeout     string  `json:"maximumTimeout"`
+	TimeoutHalflife    string  `json:"timeoutHalflife"`
+	TimeoutCoefficient float64 `json:"timeoutCoefficient"`
+}
+
+// ParseOpConfigs parses [configBytes], a JSON object that maps the names of
+// types of requests, such as "get_ancestors", to the fields of their timeout
+// configs that differ from the default. Durations are strings such as "10s".
+// Consensus requests default to [config], and application-level requests to
+// [appConfig].
+func ParseOpConfigs(
+	configBytes []byte,
+	config timer.AdaptiveTimeoutConfig,
+	appConfig timer.AdaptiveTimeoutConfig,
+) (map[constants.MsgType]timer.AdaptiveTimeoutConfig, error) {
+	parsed := map[string]opConfig{}
+	if err := json.Unmarshal(configBytes, &parsed); err != nil {
+		return nil, fmt.Errorf("couldn't parse request timeout configs: %w", err)
+	}
+
+	msgTypes := make(map[string]constants.MsgType, len(requestOps))
+	for msgType, op := range requestOps {
+		msgTypes[op] = msgType
+	}
+
+	configs := make(map[constants.MsgType]timer.AdaptiveTimeoutConfig, len(parsed))
+	for op, overrides := range parsed {
+		msgType, ok := msgTypes[op]
+		if !ok {
+			return nil, fmt.Errorf("%q isn't a type of request", op)
+		}
+		opTimeoutConfig := config
+		if msgType == constants.AppRequestMsg {
+			opTimeoutConfig = appConfig
+		}
+
+		durations := []struct {
+			value string
+			field *time.Duration
+		}{
+			{overrides.InitialTimeout, &opTimeoutConfig.InitialTimeout},
+			{overrides.MinimumTimeout, &opTimeoutConfig.MinimumTimeout},
+			{overrides.MaximumTimeout, &opTimeoutConfig.MaximumTimeout},
+			{overrides.TimeoutHalflife, &opTimeoutConfig.TimeoutHalflife},
+		}
+		for _, duration := range durations {
+			if duration.value == "" {
+				continue
+			}
+			d, err := time.ParseDuration(duration.value)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse %s timeout config: %w", op, err)
+			}
+			*duration.field = d
+		}
+		if overrides.TimeoutCoefficient != 0 {
+			opTimeoutConfig.TimeoutCoefficient = overrides.TimeoutCoefficient
+		}
+
+		switch {
+		case opTimeoutConfig.MinimumTimeout < 1:
+			return nil, fmt.Errorf("%s minimum timeout must be positive", op)
+		case opTimeoutConfig.MinimumTimeout > opTimeoutConfig.MaximumTimeout:
+			return nil, fmt.Errorf("%s maximum timeout can't be less than minimum timeout", op)
+		case opTimeoutConfig.InitialTimeout < opTimeoutConfig.MinimumTimeout ||
+			opTimeoutConfig.InitialTimeout > opTimeoutConfig.MaximumTimeout:
+			return nil, fmt.Errorf("%s initial timeout should be in the range [minimumTimeout, maximumTimeout]", op)
+		case opTimeoutConfig.TimeoutHalflife <= 0:
+			return nil, fmt.Errorf("%s timeout halflife must be positive", op)
+		case opTimeoutConfig.TimeoutCoefficient < 1:
+			return nil, fmt.Errorf("%s timeout coefficient must be >= 1", op)
+		}
+		configs[msgType] = opTimeoutConfig
+	}
+	return configs, nil
+}
@@ -0,0 +1,75 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package timeout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer"
+)
+
+func TestParseOpConfigs(t *testing.T) {
+	config := timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     5 * time.Second,
+		MinimumTimeout:     2 * time.Second,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutCoefficient: 2,
+		TimeoutHalflife:    5 * time.Minute,
+	}
+	appConfig := config
+	appConfig.MaximumTimeout = 20 * time.Second
+
+	configs, err := ParseOpConfigs(
+		[]byte(`{"get_ancestors":{"maximumTimeout":"30s","timeoutCoefficient":1.5},"app_request":{"initialTimeout":"15s"}}`),
+		config,
+		appConfig,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 2 {
+		t.Fatalf("should have parsed 2 configs, but parsed %d", len(configs))
+	}
+
+	getAncestorsConfig := config
+	getAncestorsConfig.MaximumTimeout = 30 * time.Second
+	getAncestorsConfig.TimeoutCoefficient = 1.5
+	if configs[constants.GetAncestorsMsg] != getAncestorsConfig {
+		t.Fatalf("wrong get ancestors config: %+v", configs[constants.GetAncestorsMsg])
+	}
+
+	appRequestConfig := appConfig
+	appRequestConfig.InitialTimeout = 15 * time.Second
+	if configs[constants.AppRequestMsg] != appRequestConfig {
+		t.Fatalf("wrong app request config: %+v", configs[constants.AppRequestMsg])
+	}
+}
+
+func TestParseOpConfigsErrors(t *testing.T) {
+	config := timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     5 * time.Second,
+		MinimumTimeout:     2 * time.Second,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutCoefficient: 2,
+		TimeoutHalflife:    5 * time.Minute,
+	}
+
+	tests := map[string]string{
+		"malformed":             `[]`,
+		"unknown op":            `{"chits":{}}`,
+		"malformed duration":    `{"get":{"minimumTimeout":"soon"}}`,
+		"initial out of range":  `{"get":{"initialTimeout":"1m"}}`,
+		"maximum below minimum": `{"get":{"maximumTimeout":"1s"}}`,
+		"coefficient below 1":   `{"get":{"timeoutCoefficient":0.5}}`,
+	}
+	for name, configJSON := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseOpConfigs([]byte(configJSON), config, config); err == nil {
+				t.Fatal("should have failed to parse the config")
+			}
+		})
+	}
+}
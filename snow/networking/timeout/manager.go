@@ -15,28 +15,84 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
 
-// Manager registers and fires timeouts for the snow API.
+// Manager registers and fires timeouts for the snow API. Each registered chain
+// keeps a separate timeout for each type of request, so that slow responses to
+// one type of request, or on one chain, don't delay the others.
 type Manager struct {
-	lock         sync.Mutex
-	tm           timer.AdaptiveTimeoutManager
+	lock sync.Mutex
+	// Timeouts of consensus requests regarding chains that aren't registered
+	tm timer.AdaptiveTimeoutManager
+	// Timeouts of application-level requests regarding chains that aren't
+	// registered. They are kept apart from the consensus requests' so that a
+	// slow VM doesn't change how long consensus waits for responses, and they
+	// don't affect the benchlist.
+	appTM timer.AdaptiveTimeoutManager
+	// Configs that the timeouts of registered chains are created with
+	timeoutConfig, appTimeoutConfig timer.AdaptiveTimeoutConfig
+	opTimeoutConfigs                map[constants.MsgType]timer.AdaptiveTimeoutConfig
+	// Timeouts of each registered chain. [lock] must be held when accessing
+	// [chains] or [dispatched].
+	chains map[ids.ID]*chainTimeouts
+	// True once Dispatch has been called
+	dispatched   bool
 	benchlistMgr benchlist.Manager
 	metrics      metrics
 }
 
-// Initialize this timeout manager.
-func (m *Manager) Initialize(timeoutConfig *timer.AdaptiveTimeoutConfig, benchlistMgr benchlist.Manager) error {
+// chainTimeouts are the timeouts of the requests regarding a chain
+type chainTimeouts struct {
+	alias string
+	ops   map[constants.MsgType]*timer.AdaptiveTimeoutManager
+}
+
+// Initialize this timeout manager. [timeoutConfig] configures the timeouts of
+// consensus requests, and [appTimeoutConfig] configures the timeouts of
+// application-level requests. The config of a type of request in
+// [opTimeoutConfigs] replaces its default.
+func (m *Manager) Initialize(
+	timeoutConfig *timer.AdaptiveTimeoutConfig,
+	appTimeoutConfig *timer.AdaptiveTimeoutConfig,
+	opTimeoutConfigs map[constants.MsgType]timer.AdaptiveTimeoutConfig,
+	benchlistMgr benchlist.Manager,
+) error {
 	m.benchlistMgr = benchlistMgr
-	return m.tm.Initialize(timeoutConfig)
+	m.timeoutConfig = *timeoutConfig
+	m.appTimeoutConfig = *appTimeoutConfig
+	m.opTimeoutConfigs = opTimeoutConfigs
+	m.chains = make(map[ids.ID]*chainTimeouts)
+	for msgType := range opTimeoutConfigs {
+		if _, ok := requestOps[msgType]; !ok {
+			return fmt.Errorf("%s isn't a type of request", msgType)
+		}
+	}
+	if err := m.tm.Initialize(timeoutConfig); err != nil {
+		return err
+	}
+	if err := m.appTM.Initialize(appTimeoutConfig); err != nil {
+		return fmt.Errorf("couldn't initialize app request timeouts: %w", err)
+	}
+	return nil
 }
 
 // Dispatch ...
 func (m *Manager) Dispatch() {
+	m.lock.Lock()
+	m.dispatched = true
+	for _, chain := range m.chains {
+		for _, tm := range chain.ops {
+			go tm.Dispatch()
+		}
+	}
+	m.lock.Unlock()
+
+	go m.appTM.Dispatch()
 	m.tm.Dispatch()
 }
 
-// TimeoutDuration returns the current network timeout duration
-func (m *Manager) TimeoutDuration() time.Duration {
-	return m.tm.TimeoutDuration()
+// TimeoutDuration returns the current timeout duration of requests of type
+// [msgType] regarding chain [chainID]
+func (m *Manager) TimeoutDuration(chainID ids.ID, msgType constants.MsgType) time.Duration {
+	return m.getTM(chainID, msgType).TimeoutDuration()
 }
 
 // IsBenched returns true if messages to [validatorID] regarding [chainID]
@@ -45,14 +101,46 @@ func (m *Manager) IsBenched(validatorID ids.ShortID, chainID ids.ID) bool {
 	return m.benchlistMgr.IsBenched(validatorID, chainID)
 }
 
-// RegisterChain ...
+// RegisterChain creates the timeouts of the requests regarding the chain.
+// Their metrics are registered under [namespace].
 func (m *Manager) RegisterChain(ctx *snow.Context, namespace string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
 	if err := m.metrics.RegisterChain(ctx, namespace); err != nil {
 		return fmt.Errorf("couldn't register timeout metrics for chain %s: %w", ctx.ChainID, err)
 	}
 	if err := m.benchlistMgr.RegisterChain(ctx, namespace); err != nil {
 		return fmt.Errorf("couldn't register chain %s with benchlist manager: %w", ctx.ChainID, err)
 	}
+
+	chain := &chainTimeouts{
+		alias: ctx.ChainID.String(),
+		ops:   make(map[constants.MsgType]*timer.AdaptiveTimeoutManager, len(requestOps)),
+	}
+	if ctx.BCLookup != nil {
+		if alias, err := ctx.BCLookup.PrimaryAlias(ctx.ChainID); err == nil {
+			chain.alias = alias
+		}
+	}
+	for msgType, op := range requestOps {
+		config := m.configFor(msgType)
+		config.MetricsNamespace = fmt.Sprintf("%s_%s", namespace, op)
+		config.Registerer = ctx.Metrics
+
+		tm := &timer.AdaptiveTimeoutManager{}
+		if err := tm.Initialize(&config); err != nil {
+			return fmt.Errorf("couldn't initialize %s timeouts for chain %s: %w", op, ctx.ChainID, err)
+		}
+		chain.ops[msgType] = tm
+	}
+	m.chains[ctx.ChainID] = chain
+
+	if m.dispatched {
+		for _, tm := range chain.ops {
+			go tm.Dispatch()
+		}
+	}
 	return nil
 }
 
@@ -66,12 +154,16 @@ func (m *Manager) RegisterRequest(
 	uniqueRequestID ids.ID,
 	timeoutHandler func(),
 ) (time.Time, bool) {
+	tm := m.getTM(chainID, msgType)
+	if msgType == constants.AppRequestMsg {
+		return tm.Put(uniqueRequestID, msgType, timeoutHandler), true
+	}
 	newTimeoutHandler := func() {
 		// If this request timed out, tell the benchlist manager
 		m.benchlistMgr.RegisterFailure(chainID, validatorID)
 		timeoutHandler()
 	}
-	return m.tm.Put(uniqueRequestID, msgType, newTimeoutHandler), true
+	return tm.Put(uniqueRequestID, msgType, newTimeoutHandler), true
 }
 
 // RegisterResponse registers that we received a response from [validatorID]
@@ -86,15 +178,66 @@ func (m *Manager) RegisterResponse(
 	m.lock.Lock()
 	m.metrics.observe(chainID, msgType, latency)
 	m.lock.Unlock()
-	m.benchlistMgr.RegisterResponse(chainID, validatorID)
-	m.tm.Remove(uniqueRequestID)
+	m.getTM(chainID, msgType).Remove(uniqueRequestID)
+	if msgType != constants.AppRequestMsg {
+		m.benchlistMgr.RegisterResponse(chainID, validatorID)
+	}
 }
 
 // RegisterRequestToUnreachableValidator registers that we would have sent
-// a query to a validator but they are unreachable because they are bench
-// or because of network conditions (e.g. we're not connected), so we didn't
-// send the query. For the sake// of calculating the average latency and
-// network timeout, we act as though we sent the validator a request and it timed out.
-func (m *Manager) RegisterRequestToUnreachableValidator() {
-	m.tm.ObserveLatency(m.TimeoutDuration())
+// a request of type [msgType] regarding chain [chainID] to a validator but
+// they are unreachable because they are bench or because of network conditions
+// (e.g. we're not connected), so we didn't send the query. For the sake of
+// calculating the average latency and network timeout, we act as though we
+// sent the validator a request and it timed out.
+func (m *Manager) RegisterRequestToUnreachableValidator(chainID ids.ID, msgType constants.MsgType) {
+	tm := m.getTM(chainID, msgType)
+	tm.ObserveLatency(tm.TimeoutDuration())
+}
+
+// HealthCheck reports the current timeout of each type of request regarding
+// each registered chain. Timeouts adapt to the network, so they never make
+// the node unhealthy.
+func (m *Manager) HealthCheck() (interface{}, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	details := make(map[string]map[string]string, len(m.chains))
+	for _, chain := range m.chains {
+		timeouts := make(map[string]string, len(chain.ops))
+		for msgType, tm := range chain.ops {
+			timeouts[requestOps[msgType]] = tm.TimeoutDuration().String()
+		}
+		details[chain.alias] = timeouts
+	}
+	return details, nil
+}
+
+// getTM returns the timeouts of requests of type [msgType] regarding chain
+// [chainID]
+func (m *Manager) getTM(chainID ids.ID, msgType constants.MsgType) *timer.AdaptiveTimeoutManager {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if chain, ok := m.chains[chainID]; ok {
+		if tm, ok := chain.ops[msgType]; ok {
+			return tm
+		}
+	}
+	if msgType == constants.AppRequestMsg {
+		return &m.appTM
+	}
+	return &m.tm
+}
+
+// configFor returns the config that the timeouts of requests of type
+// [msgType] are created with
+func (m *Manager) configFor(msgType constants.MsgType) timer.AdaptiveTimeoutConfig {
+	if config, ok := m.opTimeoutConfigs[msgType]; ok {
+		return config
+	}
+	if msgType == constants.AppRequestMsg {
+		return m.appTimeoutConfig
+	}
+	return m.timeoutConfig
 }
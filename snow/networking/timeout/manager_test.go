@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/timer"
@@ -27,7 +28,15 @@ func TestManagerFire(t *testing.T) {
 		TimeoutHalflife:    5 * time.Minute,
 		MetricsNamespace:   "",
 		Registerer:         prometheus.NewRegistry(),
-	}, benchlist)
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Millisecond,
+		MinimumTimeout:     time.Millisecond,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutCoefficient: 1.25,
+		TimeoutHalflife:    5 * time.Minute,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -52,7 +61,15 @@ func TestManagerCancel(t *testing.T) {
 		TimeoutHalflife:    5 * time.Minute,
 		MetricsNamespace:   "",
 		Registerer:         prometheus.NewRegistry(),
-	}, benchlist)
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Millisecond,
+		MinimumTimeout:     time.Millisecond,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutCoefficient: 1.25,
+		TimeoutHalflife:    5 * time.Minute,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -76,3 +93,116 @@ func TestManagerCancel(t *testing.T) {
 		t.Fatalf("Should have cancelled the function")
 	}
 }
+
+func TestManagerOpsAdaptIndependently(t *testing.T) {
+	manager := Manager{}
+	config := timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Second,
+		MinimumTimeout:     time.Millisecond,
+		MaximumTimeout:     time.Minute,
+		TimeoutCoefficient: 2,
+		TimeoutHalflife:    time.Nanosecond,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}
+	appConfig := config
+	appConfig.Registerer = prometheus.NewRegistry()
+	getAncestorsConfig := config
+	getAncestorsConfig.MaximumTimeout = 2 * time.Minute
+	err := manager.Initialize(&config, &appConfig, map[constants.MsgType]timer.AdaptiveTimeoutConfig{
+		constants.GetAncestorsMsg: getAncestorsConfig,
+	}, benchlist.NewNoBenchlist())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx0 := snow.DefaultContextTest()
+	ctx0.ChainID = ids.GenerateTestID()
+	if err := manager.RegisterChain(ctx0, "chain0"); err != nil {
+		t.Fatal(err)
+	}
+	ctx1 := snow.DefaultContextTest()
+	ctx1.ChainID = ids.GenerateTestID()
+	if err := manager.RegisterChain(ctx1, "chain1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A slow get ancestors request on chain 0 shouldn't change the timeouts of
+	// other requests, or of get ancestors requests on chain 1
+	for i := 0; i < 10; i++ {
+		manager.RegisterRequestToUnreachableValidator(ctx0.ChainID, constants.GetAncestorsMsg)
+	}
+
+	if timeout := manager.TimeoutDuration(ctx0.ChainID, constants.GetAncestorsMsg); timeout <= time.Minute {
+		t.Fatalf("get ancestors timeout should have grown past the default maximum, but is %s", timeout)
+	}
+	if timeout := manager.TimeoutDuration(ctx0.ChainID, constants.PushQueryMsg); timeout != time.Second {
+		t.Fatalf("push query timeout should be %s, but is %s", time.Second, timeout)
+	}
+	if timeout := manager.TimeoutDuration(ctx1.ChainID, constants.GetAncestorsMsg); timeout != time.Second {
+		t.Fatalf("chain 1's get ancestors timeout should be %s, but is %s", time.Second, timeout)
+	}
+}
+
+func TestManagerHealthCheck(t *testing.T) {
+	manager := Manager{}
+	config := timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Second,
+		MinimumTimeout:     time.Millisecond,
+		MaximumTimeout:     time.Minute,
+		TimeoutCoefficient: 1.25,
+		TimeoutHalflife:    5 * time.Minute,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}
+	appConfig := config
+	appConfig.InitialTimeout = 2 * time.Second
+	appConfig.Registerer = prometheus.NewRegistry()
+	if err := manager.Initialize(&config, &appConfig, nil, benchlist.NewNoBenchlist()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+	if err := ctx.BCLookup.(*ids.Aliaser).Alias(ctx.ChainID, "X"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.RegisterChain(ctx, "X"); err != nil {
+		t.Fatal(err)
+	}
+
+	details, err := manager.HealthCheck()
+	if err != nil {
+		t.Fatal(err)
+	}
+	timeouts, ok := details.(map[string]map[string]string)["X"]
+	switch {
+	case !ok:
+		t.Fatal("health check should report the timeouts of the X-Chain")
+	case len(timeouts) != len(requestOps):
+		t.Fatalf("health check should report %d timeouts, but reported %d", len(requestOps), len(timeouts))
+	case timeouts["pull_query"] != "1s":
+		t.Fatalf("pull query timeout should be 1s, but is %s", timeouts["pull_query"])
+	case timeouts["app_request"] != "2s":
+		t.Fatalf("app request timeout should be 2s, but is %s", timeouts["app_request"])
+	}
+}
+
+func TestManagerRejectsUnknownOp(t *testing.T) {
+	manager := Manager{}
+	config := timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Second,
+		MinimumTimeout:     time.Millisecond,
+		MaximumTimeout:     time.Minute,
+		TimeoutCoefficient: 1.25,
+		TimeoutHalflife:    5 * time.Minute,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}
+	err := manager.Initialize(&config, &config, map[constants.MsgType]timer.AdaptiveTimeoutConfig{
+		constants.ChitsMsg: config,
+	}, benchlist.NewNoBenchlist())
+	if err == nil {
+		t.Fatal("should have rejected the timeout config of a message that isn't a request")
+	}
+}
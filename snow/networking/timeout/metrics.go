@@ -93,11 +93,13 @@ type chainMetrics struct {
 
 	getAcceptedFrontierSummary, getAcceptedSummary,
 	getAncestorsSummary, getSummary,
-	pushQuerySummary, pullQuerySummary *prometheus.SummaryVec
+	pushQuerySummary, pullQuerySummary,
+	appRequestSummary *prometheus.SummaryVec
 
 	getAcceptedFrontier, getAccepted,
 	getAncestors, get,
-	pushQuery, pullQuery prometheus.Histogram
+	pushQuery, pullQuery,
+	appRequest prometheus.Histogram
 }
 
 // Initialize implements the Engine interface
@@ -113,6 +115,7 @@ func (cm *chainMetrics) Initialize(ctx *snow.Context, namespace string, summaryE
 	cm.getSummary = initSummary(queryLatencyNamespace, "get_peer", ctx.Metrics, &errs)
 	cm.pushQuerySummary = initSummary(queryLatencyNamespace, "push_query_peer", ctx.Metrics, &errs)
 	cm.pullQuerySummary = initSummary(queryLatencyNamespace, "pull_query_peer", ctx.Metrics, &errs)
+	cm.appRequestSummary = initSummary(queryLatencyNamespace, "app_request_peer", ctx.Metrics, &errs)
 
 	cm.getAcceptedFrontier = initHistogram(queryLatencyNamespace, "get_accepted_frontier", ctx.Metrics, &errs)
 	cm.getAccepted = initHistogram(queryLatencyNamespace, "get_accepted", ctx.Metrics, &errs)
@@ -120,6 +123,7 @@ func (cm *chainMetrics) Initialize(ctx *snow.Context, namespace string, summaryE
 	cm.get = initHistogram(queryLatencyNamespace, "get", ctx.Metrics, &errs)
 	cm.pushQuery = initHistogram(queryLatencyNamespace, "push_query", ctx.Metrics, &errs)
 	cm.pullQuery = initHistogram(queryLatencyNamespace, "pull_query", ctx.Metrics, &errs)
+	cm.appRequest = initHistogram(queryLatencyNamespace, "app_request", ctx.Metrics, &errs)
 
 	return errs.Err
 }
@@ -136,6 +140,8 @@ func (cm *chainMetrics) observe(validatorID ids.ShortID, msgType constants.MsgTy
 		cm.pushQuery.Observe(float64(latency))
 	case constants.PullQueryMsg:
 		cm.pullQuery.Observe(float64(latency))
+	case constants.AppRequestMsg:
+		cm.appRequest.Observe(float64(latency))
 	}
 
 	if !cm.summaryEnabled {
@@ -160,6 +166,8 @@ func (cm *chainMetrics) observe(validatorID ids.ShortID, msgType constants.MsgTy
 		observer, err = cm.pushQuerySummary.GetMetricWith(labels)
 	case constants.PullQueryMsg:
 		observer, err = cm.pullQuerySummary.GetMetricWith(labels)
+	case constants.AppRequestMsg:
+		observer, err = cm.appRequestSummary.GetMetricWith(labels)
 	default:
 		return
 	}
@@ -0,0 +1,184 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/uptime"
+)
+
+// ChainTracker is an interface for tracking chains' usage of CPU Time
+type ChainTracker interface {
+	// RegisterChain starts tracking [chainID], whose metrics are labeled with
+	// [alias]
+	RegisterChain(chainID ids.ID, alias string)
+	// RemoveChain stops tracking [chainID]
+	RemoveChain(chainID ids.ID)
+	// UtilizeTime registers the use of CPU time by [chainID] from [startTime]
+	// to [endTime]
+	UtilizeTime(chainID ids.ID, startTime, endTime time.Time)
+	// Utilization returns the current EWMA of CPU utilization for [chainID]
+	Utilization(chainID ids.ID, currentTime time.Time) float64
+	// Share returns the portion of the CPU time recently spent by all chains
+	// that was spent by [chainID]
+	Share(chainID ids.ID, currentTime time.Time) float64
+	// EndInterval updates the metrics of chains that haven't recently spent
+	// CPU time
+	EndInterval(currentTime time.Time)
+}
+
+// chainTracker implements ChainTracker
+type chainTracker struct {
+	lock sync.Mutex
+
+	factory  uptime.Factory
+	halflife time.Duration
+	chains   map[ids.ID]*trackedChain
+
+	utilization *prometheus.GaugeVec
+	share       *prometheus.GaugeVec
+}
+
+type trackedChain struct {
+	alias string
+	meter uptime.Meter
+}
+
+// NewChainTracker returns a ChainTracker that reports the CPU utilization
+// and share of each chain as metrics
+func NewChainTracker(
+	factory uptime.Factory,
+	halflife time.Duration,
+	namespace string,
+	registerer prometheus.Registerer,
+) (ChainTracker, error) {
+	ct := &chainTracker{
+		factory:  factory,
+		halflife: halflife,
+		chains:   make(map[ids.ID]*trackedChain),
+		utilization: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "chain_cpu_utilization",
+				Help:      "EWMA of the portion of time spent processing each chain's messages",
+			},
+			[]string{"chain"},
+		),
+		share: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "chain_cpu_share",
+				Help:      "Portion of the time spent processing messages that was spent on each chain",
+			},
+			[]string{"chain"},
+		),
+	}
+	if err := registerer.Register(ct.utilization); err != nil {
+		return nil, fmt.Errorf("couldn't register chain_cpu_utilization metric: %w", err)
+	}
+	if err := registerer.Register(ct.share); err != nil {
+		return nil, fmt.Errorf("couldn't register chain_cpu_share metric: %w", err)
+	}
+	return ct, nil
+}
+
+func (ct *chainTracker) RegisterChain(chainID ids.ID, alias string) {
+	ct.lock.Lock()
+	defer ct.lock.Unlock()
+
+	ct.chains[chainID] = &trackedChain{
+		alias: alias,
+		meter: ct.factory.New(ct.halflife),
+	}
+}
+
+func (ct *chainTracker) RemoveChain(chainID ids.ID) {
+	ct.lock.Lock()
+	defer ct.lock.Unlock()
+
+	chain, exists := ct.chains[chainID]
+	if !exists {
+		return
+	}
+	delete(ct.chains, chainID)
+	ct.utilization.DeleteLabelValues(chain.alias)
+	ct.share.DeleteLabelValues(chain.alias)
+}
+
+func (ct *chainTracker) UtilizeTime(chainID ids.ID, startTime, endTime time.Time) {
+	ct.lock.Lock()
+	defer ct.lock.Unlock()
+
+	chain, exists := ct.chains[chainID]
+	if !exists {
+		return
+	}
+	chain.meter.Start(startTime)
+	chain.meter.Stop(endTime)
+	ct.updateMetrics(endTime)
+}
+
+func (ct *chainTracker) Utilization(chainID ids.ID, currentTime time.Time) float64 {
+	ct.lock.Lock()
+	defer ct.lock.Unlock()
+
+	chain, exists := ct.chains[chainID]
+	if !exists {
+		return 0
+	}
+	return chain.meter.Read(currentTime)
+}
+
+func (ct *chainTracker) Share(chainID ids.ID, currentTime time.Time) float64 {
+	ct.lock.Lock()
+	defer ct.lock.Unlock()
+
+	chain, exists := ct.chains[chainID]
+	if !exists {
+		return 0
+	}
+	total := ct.cumulativeUtilization(currentTime)
+	if total <= epsilon {
+		return 0
+	}
+	return chain.meter.Read(currentTime) / total
+}
+
+func (ct *chainTracker) EndInterval(currentTime time.Time) {
+	ct.lock.Lock()
+	defer ct.lock.Unlock()
+
+	ct.updateMetrics(currentTime)
+}
+
+// cumulativeUtilization returns the sum of the chains' utilizations
+// assumes the lock is held
+func (ct *chainTracker) cumulativeUtilization(currentTime time.Time) float64 {
+	total := 0.0
+	for _, chain := range ct.chains {
+		total += chain.meter.Read(currentTime)
+	}
+	return total
+}
+
+// updateMetrics sets the utilization and share of every chain
+// assumes the lock is held
+func (ct *chainTracker) updateMetrics(currentTime time.Time) {
+	total := ct.cumulativeUtilization(currentTime)
+	for _, chain := range ct.chains {
+		utilization := chain.meter.Read(currentTime)
+		share := 0.0
+		if total > epsilon {
+			share = utilization / total
+		}
+		ct.utilization.WithLabelValues(chain.alias).Set(utilization)
+		ct.share.WithLabelValues(chain.alias).Set(share)
+	}
+}
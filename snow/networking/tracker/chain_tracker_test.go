@@ -0,0 +1,65 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/uptime"
+)
+
+func TestChainTracker(t *testing.T) {
+	halflife := time.Second
+	registerer := prometheus.NewRegistry()
+	tracker, err := NewChainTracker(uptime.ContinuousFactory{}, halflife, "", registerer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain1 := ids.ID{1}
+	chain2 := ids.ID{2}
+	tracker.RegisterChain(chain1, "chain1")
+	tracker.RegisterChain(chain2, "chain2")
+
+	startTime := time.Now()
+	tracker.UtilizeTime(chain1, startTime, startTime.Add(halflife))
+	tracker.UtilizeTime(chain2, startTime, startTime.Add(3*halflife))
+
+	endTime := startTime.Add(3 * halflife)
+	utilization1 := tracker.Utilization(chain1, endTime)
+	utilization2 := tracker.Utilization(chain2, endTime)
+	if utilization1 >= utilization2 {
+		t.Fatalf("Utilization should have been higher for the chain that spent more time")
+	}
+
+	share1 := tracker.Share(chain1, endTime)
+	share2 := tracker.Share(chain2, endTime)
+	if math.Abs(share1+share2-1) > epsilon {
+		t.Fatalf("Shares %f and %f should have summed to 1", share1, share2)
+	}
+
+	tracker.EndInterval(endTime)
+	if metricShare := testutil.ToFloat64(tracker.(*chainTracker).share.WithLabelValues("chain2")); metricShare != share2 {
+		t.Fatalf("Reported share %f should have been %f", metricShare, share2)
+	}
+
+	tracker.RemoveChain(chain2)
+	if share := tracker.Share(chain1, endTime); math.Abs(share-1) > epsilon {
+		t.Fatalf("Remaining chain's share should have been 1 but was %f", share)
+	}
+	metrics, err := registerer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, metric := range metrics {
+		if len(metric.GetMetric()) != 1 {
+			t.Fatalf("%s should only have been reported for the remaining chain", metric.GetName())
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// State allows the lookup of the validator set of a subnet at a height of the
+// P-chain, so that every node agrees on the validators of that height
+type State interface {
+	// GetCurrentHeight returns the height of the last accepted P-chain block
+	GetCurrentHeight() (uint64, error)
+
+	// GetValidatorSet returns the weights of the validators of [subnetID]
+	// once the P-chain block at [height] was accepted
+	GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.ShortID]uint64, error)
+}
+
+// NewLockedState returns a State that holds [lock] while it calls [s]
+func NewLockedState(lock sync.Locker, s State) State {
+	return &lockedState{
+		lock: lock,
+		s:    s,
+	}
+}
+
+// lockedState implements State
+type lockedState struct {
+	lock sync.Locker
+	s    State
+}
+
+func (s *lockedState) GetCurrentHeight() (uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.s.GetCurrentHeight()
+}
+
+func (s *lockedState) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.ShortID]uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.s.GetValidatorSet(height, subnetID)
+}
@@ -0,0 +1,49 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	errCurrentHeight   = errors.New("unexpectedly called GetCurrentHeight")
+	errGetValidatorSet = errors.New("unexpectedly called GetValidatorSet")
+
+	_ State = &TestState{}
+)
+
+// TestState is a State that is useful for testing.
+type TestState struct {
+	T *testing.T
+
+	CantGetCurrentHeight,
+	CantGetValidatorSet bool
+
+	GetCurrentHeightF func() (uint64, error)
+	GetValidatorSetF  func(height uint64, subnetID ids.ID) (map[ids.ShortID]uint64, error)
+}
+
+func (s *TestState) GetCurrentHeight() (uint64, error) {
+	if s.GetCurrentHeightF != nil {
+		return s.GetCurrentHeightF()
+	}
+	if s.CantGetCurrentHeight && s.T != nil {
+		s.T.Fatal(errCurrentHeight)
+	}
+	return 0, errCurrentHeight
+}
+
+func (s *TestState) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.ShortID]uint64, error) {
+	if s.GetValidatorSetF != nil {
+		return s.GetValidatorSetF(height, subnetID)
+	}
+	if s.CantGetValidatorSet && s.T != nil {
+		s.T.Fatal(errGetValidatorSet)
+	}
+	return nil, errGetValidatorSet
+}
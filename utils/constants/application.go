@@ -10,4 +10,8 @@ const (
 
 	// Name of the avalanche application
 	AppName = "avalanchego"
+
+	// Version of the database the node runs on. Nodes and tools store and look
+	// for the database in a directory with this name.
+	DatabaseVersion = "v1.0.0"
 )
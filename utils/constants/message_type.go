@@ -30,6 +30,10 @@ const (
 	GetAncestorsMsg
 	MultiPutMsg
 	GetAncestorsFailedMsg
+	AppRequestMsg
+	AppResponseMsg
+	AppRequestFailedMsg
+	AppGossipMsg
 )
 
 func (t MsgType) String() string {
@@ -76,6 +80,14 @@ func (t MsgType) String() string {
 		return "Notify"
 	case GossipMsg:
 		return "Gossip"
+	case AppRequestMsg:
+		return "App Request"
+	case AppResponseMsg:
+		return "App Response"
+	case AppRequestFailedMsg:
+		return "App Request Failed"
+	case AppGossipMsg:
+		return "App Gossip"
 	default:
 		return fmt.Sprintf("Unknown Message Type: %d", t)
 	}
@@ -0,0 +1,95 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	errUnsortedUpgrades   = errors.New("upgrades must be sorted by time")
+	errUpgradeAfterLatest = errors.New("upgrades can't require a version newer than this node's")
+	errTooOld             = errors.New("version is older than the minimum compatible version")
+)
+
+// Upgrade requires peers to run at least [MinimumVersion] from [Time] on
+type Upgrade struct {
+	Time           time.Time
+	MinimumVersion Version
+}
+
+// Compatibility decides which peer versions this node is compatible with
+type Compatibility interface {
+	// Version of this node
+	Version() Version
+
+	// MinimumVersion returns the oldest version that is compatible at [now]
+	MinimumVersion(now time.Time) Version
+
+	// Compatible returns nil if [peer] is compatible at [now]
+	Compatible(peer Version, now time.Time) error
+
+	// Upgrades returns the scheduled upgrades, sorted by time
+	Upgrades() []Upgrade
+}
+
+type compatibility struct {
+	version  Version
+	minimum  Version
+	upgrades []Upgrade
+}
+
+// NewCompatibility returns a policy under which peers must be compatible with
+// [version] and run at least [minimum]. Each of [upgrades] raises the minimum
+// once its time has passed.
+func NewCompatibility(
+	version Version,
+	minimum Version,
+	upgrades []Upgrade,
+) (Compatibility, error) {
+	if version.Before(minimum) {
+		return nil, fmt.Errorf("%w: minimum %s is newer than %s", errUpgradeAfterLatest, minimum, version)
+	}
+	for i, upgrade := range upgrades {
+		if version.Before(upgrade.MinimumVersion) {
+			return nil, fmt.Errorf("%w: upgrade to %s is newer than %s", errUpgradeAfterLatest, upgrade.MinimumVersion, version)
+		}
+		if i > 0 && upgrade.Time.Before(upgrades[i-1].Time) {
+			return nil, errUnsortedUpgrades
+		}
+	}
+	return &compatibility{
+		version:  version,
+		minimum:  minimum,
+		upgrades: upgrades,
+	}, nil
+}
+
+func (c *compatibility) Version() Version    { return c.version }
+func (c *compatibility) Upgrades() []Upgrade { return c.upgrades }
+
+func (c *compatibility) MinimumVersion(now time.Time) Version {
+	minimum := c.minimum
+	for _, upgrade := range c.upgrades {
+		if now.Before(upgrade.Time) {
+			break
+		}
+		if minimum.Before(upgrade.MinimumVersion) {
+			minimum = upgrade.MinimumVersion
+		}
+	}
+	return minimum
+}
+
+func (c *compatibility) Compatible(peer Version, now time.Time) error {
+	if err := c.version.Compatible(peer); err != nil {
+		return err
+	}
+	if minimum := c.MinimumVersion(now); peer.Before(minimum) {
+		return fmt.Errorf("%w: %s is older than %s", errTooOld, peer, minimum)
+	}
+	return nil
+}
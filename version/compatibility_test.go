@@ -0,0 +1,63 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompatibility(t *testing.T) {
+	v := NewDefaultVersion("avalanche", 1, 4, 0)
+	minimum := NewDefaultVersion("avalanche", 1, 2, 0)
+	upgradeTime := time.Unix(1000, 0)
+	upgrade := NewDefaultVersion("avalanche", 1, 3, 0)
+
+	c, err := NewCompatibility(v, minimum, []Upgrade{{
+		Time:           upgradeTime,
+		MinimumVersion: upgrade,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, v, c.Version())
+
+	before := upgradeTime.Add(-time.Second)
+	assert.Equal(t, minimum, c.MinimumVersion(before))
+	assert.Equal(t, upgrade, c.MinimumVersion(upgradeTime))
+
+	old := NewDefaultVersion("avalanche", 1, 2, 5)
+	assert.NoError(t, c.Compatible(old, before))
+	err = c.Compatible(old, upgradeTime)
+	assert.True(t, errors.Is(err, errTooOld), "peers below the upgraded minimum should be rejected")
+	assert.NoError(t, c.Compatible(NewDefaultVersion("avalanche", 1, 3, 0), upgradeTime))
+
+	err = c.Compatible(NewDefaultVersion("avalanche", 1, 1, 0), before)
+	assert.True(t, errors.Is(err, errTooOld))
+	err = c.Compatible(NewDefaultVersion("other", 1, 4, 0), before)
+	assert.Equal(t, errDifferentApps, err)
+}
+
+func TestCompatibilityInvalid(t *testing.T) {
+	v := NewDefaultVersion("avalanche", 1, 3, 0)
+	old := NewDefaultVersion("avalanche", 1, 0, 0)
+
+	_, err := NewCompatibility(v, NewDefaultVersion("avalanche", 1, 4, 0), nil)
+	assert.True(t, errors.Is(err, errUpgradeAfterLatest))
+
+	_, err = NewCompatibility(v, old, []Upgrade{{
+		Time:           time.Unix(1, 0),
+		MinimumVersion: NewDefaultVersion("avalanche", 1, 4, 0),
+	}})
+	assert.True(t, errors.Is(err, errUpgradeAfterLatest))
+
+	_, err = NewCompatibility(v, old, []Upgrade{
+		{Time: time.Unix(2, 0), MinimumVersion: v},
+		{Time: time.Unix(1, 0), MinimumVersion: v},
+	})
+	assert.Equal(t, errUnsortedUpgrades, err)
+}
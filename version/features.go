@@ -0,0 +1,87 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"strings"
+)
+
+// Feature is an optional protocol behavior. Peers advertise the features they
+// support during the handshake, so that new behaviors can be used with peers
+// that support them without comparing versions.
+type Feature uint8
+
+// Features that may be advertised. These values are sent over the wire, so
+// they must never be reordered.
+const (
+	// AppMessagesFeature is set by peers that can parse application messages
+	AppMessagesFeature Feature = iota
+	// SignedIPsFeature is set by peers that can parse SignedIP messages and
+	// PeerList messages with signed IP claims
+	SignedIPsFeature
+	// PingNoncesFeature is set by peers that can parse Pings and Pongs with a
+	// nonce
+	PingNoncesFeature
+)
+
+// CurrentFeatures are the features supported by this version of the node
+var CurrentFeatures = NewFeatures(
+	AppMessagesFeature,
+	SignedIPsFeature,
+	PingNoncesFeature,
+)
+
+func (f Feature) String() string {
+	switch f {
+	case AppMessagesFeature:
+		return "app_messages"
+	case SignedIPsFeature:
+		return "signed_ips"
+	case PingNoncesFeature:
+		return "ping_nonces"
+	default:
+		return "unknown_feature"
+	}
+}
+
+// Features is a set of features. Feature [f] is in the set if bit [f] is set.
+type Features uint64
+
+// NewFeatures returns the set of [features]
+func NewFeatures(features ...Feature) Features {
+	set := Features(0)
+	for _, feature := range features {
+		set = set.Add(feature)
+	}
+	return set
+}
+
+// Add [feature] to the set
+func (f Features) Add(feature Feature) Features { return f | 1<<feature }
+
+// Contains returns true if [feature] is in the set
+func (f Features) Contains(feature Feature) bool { return f&(1<<feature) != 0 }
+
+// Intersection returns the features in both [f] and [o]
+func (f Features) Intersection(o Features) Features { return f & o }
+
+// List the features in the set, including those this version doesn't know
+func (f Features) List() []Feature {
+	features := []Feature(nil)
+	for feature := Feature(0); feature < 64; feature++ {
+		if f.Contains(feature) {
+			features = append(features, feature)
+		}
+	}
+	return features
+}
+
+func (f Features) String() string {
+	features := f.List()
+	names := make([]string, len(features))
+	for i, feature := range features {
+		names[i] = feature.String()
+	}
+	return "{" + strings.Join(names, ", ") + "}"
+}
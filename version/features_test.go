@@ -0,0 +1,24 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatures(t *testing.T) {
+	f := NewFeatures(AppMessagesFeature, PingNoncesFeature)
+
+	assert.True(t, f.Contains(AppMessagesFeature))
+	assert.False(t, f.Contains(SignedIPsFeature))
+	assert.True(t, f.Contains(PingNoncesFeature))
+	assert.Equal(t, []Feature{AppMessagesFeature, PingNoncesFeature}, f.List())
+	assert.Equal(t, "{app_messages, ping_nonces}", f.String())
+
+	assert.Equal(t, NewFeatures(PingNoncesFeature), f.Intersection(NewFeatures(SignedIPsFeature, PingNoncesFeature)))
+	assert.Equal(t, "{}", Features(0).String())
+	assert.Equal(t, "{unknown_feature}", NewFeatures(63).String(), "features from newer versions should be kept")
+}
@@ -39,8 +39,8 @@ var (
 // CreateAssetTx is a transaction that creates a new asset.
 type CreateAssetTx struct {
 	BaseTx       `serialize:"true"`
-	Name         string          `serialize:"true" json:"name"`
-	Symbol       string          `serialize:"true" json:"symbol"`
+	Name         string          `serialize:"true" len:"128" json:"name"` // At most maxNameLen bytes
+	Symbol       string          `serialize:"true" len:"4" json:"symbol"` // At most maxSymbolLen bytes
 	Denomination byte            `serialize:"true" json:"denomination"`
 	States       []*InitialState `serialize:"true" json:"initialStates"`
 }
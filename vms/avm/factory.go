@@ -17,6 +17,8 @@ var (
 type Factory struct {
 	CreationFee uint64
 	Fee         uint64
+	// If positive, byte budget of the VM's state cache
+	CacheBytes int
 }
 
 // New ...
@@ -24,5 +26,6 @@ func (f *Factory) New(*snow.Context) (interface{}, error) {
 	return &VM{
 		creationTxFee: f.CreationFee,
 		txFee:         f.Fee,
+		cacheBytes:    f.CacheBytes,
 	}, nil
 }
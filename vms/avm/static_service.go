@@ -14,9 +14,14 @@ import (
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/codec/reflectcodec"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
@@ -187,3 +192,25 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 	reply.Encoding = args.Encoding
 	return nil
 }
+
+// GetCodecSchema returns a description of the wire format of the transactions
+// and UTXOs of an AVM running the secp256k1fx, nftfx and propertyfx, in that
+// order, as the X-Chain does
+func (ss *StaticService) GetCodecSchema(_ *http.Request, _ *struct{}, reply *codec.Schema) error {
+	vm := &VM{ctx: &snow.Context{Log: logging.NoLog{}}}
+	err := vm.initCodecs([]*common.Fx{
+		{ID: secp256k1fx.ID, Fx: &secp256k1fx.Fx{}},
+		{ID: nftfx.ID, Fx: &nftfx.Fx{}},
+		{ID: propertyfx.ID, Fx: &propertyfx.Fx{}},
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't initialize codecs: %w", err)
+	}
+
+	schema, err := vm.codec.Schema(Tx{}, avax.UTXO{})
+	if err != nil {
+		return fmt.Errorf("couldn't describe codec: %w", err)
+	}
+	*reply = *schema
+	return nil
+}
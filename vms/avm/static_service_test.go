@@ -4,11 +4,17 @@
 package avm
 
 import (
+	"encoding/binary"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var addrStrArray = []string{
@@ -105,3 +111,47 @@ func TestBuildGenesis(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestGetCodecSchema(t *testing.T) {
+	ss := CreateStaticService()
+	schema := codec.Schema{}
+	if err := ss.GetCodecSchema(nil, nil, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if len(schema.Codecs) != 1 || schema.Codecs[0].Version != codecVersion {
+		t.Fatalf("expected codec version %d but got %+v", codecVersion, schema.Codecs)
+	}
+	codecSchema := schema.Codecs[0]
+
+	typeIDs := map[string]uint32{}
+	for _, registered := range codecSchema.Types {
+		typeIDs[registered.Name] = registered.TypeID[0]
+	}
+	for _, name := range []string{"avm.BaseTx", "avm.ExportTx", "nftfx.MintOutput", "propertyfx.Credential"} {
+		if _, ok := typeIDs[name]; !ok {
+			t.Fatalf("%s should have been registered", name)
+		}
+	}
+	if _, ok := codecSchema.Structs["avm.Tx"]; !ok {
+		t.Fatal("transactions should have been described")
+	}
+	if implementations := codecSchema.Interfaces["avm.UnsignedTx"]; len(implementations) != 5 {
+		t.Fatalf("expected 5 transaction types but got %v", implementations)
+	}
+
+	// The described type ID must match the type ID that is written
+	vm := &VM{ctx: &snow.Context{Log: logging.NoLog{}}}
+	if err := vm.initCodecs(testFxs()); err != nil {
+		t.Fatal(err)
+	}
+	utxo := &avax.UTXO{Out: &secp256k1fx.TransferOutput{}}
+	b, err := vm.codec.Marshal(codecVersion, utxo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// codec version, tx ID, output index, asset ID
+	offset := 2 + 32 + 4 + 32
+	if typeID := binary.BigEndian.Uint32(b[offset:]); typeID != typeIDs["secp256k1fx.TransferOutput"] {
+		t.Fatalf("described type ID %d but wrote %d", typeIDs["secp256k1fx.TransferOutput"], typeID)
+	}
+}
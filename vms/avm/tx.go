@@ -52,7 +52,10 @@ type UnsignedTx interface {
 type Tx struct {
 	UnsignedTx `serialize:"true" json:"unsignedTx"`
 
-	Creds []verify.Verifiable `serialize:"true" json:"credentials"` // The credentials of this transaction
+	// The credentials of this transaction. A transaction has at most one
+	// credential per input or operation, so the number of credentials is
+	// bounded by the maximum transaction size.
+	Creds []verify.Verifiable `serialize:"true" len:"4096" json:"credentials"`
 }
 
 // Credentials describes the authorization that allows the Inputs to consume the
@@ -4,12 +4,17 @@
 package avm
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -210,3 +215,169 @@ func TestTxInvalidNumberOfCredentials(t *testing.T) {
 		t.Fatalf("Tx should have failed due to an invalid unsigned tx")
 	}
 }
+
+// Transactions that claim more credentials than their bytes could hold must be
+// rejected before the credentials are allocated
+func TestTxMissingCredentials(t *testing.T) {
+	_, m := setupCodec()
+
+	tx := &Tx{UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+	}}}
+	b, err := m.Marshal(codecVersion, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The number of credentials is the last field of the transaction
+	for _, numCreds := range []uint32{1, math.MaxUint32} {
+		binary.BigEndian.PutUint32(b[len(b)-4:], numCreds)
+		parsedTx := Tx{}
+		if _, err := m.Unmarshal(b, &parsedTx); err == nil {
+			t.Fatalf("Should have errored due to %d missing credentials", numCreds)
+		}
+	}
+}
+
+// addElement returns a copy of [b] in which the slice whose length is stored at
+// [lenOffset] holds one more element. The element's [elem] bytes are inserted
+// at [elemOffset].
+func addElement(b []byte, lenOffset int, elemOffset int, elem []byte) []byte {
+	modified := make([]byte, 0, len(b)+len(elem))
+	modified = append(modified, b[:elemOffset]...)
+	modified = append(modified, elem...)
+	modified = append(modified, b[elemOffset:]...)
+	numElems := binary.BigEndian.Uint32(modified[lenOffset:])
+	binary.BigEndian.PutUint32(modified[lenOffset:], numElems+1)
+	return modified
+}
+
+// Transactions that hold more credentials or signatures than allowed must be
+// rejected when they are parsed
+func TestParseTxTooManyCredentials(t *testing.T) {
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	tx := &Tx{UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+	}}}
+	for i := 0; i < 4097; i++ {
+		tx.Creds = append(tx.Creds, &secp256k1fx.Credential{})
+	}
+	if _, err := vm.codec.Marshal(codecVersion, tx); err == nil {
+		t.Fatalf("Should have errored due to too many credentials")
+	}
+
+	// The credentials end the transaction
+	tx.Creds = tx.Creds[:4096]
+	b, err := vm.codec.Marshal(codecVersion, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.parsePrivateTx(b); err != nil {
+		t.Fatal(err)
+	}
+
+	// An empty credential is its type ID followed by its number of signatures
+	credLen := 2 * wrappers.IntLen
+	tooMany := addElement(b, len(b)-4096*credLen-4, len(b), b[len(b)-credLen:])
+	if _, err := vm.Parse(tooMany); err == nil {
+		t.Fatalf("Should have errored due to too many credentials")
+	}
+}
+
+func TestParseTxTooManySignatures(t *testing.T) {
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	tx := &Tx{
+		UnsignedTx: &BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		}},
+		Creds: []verify.Verifiable{&secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, 257),
+		}},
+	}
+	if _, err := vm.codec.Marshal(codecVersion, tx); err == nil {
+		t.Fatalf("Should have errored due to too many signatures")
+	}
+
+	// The signatures end the transaction
+	tx.Creds = []verify.Verifiable{&secp256k1fx.Credential{
+		Sigs: make([][crypto.SECP256K1RSigLen]byte, 256),
+	}}
+	b, err := vm.codec.Marshal(codecVersion, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.parsePrivateTx(b); err != nil {
+		t.Fatal(err)
+	}
+
+	sigsStart := len(b) - 256*crypto.SECP256K1RSigLen
+	tooMany := addElement(b, sigsStart-4, len(b), make([]byte, crypto.SECP256K1RSigLen))
+	if _, err := vm.Parse(tooMany); err == nil {
+		t.Fatalf("Should have errored due to too many signatures")
+	}
+}
+
+func TestParseCreateAssetTxNameTooLong(t *testing.T) {
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	tx := &Tx{UnsignedTx: &CreateAssetTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		}},
+		Name:   strings.Repeat("a", maxNameLen+1),
+		Symbol: "TST",
+	}}
+	if _, err := vm.codec.Marshal(codecVersion, tx); err == nil {
+		t.Fatalf("Should have errored due to too long of a name")
+	}
+
+	name := strings.Repeat("a", maxNameLen)
+	tx.UnsignedTx.(*CreateAssetTx).Name = name
+	b, err := vm.codec.Marshal(codecVersion, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.parsePrivateTx(b); err != nil {
+		t.Fatal(err)
+	}
+
+	// Lengthen the name by one byte. Its length is a short.
+	nameStart := bytes.Index(b, []byte(name))
+	if nameStart < 0 {
+		t.Fatal("couldn't find the name")
+	}
+	tooLong := make([]byte, 0, len(b)+1)
+	tooLong = append(tooLong, b[:nameStart]...)
+	tooLong = append(tooLong, 'a')
+	tooLong = append(tooLong, b[nameStart:]...)
+	binary.BigEndian.PutUint16(tooLong[nameStart-2:], maxNameLen+1)
+	if _, err := vm.Parse(tooLong); err == nil {
+		t.Fatalf("Should have errored due to too long of a name")
+	}
+}
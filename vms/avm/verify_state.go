@@ -0,0 +1,71 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// VerifyState checks that the persisted state of an AVM chain decodes with
+// this VM's codecs. [db] must be the database the VM was initialized with and
+// [fxs] must be the fxs the chain was created with, in the same order.
+//
+// Values are stored under hashed keys, so every value is expected to decode as
+// either a transaction status, a UTXO or a transaction. Empty values are
+// entries of the address to UTXO index.
+//
+// VerifyState only reads from [db]. It returns the number of values that were
+// decoded, keyed by kind, or the first value that failed to decode.
+func VerifyState(db database.Database, fxs []*common.Fx) (map[string]int, error) {
+	vm := &VM{ctx: &snow.Context{Log: logging.NoLog{}}}
+	if err := vm.initCodecs(fxs); err != nil {
+		return nil, err
+	}
+
+	iter := db.NewIterator()
+	defer iter.Release()
+
+	counts := make(map[string]int)
+	for iter.Next() {
+		kind, err := vm.verifyValue(iter.Value())
+		if err != nil {
+			return counts, fmt.Errorf("key 0x%x: %w", iter.Key(), err)
+		}
+		counts[kind]++
+	}
+	return counts, iter.Error()
+}
+
+// verifyValue returns the kind of value [bytes] decodes as
+func (vm *VM) verifyValue(bytes []byte) (string, error) {
+	if len(bytes) == 0 {
+		return "address index entries", nil
+	}
+
+	status := choices.Unknown
+	if _, err := vm.codec.Unmarshal(bytes, &status); err == nil {
+		if err := status.Valid(); err != nil {
+			return "", err
+		}
+		return "statuses", nil
+	}
+
+	utxo := avax.UTXO{}
+	if _, err := vm.codec.Unmarshal(bytes, &utxo); err == nil {
+		return "utxos", nil
+	}
+
+	tx := Tx{}
+	if _, err := vm.genesisCodec.Unmarshal(bytes, &tx); err != nil {
+		return "", fmt.Errorf("value doesn't decode as a status, UTXO or transaction: %w", err)
+	}
+	return "transactions", nil
+}
@@ -0,0 +1,65 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func testFxs() []*common.Fx {
+	return []*common.Fx{
+		{
+			ID: ids.Empty,
+			Fx: &secp256k1fx.Fx{},
+		},
+		{
+			ID: nftfx.ID,
+			Fx: &nftfx.Fx{},
+		},
+	}
+}
+
+func TestVerifyState(t *testing.T) {
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	counts, err := VerifyState(vm.baseDB, testFxs())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kind := range []string{"statuses", "utxos", "address index entries"} {
+		if counts[kind] == 0 {
+			t.Fatalf("expected to decode %s", kind)
+		}
+	}
+}
+
+func TestVerifyStateCorruptedValue(t *testing.T) {
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	if err := vm.baseDB.Put([]byte{1, 2, 3}, []byte{0, 0, 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyState(vm.baseDB, testFxs()); err == nil {
+		t.Fatal("should have failed to decode the corrupted value")
+	}
+}
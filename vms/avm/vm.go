@@ -15,6 +15,7 @@ import (
 	"github.com/gorilla/rpc/v2"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/codec/reflectcodec"
@@ -92,6 +93,10 @@ type VM struct {
 	// fee that must be burned by every non-state creating transaction
 	txFee uint64
 
+	// If positive, the state cache is limited to this many bytes rather than
+	// [stateCacheSize] entries
+	cacheBytes int
+
 	// Asset ID --> Bit set with fx IDs the asset supports
 	assetToFxCache *cache.LRU
 
@@ -128,18 +133,11 @@ func (vm *VM) Initialize(
 	vm.toEngine = toEngine
 	vm.baseDB = db
 	vm.db = versiondb.New(db)
-	vm.typeToFxIndex = map[reflect.Type]int{}
 	vm.Aliaser.Initialize()
 	vm.assetToFxCache = &cache.LRU{Size: assetToFxCacheSize}
 
 	vm.pubsub = cjson.NewPubSubServer(ctx)
 
-	genesisCodec := linearcodec.New(reflectcodec.DefaultTagName, 1<<20)
-	c := linearcodec.NewDefault()
-
-	vm.genesisCodec = codec.NewManager(math.MaxInt32)
-	vm.codec = codec.NewDefaultManager()
-
 	errs := wrappers.Errs{}
 	errs.Add(
 		vm.metrics.Initialize(ctx.Namespace, ctx.Metrics),
@@ -147,51 +145,34 @@ func (vm *VM) Initialize(
 		vm.pubsub.Register("accepted"),
 		vm.pubsub.Register("rejected"),
 		vm.pubsub.Register("verified"),
-
-		c.RegisterType(&BaseTx{}),
-		c.RegisterType(&CreateAssetTx{}),
-		c.RegisterType(&OperationTx{}),
-		c.RegisterType(&ImportTx{}),
-		c.RegisterType(&ExportTx{}),
-		vm.codec.RegisterCodec(codecVersion, c),
-
-		genesisCodec.RegisterType(&BaseTx{}),
-		genesisCodec.RegisterType(&CreateAssetTx{}),
-		genesisCodec.RegisterType(&OperationTx{}),
-		genesisCodec.RegisterType(&ImportTx{}),
-		genesisCodec.RegisterType(&ExportTx{}),
-		vm.genesisCodec.RegisterCodec(codecVersion, genesisCodec),
 	)
 	if errs.Errored() {
 		return errs.Err
 	}
 
-	vm.fxs = make([]*parsedFx, len(fxs))
-	for i, fxContainer := range fxs {
-		if fxContainer == nil {
-			return errIncompatibleFx
-		}
-		fx, ok := fxContainer.Fx.(Fx)
-		if !ok {
-			return errIncompatibleFx
-		}
-		vm.fxs[i] = &parsedFx{
-			ID: fxContainer.ID,
-			Fx: fx,
-		}
-		vm.codecRegistry = &codecRegistry{
-			codecs:      []codec.Registry{genesisCodec, c},
-			index:       i,
-			typeToIndex: vm.typeToFxIndex,
-		}
-		if err := fx.Initialize(vm); err != nil {
-			return err
+	if err := vm.initCodecs(fxs); err != nil {
+		return err
+	}
+
+	var stateCache cache.Cacher = &cache.LRU{Size: stateCacheSize}
+	if vm.cacheBytes > 0 {
+		sizedCache, err := metercacher.New(
+			fmt.Sprintf("%s_state_cache", ctx.Namespace),
+			ctx.Metrics,
+			&cache.SizedLRU{
+				MaxSize:  vm.cacheBytes,
+				SizeFunc: stateCacheEntrySize,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't initialize state cache: %w", err)
 		}
+		stateCache = sizedCache
 	}
 
 	vm.state = &prefixedState{
 		state: &state{State: avax.State{
-			Cache:        &cache.LRU{Size: stateCacheSize},
+			Cache:        stateCache,
 			DB:           vm.db,
 			GenesisCodec: vm.genesisCodec,
 			Codec:        vm.codec,
@@ -606,6 +587,71 @@ func (vm *VM) FlushTxs() {
  ******************************************************************************
  */
 
+// stateCacheEntrySize returns the size of a transaction or status cached in
+// the VM's state. UTXOs are cached with the size of their serialized bytes,
+// which the state already has, so they aren't sized here.
+func stateCacheEntrySize(value interface{}) int {
+	if tx, ok := value.(*Tx); ok {
+		return avax.StateCacheEntrySize(len(tx.Bytes()))
+	}
+	return avax.StateCacheEntrySize(0)
+}
+
+// initCodecs creates the codecs of this VM and registers the types of [fxs]
+// into them. [vm.ctx] must be set, as the fxs are initialized with this VM.
+func (vm *VM) initCodecs(fxs []*common.Fx) error {
+	genesisCodec := linearcodec.New(reflectcodec.DefaultTagName, 1<<20)
+	c := linearcodec.NewDefault()
+
+	vm.genesisCodec = codec.NewManager(math.MaxInt32)
+	vm.codec = codec.NewDefaultManager()
+	vm.typeToFxIndex = map[reflect.Type]int{}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&BaseTx{}),
+		c.RegisterType(&CreateAssetTx{}),
+		c.RegisterType(&OperationTx{}),
+		c.RegisterType(&ImportTx{}),
+		c.RegisterType(&ExportTx{}),
+		vm.codec.RegisterCodec(codecVersion, c),
+
+		genesisCodec.RegisterType(&BaseTx{}),
+		genesisCodec.RegisterType(&CreateAssetTx{}),
+		genesisCodec.RegisterType(&OperationTx{}),
+		genesisCodec.RegisterType(&ImportTx{}),
+		genesisCodec.RegisterType(&ExportTx{}),
+		vm.genesisCodec.RegisterCodec(codecVersion, genesisCodec),
+	)
+	if errs.Errored() {
+		return errs.Err
+	}
+
+	vm.fxs = make([]*parsedFx, len(fxs))
+	for i, fxContainer := range fxs {
+		if fxContainer == nil {
+			return errIncompatibleFx
+		}
+		fx, ok := fxContainer.Fx.(Fx)
+		if !ok {
+			return errIncompatibleFx
+		}
+		vm.fxs[i] = &parsedFx{
+			ID: fxContainer.ID,
+			Fx: fx,
+		}
+		vm.codecRegistry = &codecRegistry{
+			codecs:      []codec.Registry{genesisCodec, c},
+			index:       i,
+			typeToIndex: vm.typeToFxIndex,
+		}
+		if err := fx.Initialize(vm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (vm *VM) initAliases(genesisBytes []byte) error {
 	genesis := Genesis{}
 	if _, err := vm.genesisCodec.Unmarshal(genesisBytes, &genesis); err != nil {
@@ -6,8 +6,11 @@ package avm
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database/memdb"
@@ -1548,3 +1551,52 @@ func TestTxVerifyAfterVerifyAncestorTx(t *testing.T) {
 		t.Fatalf("Should have errored due to a missing UTXO")
 	}
 }
+
+func TestStateCacheBytes(t *testing.T) {
+	genesisBytes := BuildGenesisTest(t)
+	ctx := NewContext(t)
+	registry := prometheus.NewRegistry()
+	ctx.Metrics = registry
+
+	vm := &VM{
+		txFee:         testTxFee,
+		creationTxFee: testTxFee,
+		cacheBytes:    1024,
+	}
+	err := vm.Initialize(
+		ctx,
+		memdb.New(),
+		genesisBytes,
+		make(chan common.Message, 1),
+		[]*common.Fx{{
+			ID: ids.Empty,
+			Fx: &secp256k1fx.Fx{},
+		}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		ctx.Lock.Lock()
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	metrics, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, metric := range metrics {
+		if metric.GetName() != fmt.Sprintf("%s_state_cache_bytes", ctx.Namespace) {
+			continue
+		}
+		// Initializing the genesis caches the genesis transactions and UTXOs
+		if value := metric.GetMetric()[0].GetGauge().GetValue(); value <= 0 || value > 1024 {
+			t.Fatalf("state cache holds %f bytes but expected (0, %d]", value, 1024)
+		}
+		return
+	}
+	t.Fatal("missing state cache bytes metric")
+}
@@ -17,6 +17,10 @@ import (
 
 const (
 	codecVersion = 0
+
+	// stateCacheOverhead is the size charged for every entry of a byte
+	// budgeted State cache, in addition to the entry's serialized size
+	stateCacheOverhead = 64
 )
 
 // Addressable is the interface a feature extension must provide to be able to
@@ -257,7 +261,7 @@ func (s *State) UTXO(id ids.ID) (*UTXO, error) {
 		return nil, err
 	}
 
-	s.Cache.Put(id, utxo)
+	s.putUTXO(id, utxo, bytes)
 	return utxo, nil
 }
 
@@ -273,10 +277,25 @@ func (s *State) SetUTXO(id ids.ID, utxo *UTXO) error {
 		return err
 	}
 
-	s.Cache.Put(id, utxo)
+	s.putUTXO(id, utxo, bytes)
 	return s.DB.Put(id[:], bytes)
 }
 
+// StateCacheEntrySize returns the size charged in a byte budgeted State cache
+// for an entry that is serialized to [numBytes] bytes
+func StateCacheEntrySize(numBytes int) int { return stateCacheOverhead + numBytes }
+
+// putUTXO caches [utxo], which is serialized as [bytes]. A cache that is
+// bounded by size is given the size of [bytes], so that the UTXO doesn't need
+// to be serialized again to size it.
+func (s *State) putUTXO(id ids.ID, utxo *UTXO, bytes []byte) {
+	if sizedCache, ok := s.Cache.(cache.SizedCacher); ok {
+		sizedCache.PutWithSize(id, utxo, StateCacheEntrySize(len(bytes)))
+		return
+	}
+	s.Cache.Put(id, utxo)
+}
+
 // Status returns a status from storage.
 func (s *State) Status(id ids.ID) (choices.Status, error) {
 	if statusIntf, found := s.Cache.Get(id); found {
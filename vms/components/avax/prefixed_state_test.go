@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/database/memdb"
@@ -63,3 +64,39 @@ func TestPrefixedFunds(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, utxoIDs, 0)
 }
+
+func TestStateSizedCache(t *testing.T) {
+	c := linearcodec.NewDefault()
+	if err := c.RegisterType(&TestAddressable{}); err != nil {
+		t.Fatal(err)
+	}
+	manager := codec.NewDefaultManager()
+	if err := manager.RegisterCodec(codecVersion, c); err != nil {
+		t.Fatal(err)
+	}
+
+	sizedCache := &cache.SizedLRU{
+		MaxSize: 1024,
+		SizeFunc: func(interface{}) int {
+			t.Fatal("UTXOs should be cached with the size of their bytes")
+			return 0
+		},
+	}
+	st := &State{
+		Cache:        sizedCache,
+		DB:           memdb.New(),
+		GenesisCodec: manager,
+		Codec:        manager,
+	}
+
+	utxo := &UTXO{
+		Asset: Asset{ID: ids.Empty},
+		Out:   &TestAddressable{Addrs: [][]byte{{1}}},
+	}
+	utxoBytes, err := manager.Marshal(codecVersion, utxo)
+	assert.NoError(t, err)
+	assert.NoError(t, st.SetUTXO(utxo.InputID(), utxo))
+
+	// UTXOs are charged the same overhead as the other entries of the cache
+	assert.Equal(t, StateCacheEntrySize(len(utxoBytes)), sizedCache.Bytes())
+}
@@ -122,6 +122,9 @@ func (ab *AtomicBlock) Accept() error {
 	if err := ab.onAcceptDB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit onAcceptDB for block %s: %w", ab.ID(), err)
 	}
+	if err := ab.vm.writeValidatorDiffs(ab.vm.DB, ab.Height()); err != nil {
+		return fmt.Errorf("failed to write validator diffs for block %s: %w", ab.ID(), err)
+	}
 
 	batch, err := ab.vm.DB.CommitBatch()
 	if err != nil {
@@ -180,8 +180,14 @@ func (cb *CommonBlock) parentBlock() Block {
 	return parent.(Block)
 }
 
-// addChild adds [child] as a child of this block
-func (cb *CommonBlock) addChild(child Block) { cb.children = append(cb.children, child) }
+// addChild adds [child] as a child of this block. Decided blocks may be cached,
+// so they don't hold on to their children, which they never need to update.
+func (cb *CommonBlock) addChild(child Block) {
+	if cb.Status().Decided() {
+		return
+	}
+	cb.children = append(cb.children, child)
+}
 
 // CommonDecisionBlock contains the fields and methods common to all decision blocks
 type CommonDecisionBlock struct {
@@ -239,6 +245,9 @@ func (sdb *SingleDecisionBlock) Accept() error {
 	if err := sdb.onAcceptDB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit onAcceptDB: %w", err)
 	}
+	if err := sdb.vm.writeValidatorDiffs(sdb.vm.DB, sdb.Height()); err != nil {
+		return fmt.Errorf("failed to write validator diffs: %w", err)
+	}
 	if err := sdb.vm.DB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit vm's DB: %w", err)
 	}
@@ -283,6 +292,9 @@ func (ddb *DoubleDecisionBlock) Accept() error {
 	if err := ddb.onAcceptDB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit onAcceptDB: %w", err)
 	}
+	if err := ddb.vm.writeValidatorDiffs(ddb.vm.DB, ddb.Height()); err != nil {
+		return fmt.Errorf("failed to write validator diffs: %w", err)
+	}
 	if err := ddb.vm.DB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit vm's DB: %w", err)
 	}
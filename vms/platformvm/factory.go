@@ -33,6 +33,7 @@ type Factory struct {
 	MaxStakeDuration   time.Duration // Max time allowed for validating
 	StakeMintingPeriod time.Duration // Staking consumption period
 	ApricotPhase0Time  time.Time     // Time of the Phase 0 upgrade
+	CacheBytes         int           // If positive, byte budget of the decided blocks cache
 }
 
 // New returns a new instance of the Platform Chain
@@ -52,5 +53,6 @@ func (f *Factory) New(*snow.Context) (interface{}, error) {
 		maxStakeDuration:   f.MaxStakeDuration,
 		stakeMintingPeriod: f.StakeMintingPeriod,
 		apricotPhase0Time:  f.ApricotPhase0Time,
+		cacheBytes:         f.CacheBytes,
 	}, nil
 }
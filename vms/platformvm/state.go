@@ -211,6 +211,7 @@ func (vm *VM) addStaker(db database.Database, subnetID ids.ID, tx *rewardTx) err
 	errs.Add(
 		prefixStopDB.Put(stopKey, txBytes),
 		prefixStopDB.Close(),
+		vm.markValidatorsChanged(db, subnetID),
 	)
 	return errs.Err
 }
@@ -258,6 +259,7 @@ func (vm *VM) removeStaker(db database.Database, subnetID ids.ID, tx *rewardTx)
 	errs.Add(
 		prefixStopDB.Delete(stopKey),
 		prefixStopDB.Close(),
+		vm.markValidatorsChanged(db, subnetID),
 	)
 	return errs.Err
 }
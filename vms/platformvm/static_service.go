@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"sort"
 
+	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -403,3 +404,14 @@ func (xa innerSortAPIUTXO) Len() int      { return len(xa) }
 func (xa innerSortAPIUTXO) Swap(i, j int) { xa[j], xa[i] = xa[i], xa[j] }
 
 func sortAPIUTXOs(a []APIUTXO) { sort.Sort(innerSortAPIUTXO(a)) }
+
+// GetCodecSchema returns a description of the wire format of the blocks,
+// transactions and UTXOs of the Platform Chain
+func (ss *StaticService) GetCodecSchema(_ *http.Request, _ *struct{}, reply *codec.Schema) error {
+	schema, err := Codec.Schema(Tx{}, avax.UTXO{})
+	if err != nil {
+		return fmt.Errorf("couldn't describe codec: %w", err)
+	}
+	*reply = *schema
+	return nil
+}
@@ -6,6 +6,7 @@ package platformvm
 import (
 	"testing"
 
+	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -254,3 +255,26 @@ func TestBuildGenesisReturnsSortedValidators(t *testing.T) {
 		t.Fatal("Validators should contain 3 validators")
 	}
 }
+
+func TestGetCodecSchema(t *testing.T) {
+	ss := CreateStaticService()
+	schema := codec.Schema{}
+	if err := ss.GetCodecSchema(nil, nil, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if len(schema.Codecs) != 1 || schema.Codecs[0].Version != codecVersion {
+		t.Fatalf("expected codec version %d but got %+v", codecVersion, schema.Codecs)
+	}
+	codecSchema := schema.Codecs[0]
+	if first := codecSchema.Types[0]; first.Name != "platformvm.ProposalBlock" || first.TypeID[0] != 0 {
+		t.Fatalf("expected type ID 0 to be platformvm.ProposalBlock but got %+v", first)
+	}
+	for _, name := range []string{"platformvm.Tx", "platformvm.UnsignedAddValidatorTx", "avax.UTXO"} {
+		if _, ok := codecSchema.Structs[name]; !ok {
+			t.Fatalf("%s should have been described", name)
+		}
+	}
+	if implementations := codecSchema.Interfaces["platformvm.UnsignedTx"]; len(implementations) == 0 {
+		t.Fatal("transaction types should have been described")
+	}
+}
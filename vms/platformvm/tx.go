@@ -69,8 +69,10 @@ type Tx struct {
 	// The body of this transaction
 	UnsignedTx `serialize:"true" json:"unsignedTx"`
 
-	// The credentials of this transaction
-	Creds []verify.Verifiable `serialize:"true" json:"credentials"`
+	// The credentials of this transaction. A transaction has at most one
+	// credential per input, so the number of credentials is bounded by the
+	// maximum transaction size.
+	Creds []verify.Verifiable `serialize:"true" len:"4096" json:"credentials"`
 }
 
 // Sign this transaction with the provided signers
@@ -0,0 +1,170 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// Transactions that claim more credentials than their bytes could hold must be
+// rejected before the credentials are allocated
+func TestTxMissingCredentials(t *testing.T) {
+	tx := &Tx{UnsignedTx: &UnsignedAdvanceTimeTx{Time: 1}}
+	b, err := Codec.Marshal(codecVersion, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The number of credentials is the last field of the transaction
+	for _, numCreds := range []uint32{1, math.MaxUint32} {
+		binary.BigEndian.PutUint32(b[len(b)-4:], numCreds)
+		parsedTx := Tx{}
+		if _, err := Codec.Unmarshal(b, &parsedTx); err == nil {
+			t.Fatalf("Should have errored due to %d missing credentials", numCreds)
+		}
+	}
+}
+
+// addElement returns a copy of [b] in which the slice whose length is stored at
+// [lenOffset] holds one more element. The element's [elem] bytes are inserted
+// at [elemOffset].
+func addElement(b []byte, lenOffset int, elemOffset int, elem []byte) []byte {
+	modified := make([]byte, 0, len(b)+len(elem))
+	modified = append(modified, b[:elemOffset]...)
+	modified = append(modified, elem...)
+	modified = append(modified, b[elemOffset:]...)
+	numElems := binary.BigEndian.Uint32(modified[lenOffset:])
+	binary.BigEndian.PutUint32(modified[lenOffset:], numElems+1)
+	return modified
+}
+
+// Blocks with transactions that hold more credentials or signatures than
+// allowed must be rejected when they are parsed
+func TestParseBlockTooManyCredentials(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	tx := &Tx{UnsignedTx: &UnsignedAdvanceTimeTx{Time: 1}}
+	for i := 0; i < 4097; i++ {
+		tx.Creds = append(tx.Creds, &secp256k1fx.Credential{})
+	}
+	if _, err := vm.codec.Marshal(codecVersion, tx); err == nil {
+		t.Fatalf("Should have errored due to too many credentials")
+	}
+
+	// The credentials end the block
+	tx.Creds = tx.Creds[:4096]
+	blk, err := vm.newStandardBlock(vm.Preferred(), 1, []*Tx{tx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := blk.Bytes()
+	if _, err := vm.ParseBlock(b); err != nil {
+		t.Fatal(err)
+	}
+
+	// An empty credential is its type ID followed by its number of signatures
+	credLen := 2 * wrappers.IntLen
+	tooMany := addElement(b, len(b)-4096*credLen-4, len(b), b[len(b)-credLen:])
+	if _, err := vm.ParseBlock(tooMany); err == nil {
+		t.Fatalf("Should have errored due to too many credentials")
+	}
+}
+
+func TestParseBlockTooManySignatures(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	tx := &Tx{
+		UnsignedTx: &UnsignedAdvanceTimeTx{Time: 1},
+		Creds: []verify.Verifiable{&secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, 257),
+		}},
+	}
+	if _, err := vm.codec.Marshal(codecVersion, tx); err == nil {
+		t.Fatalf("Should have errored due to too many signatures")
+	}
+
+	// The signatures end the block
+	tx.Creds = []verify.Verifiable{&secp256k1fx.Credential{
+		Sigs: make([][crypto.SECP256K1RSigLen]byte, 256),
+	}}
+	blk, err := vm.newStandardBlock(vm.Preferred(), 1, []*Tx{tx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := blk.Bytes()
+	if _, err := vm.ParseBlock(b); err != nil {
+		t.Fatal(err)
+	}
+
+	sigsStart := len(b) - 256*crypto.SECP256K1RSigLen
+	tooMany := addElement(b, sigsStart-4, len(b), make([]byte, crypto.SECP256K1RSigLen))
+	if _, err := vm.ParseBlock(tooMany); err == nil {
+		t.Fatalf("Should have errored due to too many signatures")
+	}
+}
+
+func TestParseBlockSubnetAuthTooManySignatures(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	tx := &Tx{UnsignedTx: &UnsignedCreateChainTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    testNetworkID,
+			BlockchainID: ids.Empty,
+		}},
+		ChainName:  "chain",
+		SubnetAuth: &secp256k1fx.Input{SigIndices: make([]uint32, 257)},
+	}}
+	if _, err := vm.codec.Marshal(codecVersion, tx); err == nil {
+		t.Fatalf("Should have errored due to too many signatures")
+	}
+
+	// The subnet authorization ends the unsigned transaction, so its
+	// signatures are followed only by the number of credentials
+	tx.UnsignedTx.(*UnsignedCreateChainTx).SubnetAuth = &secp256k1fx.Input{
+		SigIndices: make([]uint32, 256),
+	}
+	blk, err := vm.newStandardBlock(vm.Preferred(), 1, []*Tx{tx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := blk.Bytes()
+	if _, err := vm.ParseBlock(b); err != nil {
+		t.Fatal(err)
+	}
+
+	sigsEnd := len(b) - 4
+	tooMany := addElement(b, sigsEnd-256*4-4, sigsEnd, make([]byte, 4))
+	if _, err := vm.ParseBlock(tooMany); err == nil {
+		t.Fatalf("Should have errored due to too many signatures")
+	}
+}
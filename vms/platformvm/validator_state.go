@@ -0,0 +1,324 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// This file contains methods of VM that keep the history of the validator
+// sets, so that other chains can look up the validators of a subnet at a
+// P-chain height.
+//
+// The current weight of each validator is stored per subnet. Whenever a block
+// that changes a subnet's validators is accepted, the weights the changed
+// validators had before the block are stored under the block's height. The
+// validator set at a height is the current one, with the weights stored
+// under every later height restored.
+
+const (
+	validatorWeightsDBPrefix  = "validatorWeights"
+	validatorDiffsDBPrefix    = "validatorDiffs"
+	changedValidatorsDBPrefix = "changedValidators"
+)
+
+var (
+	errValidatorHistoryUnavailable = errors.New("validator sets below the height the history starts at are unknown")
+	errFutureHeight                = errors.New("height is above the last accepted block's")
+
+	validatorHistoryStartKey = []byte("validatorHistoryStart")
+
+	_ validators.State = &VM{}
+)
+
+// GetCurrentHeight implements the validators.State interface
+func (vm *VM) GetCurrentHeight() (uint64, error) {
+	lastAccepted, err := vm.getBlock(vm.LastAcceptedID)
+	if err != nil {
+		return 0, err
+	}
+	return lastAccepted.Height(), nil
+}
+
+// GetValidatorSet implements the validators.State interface
+func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.ShortID]uint64, error) {
+	startBytes, err := vm.DB.Get(validatorHistoryStartKey)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the height the validator history starts at: %w", err)
+	}
+	start, err := parseUint64(startBytes)
+	if err != nil {
+		return nil, err
+	}
+	if height < start {
+		return nil, fmt.Errorf("%w: %d is below %d", errValidatorHistoryUnavailable, height, start)
+	}
+	currentHeight, err := vm.GetCurrentHeight()
+	if err != nil {
+		return nil, err
+	}
+	if height > currentHeight {
+		return nil, fmt.Errorf("%w: %d is above %d", errFutureHeight, height, currentHeight)
+	}
+
+	weights, err := vm.getValidatorWeights(vm.DB, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	diffDB := prefixdb.NewNested([]byte(fmt.Sprintf("%s%s", subnetID, validatorDiffsDBPrefix)), vm.DB)
+	defer diffDB.Close()
+	diffIter := diffDB.NewIterator()
+	defer diffIter.Release()
+
+	for diffIter.Next() { // Iterates in order of decreasing height
+		key := diffIter.Key()
+		if len(key) != wrappers.LongLen+hashing.AddrLen {
+			return nil, fmt.Errorf("validator diff key has length %d", len(key))
+		}
+		if diffHeight := ^binary.BigEndian.Uint64(key); diffHeight <= height {
+			break
+		}
+		nodeID, err := ids.ToShortID(key[wrappers.LongLen:])
+		if err != nil {
+			return nil, err
+		}
+		weight, err := parseUint64(diffIter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if weight == 0 {
+			delete(weights, nodeID)
+		} else {
+			weights[nodeID] = weight
+		}
+	}
+	return weights, diffIter.Error()
+}
+
+// initValidatorHistory starts keeping the history of the validator sets at the
+// last accepted block, unless it's already kept. Nodes whose database was
+// created before the history was kept don't know the validator sets below
+// the height it starts at.
+func (vm *VM) initValidatorHistory() error {
+	if started, err := vm.DB.Has(validatorHistoryStartKey); err != nil || started {
+		return err
+	}
+	height, err := vm.GetCurrentHeight()
+	if err != nil {
+		return err
+	}
+
+	subnets, err := vm.getSubnets(vm.DB)
+	if err != nil {
+		return err
+	}
+	if err := vm.markValidatorsChanged(vm.DB, constants.PrimaryNetworkID); err != nil {
+		return err
+	}
+	for _, subnet := range subnets {
+		if err := vm.markValidatorsChanged(vm.DB, subnet.ID()); err != nil {
+			return err
+		}
+	}
+	if err := vm.writeValidatorDiffs(vm.DB, height); err != nil {
+		return err
+	}
+
+	if err := vm.DB.Put(validatorHistoryStartKey, packUint64(height)); err != nil {
+		return err
+	}
+	vm.Ctx.Log.Info("keeping the history of the validator sets from height %d", height)
+	return vm.DB.Commit()
+}
+
+// markValidatorsChanged records in [db] that the validators of [subnetID]
+// changed since the last accepted block
+func (vm *VM) markValidatorsChanged(db database.Database, subnetID ids.ID) error {
+	changedDB := prefixdb.NewNested([]byte(changedValidatorsDBPrefix), db)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		changedDB.Put(subnetID[:], nil),
+		changedDB.Close(),
+	)
+	return errs.Err
+}
+
+// writeValidatorDiffs stores, under [height], the weights the validators of
+// the subnets that changed since the last accepted block had before the
+// change, and updates their current weights. It's called with the state of
+// the chain once the block at [height] is accepted.
+func (vm *VM) writeValidatorDiffs(db database.Database, height uint64) error {
+	changedDB := prefixdb.NewNested([]byte(changedValidatorsDBPrefix), db)
+	defer changedDB.Close()
+
+	changedIter := changedDB.NewIterator()
+	subnetIDs := []ids.ID(nil)
+	for changedIter.Next() {
+		subnetID, err := ids.ToID(changedIter.Key())
+		if err != nil {
+			changedIter.Release()
+			return err
+		}
+		subnetIDs = append(subnetIDs, subnetID)
+	}
+	err := changedIter.Error()
+	changedIter.Release()
+	if err != nil {
+		return err
+	}
+
+	for _, subnetID := range subnetIDs {
+		if err := vm.writeSubnetValidatorDiffs(db, subnetID, height); err != nil {
+			return err
+		}
+		if err := changedDB.Delete(subnetID[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSubnetValidatorDiffs stores, under [height], the weights the validators
+// of [subnetID] had before they changed to their weights in [db]
+func (vm *VM) writeSubnetValidatorDiffs(db database.Database, subnetID ids.ID, height uint64) error {
+	oldWeights, err := vm.getValidatorWeights(db, subnetID)
+	if err != nil {
+		return err
+	}
+	newWeights, err := vm.getCurrentWeights(db, subnetID)
+	if err != nil {
+		return err
+	}
+
+	weightsDB := prefixdb.NewNested([]byte(fmt.Sprintf("%s%s", subnetID, validatorWeightsDBPrefix)), db)
+	defer weightsDB.Close()
+	diffDB := prefixdb.NewNested([]byte(fmt.Sprintf("%s%s", subnetID, validatorDiffsDBPrefix)), db)
+	defer diffDB.Close()
+
+	writeDiff := func(nodeID ids.ShortID, oldWeight, newWeight uint64) error {
+		if oldWeight == newWeight {
+			return nil
+		}
+		// Heights are inverted so that the latest diffs are iterated over first
+		p := wrappers.Packer{MaxSize: wrappers.LongLen + hashing.AddrLen}
+		p.PackLong(^height)
+		p.PackFixedBytes(nodeID[:])
+		if p.Err != nil {
+			return fmt.Errorf("couldn't serialize validator diff key: %w", p.Err)
+		}
+		if err := diffDB.Put(p.Bytes, packUint64(oldWeight)); err != nil {
+			return err
+		}
+		if newWeight == 0 {
+			return weightsDB.Delete(nodeID[:])
+		}
+		return weightsDB.Put(nodeID[:], packUint64(newWeight))
+	}
+	for nodeID, newWeight := range newWeights {
+		if err := writeDiff(nodeID, oldWeights[nodeID], newWeight); err != nil {
+			return err
+		}
+	}
+	for nodeID, oldWeight := range oldWeights {
+		if _, ok := newWeights[nodeID]; !ok {
+			if err := writeDiff(nodeID, oldWeight, 0); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getValidatorWeights returns the weights of the validators of [subnetID] in
+// the validator history
+func (vm *VM) getValidatorWeights(db database.Database, subnetID ids.ID) (map[ids.ShortID]uint64, error) {
+	weightsDB := prefixdb.NewNested([]byte(fmt.Sprintf("%s%s", subnetID, validatorWeightsDBPrefix)), db)
+	defer weightsDB.Close()
+	weightsIter := weightsDB.NewIterator()
+	defer weightsIter.Release()
+
+	weights := make(map[ids.ShortID]uint64)
+	for weightsIter.Next() {
+		nodeID, err := ids.ToShortID(weightsIter.Key())
+		if err != nil {
+			return nil, err
+		}
+		weight, err := parseUint64(weightsIter.Value())
+		if err != nil {
+			return nil, err
+		}
+		weights[nodeID] = weight
+	}
+	return weights, weightsIter.Error()
+}
+
+// getCurrentWeights returns the weights of the current stakers of [subnetID].
+// A validator's weight includes the stake delegated to it.
+func (vm *VM) getCurrentWeights(db database.Database, subnetID ids.ID) (map[ids.ShortID]uint64, error) {
+	stopPrefix := []byte(fmt.Sprintf("%s%s", subnetID, stopDBPrefix))
+	stopDB := prefixdb.NewNested(stopPrefix, db)
+	defer stopDB.Close()
+	stopIter := stopDB.NewIterator()
+	defer stopIter.Release()
+
+	weights := make(map[ids.ShortID]uint64)
+	for stopIter.Next() { // Iterates in order of increasing stop time
+		txBytes := stopIter.Value()
+
+		tx := rewardTx{}
+		if _, err := vm.codec.Unmarshal(txBytes, &tx); err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal validator tx: %w", err)
+		}
+
+		var (
+			nodeID ids.ShortID
+			weight uint64
+		)
+		switch staker := tx.Tx.UnsignedTx.(type) {
+		case *UnsignedAddDelegatorTx:
+			nodeID, weight = staker.Validator.NodeID, staker.Validator.Weight()
+		case *UnsignedAddValidatorTx:
+			nodeID, weight = staker.Validator.NodeID, staker.Validator.Weight()
+		case *UnsignedAddSubnetValidatorTx:
+			nodeID, weight = staker.Validator.NodeID, staker.Validator.Weight()
+		default:
+			return nil, fmt.Errorf("expected validator but got %T", tx.Tx.UnsignedTx)
+		}
+		newWeight, err := safemath.Add64(weights[nodeID], weight)
+		if err != nil {
+			return nil, err
+		}
+		weights[nodeID] = newWeight
+	}
+	return weights, stopIter.Error()
+}
+
+// packUint64 returns the big endian representation of [n]
+func packUint64(n uint64) []byte {
+	b := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
+
+// parseUint64 parses the big endian representation of a uint64
+func parseUint64(b []byte) (uint64, error) {
+	if len(b) != wrappers.LongLen {
+		return 0, fmt.Errorf("expected %d bytes but got %d", wrappers.LongLen, len(b))
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
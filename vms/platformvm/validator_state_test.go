@@ -0,0 +1,108 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// acceptProposal builds a proposal block, accepts it and its commit option,
+// and prefers the commit option
+func acceptProposal(t *testing.T, vm *VM) {
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	block := blk.(*ProposalBlock)
+	options, err := block.Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, ok := options[0].(*Commit)
+	if !ok {
+		t.Fatal(errShouldPrefCommit)
+	}
+	if err := block.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if err := commit.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := commit.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.SetPreference(commit.ID()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetValidatorSet(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	genesisVdrs, err := vm.GetValidatorSet(0, constants.PrimaryNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(genesisVdrs) != len(keys) {
+		t.Fatalf("expected %d genesis validators, got %d", len(keys), len(genesisVdrs))
+	}
+	for _, key := range keys {
+		if weight := genesisVdrs[key.PublicKey().Address()]; weight != defaultWeight {
+			t.Fatalf("expected genesis validator to have weight %d, got %d", defaultWeight, weight)
+		}
+	}
+
+	// Advance time to when the genesis validators leave, and remove one of them
+	vm.clock.Set(defaultValidateEndTime)
+	acceptProposal(t, vm)
+	acceptProposal(t, vm)
+
+	height, err := vm.GetCurrentHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 4 {
+		t.Fatalf("expected height 4, got %d", height)
+	}
+	currentVdrs, err := vm.GetValidatorSet(height, constants.PrimaryNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(currentVdrs) != len(keys)-1 {
+		t.Fatalf("expected %d validators, got %d", len(keys)-1, len(currentVdrs))
+	}
+
+	// The validator sets of earlier heights don't change
+	for h := uint64(0); h < height; h++ {
+		vdrs, err := vm.GetValidatorSet(h, constants.PrimaryNetworkID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(vdrs) != len(genesisVdrs) {
+			t.Fatalf("expected %d validators at height %d, got %d", len(genesisVdrs), h, len(vdrs))
+		}
+		for nodeID, weight := range genesisVdrs {
+			if vdrs[nodeID] != weight {
+				t.Fatalf("validator %s had weight %d at height %d, but got %d", nodeID, weight, h, vdrs[nodeID])
+			}
+		}
+	}
+
+	if _, err := vm.GetValidatorSet(height+1, constants.PrimaryNetworkID); !errors.Is(err, errFutureHeight) {
+		t.Fatalf("expected %s, got %v", errFutureHeight, err)
+	}
+}
@@ -0,0 +1,132 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/core"
+)
+
+// VerifyState checks that the persisted state of a platform chain decodes with
+// this VM's codecs. [db] must be the database the VM was initialized with.
+// Every accepted block is decoded, from the last accepted block down to
+// genesis, along with the chain's singletons, staker sets and uptimes.
+//
+// VerifyState only reads from [db]. It returns the number of values that were
+// decoded, keyed by kind, or the first value that failed to decode.
+func VerifyState(db database.Database) (map[string]int, error) {
+	vm := &VM{SnowmanVM: &core.SnowmanVM{
+		Ctx: &snow.Context{Log: logging.NoLog{}},
+	}}
+	vm.codec = Codec
+	state, err := core.NewSnowmanState(vm.unmarshalBlockFunc)
+	if err != nil {
+		return nil, err
+	}
+	vm.State = state
+	vm.registerDBTypes()
+
+	counts := make(map[string]int)
+
+	if _, err := vm.getTimestamp(db); err != nil {
+		return counts, fmt.Errorf("couldn't decode timestamp: %w", err)
+	}
+	if _, err := vm.getCurrentSupply(db); err != nil {
+		return counts, fmt.Errorf("couldn't decode current supply: %w", err)
+	}
+	chains, err := vm.getChains(db)
+	if err != nil {
+		return counts, fmt.Errorf("couldn't decode chains: %w", err)
+	}
+	counts["chains"] = len(chains)
+	subnets, err := vm.getSubnets(db)
+	if err != nil {
+		return counts, fmt.Errorf("couldn't decode subnets: %w", err)
+	}
+	counts["subnets"] = len(subnets)
+
+	blkID, err := vm.State.GetLastAccepted(db)
+	if err != nil {
+		return counts, fmt.Errorf("couldn't decode last accepted block ID: %w", err)
+	}
+	for {
+		blk, err := vm.State.GetBlock(db, blkID)
+		if err != nil {
+			return counts, fmt.Errorf("couldn't decode block %s: %w", blkID, err)
+		}
+		counts["blocks"]++
+		if blk.Height() == 0 {
+			break
+		}
+		parent, ok := blk.(interface{ ParentID() ids.ID })
+		if !ok {
+			return counts, fmt.Errorf("block %s has unexpected type %T", blkID, blk)
+		}
+		blkID = parent.ParentID()
+	}
+
+	subnetIDs := []ids.ID{constants.PrimaryNetworkID}
+	for _, subnet := range subnets {
+		subnetIDs = append(subnetIDs, subnet.ID())
+	}
+	for _, subnetID := range subnetIDs {
+		n, err := verifyValues(prefixdb.NewNested([]byte(fmt.Sprintf("%s%s", subnetID, startDBPrefix)), db), func(b []byte) error {
+			tx := Tx{}
+			if _, err := Codec.Unmarshal(b, &tx); err != nil {
+				return err
+			}
+			return tx.Sign(vm.codec, nil)
+		})
+		counts["pending stakers"] += n
+		if err != nil {
+			return counts, fmt.Errorf("couldn't decode pending staker of subnet %s: %w", subnetID, err)
+		}
+
+		n, err = verifyValues(prefixdb.NewNested([]byte(fmt.Sprintf("%s%s", subnetID, stopDBPrefix)), db), func(b []byte) error {
+			tx := rewardTx{}
+			if _, err := Codec.Unmarshal(b, &tx); err != nil {
+				return err
+			}
+			return tx.Tx.Sign(vm.codec, nil)
+		})
+		counts["current stakers"] += n
+		if err != nil {
+			return counts, fmt.Errorf("couldn't decode current staker of subnet %s: %w", subnetID, err)
+		}
+	}
+
+	n, err := verifyValues(prefixdb.NewNested([]byte(uptimeDBPrefix), db), func(b []byte) error {
+		uptime := validatorUptime{}
+		_, err := Codec.Unmarshal(b, &uptime)
+		return err
+	})
+	counts["uptimes"] = n
+	if err != nil {
+		return counts, fmt.Errorf("couldn't decode uptime: %w", err)
+	}
+	return counts, nil
+}
+
+// verifyValues calls [verify] on every value in [db] and returns the number of
+// values that were verified.
+func verifyValues(db database.Database, verify func([]byte) error) (int, error) {
+	iter := db.NewIterator()
+	defer iter.Release()
+
+	n := 0
+	for iter.Next() {
+		if err := verify(iter.Value()); err != nil {
+			return n, fmt.Errorf("key 0x%x: %w", iter.Key(), err)
+		}
+		n++
+	}
+	return n, iter.Error()
+}
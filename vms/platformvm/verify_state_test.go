@@ -0,0 +1,58 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/vms/components/state"
+)
+
+func TestVerifyState(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	counts, err := VerifyState(vm.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The genesis block and the block that created [testSubnet1]
+	if counts["blocks"] != 2 {
+		t.Fatalf("expected to decode 2 blocks but decoded %d", counts["blocks"])
+	}
+	if counts["subnets"] != 1 {
+		t.Fatalf("expected to decode 1 subnet but decoded %d", counts["subnets"])
+	}
+	if counts["chains"] != 0 {
+		t.Fatalf("expected to decode 0 chains but decoded %d", counts["chains"])
+	}
+	if counts["current stakers"] != len(keys) {
+		t.Fatalf("expected to decode %d current stakers but decoded %d", len(keys), counts["current stakers"])
+	}
+}
+
+func TestVerifyStateCorruptedBlock(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	blkKey := vm.LastAcceptedID.Prefix(state.BlockTypeID)
+	if err := vm.DB.Put(blkKey[:], []byte{0, 0, 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyState(vm.DB); err == nil {
+		t.Fatal("should have failed to decode the corrupted block")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
@@ -55,6 +56,11 @@ const (
 	PercentDenominator = 1000000
 
 	droppedTxCacheSize = 50
+	decidedCacheSize   = 500
+
+	// blockCacheOverhead is the size charged for every block in a byte
+	// budgeted cache, in addition to the block's bytes
+	blockCacheOverhead = 128
 
 	maxUTXOsToFetch = 1024
 
@@ -162,6 +168,13 @@ type VM struct {
 	// Value: String repr. of the verification error
 	droppedTxCache cache.LRU
 
+	// Key: Block ID
+	// Value: Decided block with that ID
+	decidedBlocks cache.Cacher
+	// If positive, [decidedBlocks] is limited to this many bytes rather than
+	// to a number of blocks
+	cacheBytes int
+
 	// Bootstrapped remembers if this chain has finished bootstrapping or not
 	bootstrapped bool
 
@@ -200,6 +213,24 @@ func (vm *VM) Initialize(
 	}
 
 	vm.droppedTxCache = cache.LRU{Size: droppedTxCacheSize}
+	var decidedBlocks cache.Cacher = &cache.LRU{Size: decidedCacheSize}
+	if vm.cacheBytes > 0 {
+		sizedCache, err := metercacher.New(
+			fmt.Sprintf("%s_decided_cache", ctx.Namespace),
+			ctx.Metrics,
+			&cache.SizedLRU{
+				MaxSize: vm.cacheBytes,
+				SizeFunc: func(blkIntf interface{}) int {
+					return blockCacheOverhead + len(blkIntf.(Block).Bytes())
+				},
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't initialize decided blocks cache: %w", err)
+		}
+		decidedBlocks = sizedCache
+	}
+	vm.decidedBlocks = decidedBlocks
 	vm.connections = make(map[ids.ShortID]time.Time)
 
 	// Register this VM's types with the database so we can get/put structs to/from it
@@ -314,6 +345,10 @@ func (vm *VM) Initialize(
 
 	vm.currentBlocks = make(map[ids.ID]Block)
 
+	if err := vm.initValidatorHistory(); err != nil {
+		return fmt.Errorf("couldn't start keeping the validator history: %w", err)
+	}
+
 	if err := vm.initSubnets(); err != nil {
 		ctx.Log.Error("failed to initialize Subnets: %s", err)
 		return err
@@ -599,12 +634,19 @@ func (vm *VM) getBlock(blkID ids.ID) (Block, error) {
 	if blk, exists := vm.currentBlocks[blkID]; exists {
 		return blk, nil
 	}
+	// Decided blocks don't change, so they may be cached
+	if blk, cached := vm.decidedBlocks.Get(blkID); cached {
+		return blk.(Block), nil
+	}
 	// Block isn't in memory. If block is in database, return it.
 	blkInterface, err := vm.State.GetBlock(vm.DB, blkID)
 	if err != nil {
 		return nil, err
 	}
 	if block, ok := blkInterface.(Block); ok {
+		if block.Status().Decided() {
+			vm.decidedBlocks.Put(blkID, block)
+		}
 		return block, nil
 	}
 	return nil, errors.New("block not found")
@@ -972,48 +1014,18 @@ func (vm *VM) updateVdrMgr(force bool) error {
 }
 
 func (vm *VM) updateVdrSet(subnetID ids.ID) error {
-	vdrs := validators.NewSet()
-
-	stopPrefix := []byte(fmt.Sprintf("%s%s", subnetID, stopDBPrefix))
-	stopDB := prefixdb.NewNested(stopPrefix, vm.DB)
-	defer stopDB.Close()
-	stopIter := stopDB.NewIterator()
-	defer stopIter.Release()
-
-	for stopIter.Next() { // Iterates in order of increasing stop time
-		txBytes := stopIter.Value()
-
-		tx := rewardTx{}
-		if _, err := vm.codec.Unmarshal(txBytes, &tx); err != nil {
-			return fmt.Errorf("couldn't unmarshal validator tx: %w", err)
-		}
-		if err := tx.Tx.Sign(vm.codec, nil); err != nil {
-			return err
-		}
+	weights, err := vm.getCurrentWeights(vm.DB, subnetID)
+	if err != nil {
+		return err
+	}
 
-		var err error
-		switch staker := tx.Tx.UnsignedTx.(type) {
-		case *UnsignedAddDelegatorTx:
-			err = vdrs.AddWeight(staker.Validator.NodeID, staker.Validator.Weight())
-		case *UnsignedAddValidatorTx:
-			err = vdrs.AddWeight(staker.Validator.NodeID, staker.Validator.Weight())
-		case *UnsignedAddSubnetValidatorTx:
-			err = vdrs.AddWeight(staker.Validator.NodeID, staker.Validator.Weight())
-		default:
-			err = fmt.Errorf("expected validator but got %T", tx.Tx.UnsignedTx)
-		}
-		if err != nil {
+	vdrs := validators.NewSet()
+	for nodeID, weight := range weights {
+		if err := vdrs.AddWeight(nodeID, weight); err != nil {
 			return err
 		}
 	}
-
-	errs := wrappers.Errs{}
-	errs.Add(
-		vm.vdrMgr.Set(subnetID, vdrs),
-		stopIter.Error(),
-		stopDB.Close(),
-	)
-	return errs.Err
+	return vm.vdrMgr.Set(subnetID, vdrs)
 }
 
 // Codec ...
@@ -2150,14 +2150,22 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		TimeoutCoefficient: 1.25,
 		MetricsNamespace:   "",
 		Registerer:         prometheus.NewRegistry(),
-	}, benchlist)
+	}, &timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Millisecond,
+		MinimumTimeout:     time.Millisecond,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutHalflife:    5 * time.Minute,
+		TimeoutCoefficient: 1.25,
+		MetricsNamespace:   "",
+		Registerer:         prometheus.NewRegistry(),
+	}, nil, benchlist)
 	if err != nil {
 		t.Fatal(err)
 	}
 	go timeoutManager.Dispatch()
 
 	chainRouter := &router.ChainRouter{}
-	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &timeoutManager, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
+	err = chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &timeoutManager, time.Hour, time.Second, ids.Set{}, nil, router.HealthConfig{}, router.SchedulerConfig{}, "", prometheus.NewRegistry())
 	assert.NoError(t, err)
 
 	externalSender := &sender.ExternalSenderTest{T: t}
@@ -2729,3 +2737,65 @@ func TestUnverifiedParentPanic(t *testing.T) {
 	// Doesn't matter what verify returns as long as it's not panicking.
 	_ = addSubnetBlk2.Verify()
 }
+
+func TestDecidedBlocksCacheBytes(t *testing.T) {
+	_, genesisBytes := defaultGenesis()
+
+	vm := &VM{
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		minStakeDuration:   defaultMinStakingDuration,
+		maxStakeDuration:   defaultMaxStakingDuration,
+		stakeMintingPeriod: defaultMaxStakingDuration,
+		cacheBytes:         1024,
+	}
+	vm.vdrMgr = validators.NewManager()
+	vm.clock.Set(defaultGenesisTime)
+
+	ctx := defaultContext()
+	registry := prometheus.NewRegistry()
+	ctx.Metrics = registry
+	ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	msgChan := make(chan common.Message, 1)
+	if err := vm.Initialize(ctx, memdb.New(), genesisBytes, msgChan, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The genesis block is decided, so it's cached once it's read
+	lastAcceptedID, err := vm.LastAccepted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk, err := vm.GetBlock(lastAcceptedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cachedBlk, err := vm.GetBlock(lastAcceptedID); err != nil {
+		t.Fatal(err)
+	} else if cachedBlk != blk {
+		t.Fatal("decided block should have been cached")
+	}
+
+	metrics, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, metric := range metrics {
+		if metric.GetName() != fmt.Sprintf("%s_decided_cache_bytes", ctx.Namespace) {
+			continue
+		}
+		expected := float64(blockCacheOverhead + len(blk.Bytes()))
+		if value := metric.GetMetric()[0].GetGauge().GetValue(); value != expected {
+			t.Fatalf("decided blocks cache holds %f bytes but expected %f", value, expected)
+		}
+		return
+	}
+	t.Fatal("missing decided blocks cache bytes metric")
+}
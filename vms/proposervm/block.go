@@ -0,0 +1,280 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/missing"
+)
+
+// maxClockSkew is how far past the local time a block's timestamp may be
+const maxClockSkew = 10 * time.Second
+
+var (
+	errUnsupportedKey           = errors.New("unsupported staking key type")
+	errInnerParentMismatch      = errors.New("inner block's parent isn't the inner block of the block's parent")
+	errTimestampTooEarly        = errors.New("block's timestamp is before its parent's")
+	errTimestampTooLate         = errors.New("block's timestamp is too far in the future")
+	errProposerWindowNotStarted = errors.New("proposer's window hasn't started")
+	errInnerBlockDecided        = errors.New("inner block was already decided")
+	errPChainHeightDecreased    = errors.New("block's P-chain height is below its parent's")
+	errPChainHeightNotReached   = errors.New("block's P-chain height is above the P-chain's current height")
+
+	_ snowman.Block = &postForkBlock{}
+)
+
+// statelessBlock is the serialized form of a block that's wrapped by the VM.
+// It's signed by the node that proposed it.
+type statelessBlock struct {
+	// ID of the parent block. This is the ID of the parent's wrapper, unless
+	// the parent is below the activation height.
+	ParentID ids.ID `serialize:"true"`
+	// Time the block was proposed, in nanoseconds since the Unix epoch
+	Timestamp int64 `serialize:"true"`
+	// Height of the P-chain that the proposers of the block's children are
+	// sampled at. It's never below the parent's.
+	PChainHeight uint64 `serialize:"true"`
+	// DER encoded staking certificate of the proposer
+	Certificate []byte `serialize:"true"`
+	// Bytes of the inner block
+	Block []byte `serialize:"true"`
+	// Signature of the proposer over the other fields
+	Signature []byte `serialize:"true"`
+}
+
+// unsignedBytes returns the bytes that the block's signature is over
+func (b *statelessBlock) unsignedBytes() ([]byte, error) {
+	unsigned := *b
+	unsigned.Signature = nil
+	return c.Marshal(codecVersion, &unsigned)
+}
+
+// sign the block with [key]
+func (b *statelessBlock) sign(key crypto.Signer) error {
+	unsignedBytes, err := b.unsignedBytes()
+	if err != nil {
+		return err
+	}
+	sig, err := key.Sign(rand.Reader, hashing.ComputeHash256(unsignedBytes), crypto.SHA256)
+	if err != nil {
+		return err
+	}
+	b.Signature = sig
+	return nil
+}
+
+// verify that the block was signed with the key of its certificate, and
+// return the NodeID of its proposer
+func (b *statelessBlock) verify() (ids.ShortID, error) {
+	cert, err := x509.ParseCertificate(b.Certificate)
+	if err != nil {
+		return ids.ShortID{}, fmt.Errorf("couldn't parse proposer's certificate: %w", err)
+	}
+	var algorithm x509.SignatureAlgorithm
+	switch cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		algorithm = x509.SHA256WithRSA
+	case *ecdsa.PublicKey:
+		algorithm = x509.ECDSAWithSHA256
+	default:
+		return ids.ShortID{}, fmt.Errorf("%w: %T", errUnsupportedKey, cert.PublicKey)
+	}
+	unsignedBytes, err := b.unsignedBytes()
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	if err := cert.CheckSignature(algorithm, unsignedBytes, b.Signature); err != nil {
+		return ids.ShortID{}, err
+	}
+	return certToID(b.Certificate), nil
+}
+
+// certToID returns the NodeID of the node with the staking certificate
+// [certBytes]
+func certToID(certBytes []byte) ids.ShortID {
+	return ids.ShortID(
+		hashing.ComputeHash160Array(
+			hashing.ComputeHash256(certBytes)))
+}
+
+// postForkBlock is a block at or above the activation height, which wraps a
+// block of the inner VM
+type postForkBlock struct {
+	vm        *VM
+	id        ids.ID
+	bytes     []byte
+	stateless statelessBlock
+	inner     snowman.Block
+	status    choices.Status
+}
+
+// ID implements the snowman.Block interface
+func (b *postForkBlock) ID() ids.ID { return b.id }
+
+// Status implements the snowman.Block interface
+func (b *postForkBlock) Status() choices.Status { return b.status }
+
+// Bytes implements the snowman.Block interface
+func (b *postForkBlock) Bytes() []byte { return b.bytes }
+
+// Height implements the snowman.Block interface
+func (b *postForkBlock) Height() uint64 { return b.inner.Height() }
+
+// Parent implements the snowman.Block interface
+func (b *postForkBlock) Parent() snowman.Block {
+	parent, err := b.vm.getBlock(b.stateless.ParentID)
+	if err != nil {
+		return &missing.Block{BlkID: b.stateless.ParentID}
+	}
+	return parent
+}
+
+// timestamp returns the time the block was proposed
+func (b *postForkBlock) timestamp() time.Time { return time.Unix(0, b.stateless.Timestamp) }
+
+// Verify implements the snowman.Block interface. Once the chain is
+// bootstrapped, blocks proposed before their proposer's window started, or
+// whose P-chain height this node's P-chain hasn't reached, are invalid. While
+// bootstrapping, the accepted blocks fetched from the beacons are trusted, so
+// neither is checked.
+func (b *postForkBlock) Verify() error {
+	parent, err := b.vm.getBlock(b.stateless.ParentID)
+	if err != nil {
+		return fmt.Errorf("couldn't get parent %s: %w", b.stateless.ParentID, err)
+	}
+	innerID := b.inner.ID()
+	if b.inner.Parent().ID() != innerBlockID(parent) {
+		return errInnerParentMismatch
+	}
+	if b.inner.Status().Decided() {
+		return errInnerBlockDecided
+	}
+
+	timestamp := b.timestamp()
+	parentTimestamp := timestampOf(parent)
+	if timestamp.Before(parentTimestamp) {
+		return errTimestampTooEarly
+	}
+	if maxTimestamp := b.vm.clock.Time().Add(maxClockSkew); timestamp.After(maxTimestamp) {
+		return fmt.Errorf("%w: %s is after %s", errTimestampTooLate, timestamp, maxTimestamp)
+	}
+	if pChainHeight := b.stateless.PChainHeight; pChainHeight < pChainHeightOf(parent) {
+		return fmt.Errorf("%w: %d is below %d", errPChainHeightDecreased, pChainHeight, pChainHeightOf(parent))
+	}
+	proposer, err := b.stateless.verify()
+	if err != nil {
+		return fmt.Errorf("invalid proposer signature: %w", err)
+	}
+	if b.vm.bootstrapped {
+		currentPChainHeight, err := b.vm.state.GetCurrentHeight()
+		if err != nil {
+			return fmt.Errorf("couldn't get the P-chain's height: %w", err)
+		}
+		if pChainHeight := b.stateless.PChainHeight; pChainHeight > currentPChainHeight {
+			return fmt.Errorf("%w: %d is above %d", errPChainHeightNotReached, pChainHeight, currentPChainHeight)
+		}
+		windowStart, err := b.vm.windowStart(parent, proposer)
+		if err != nil {
+			return fmt.Errorf("couldn't get the proposer's window: %w", err)
+		}
+		if timestamp.Before(windowStart) {
+			return fmt.Errorf("%w: %s may propose from %s, but the block's timestamp is %s",
+				errProposerWindowNotStarted,
+				proposer.PrefixedString(constants.NodeIDPrefix),
+				windowStart,
+				timestamp,
+			)
+		}
+	}
+
+	// The inner block only needs to be verified once, no matter how many
+	// blocks wrap it, and all of them use the instance that was verified
+	if inner, ok := b.vm.verifiedInner[innerID]; ok {
+		b.inner = inner.Block
+		inner.wrappers.Add(b.id)
+	} else {
+		if err := b.inner.Verify(); err != nil {
+			return err
+		}
+		wrappers := ids.Set{}
+		wrappers.Add(b.id)
+		b.vm.verifiedInner[innerID] = &innerBlock{
+			Block:    b.inner,
+			wrappers: wrappers,
+		}
+	}
+	b.vm.verified[b.id] = b
+	return nil
+}
+
+// Accept implements the snowman.Block interface. The other blocks that wrap
+// the same inner block conflict with this block, so they'll be rejected, but
+// their inner block stays accepted. This block is stored before its inner
+// block is accepted, so that the VM can undo it on restart if the inner block
+// wasn't.
+func (b *postForkBlock) Accept() error {
+	b.status = choices.Accepted
+	delete(b.vm.verified, b.id)
+	delete(b.vm.verifiedInner, b.inner.ID())
+	if err := b.vm.accept(b); err != nil {
+		return err
+	}
+	return b.inner.Accept()
+}
+
+// Reject implements the snowman.Block interface. The inner block is only
+// rejected once every block that wraps it is.
+func (b *postForkBlock) Reject() error {
+	b.status = choices.Rejected
+	if err := b.vm.reject(b); err != nil {
+		return err
+	}
+	inner, ok := b.vm.removeVerified(b)
+	if !ok {
+		// The inner block is wrapped by another verified block, or this block
+		// was never verified
+		return nil
+	}
+	return inner.Reject()
+}
+
+// innerBlockID returns the ID of the inner block of [blk], which is [blk]
+// itself if it's below the activation height
+func innerBlockID(blk snowman.Block) ids.ID {
+	if postFork, ok := blk.(*postForkBlock); ok {
+		return postFork.inner.ID()
+	}
+	return blk.ID()
+}
+
+// timestampOf returns the time [blk] was proposed, or the Unix epoch if it's
+// below the activation height
+func timestampOf(blk snowman.Block) time.Time {
+	if postFork, ok := blk.(*postForkBlock); ok {
+		return postFork.timestamp()
+	}
+	return time.Unix(0, 0)
+}
+
+// pChainHeightOf returns the P-chain height recorded in [blk], or 0 if it's
+// below the activation height
+func pChainHeightOf(blk snowman.Block) uint64 {
+	if postFork, ok := blk.(*postForkBlock); ok {
+		return postFork.stateless.PChainHeight
+	}
+	return 0
+}
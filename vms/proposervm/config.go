@@ -0,0 +1,111 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultNumProposers is the default number of proposers of each height
+	DefaultNumProposers = 6
+
+	// DefaultWindowDuration is the default length of each proposer's window
+	DefaultWindowDuration = 5 * time.Second
+)
+
+var (
+	errNonPositiveProposers = errors.New("number of proposers must be positive")
+	errNonPositiveWindow    = errors.New("window duration must be positive")
+)
+
+// Config defines when blocks may be proposed, and by whom.
+//
+// Each height has an ordered list of [NumProposers] proposers, sampled by
+// stake from the validators of the chain's subnet at the P-chain height
+// recorded in the block's parent. The i-th proposer's window starts
+// [i] * [WindowDuration] after its parent's timestamp. A proposer may only
+// propose a block once its window has started, and once every proposer's
+// window has started, anyone may.
+type Config struct {
+	NumProposers   int
+	WindowDuration time.Duration
+	// Blocks below this height aren't wrapped, so that proposer windows can be
+	// enabled on a chain that already has blocks. The genesis block is never
+	// wrapped. If it's 0, the activation height that was stored when the chain
+	// was first run with proposer windows is used, or 1 if the chain has no
+	// blocks other than its genesis. It must be set to enable proposer
+	// windows on a chain that already has blocks, and can't change once a
+	// wrapped block was accepted.
+	ActivationHeight uint64
+}
+
+// DefaultConfig returns the default proposer window config
+func DefaultConfig() Config {
+	return Config{
+		NumProposers:   DefaultNumProposers,
+		WindowDuration: DefaultWindowDuration,
+	}
+}
+
+// Verify that the config is valid
+func (c Config) Verify() error {
+	switch {
+	case c.NumProposers <= 0:
+		return errNonPositiveProposers
+	case c.WindowDuration <= 0:
+		return errNonPositiveWindow
+	default:
+		return nil
+	}
+}
+
+// maxDelay is the time after a block's parent at which anyone may propose it
+func (c Config) maxDelay() time.Duration {
+	return time.Duration(c.NumProposers) * c.WindowDuration
+}
+
+// config is the JSON representation of a Config. Unset fields keep their
+// default value.
+type config struct {
+	NumProposers     int    `json:"numProposers"`
+	WindowDuration   string `json:"windowDuration"`
+	ActivationHeight uint64 `json:"activationHeight"`
+}
+
+// ParseConfigs parses [configBytes], a JSON object that maps the IDs or
+// aliases of VMs to the fields of their proposer window configs that differ
+// from the default. Durations are strings such as "5s".
+func ParseConfigs(configBytes []byte) (map[string]Config, error) {
+	parsed := map[string]config{}
+	if err := json.Unmarshal(configBytes, &parsed); err != nil {
+		return nil, fmt.Errorf("couldn't parse proposer window configs: %w", err)
+	}
+
+	configs := make(map[string]Config, len(parsed))
+	for vm, overrides := range parsed {
+		vmConfig := DefaultConfig()
+		if overrides.NumProposers != 0 {
+			vmConfig.NumProposers = overrides.NumProposers
+		}
+		if overrides.WindowDuration != "" {
+			windowDuration, err := time.ParseDuration(overrides.WindowDuration)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't parse window duration of %s: %w", vm, err)
+			}
+			vmConfig.WindowDuration = windowDuration
+		}
+		if overrides.ActivationHeight != 0 {
+			vmConfig.ActivationHeight = overrides.ActivationHeight
+		}
+		if err := vmConfig.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid proposer window config of %s: %w", vm, err)
+		}
+		configs[vm] = vmConfig
+	}
+	return configs, nil
+}
@@ -0,0 +1,37 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfigs(t *testing.T) {
+	assert := assert.New(t)
+
+	configs, err := ParseConfigs([]byte(`{"evm":{"windowDuration":"2s","activationHeight":100},"timestampvm":{}}`))
+	assert.NoError(err)
+	assert.Equal(map[string]Config{
+		"evm": {
+			NumProposers:     DefaultNumProposers,
+			WindowDuration:   2 * time.Second,
+			ActivationHeight: 100,
+		},
+		"timestampvm": DefaultConfig(),
+	}, configs)
+
+	configs, err = ParseConfigs([]byte(`{}`))
+	assert.NoError(err)
+	assert.Empty(configs)
+
+	_, err = ParseConfigs([]byte(`{"evm":{"windowDuration":"soon"}}`))
+	assert.Error(err)
+
+	_, err = ParseConfigs([]byte(`{"evm":{"numProposers":-1}}`))
+	assert.True(errors.Is(err, errNonPositiveProposers))
+}
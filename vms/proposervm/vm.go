@@ -0,0 +1,574 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"crypto"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	codecVersion = 0
+
+	// Maximum size of a wrapped block. This leaves room for the largest block
+	// a peer may send.
+	maxBlockSize = 1 << 22
+)
+
+// Prefixes of the database keys of accepted wrapped blocks, of the last
+// accepted wrapped block, of the activation height and of rejected wrapped
+// blocks
+const (
+	blockPrefix byte = iota
+	lastAcceptedPrefix
+	activationHeightPrefix
+	rejectedPrefix
+)
+
+var (
+	errUnwrappedBlock = errors.New("block at or above the activation height isn't wrapped")
+	errWrappedBlock   = errors.New("block below the activation height is wrapped")
+	errNoStakingKey   = errors.New("proposer windows require a staking key and certificate")
+
+	errMissingActivationHeight  = errors.New("activation height must be set to enable proposer windows on a chain that already has blocks")
+	errActivationHeightChanged  = errors.New("activation height can't change once a wrapped block was accepted")
+	errActivationHeightPassed   = errors.New("last accepted block is at or above the activation height but isn't wrapped")
+	errInconsistentLastAccepted = errors.New("last accepted block doesn't wrap the inner VM's last accepted block")
+
+	lastAcceptedKey     = []byte{lastAcceptedPrefix}
+	activationHeightKey = []byte{activationHeightPrefix}
+
+	c codec.Manager
+
+	_ block.ChainVM = &VM{}
+)
+
+func init() {
+	lc := linearcodec.NewDefault()
+	c = codec.NewManager(maxBlockSize)
+	if err := c.RegisterCodec(codecVersion, lc); err != nil {
+		panic(err)
+	}
+}
+
+// VM wraps a block.ChainVM so that each height has a stake-weighted ordered
+// list of proposers, each of which may only propose a block once its window
+// has started. This reduces the number of competing blocks on busy chains.
+//
+// Blocks at or above the activation height wrap a block of the inner VM with
+// the timestamp, staking certificate and signature of their proposer. The
+// inner VM is unaware of the wrapping, so any ChainVM, including a plugin VM,
+// can be wrapped.
+type VM struct {
+	block.ChainVM
+
+	config   Config
+	windower windower
+
+	// Validator sets of the P-chain, and its current height
+	state validators.State
+
+	// Stores the accepted wrapped blocks, and the ID of the last one. The
+	// inner VM keeps its own database.
+	db database.Database
+
+	// Staking key and DER encoded certificate that blocks are signed with, and
+	// the NodeID they belong to
+	key    crypto.Signer
+	cert   []byte
+	nodeID ids.ShortID
+
+	ctx      *snow.Context
+	toEngine chan<- common.Message
+	clock    timer.Clock
+
+	bootstrapped bool
+	preferred    ids.ID
+
+	// ID --> Wrapped block that was verified but not decided
+	verified map[ids.ID]*postForkBlock
+	// ID of an inner block --> The inner block and the verified blocks that
+	// wrap it. Several blocks may wrap the same inner block, such as when a
+	// proposer's block is proposed again by someone else.
+	verifiedInner map[ids.ID]*innerBlock
+
+	// Notifies the engine to build a block once this node's window starts
+	buildTimer *time.Timer
+}
+
+// New returns a VM that wraps [inner] with proposer windows described by
+// [config]. Proposers are sampled from the validator sets in [state]. Wrapped
+// blocks are stored in [db], and blocks that this node proposes are signed
+// with [key], which belongs to the DER encoded certificate [cert].
+func New(
+	inner block.ChainVM,
+	config Config,
+	db database.Database,
+	state validators.State,
+	key crypto.Signer,
+	cert []byte,
+) (*VM, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	if key == nil || len(cert) == 0 {
+		return nil, errNoStakingKey
+	}
+	return &VM{
+		ChainVM: inner,
+		config:  config,
+		windower: windower{
+			state:  state,
+			config: config,
+		},
+		state:         state,
+		db:            db,
+		key:           key,
+		cert:          cert,
+		nodeID:        certToID(cert),
+		verified:      make(map[ids.ID]*postForkBlock),
+		verifiedInner: make(map[ids.ID]*innerBlock),
+	}, nil
+}
+
+// Initialize implements the block.ChainVM interface. [db] is passed to the
+// inner VM unchanged.
+func (vm *VM) Initialize(
+	ctx *snow.Context,
+	db database.Database,
+	genesisBytes []byte,
+	toEngine chan<- common.Message,
+	fxs []*common.Fx,
+) error {
+	vm.ctx = ctx
+	vm.toEngine = toEngine
+	vm.windower.chainID = ctx.ChainID
+	vm.windower.subnetID = ctx.SubnetID
+	if err := vm.ChainVM.Initialize(ctx, db, genesisBytes, toEngine, fxs); err != nil {
+		return err
+	}
+	if err := vm.initActivationHeight(); err != nil {
+		return err
+	}
+	if err := vm.repairLastAccepted(); err != nil {
+		return err
+	}
+	ctx.Log.Info("proposer windows are active from height %d", vm.config.ActivationHeight)
+
+	lastAccepted, err := vm.LastAccepted()
+	if err != nil {
+		return err
+	}
+	vm.preferred = lastAccepted
+	return nil
+}
+
+// Bootstrapping implements the block.ChainVM interface
+func (vm *VM) Bootstrapping() error {
+	vm.bootstrapped = false
+	return vm.ChainVM.Bootstrapping()
+}
+
+// Bootstrapped implements the block.ChainVM interface
+func (vm *VM) Bootstrapped() error {
+	vm.bootstrapped = true
+	return vm.ChainVM.Bootstrapped()
+}
+
+// Shutdown implements the block.ChainVM interface
+func (vm *VM) Shutdown() error {
+	if vm.buildTimer != nil {
+		vm.buildTimer.Stop()
+	}
+	return vm.ChainVM.Shutdown()
+}
+
+// BuildBlock implements the block.ChainVM interface. If this node's window to
+// propose the next block hasn't started, an error is returned and the engine
+// is notified again once it starts.
+func (vm *VM) BuildBlock() (snowman.Block, error) {
+	parent, err := vm.getBlock(vm.preferred)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get preferred block %s: %w", vm.preferred, err)
+	}
+	height := parent.Height() + 1
+	if height < vm.config.ActivationHeight {
+		return vm.ChainVM.BuildBlock()
+	}
+
+	now := vm.clock.Time()
+	parentTimestamp := timestampOf(parent)
+	windowStart, err := vm.windowStart(parent, vm.nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get this node's window: %w", err)
+	}
+	if now.Before(windowStart) {
+		vm.notifyAt(windowStart)
+		return nil, fmt.Errorf("%w: it starts at %s", errProposerWindowNotStarted, windowStart)
+	}
+
+	inner, err := vm.ChainVM.BuildBlock()
+	if err != nil {
+		return nil, err
+	}
+	if inner.Parent().ID() != innerBlockID(parent) {
+		return nil, errInnerParentMismatch
+	}
+	timestamp := now
+	if timestamp.Before(parentTimestamp) {
+		timestamp = parentTimestamp
+	}
+	pChainHeight, err := vm.state.GetCurrentHeight()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the P-chain's height: %w", err)
+	}
+	if parentPChainHeight := pChainHeightOf(parent); pChainHeight < parentPChainHeight {
+		// The P-chain hasn't caught up with the parent, which was accepted
+		// while bootstrapping
+		return nil, fmt.Errorf("%w: the parent's P-chain height %d is above %d",
+			errPChainHeightNotReached, parentPChainHeight, pChainHeight)
+	}
+	stateless := statelessBlock{
+		ParentID:     parent.ID(),
+		Timestamp:    timestamp.UnixNano(),
+		PChainHeight: pChainHeight,
+		Certificate:  vm.cert,
+		Block:        inner.Bytes(),
+	}
+	if err := stateless.sign(vm.key); err != nil {
+		return nil, fmt.Errorf("couldn't sign block: %w", err)
+	}
+	bytes, err := c.Marshal(codecVersion, &stateless)
+	if err != nil {
+		return nil, err
+	}
+	vm.ctx.Log.Verbo("proposed block at height %d with timestamp %s", height, timestamp)
+	return &postForkBlock{
+		vm:        vm,
+		id:        hashing.ComputeHash256Array(bytes),
+		bytes:     bytes,
+		stateless: stateless,
+		inner:     inner,
+		status:    choices.Processing,
+	}, nil
+}
+
+// ParseBlock implements the block.ChainVM interface
+func (vm *VM) ParseBlock(bytes []byte) (snowman.Block, error) {
+	if blk, err := vm.parsePostForkBlock(bytes); err == nil {
+		return blk, nil
+	}
+	blk, err := vm.ChainVM.ParseBlock(bytes)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Height() >= vm.config.ActivationHeight {
+		return nil, errUnwrappedBlock
+	}
+	return blk, nil
+}
+
+// GetBlock implements the block.ChainVM interface
+func (vm *VM) GetBlock(blkID ids.ID) (snowman.Block, error) { return vm.getBlock(blkID) }
+
+// SetPreference implements the block.ChainVM interface
+func (vm *VM) SetPreference(blkID ids.ID) error {
+	blk, err := vm.getBlock(blkID)
+	if err != nil {
+		return err
+	}
+	vm.preferred = blkID
+	return vm.ChainVM.SetPreference(innerBlockID(blk))
+}
+
+// LastAccepted implements the block.ChainVM interface
+func (vm *VM) LastAccepted() (ids.ID, error) {
+	lastAcceptedBytes, err := vm.db.Get(lastAcceptedKey)
+	if err == database.ErrNotFound {
+		// No wrapped block has been accepted yet
+		return vm.ChainVM.LastAccepted()
+	}
+	if err != nil {
+		return ids.ID{}, err
+	}
+	return ids.ToID(lastAcceptedBytes)
+}
+
+// initActivationHeight sets the activation height if it isn't configured, and
+// stores it so that later runs of the chain use the same one
+func (vm *VM) initActivationHeight() error {
+	wrapped, err := vm.db.Has(lastAcceptedKey)
+	if err != nil {
+		return err
+	}
+	storedBytes, err := vm.db.Get(activationHeightKey)
+	switch err {
+	case nil:
+		if len(storedBytes) != wrappers.LongLen {
+			return fmt.Errorf("stored activation height has length %d", len(storedBytes))
+		}
+		stored := binary.BigEndian.Uint64(storedBytes)
+		switch {
+		case vm.config.ActivationHeight == 0:
+			vm.config.ActivationHeight = stored
+		case vm.config.ActivationHeight != stored && wrapped:
+			return fmt.Errorf("%w: it was %d but is configured as %d",
+				errActivationHeightChanged, stored, vm.config.ActivationHeight)
+		}
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+
+	innerLastAcceptedID, err := vm.ChainVM.LastAccepted()
+	if err != nil {
+		return err
+	}
+	innerLastAccepted, err := vm.ChainVM.GetBlock(innerLastAcceptedID)
+	if err != nil {
+		return fmt.Errorf("couldn't get inner last accepted block %s: %w", innerLastAcceptedID, err)
+	}
+	height := innerLastAccepted.Height()
+	if vm.config.ActivationHeight == 0 {
+		if wrapped || height > 0 {
+			return errMissingActivationHeight
+		}
+		// The genesis block is never wrapped
+		vm.config.ActivationHeight = 1
+	}
+	if !wrapped && height >= vm.config.ActivationHeight {
+		return fmt.Errorf("%w: it's at height %d but the activation height is %d",
+			errActivationHeightPassed, height, vm.config.ActivationHeight)
+	}
+
+	heightBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(heightBytes, vm.config.ActivationHeight)
+	return vm.db.Put(activationHeightKey, heightBytes)
+}
+
+// repairLastAccepted makes the last accepted wrapped block wrap the inner VM's
+// last accepted block. A wrapped block is stored before its inner block is
+// accepted, so if the node stopped in between, the wrapped block is unaccepted
+// again.
+func (vm *VM) repairLastAccepted() error {
+	lastAcceptedBytes, err := vm.db.Get(lastAcceptedKey)
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	lastAcceptedID, err := ids.ToID(lastAcceptedBytes)
+	if err != nil {
+		return err
+	}
+	lastAccepted, err := vm.getBlock(lastAcceptedID)
+	if err != nil {
+		return fmt.Errorf("couldn't get last accepted block %s: %w", lastAcceptedID, err)
+	}
+	innerLastAcceptedID, err := vm.ChainVM.LastAccepted()
+	if err != nil {
+		return err
+	}
+	if innerBlockID(lastAccepted) == innerLastAcceptedID {
+		return nil
+	}
+
+	postFork, ok := lastAccepted.(*postForkBlock)
+	if !ok {
+		return fmt.Errorf("%w: last accepted block %s isn't wrapped", errInconsistentLastAccepted, lastAcceptedID)
+	}
+	parentID := postFork.stateless.ParentID
+	parent, err := vm.getBlock(parentID)
+	if err != nil {
+		return fmt.Errorf("couldn't get parent %s of last accepted block: %w", parentID, err)
+	}
+	if innerBlockID(parent) != innerLastAcceptedID {
+		return fmt.Errorf("%w: last accepted block is %s but the inner VM's is %s",
+			errInconsistentLastAccepted, lastAcceptedID, innerLastAcceptedID)
+	}
+
+	vm.ctx.Log.Info("inner block of last accepted block %s wasn't accepted, so its parent %s is the last accepted block",
+		lastAcceptedID, parentID)
+	batch := vm.db.NewBatch()
+	if err := batch.Delete(blockKey(lastAcceptedID)); err != nil {
+		return err
+	}
+	if _, ok := parent.(*postForkBlock); ok {
+		err = batch.Put(lastAcceptedKey, parentID[:])
+	} else {
+		// The parent is below the activation height
+		err = batch.Delete(lastAcceptedKey)
+	}
+	if err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// windowStart returns when [nodeID]'s window to propose a child of [parent]
+// starts. The proposers are sampled at the P-chain height recorded in
+// [parent], so every node agrees on them. The parent of the first wrapped
+// block doesn't record a P-chain height, so anyone may propose that block.
+func (vm *VM) windowStart(parent snowman.Block, nodeID ids.ShortID) (time.Time, error) {
+	postFork, ok := parent.(*postForkBlock)
+	if !ok {
+		return timestampOf(parent), nil
+	}
+	delay, err := vm.windower.delay(postFork.stateless.PChainHeight, parent.Height()+1, nodeID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return postFork.timestamp().Add(delay), nil
+}
+
+// notifyAt notifies the engine at [t] that it should try to build a block
+func (vm *VM) notifyAt(t time.Time) {
+	if vm.buildTimer != nil {
+		vm.buildTimer.Stop()
+	}
+	vm.buildTimer = time.AfterFunc(t.Sub(vm.clock.Time()), func() {
+		select {
+		case vm.toEngine <- common.PendingTxs:
+		default:
+		}
+	})
+}
+
+// getBlock returns the wrapped block with ID [blkID], or the inner block with
+// ID [blkID] if it's below the activation height
+func (vm *VM) getBlock(blkID ids.ID) (snowman.Block, error) {
+	if blk, ok := vm.verified[blkID]; ok {
+		return blk, nil
+	}
+	bytes, err := vm.db.Get(blockKey(blkID))
+	switch err {
+	case nil:
+		return vm.parsePostForkBlock(bytes)
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	blk, err := vm.ChainVM.GetBlock(blkID)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Height() >= vm.config.ActivationHeight {
+		// The inner block is only known by the ID of its wrapper
+		return nil, errUnwrappedBlock
+	}
+	return blk, nil
+}
+
+// parsePostForkBlock parses the wrapped block [bytes]
+func (vm *VM) parsePostForkBlock(bytes []byte) (*postForkBlock, error) {
+	stateless := statelessBlock{}
+	if _, err := c.Unmarshal(bytes, &stateless); err != nil {
+		return nil, err
+	}
+	blkID := hashing.ComputeHash256Array(bytes)
+	if blk, ok := vm.verified[blkID]; ok {
+		return blk, nil
+	}
+	status, err := vm.status(blkID)
+	if err != nil {
+		return nil, err
+	}
+
+	inner, err := vm.ChainVM.ParseBlock(stateless.Block)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse inner block: %w", err)
+	}
+	if inner.Height() < vm.config.ActivationHeight {
+		return nil, errWrappedBlock
+	}
+	return &postForkBlock{
+		vm:        vm,
+		id:        blkID,
+		bytes:     bytes,
+		stateless: stateless,
+		inner:     inner,
+		status:    status,
+	}, nil
+}
+
+// innerBlock is a verified inner block, and the IDs of the verified blocks
+// that wrap it
+type innerBlock struct {
+	snowman.Block
+	wrappers ids.Set
+}
+
+// removeVerified removes [blk] from the verified blocks. If no other verified
+// block wraps the inner block of [blk], the inner block is returned.
+func (vm *VM) removeVerified(blk *postForkBlock) (snowman.Block, bool) {
+	delete(vm.verified, blk.id)
+	innerID := blk.inner.ID()
+	inner, ok := vm.verifiedInner[innerID]
+	if !ok {
+		return nil, false
+	}
+	inner.wrappers.Remove(blk.id)
+	if inner.wrappers.Len() > 0 {
+		return nil, false
+	}
+	delete(vm.verifiedInner, innerID)
+	return inner.Block, true
+}
+
+// status returns the stored status of the wrapped block with ID [blkID]
+func (vm *VM) status(blkID ids.ID) (choices.Status, error) {
+	if accepted, err := vm.db.Has(blockKey(blkID)); err != nil {
+		return choices.Unknown, err
+	} else if accepted {
+		return choices.Accepted, nil
+	}
+	if rejected, err := vm.db.Has(rejectedKey(blkID)); err != nil {
+		return choices.Unknown, err
+	} else if rejected {
+		return choices.Rejected, nil
+	}
+	return choices.Processing, nil
+}
+
+// accept stores [blk] as the last accepted block. Both are written at once, so
+// that the last accepted block is always stored.
+func (vm *VM) accept(blk *postForkBlock) error {
+	batch := vm.db.NewBatch()
+	if err := batch.Put(blockKey(blk.id), blk.bytes); err != nil {
+		return err
+	}
+	if err := batch.Put(lastAcceptedKey, blk.id[:]); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// reject stores that [blk] was rejected
+func (vm *VM) reject(blk *postForkBlock) error { return vm.db.Put(rejectedKey(blk.id), nil) }
+
+// blockKey returns the database key of the wrapped block with ID [blkID]
+func blockKey(blkID ids.ID) []byte { return append([]byte{blockPrefix}, blkID[:]...) }
+
+// rejectedKey returns the database key that marks the wrapped block with ID
+// [blkID] as rejected
+func rejectedKey(blkID ids.ID) []byte { return append([]byte{rejectedPrefix}, blkID[:]...) }
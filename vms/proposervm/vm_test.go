@@ -0,0 +1,729 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+var (
+	// Timestamp of blocks below the activation height
+	genesisTime = time.Unix(0, 0)
+
+	errUnknownBlock = errors.New("unknown block")
+)
+
+// testPChainHeight is the P-chain's height in tests
+const testPChainHeight = 10
+
+func newTestKey(t *testing.T) (crypto.Signer, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(0),
+		NotBefore:             time.Date(2000, time.January, 0, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Now().AddDate(100, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, certTemplate, certTemplate, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, certBytes
+}
+
+// innerVM is a block.TestVM whose blocks are the genesis block and [blocks]
+type innerVM struct {
+	block.TestVM
+
+	genesis *snowman.TestBlock
+	blocks  []*snowman.TestBlock
+}
+
+func newInnerVM(t *testing.T, numBlocks int) *innerVM {
+	inner := &innerVM{
+		genesis: &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Accepted,
+			},
+			BytesV: []byte{0},
+		},
+	}
+	parent := inner.genesis
+	for i := 1; i <= numBlocks; i++ {
+		blk := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			ParentV: parent,
+			HeightV: uint64(i),
+			BytesV:  []byte{byte(i)},
+		}
+		inner.blocks = append(inner.blocks, blk)
+		parent = blk
+	}
+
+	inner.T = t
+	inner.InitializeF = func(*snow.Context, database.Database, []byte, chan<- common.Message, []*common.Fx) error {
+		return nil
+	}
+	inner.LastAcceptedF = func() (ids.ID, error) { return inner.genesis.ID(), nil }
+	inner.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range inner.all() {
+			if blk.ID() == blkID {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+	inner.ParseBlockF = func(b []byte) (snowman.Block, error) {
+		for _, blk := range inner.all() {
+			if bytes.Equal(blk.Bytes(), b) {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+	return inner
+}
+
+func (vm *innerVM) all() []*snowman.TestBlock {
+	return append([]*snowman.TestBlock{vm.genesis}, vm.blocks...)
+}
+
+// newTestVM returns a bootstrapped VM that wraps [inner]. The P-chain is at
+// [testPChainHeight], and every height of it has the same validators. The
+// VM's node isn't a validator, so it may only propose once every proposer's
+// window started.
+func newTestVM(t *testing.T, inner *innerVM, config Config) (*VM, *validators.TestState) {
+	vdrs := make(map[ids.ShortID]uint64, config.NumProposers)
+	for i := 0; i < config.NumProposers; i++ {
+		vdrs[ids.GenerateTestShortID()] = 1
+	}
+	state := &validators.TestState{
+		T:                    t,
+		CantGetCurrentHeight: true,
+		CantGetValidatorSet:  true,
+		GetCurrentHeightF:    func() (uint64, error) { return testPChainHeight, nil },
+		GetValidatorSetF: func(uint64, ids.ID) (map[ids.ShortID]uint64, error) {
+			return vdrs, nil
+		},
+	}
+	key, cert := newTestKey(t)
+	vm, err := New(inner, config, memdb.New(), state, key, cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Initialize(snow.DefaultContextTest(), memdb.New(), nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+	return vm, state
+}
+
+func testConfig() Config {
+	return Config{
+		NumProposers:     3,
+		WindowDuration:   time.Minute,
+		ActivationHeight: 1,
+	}
+}
+
+// newSignedBlock returns the bytes of a block that wraps [inner], proposed at
+// [timestamp] and [pChainHeight] by the holder of [key]
+func newSignedBlock(
+	t *testing.T,
+	parentID ids.ID,
+	timestamp time.Time,
+	pChainHeight uint64,
+	key crypto.Signer,
+	cert []byte,
+	inner snowman.Block,
+) []byte {
+	stateless := statelessBlock{
+		ParentID:     parentID,
+		Timestamp:    timestamp.UnixNano(),
+		PChainHeight: pChainHeight,
+		Certificate:  cert,
+		Block:        inner.Bytes(),
+	}
+	if err := stateless.sign(key); err != nil {
+		t.Fatal(err)
+	}
+	b, err := c.Marshal(codecVersion, &stateless)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestNewRequiresStakingKey(t *testing.T) {
+	_, err := New(newInnerVM(t, 0), testConfig(), memdb.New(), &validators.TestState{}, nil, nil)
+	if !errors.Is(err, errNoStakingKey) {
+		t.Fatalf("expected %s, got %v", errNoStakingKey, err)
+	}
+}
+
+func TestBuildBlockWaitsForWindow(t *testing.T) {
+	inner := newInnerVM(t, 2)
+	built := 0
+	inner.BuildBlockF = func() (snowman.Block, error) {
+		built++
+		return inner.blocks[built-1], nil
+	}
+	vm, _ := newTestVM(t, inner, testConfig())
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// The parent of the first wrapped block doesn't record a P-chain height,
+	// so anyone may propose it
+	vm.clock.Set(genesisTime.Add(time.Minute))
+	first, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID() == inner.blocks[0].ID() {
+		t.Fatal("block should be wrapped")
+	}
+	if first.Parent().ID() != inner.genesis.ID() {
+		t.Fatalf("wrong parent %s", first.Parent().ID())
+	}
+	if pChainHeight := pChainHeightOf(first); pChainHeight != testPChainHeight {
+		t.Fatalf("block should record P-chain height %d, but records %d", testPChainHeight, pChainHeight)
+	}
+	if err := first.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.SetPreference(first.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if status := inner.blocks[0].Status(); status != choices.Accepted {
+		t.Fatalf("inner block should be accepted, but is %s", status)
+	}
+
+	lastAccepted, err := vm.LastAccepted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastAccepted != first.ID() {
+		t.Fatalf("last accepted should be %s, but is %s", first.ID(), lastAccepted)
+	}
+
+	parsed, err := vm.ParseBlock(first.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.ID() != first.ID() {
+		t.Fatalf("parsed block should be %s, but is %s", first.ID(), parsed.ID())
+	}
+	if status := parsed.Status(); status != choices.Accepted {
+		t.Fatalf("parsed block should be accepted, but is %s", status)
+	}
+
+	// This node isn't a proposer of the next block
+	vm.clock.Set(genesisTime.Add(3 * time.Minute))
+	if _, err := vm.BuildBlock(); !errors.Is(err, errProposerWindowNotStarted) {
+		t.Fatalf("expected %s, got %v", errProposerWindowNotStarted, err)
+	}
+
+	vm.clock.Set(genesisTime.Add(4 * time.Minute))
+	second, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Parent().ID() != first.ID() {
+		t.Fatalf("wrong parent %s", second.Parent().ID())
+	}
+	if err := second.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyProposerWindows(t *testing.T) {
+	inner := newInnerVM(t, 2)
+	vm, state := newTestVM(t, inner, testConfig())
+	key, cert := newTestKey(t)
+
+	first, err := vm.ParseBlock(newSignedBlock(t, inner.genesis.ID(), genesisTime, 5, key, cert, inner.blocks[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A node that isn't a proposer proposes a block before anyone may
+	early := newSignedBlock(t, first.ID(), genesisTime.Add(2*time.Minute), 5, key, cert, inner.blocks[1])
+	blk, err := vm.ParseBlock(early)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); !errors.Is(err, errProposerWindowNotStarted) {
+		t.Fatalf("expected %s, got %v", errProposerWindowNotStarted, err)
+	}
+
+	// Windows aren't checked while bootstrapping
+	if err := vm.Bootstrapping(); err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Reject(); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+	inner.blocks[1].StatusV = choices.Processing
+
+	// The proposers are sampled at the parent's P-chain height, no matter what
+	// the current validators are. The first proposer may propose immediately.
+	state.GetValidatorSetF = func(height uint64, _ ids.ID) (map[ids.ShortID]uint64, error) {
+		if height != 5 {
+			return map[ids.ShortID]uint64{ids.GenerateTestShortID(): 1}, nil
+		}
+		return map[ids.ShortID]uint64{certToID(cert): 1}, nil
+	}
+	blk, err = vm.ParseBlock(early)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyRejectsInvalidBlocks(t *testing.T) {
+	inner := newInnerVM(t, 2)
+	vm, _ := newTestVM(t, inner, testConfig())
+	key, cert := newTestKey(t)
+	onTime := genesisTime.Add(3 * time.Minute)
+
+	// The inner block doesn't build on the inner block of the parent
+	blk, err := vm.ParseBlock(newSignedBlock(t, inner.genesis.ID(), onTime, 0, key, cert, inner.blocks[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); !errors.Is(err, errInnerParentMismatch) {
+		t.Fatalf("expected %s, got %v", errInnerParentMismatch, err)
+	}
+
+	// The block is too far in the future
+	vm.clock.Set(onTime)
+	blk, err = vm.ParseBlock(newSignedBlock(t, inner.genesis.ID(), onTime.Add(time.Minute), 0, key, cert, inner.blocks[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); !errors.Is(err, errTimestampTooLate) {
+		t.Fatalf("expected %s, got %v", errTimestampTooLate, err)
+	}
+
+	// The signature doesn't match the certificate
+	otherKey, _ := newTestKey(t)
+	blk, err = vm.ParseBlock(newSignedBlock(t, inner.genesis.ID(), onTime, 0, otherKey, cert, inner.blocks[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err == nil {
+		t.Fatal("should have failed due to an invalid signature")
+	}
+
+	first, err := vm.ParseBlock(newSignedBlock(t, inner.genesis.ID(), onTime, 1, key, cert, inner.blocks[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A child's timestamp can't be before its parent's
+	child, err := vm.ParseBlock(newSignedBlock(t, first.ID(), onTime.Add(-time.Second), 1, key, cert, inner.blocks[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := child.Verify(); !errors.Is(err, errTimestampTooEarly) {
+		t.Fatalf("expected %s, got %v", errTimestampTooEarly, err)
+	}
+
+	// A child's P-chain height can't be below its parent's
+	child, err = vm.ParseBlock(newSignedBlock(t, first.ID(), onTime, 0, key, cert, inner.blocks[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := child.Verify(); !errors.Is(err, errPChainHeightDecreased) {
+		t.Fatalf("expected %s, got %v", errPChainHeightDecreased, err)
+	}
+
+	// The P-chain must have reached the block's P-chain height
+	child, err = vm.ParseBlock(newSignedBlock(t, first.ID(), onTime, testPChainHeight+1, key, cert, inner.blocks[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := child.Verify(); !errors.Is(err, errPChainHeightNotReached) {
+		t.Fatalf("expected %s, got %v", errPChainHeightNotReached, err)
+	}
+}
+
+func TestBlocksShareInnerBlock(t *testing.T) {
+	inner := newInnerVM(t, 1)
+	vm, _ := newTestVM(t, inner, testConfig())
+	key, cert := newTestKey(t)
+	onTime := genesisTime.Add(3 * time.Minute)
+	vm.clock.Set(onTime.Add(time.Minute))
+
+	wrappers := make([]snowman.Block, 3)
+	for i := range wrappers {
+		blk, err := vm.ParseBlock(newSignedBlock(t, inner.genesis.ID(), onTime.Add(time.Duration(i)*time.Second), 0, key, cert, inner.blocks[0]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := blk.Verify(); err != nil {
+			t.Fatalf("block %d should be valid, but got %s", i, err)
+		}
+		wrappers[i] = blk
+	}
+
+	// The inner block is only rejected once every block that wraps it is
+	if err := wrappers[0].Reject(); err != nil {
+		t.Fatal(err)
+	}
+	if status := inner.blocks[0].Status(); status != choices.Processing {
+		t.Fatalf("inner block should be processing, but is %s", status)
+	}
+
+	// Once a block that wraps it is accepted, the inner block stays accepted
+	if err := wrappers[1].Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if err := wrappers[2].Reject(); err != nil {
+		t.Fatal(err)
+	}
+	if status := inner.blocks[0].Status(); status != choices.Accepted {
+		t.Fatalf("inner block should be accepted, but is %s", status)
+	}
+}
+
+func TestRejectingEveryWrapperRejectsInnerBlock(t *testing.T) {
+	inner := newInnerVM(t, 1)
+	vm, _ := newTestVM(t, inner, testConfig())
+	key, cert := newTestKey(t)
+	onTime := genesisTime.Add(3 * time.Minute)
+	vm.clock.Set(onTime.Add(time.Minute))
+
+	wrappers := make([]snowman.Block, 2)
+	for i := range wrappers {
+		blk, err := vm.ParseBlock(newSignedBlock(t, inner.genesis.ID(), onTime.Add(time.Duration(i)*time.Second), 0, key, cert, inner.blocks[0]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := blk.Verify(); err != nil {
+			t.Fatal(err)
+		}
+		wrappers[i] = blk
+	}
+	for _, blk := range wrappers {
+		if err := blk.Reject(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if status := inner.blocks[0].Status(); status != choices.Rejected {
+		t.Fatalf("inner block should be rejected, but is %s", status)
+	}
+}
+
+func TestActivationHeight(t *testing.T) {
+	inner := newInnerVM(t, 2)
+	inner.BuildBlockF = func() (snowman.Block, error) { return inner.blocks[0], nil }
+	config := testConfig()
+	config.ActivationHeight = 2
+	vm, _ := newTestVM(t, inner, config)
+
+	// Blocks below the activation height aren't wrapped
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blk.ID() != inner.blocks[0].ID() {
+		t.Fatal("block below the activation height shouldn't be wrapped")
+	}
+	if err := blk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.SetPreference(blk.ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Blocks at the activation height must be wrapped
+	if _, err := vm.ParseBlock(inner.blocks[1].Bytes()); !errors.Is(err, errUnwrappedBlock) {
+		t.Fatalf("expected %s, got %v", errUnwrappedBlock, err)
+	}
+	key, cert := newTestKey(t)
+	if _, err := vm.ParseBlock(newSignedBlock(t, inner.genesis.ID(), genesisTime, 0, key, cert, inner.blocks[0])); err == nil {
+		t.Fatal("block below the activation height shouldn't be wrapped")
+	}
+
+	wrapped, err := vm.ParseBlock(newSignedBlock(t, blk.ID(), genesisTime.Add(3*time.Minute), 0, key, cert, inner.blocks[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wrapped.ID() != hashing.ComputeHash256Array(wrapped.Bytes()) {
+		t.Fatal("wrapped block should be identified by the hash of its bytes")
+	}
+	if err := wrapped.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestActivationHeightIsStored(t *testing.T) {
+	inner := newInnerVM(t, 3)
+	key, cert := newTestKey(t)
+	initialize := func(activationHeight uint64, db database.Database) (*VM, error) {
+		config := testConfig()
+		config.ActivationHeight = activationHeight
+		vm, err := New(inner, config, db, &validators.TestState{}, key, cert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return vm, vm.Initialize(snow.DefaultContextTest(), memdb.New(), nil, nil, nil)
+	}
+
+	// A chain with only its genesis block defaults to wrapping every block
+	db := memdb.New()
+	vm, err := initialize(0, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vm.config.ActivationHeight != 1 {
+		t.Fatalf("expected activation height 1, got %d", vm.config.ActivationHeight)
+	}
+
+	// The stored activation height is used once the chain has blocks
+	inner.LastAcceptedF = func() (ids.ID, error) { return inner.blocks[0].ID(), nil }
+	if _, err := initialize(0, db); !errors.Is(err, errActivationHeightPassed) {
+		t.Fatalf("expected %s, got %v", errActivationHeightPassed, err)
+	}
+
+	// The activation height must be set for a chain that already has blocks
+	db = memdb.New()
+	if _, err := initialize(0, db); !errors.Is(err, errMissingActivationHeight) {
+		t.Fatalf("expected %s, got %v", errMissingActivationHeight, err)
+	}
+	if _, err := initialize(1, db); !errors.Is(err, errActivationHeightPassed) {
+		t.Fatalf("expected %s, got %v", errActivationHeightPassed, err)
+	}
+	if _, err := initialize(5, db); err != nil {
+		t.Fatal(err)
+	}
+	vm, err = initialize(0, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vm.config.ActivationHeight != 5 {
+		t.Fatalf("expected the stored activation height 5, got %d", vm.config.ActivationHeight)
+	}
+
+	// The activation height may change until a wrapped block is accepted
+	if _, err := initialize(3, db); err != nil {
+		t.Fatal(err)
+	}
+	wrappedBytes := newSignedBlock(t, inner.blocks[1].ID(), genesisTime, 0, key, cert, inner.blocks[2])
+	wrappedID := hashing.ComputeHash256Array(wrappedBytes)
+	if err := db.Put(blockKey(wrappedID), wrappedBytes); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put(lastAcceptedKey, wrappedID[:]); err != nil {
+		t.Fatal(err)
+	}
+	inner.LastAcceptedF = func() (ids.ID, error) { return inner.blocks[2].ID(), nil }
+	if _, err := initialize(4, db); !errors.Is(err, errActivationHeightChanged) {
+		t.Fatalf("expected %s, got %v", errActivationHeightChanged, err)
+	}
+	vm, err = initialize(0, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vm.config.ActivationHeight != 3 {
+		t.Fatalf("expected the stored activation height 3, got %d", vm.config.ActivationHeight)
+	}
+}
+
+func TestRestartUndoesAcceptedBlockWithoutInnerBlock(t *testing.T) {
+	inner := newInnerVM(t, 2)
+	innerLastAccepted := inner.genesis.ID()
+	inner.LastAcceptedF = func() (ids.ID, error) { return innerLastAccepted, nil }
+	db := memdb.New()
+	key, cert := newTestKey(t)
+	initialize := func() *VM {
+		vm, err := New(inner, testConfig(), db, &validators.TestState{}, key, cert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := vm.Initialize(snow.DefaultContextTest(), memdb.New(), nil, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		return vm
+	}
+	errCrash := errors.New("crashed")
+
+	// The node stops after the first wrapped block is stored, but before its
+	// inner block is accepted
+	vm := initialize()
+	first, err := vm.ParseBlock(newSignedBlock(t, inner.genesis.ID(), genesisTime, 0, key, cert, inner.blocks[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	inner.blocks[0].AcceptV = errCrash
+	if err := first.Accept(); !errors.Is(err, errCrash) {
+		t.Fatalf("expected %s, got %v", errCrash, err)
+	}
+	inner.blocks[0].StatusV = choices.Processing
+	inner.blocks[0].AcceptV = nil
+
+	// On restart, the last accepted block is the genesis block again
+	vm = initialize()
+	if lastAccepted, err := vm.LastAccepted(); err != nil {
+		t.Fatal(err)
+	} else if lastAccepted != inner.genesis.ID() {
+		t.Fatalf("expected last accepted block %s, got %s", inner.genesis.ID(), lastAccepted)
+	}
+	first, err = vm.ParseBlock(first.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := first.Status(); status != choices.Processing {
+		t.Fatalf("block should be processing, but is %s", status)
+	}
+
+	// The first block is accepted this time, and the node stops while
+	// accepting the second one
+	if err := first.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	innerLastAccepted = inner.blocks[0].ID()
+	second, err := vm.ParseBlock(newSignedBlock(t, first.ID(), genesisTime, 0, key, cert, inner.blocks[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	inner.blocks[1].AcceptV = errCrash
+	if err := second.Accept(); !errors.Is(err, errCrash) {
+		t.Fatalf("expected %s, got %v", errCrash, err)
+	}
+	inner.blocks[1].StatusV = choices.Processing
+
+	// On restart, the first block is the last accepted block
+	vm = initialize()
+	if lastAccepted, err := vm.LastAccepted(); err != nil {
+		t.Fatal(err)
+	} else if lastAccepted != first.ID() {
+		t.Fatalf("expected last accepted block %s, got %s", first.ID(), lastAccepted)
+	}
+
+	// A last accepted block that the inner VM's last accepted block doesn't
+	// lead to can't be repaired
+	innerLastAccepted = inner.genesis.ID()
+	vm, err = New(inner, testConfig(), db, &validators.TestState{}, key, cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondID := second.ID()
+	if err := db.Put(lastAcceptedKey, secondID[:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put(blockKey(secondID), second.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	err = vm.Initialize(snow.DefaultContextTest(), memdb.New(), nil, nil, nil)
+	if !errors.Is(err, errInconsistentLastAccepted) {
+		t.Fatalf("expected %s, got %v", errInconsistentLastAccepted, err)
+	}
+}
+
+func TestRejectedStatusIsStored(t *testing.T) {
+	inner := newInnerVM(t, 1)
+	vm, _ := newTestVM(t, inner, testConfig())
+	key, cert := newTestKey(t)
+	onTime := genesisTime.Add(3 * time.Minute)
+	vm.clock.Set(onTime.Add(time.Minute))
+
+	wrappers := make([]snowman.Block, 2)
+	for i := range wrappers {
+		blk, err := vm.ParseBlock(newSignedBlock(t, inner.genesis.ID(), onTime.Add(time.Duration(i)*time.Second), 0, key, cert, inner.blocks[0]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := blk.Verify(); err != nil {
+			t.Fatal(err)
+		}
+		wrappers[i] = blk
+	}
+	if err := wrappers[0].Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if err := wrappers[1].Reject(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A VM on the same database knows the rejected block was rejected
+	restarted, err := New(inner, testConfig(), vm.db, &validators.TestState{}, key, cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner.LastAcceptedF = func() (ids.ID, error) { return inner.blocks[0].ID(), nil }
+	if err := restarted.Initialize(snow.DefaultContextTest(), memdb.New(), nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	expected := []choices.Status{choices.Accepted, choices.Rejected}
+	for i, wrapper := range wrappers {
+		blk, err := restarted.ParseBlock(wrapper.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status := blk.Status(); status != expected[i] {
+			t.Fatalf("block %d should be %s, but is %s", i, expected[i], status)
+		}
+	}
+}
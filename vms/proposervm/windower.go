@@ -0,0 +1,88 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// windower assigns the proposers of each height their windows
+type windower struct {
+	chainID  ids.ID
+	subnetID ids.ID
+	state    validators.State
+	config   Config
+}
+
+// proposers returns the ordered proposers of [height], sampled from the
+// validators of the chain's subnet at [pChainHeight]. Every node gets the same
+// proposers. Validators are sampled without replacement, with probability
+// proportional to their stake, so there are fewer than [NumProposers]
+// proposers if there are fewer validators.
+func (w *windower) proposers(pChainHeight, height uint64) ([]ids.ShortID, error) {
+	weights, err := w.state.GetValidatorSet(pChainHeight, w.subnetID)
+	if err != nil {
+		return nil, err
+	}
+	vdrIDs := make([]ids.ShortID, 0, len(weights))
+	totalWeight := uint64(0)
+	for vdrID, weight := range weights {
+		vdrIDs = append(vdrIDs, vdrID)
+		totalWeight, err = safemath.Add64(totalWeight, weight)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Maps are iterated over in any order
+	ids.SortShortIDs(vdrIDs)
+
+	p := wrappers.Packer{MaxSize: len(w.chainID) + wrappers.LongLen}
+	p.PackFixedBytes(w.chainID[:])
+	p.PackLong(height)
+	hash := hashing.ComputeHash256(p.Bytes)
+	source := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(hash)))) // #nosec G404
+
+	proposers := make([]ids.ShortID, 0, w.config.NumProposers)
+	for len(proposers) < w.config.NumProposers && totalWeight > 0 {
+		value := source.Uint64() % totalWeight
+		for i, vdrID := range vdrIDs {
+			weight := weights[vdrID]
+			if value >= weight {
+				value -= weight
+				continue
+			}
+			proposers = append(proposers, vdrID)
+			totalWeight -= weight
+			vdrIDs = append(vdrIDs[:i], vdrIDs[i+1:]...)
+			break
+		}
+	}
+	return proposers, nil
+}
+
+// delay returns how long after the parent of a block at [height] [nodeID]'s
+// window starts, if the proposers are sampled at [pChainHeight]. Nodes that
+// aren't proposers of [height] may propose once every proposer's window has
+// started.
+func (w *windower) delay(pChainHeight, height uint64, nodeID ids.ShortID) (time.Duration, error) {
+	proposers, err := w.proposers(pChainHeight, height)
+	if err != nil {
+		return 0, err
+	}
+	for i, proposer := range proposers {
+		if proposer == nodeID {
+			return time.Duration(i) * w.config.WindowDuration, nil
+		}
+	}
+	return w.config.maxDelay(), nil
+}
@@ -0,0 +1,140 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+// newTestWindower returns a windower whose subnet has validators with
+// [weights] at P-chain height 1, and no validators at other heights
+func newTestWindower(t *testing.T, numProposers int, weights ...uint64) (*windower, []ids.ShortID) {
+	vdrs := make(map[ids.ShortID]uint64, len(weights))
+	vdrIDs := make([]ids.ShortID, len(weights))
+	for i, weight := range weights {
+		vdrIDs[i] = ids.GenerateTestShortID()
+		vdrs[vdrIDs[i]] = weight
+	}
+	subnetID := ids.GenerateTestID()
+	return &windower{
+		chainID:  ids.GenerateTestID(),
+		subnetID: subnetID,
+		state: &validators.TestState{
+			T:                   t,
+			CantGetValidatorSet: true,
+			GetValidatorSetF: func(height uint64, requestedSubnetID ids.ID) (map[ids.ShortID]uint64, error) {
+				if requestedSubnetID != subnetID {
+					t.Fatalf("validators of the wrong subnet %s were requested", requestedSubnetID)
+				}
+				if height != 1 {
+					return nil, nil
+				}
+				return vdrs, nil
+			},
+		},
+		config: Config{
+			NumProposers:   numProposers,
+			WindowDuration: time.Second,
+		},
+	}, vdrIDs
+}
+
+func TestWindowerProposers(t *testing.T) {
+	assert := assert.New(t)
+
+	w, _ := newTestWindower(t, 3, 1, 1, 1, 1, 1)
+
+	proposers, err := w.proposers(1, 10)
+	assert.NoError(err)
+	assert.Len(proposers, 3)
+	for i := 0; i < 10; i++ {
+		again, err := w.proposers(1, 10)
+		assert.NoError(err)
+		assert.Equal(proposers, again, "proposers should be deterministic")
+	}
+
+	seen := ids.ShortSet{}
+	for _, proposer := range proposers {
+		assert.False(seen.Contains(proposer), "proposers should be distinct")
+		seen.Add(proposer)
+	}
+
+	// Different heights should usually have different first proposers
+	differ := false
+	for height := uint64(11); height < 30 && !differ; height++ {
+		other, err := w.proposers(1, height)
+		assert.NoError(err)
+		differ = other[0] != proposers[0]
+	}
+	assert.True(differ, "every height had the same first proposer")
+
+	// The proposers are sampled from the validators at the P-chain height
+	proposers, err = w.proposers(2, 10)
+	assert.NoError(err)
+	assert.Empty(proposers)
+}
+
+func TestWindowerFewValidators(t *testing.T) {
+	assert := assert.New(t)
+
+	w, vdrIDs := newTestWindower(t, 6, 1, 2)
+
+	proposers, err := w.proposers(1, 1)
+	assert.NoError(err)
+	assert.Len(proposers, 2)
+	assert.ElementsMatch(vdrIDs, proposers)
+
+	w, _ = newTestWindower(t, 6)
+	proposers, err = w.proposers(1, 1)
+	assert.NoError(err)
+	assert.Empty(proposers)
+}
+
+func TestWindowerStakeWeighted(t *testing.T) {
+	assert := assert.New(t)
+
+	w, vdrIDs := newTestWindower(t, 1, 99, 1)
+
+	first := 0
+	for height := uint64(1); height <= 1000; height++ {
+		proposers, err := w.proposers(1, height)
+		assert.NoError(err)
+		if proposers[0] == vdrIDs[0] {
+			first++
+		}
+	}
+	assert.Greater(first, 900, "heavier validator should usually be the first proposer")
+}
+
+func TestWindowerDelay(t *testing.T) {
+	assert := assert.New(t)
+
+	w, _ := newTestWindower(t, 3, 1, 1, 1, 1, 1)
+
+	proposers, err := w.proposers(1, 5)
+	assert.NoError(err)
+	for i, proposer := range proposers {
+		delay, err := w.delay(1, 5, proposer)
+		assert.NoError(err)
+		assert.Equal(time.Duration(i)*time.Second, delay)
+	}
+	delay, err := w.delay(1, 5, ids.GenerateTestShortID())
+	assert.NoError(err)
+	assert.Equal(3*time.Second, delay)
+
+	// The validator set can't be looked up
+	errUnknownHeight := errors.New("unknown height")
+	w.state.(*validators.TestState).GetValidatorSetF = func(uint64, ids.ID) (map[ids.ShortID]uint64, error) {
+		return nil, errUnknownHeight
+	}
+	_, err = w.delay(1, 5, proposers[0])
+	assert.True(errors.Is(err, errUnknownHeight))
+}
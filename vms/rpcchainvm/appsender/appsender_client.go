@@ -0,0 +1,56 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package appsender
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/appsender/appsenderproto"
+)
+
+var _ common.AppSender = &Client{}
+
+// Client is an implementation of an application message sender that talks
+// over RPC.
+type Client struct {
+	client appsenderproto.AppSenderClient
+}
+
+// NewClient returns a client that is connected to a remote application
+// message sender
+func NewClient(client appsenderproto.AppSenderClient) *Client {
+	return &Client{client: client}
+}
+
+func (c *Client) SendAppRequest(nodeIDs ids.ShortSet, requestID uint32, request []byte) error {
+	nodeIDsBytes := make([][]byte, 0, nodeIDs.Len())
+	for nodeID := range nodeIDs {
+		nodeID := nodeID // Prevent overwrite in next loop iteration
+		nodeIDsBytes = append(nodeIDsBytes, nodeID[:])
+	}
+	_, err := c.client.SendAppRequest(context.Background(), &appsenderproto.SendAppRequestMsg{
+		NodeIDs:   nodeIDsBytes,
+		RequestID: requestID,
+		Request:   request,
+	})
+	return err
+}
+
+func (c *Client) SendAppResponse(nodeID ids.ShortID, requestID uint32, response []byte) error {
+	_, err := c.client.SendAppResponse(context.Background(), &appsenderproto.SendAppResponseMsg{
+		NodeID:    nodeID[:],
+		RequestID: requestID,
+		Response:  response,
+	})
+	return err
+}
+
+func (c *Client) SendAppGossip(msg []byte) error {
+	_, err := c.client.SendAppGossip(context.Background(), &appsenderproto.SendAppGossipMsg{
+		Msg: msg,
+	})
+	return err
+}
@@ -0,0 +1,52 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package appsender
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/appsender/appsenderproto"
+)
+
+var _ appsenderproto.AppSenderServer = &Server{}
+
+// Server is an application message sender that is managed over RPC.
+type Server struct {
+	appSender common.AppSender
+}
+
+// NewServer returns an application message sender connected to a remote
+// application message sender
+func NewServer(appSender common.AppSender) *Server {
+	return &Server{appSender: appSender}
+}
+
+func (s *Server) SendAppRequest(_ context.Context, req *appsenderproto.SendAppRequestMsg) (*appsenderproto.EmptyMsg, error) {
+	nodeIDs := ids.ShortSet{}
+	for _, nodeIDBytes := range req.NodeIDs {
+		nodeID, err := ids.ToShortID(nodeIDBytes)
+		if err != nil {
+			return nil, err
+		}
+		nodeIDs.Add(nodeID)
+	}
+	err := s.appSender.SendAppRequest(nodeIDs, req.RequestID, req.Request)
+	return &appsenderproto.EmptyMsg{}, err
+}
+
+func (s *Server) SendAppResponse(_ context.Context, req *appsenderproto.SendAppResponseMsg) (*appsenderproto.EmptyMsg, error) {
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.appSender.SendAppResponse(nodeID, req.RequestID, req.Response)
+	return &appsenderproto.EmptyMsg{}, err
+}
+
+func (s *Server) SendAppGossip(_ context.Context, req *appsenderproto.SendAppGossipMsg) (*appsenderproto.EmptyMsg, error) {
+	err := s.appSender.SendAppGossip(req.Msg)
+	return &appsenderproto.EmptyMsg{}, err
+}
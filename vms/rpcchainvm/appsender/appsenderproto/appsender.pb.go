@@ -0,0 +1,541 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.23.0-devel
+// 	protoc        v3.6.1
+// source: appsender.proto
+
+package appsenderproto
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type SendAppRequestMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeIDs   [][]byte `protobuf:"bytes,1,rep,name=nodeIDs,proto3" json:"nodeIDs,omitempty"`
+	RequestID uint32   `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Request   []byte   `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+}
+
+func (x *SendAppRequestMsg) Reset() {
+	*x = SendAppRequestMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_appsender_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendAppRequestMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendAppRequestMsg) ProtoMessage() {}
+
+func (x *SendAppRequestMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_appsender_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendAppRequestMsg.ProtoReflect.Descriptor instead.
+func (*SendAppRequestMsg) Descriptor() ([]byte, []int) {
+	return file_appsender_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SendAppRequestMsg) GetNodeIDs() [][]byte {
+	if x != nil {
+		return x.NodeIDs
+	}
+	return nil
+}
+
+func (x *SendAppRequestMsg) GetRequestID() uint32 {
+	if x != nil {
+		return x.RequestID
+	}
+	return 0
+}
+
+func (x *SendAppRequestMsg) GetRequest() []byte {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type SendAppResponseMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeID    []byte `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	RequestID uint32 `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Response  []byte `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (x *SendAppResponseMsg) Reset() {
+	*x = SendAppResponseMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_appsender_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendAppResponseMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendAppResponseMsg) ProtoMessage() {}
+
+func (x *SendAppResponseMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_appsender_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendAppResponseMsg.ProtoReflect.Descriptor instead.
+func (*SendAppResponseMsg) Descriptor() ([]byte, []int) {
+	return file_appsender_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SendAppResponseMsg) GetNodeID() []byte {
+	if x != nil {
+		return x.NodeID
+	}
+	return nil
+}
+
+func (x *SendAppResponseMsg) GetRequestID() uint32 {
+	if x != nil {
+		return x.RequestID
+	}
+	return 0
+}
+
+func (x *SendAppResponseMsg) GetResponse() []byte {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type SendAppGossipMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg []byte `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *SendAppGossipMsg) Reset() {
+	*x = SendAppGossipMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_appsender_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendAppGossipMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendAppGossipMsg) ProtoMessage() {}
+
+func (x *SendAppGossipMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_appsender_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendAppGossipMsg.ProtoReflect.Descriptor instead.
+func (*SendAppGossipMsg) Descriptor() ([]byte, []int) {
+	return file_appsender_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SendAppGossipMsg) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+type EmptyMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EmptyMsg) Reset() {
+	*x = EmptyMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_appsender_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmptyMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyMsg) ProtoMessage() {}
+
+func (x *EmptyMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_appsender_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyMsg.ProtoReflect.Descriptor instead.
+func (*EmptyMsg) Descriptor() ([]byte, []int) {
+	return file_appsender_proto_rawDescGZIP(), []int{3}
+}
+
+var File_appsender_proto protoreflect.FileDescriptor
+
+var file_appsender_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x61, 0x70, 0x70, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0e, 0x61, 0x70, 0x70, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x65, 0x0a, 0x11, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x4d, 0x73, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x66, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64,
+	0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x16,
+	0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x24, 0x0a, 0x10, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x70, 0x70, 0x47, 0x6f, 0x73, 0x73, 0x69,
+	0x70, 0x4d, 0x73, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x0a, 0x0a, 0x08, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4d,
+	0x73, 0x67, 0x32, 0xf8, 0x01, 0x0a, 0x09, 0x41, 0x70, 0x70, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x12, 0x4d, 0x0a, 0x0e, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x21, 0x2e, 0x61, 0x70, 0x70, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x70, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4d, 0x73, 0x67, 0x1a, 0x18, 0x2e, 0x61, 0x70, 0x70, 0x73, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4d, 0x73, 0x67, 0x12,
+	0x4f, 0x0a, 0x0f, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x70, 0x70, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x70, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x4d, 0x73, 0x67, 0x1a, 0x18, 0x2e, 0x61, 0x70, 0x70, 0x73, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4d, 0x73, 0x67,
+	0x12, 0x4b, 0x0a, 0x0d, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x70, 0x70, 0x47, 0x6f, 0x73, 0x73, 0x69,
+	0x70, 0x12, 0x20, 0x2e, 0x61, 0x70, 0x70, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x70, 0x70, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70,
+	0x4d, 0x73, 0x67, 0x1a, 0x18, 0x2e, 0x61, 0x70, 0x70, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4d, 0x73, 0x67, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_appsender_proto_rawDescOnce sync.Once
+	file_appsender_proto_rawDescData = file_appsender_proto_rawDesc
+)
+
+func file_appsender_proto_rawDescGZIP() []byte {
+	file_appsender_proto_rawDescOnce.Do(func() {
+		file_appsender_proto_rawDescData = protoimpl.X.CompressGZIP(file_appsender_proto_rawDescData)
+	})
+	return file_appsender_proto_rawDescData
+}
+
+var file_appsender_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_appsender_proto_goTypes = []interface{}{
+	(*SendAppRequestMsg)(nil),  // 0: appsenderproto.SendAppRequestMsg
+	(*SendAppResponseMsg)(nil), // 1: appsenderproto.SendAppResponseMsg
+	(*SendAppGossipMsg)(nil),   // 2: appsenderproto.SendAppGossipMsg
+	(*EmptyMsg)(nil),           // 3: appsenderproto.EmptyMsg
+}
+var file_appsender_proto_depIdxs = []int32{
+	0, // 0: appsenderproto.AppSender.SendAppRequest:input_type -> appsenderproto.SendAppRequestMsg
+	1, // 1: appsenderproto.AppSender.SendAppResponse:input_type -> appsenderproto.SendAppResponseMsg
+	2, // 2: appsenderproto.AppSender.SendAppGossip:input_type -> appsenderproto.SendAppGossipMsg
+	3, // 3: appsenderproto.AppSender.SendAppRequest:output_type -> appsenderproto.EmptyMsg
+	3, // 4: appsenderproto.AppSender.SendAppResponse:output_type -> appsenderproto.EmptyMsg
+	3, // 5: appsenderproto.AppSender.SendAppGossip:output_type -> appsenderproto.EmptyMsg
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_appsender_proto_init() }
+func file_appsender_proto_init() {
+	if File_appsender_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_appsender_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendAppRequestMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_appsender_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendAppResponseMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_appsender_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendAppGossipMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_appsender_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmptyMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_appsender_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_appsender_proto_goTypes,
+		DependencyIndexes: file_appsender_proto_depIdxs,
+		MessageInfos:      file_appsender_proto_msgTypes,
+	}.Build()
+	File_appsender_proto = out.File
+	file_appsender_proto_rawDesc = nil
+	file_appsender_proto_goTypes = nil
+	file_appsender_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// AppSenderClient is the client API for AppSender service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AppSenderClient interface {
+	SendAppRequest(ctx context.Context, in *SendAppRequestMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	SendAppResponse(ctx context.Context, in *SendAppResponseMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	SendAppGossip(ctx context.Context, in *SendAppGossipMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+}
+
+type appSenderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAppSenderClient(cc grpc.ClientConnInterface) AppSenderClient {
+	return &appSenderClient{cc}
+}
+
+func (c *appSenderClient) SendAppRequest(ctx context.Context, in *SendAppRequestMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/appsenderproto.AppSender/SendAppRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appSenderClient) SendAppResponse(ctx context.Context, in *SendAppResponseMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/appsenderproto.AppSender/SendAppResponse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appSenderClient) SendAppGossip(ctx context.Context, in *SendAppGossipMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/appsenderproto.AppSender/SendAppGossip", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AppSenderServer is the server API for AppSender service.
+type AppSenderServer interface {
+	SendAppRequest(context.Context, *SendAppRequestMsg) (*EmptyMsg, error)
+	SendAppResponse(context.Context, *SendAppResponseMsg) (*EmptyMsg, error)
+	SendAppGossip(context.Context, *SendAppGossipMsg) (*EmptyMsg, error)
+}
+
+// UnimplementedAppSenderServer can be embedded to have forward compatible implementations.
+type UnimplementedAppSenderServer struct {
+}
+
+func (*UnimplementedAppSenderServer) SendAppRequest(context.Context, *SendAppRequestMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendAppRequest not implemented")
+}
+func (*UnimplementedAppSenderServer) SendAppResponse(context.Context, *SendAppResponseMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendAppResponse not implemented")
+}
+func (*UnimplementedAppSenderServer) SendAppGossip(context.Context, *SendAppGossipMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendAppGossip not implemented")
+}
+
+func RegisterAppSenderServer(s *grpc.Server, srv AppSenderServer) {
+	s.RegisterService(&_AppSender_serviceDesc, srv)
+}
+
+func _AppSender_SendAppRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendAppRequestMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppSenderServer).SendAppRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/appsenderproto.AppSender/SendAppRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppSenderServer).SendAppRequest(ctx, req.(*SendAppRequestMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppSender_SendAppResponse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendAppResponseMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppSenderServer).SendAppResponse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/appsenderproto.AppSender/SendAppResponse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppSenderServer).SendAppResponse(ctx, req.(*SendAppResponseMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppSender_SendAppGossip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendAppGossipMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppSenderServer).SendAppGossip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/appsenderproto.AppSender/SendAppGossip",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppSenderServer).SendAppGossip(ctx, req.(*SendAppGossipMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AppSender_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "appsenderproto.AppSender",
+	HandlerType: (*AppSenderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendAppRequest",
+			Handler:    _AppSender_SendAppRequest_Handler,
+		},
+		{
+			MethodName: "SendAppResponse",
+			Handler:    _AppSender_SendAppResponse_Handler,
+		},
+		{
+			MethodName: "SendAppGossip",
+			Handler:    _AppSender_SendAppGossip_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "appsender.proto",
+}
@@ -23,6 +23,8 @@ var (
 type Factory struct {
 	Path   string
 	Config string
+	// If positive, byte budget of the VM's decided block cache
+	CacheBytes int
 }
 
 // New ...
@@ -75,5 +77,6 @@ func (f *Factory) New(ctx *snow.Context) (interface{}, error) {
 
 	vm.SetProcess(client)
 	vm.ctx = ctx
+	vm.cacheBytes = f.CacheBytes
 	return vm, nil
 }
@@ -9,6 +9,8 @@ import (
 	"fmt"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/go-plugin"
 	"github.com/prometheus/client_golang/prometheus"
@@ -26,6 +28,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/missing"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/appsender"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/appsender/appsenderproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/galiaslookup"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/galiaslookup/galiaslookupproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/ghttp"
@@ -45,11 +49,16 @@ import (
 var (
 	errUnsupportedFXs = errors.New("unsupported feature extensions")
 
-	_ block.ChainVM = &VMClient{}
+	_ block.ChainVM     = &VMClient{}
+	_ common.AppHandler = &VMClient{}
 )
 
 const (
 	decidedCacheSize = 500
+
+	// blockCacheOverhead is the size charged for every block in a byte
+	// budgeted cache, in addition to the block's bytes
+	blockCacheOverhead = 128
 )
 
 // VMClient is an implementation of VM that talks over RPC.
@@ -64,6 +73,7 @@ type VMClient struct {
 	sharedMemory *gsharedmemory.Server
 	bcLookup     *galiaslookup.Server
 	snLookup     *gsubnetlookup.Server
+	appSender    *appsender.Server
 
 	serverCloser grpcutils.ServerCloser
 	conns        []*grpc.ClientConn
@@ -72,6 +82,9 @@ type VMClient struct {
 	blks map[ids.ID]*BlockClient
 
 	decidedBlocks cache.Cacher
+	// If positive, [decidedBlocks] is limited to this many bytes rather than
+	// [decidedCacheSize] blocks
+	cacheBytes int
 
 	lastAccepted ids.ID
 }
@@ -94,10 +107,19 @@ func (vm *VMClient) SetProcess(proc *plugin.Client) {
 // wraps these caches in metercacher so that we can get prometheus metrics
 // about their performance.
 func (vm *VMClient) initializeCaches(registerer prometheus.Registerer, namespace string) error {
+	var decidedBlocks cache.Cacher = &cache.LRU{Size: decidedCacheSize}
+	if vm.cacheBytes > 0 {
+		decidedBlocks = &cache.SizedLRU{
+			MaxSize: vm.cacheBytes,
+			SizeFunc: func(blkIntf interface{}) int {
+				return blockCacheOverhead + len(blkIntf.(*BlockClient).Bytes())
+			},
+		}
+	}
 	decidedCache, err := metercacher.New(
 		fmt.Sprintf("%s_rpcchainvm_decided_cache", namespace),
 		registerer,
-		&cache.LRU{Size: decidedCacheSize},
+		decidedBlocks,
 	)
 	if err != nil {
 		return fmt.Errorf("could not initialize decided blocks cache: %w", err)
@@ -160,6 +182,13 @@ func (vm *VMClient) Initialize(
 	snLookupBrokerID := vm.broker.NextId()
 	go vm.broker.AcceptAndServe(snLookupBrokerID, vm.startSNLookupServer)
 
+	// start the application message sender server, if there is a sender
+	var appSenderBrokerID uint32
+	if vm.appSender != nil {
+		appSenderBrokerID = vm.broker.NextId()
+		go vm.broker.AcceptAndServe(appSenderBrokerID, vm.startAppSenderServer)
+	}
+
 	resp, err := vm.client.Initialize(context.Background(), &vmproto.InitializeRequest{
 		NetworkID:            ctx.NetworkID,
 		SubnetID:             ctx.SubnetID[:],
@@ -176,6 +205,7 @@ func (vm *VMClient) Initialize(
 		SnLookupServer:       snLookupBrokerID,
 		EpochFirstTransition: epochFirstTransitionBytes,
 		EpochDuration:        uint64(ctx.EpochDuration),
+		AppSenderServer:      appSenderBrokerID,
 	})
 	if err != nil {
 		return err
@@ -232,6 +262,13 @@ func (vm *VMClient) startSNLookupServer(opts []grpc.ServerOption) *grpc.Server {
 	return server
 }
 
+func (vm *VMClient) startAppSenderServer(opts []grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	vm.serverCloser.Add(server)
+	appsenderproto.RegisterAppSenderServer(server, vm.appSender)
+	return server
+}
+
 func (vm *VMClient) Bootstrapping() error {
 	_, err := vm.client.Bootstrapping(context.Background(), &vmproto.BootstrappingRequest{})
 	return err
@@ -390,6 +427,56 @@ func (vm *VMClient) HealthCheck() (interface{}, error) {
 	)
 }
 
+// SetAppSender implements the common.AppHandler interface. It must be called
+// before Initialize.
+func (vm *VMClient) SetAppSender(appSender common.AppSender) {
+	vm.appSender = appsender.NewServer(appSender)
+}
+
+func (vm *VMClient) AppRequest(nodeID ids.ShortID, requestID uint32, request []byte) error {
+	_, err := vm.client.AppRequest(context.Background(), &vmproto.AppRequestMsg{
+		NodeID:    nodeID[:],
+		RequestID: requestID,
+		Request:   request,
+	})
+	return ignoreUnimplemented(err)
+}
+
+func (vm *VMClient) AppRequestFailed(nodeID ids.ShortID, requestID uint32) error {
+	_, err := vm.client.AppRequestFailed(context.Background(), &vmproto.AppRequestFailedMsg{
+		NodeID:    nodeID[:],
+		RequestID: requestID,
+	})
+	return ignoreUnimplemented(err)
+}
+
+func (vm *VMClient) AppResponse(nodeID ids.ShortID, requestID uint32, response []byte) error {
+	_, err := vm.client.AppResponse(context.Background(), &vmproto.AppResponseMsg{
+		NodeID:    nodeID[:],
+		RequestID: requestID,
+		Response:  response,
+	})
+	return ignoreUnimplemented(err)
+}
+
+func (vm *VMClient) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	_, err := vm.client.AppGossip(context.Background(), &vmproto.AppGossipMsg{
+		NodeID: nodeID[:],
+		Msg:    msg,
+	})
+	return ignoreUnimplemented(err)
+}
+
+// ignoreUnimplemented returns nil if [err] is because the plugin was built
+// before application messages were added to the protocol. Such a plugin
+// doesn't handle application messages, so they are dropped.
+func ignoreUnimplemented(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	return err
+}
+
 // BlockClient is an implementation of Block that talks over RPC.
 type BlockClient struct {
 	vm *VMClient
@@ -20,6 +20,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/appsender"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/appsender/appsenderproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/galiaslookup"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/galiaslookup/galiaslookupproto"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/ghttp"
@@ -139,6 +141,25 @@ func (vm *VMServer) Initialize(_ context.Context, req *vmproto.InitializeRequest
 	bcLookupClient := galiaslookup.NewClient(galiaslookupproto.NewAliasLookupClient(bcLookupConn))
 	snLookupClient := gsubnetlookup.NewClient(gsubnetlookupproto.NewSubnetLookupClient(snLookupConn))
 
+	// The node only serves an application message sender if this VM may
+	// handle application messages
+	appHandler, isAppHandler := vm.vm.(common.AppHandler)
+	var appSenderConn *grpc.ClientConn
+	if isAppHandler && req.AppSenderServer != 0 {
+		appSenderConn, err = vm.broker.Dial(req.AppSenderServer)
+		if err != nil {
+			// Ignore closing error to return the original error
+			_ = dbConn.Close()
+			_ = msgConn.Close()
+			_ = keystoreConn.Close()
+			_ = sharedMemoryConn.Close()
+			_ = bcLookupConn.Close()
+			_ = snLookupConn.Close()
+			return nil, err
+		}
+		appHandler.SetAppSender(appsender.NewClient(appsenderproto.NewAppSenderClient(appSenderConn)))
+	}
+
 	toEngine := make(chan common.Message, 1)
 	go func() {
 		for msg := range toEngine {
@@ -173,12 +194,18 @@ func (vm *VMServer) Initialize(_ context.Context, req *vmproto.InitializeRequest
 		_ = sharedMemoryConn.Close()
 		_ = bcLookupConn.Close()
 		_ = snLookupConn.Close()
+		if appSenderConn != nil {
+			_ = appSenderConn.Close()
+		}
 		close(toEngine)
 		return nil, err
 	}
 
 	vm.conns = append(vm.conns, dbConn)
 	vm.conns = append(vm.conns, msgConn)
+	if appSenderConn != nil {
+		vm.conns = append(vm.conns, appSenderConn)
+	}
 	vm.toEngine = toEngine
 	lastAccepted, err := vm.vm.LastAccepted()
 	return &vmproto.InitializeResponse{
@@ -322,6 +349,54 @@ func (vm *VMServer) Health(_ context.Context, req *vmproto.HealthRequest) (*vmpr
 	}, nil
 }
 
+func (vm *VMServer) AppRequest(_ context.Context, req *vmproto.AppRequestMsg) (*vmproto.AppMsgResponse, error) {
+	appHandler, ok := vm.vm.(common.AppHandler)
+	if !ok {
+		return &vmproto.AppMsgResponse{}, nil
+	}
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.AppMsgResponse{}, appHandler.AppRequest(nodeID, req.RequestID, req.Request)
+}
+
+func (vm *VMServer) AppRequestFailed(_ context.Context, req *vmproto.AppRequestFailedMsg) (*vmproto.AppMsgResponse, error) {
+	appHandler, ok := vm.vm.(common.AppHandler)
+	if !ok {
+		return &vmproto.AppMsgResponse{}, nil
+	}
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.AppMsgResponse{}, appHandler.AppRequestFailed(nodeID, req.RequestID)
+}
+
+func (vm *VMServer) AppResponse(_ context.Context, req *vmproto.AppResponseMsg) (*vmproto.AppMsgResponse, error) {
+	appHandler, ok := vm.vm.(common.AppHandler)
+	if !ok {
+		return &vmproto.AppMsgResponse{}, nil
+	}
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.AppMsgResponse{}, appHandler.AppResponse(nodeID, req.RequestID, req.Response)
+}
+
+func (vm *VMServer) AppGossip(_ context.Context, req *vmproto.AppGossipMsg) (*vmproto.AppMsgResponse, error) {
+	appHandler, ok := vm.vm.(common.AppHandler)
+	if !ok {
+		return &vmproto.AppMsgResponse{}, nil
+	}
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.AppMsgResponse{}, appHandler.AppGossip(nodeID, req.Msg)
+}
+
 func (vm *VMServer) BlockVerify(_ context.Context, req *vmproto.BlockVerifyRequest) (*vmproto.BlockVerifyResponse, error) {
 	id, err := ids.ToID(req.Id)
 	if err != nil {
@@ -22,7 +22,8 @@ const (
 
 // Credential ...
 type Credential struct {
-	Sigs [][crypto.SECP256K1RSigLen]byte `serialize:"true" json:"signatures"`
+	// At most as many signatures as an Input may spend
+	Sigs [][crypto.SECP256K1RSigLen]byte `serialize:"true" len:"256" json:"signatures"`
 }
 
 // MarshalJSON marshals [cr] to JSON
@@ -19,7 +19,8 @@ type Input struct {
 	// This input consumes an output, which has an owner list.
 	// This input will be spent with a list of signatures.
 	// SignatureList[i] is the signature of OwnerList[i]
-	SigIndices []uint32 `serialize:"true" json:"signatureIndices"`
+	// At most 256 signatures may be spent.
+	SigIndices []uint32 `serialize:"true" len:"256" json:"signatureIndices"`
 }
 
 // Verify this input is syntactically valid